@@ -81,8 +81,12 @@ func Run() {
 
 	if cfg.Frontend.RatelimitEnabled {
 		log.Infof("enabling ratelimit")
-		ratelimit.Init()
-		router.Use(ratelimit.HttpMiddleware)
+		rateLimiter, err := ratelimit.NewRateLimiterFromConfig()
+		if err != nil {
+			log.Fatal(err, "error creating rate limiter", 0)
+		}
+		rateLimiter.Init()
+		router.Use(rateLimiter.HttpMiddleware)
 	}
 
 	var srv *http.Server
@@ -18,6 +18,7 @@ import (
 
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/erc20"
+	"github.com/gobitfly/beaconchain/pkg/commons/gasoracle"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
 	"github.com/gobitfly/beaconchain/pkg/commons/rpc"
@@ -59,10 +60,13 @@ func Run() {
 	offsetData := fs.Int64("data.offset", 1000, "Data offset")
 	checkDataGaps := fs.Bool("data.gaps", false, "Check for gaps in the data table")
 	checkDataGapsLookback := fs.Int("data.gaps.lookback", 1000000, "Lookback for gaps check of the blocks table")
+	dataTransformers := fs.String("data.transformers", "", "Comma separated list of transformers to run when indexing data from bigtable (e.g. 'TransformEventLogs,TransformERC20' to backfill event logs and token supplies), defaults to all except TransformEventLogs which is enabled via -eventlogs.enabled")
+	enableEventLogs := fs.Bool("eventlogs.enabled", false, "Index the event logs of all contracts in addition to the default transformers, they are required by the /addresses/{address}/event-logs endpoint but add a row per log")
 
 	enableBalanceUpdater := fs.Bool("balances.enabled", false, "Enable balance update process")
 	enableFullBalanceUpdater := fs.Bool("balances.full.enabled", false, "Enable full balance update process")
 	balanceUpdaterBatchSize := fs.Int("balances.batch", 1000, "Batch size for balance updates")
+	enableBalanceSnapshots := fs.Bool("balances.snapshots.enabled", false, "Enable the process that records the balance and token supply history")
 
 	tokenPriceExport := fs.Bool("token.price.enabled", false, "Enable token export process")
 	tokenPriceExportList := fs.String("token.price.list", "", "Tokenlist path to use for the token price export")
@@ -75,6 +79,8 @@ func Run() {
 	enableEnsUpdater := fs.Bool("ens.enabled", false, "Enable ens update process")
 	ensBatchSize := fs.Int64("ens.batch", 200, "Batch size for ens updates")
 
+	enableGasNowUpdater := fs.Bool("gasnow.enabled", false, "Enable the gas oracle that records gas price recommendations in the interval of indexer.gasNowFrequency of the config")
+
 	_ = fs.Parse(os.Args[2:])
 
 	log.Info(*configPath)
@@ -170,6 +176,10 @@ func Run() {
 		log.Fatal(fmt.Errorf("node chain id mismatch, wanted %v got %v", chainId, nodeChainId.String()), "", 0)
 	}
 
+	if *enableBalanceSnapshots {
+		// the transformers only mark the balance changes for the snapshots if they are processed
+		utils.Config.Bigtable.BalanceSnapshots = true
+	}
 	bt, err := db.InitBigtable(utils.Config.Bigtable.Project, utils.Config.Bigtable.Instance, chainId, utils.Config.RedisCacheEndpoint)
 	if err != nil {
 		log.Fatal(err, "error connecting to bigtable", 0)
@@ -193,24 +203,61 @@ func Run() {
 		go ImportEnsUpdatesLoop(bt, client, *ensBatchSize)
 	}
 
+	if *enableGasNowUpdater {
+		go UpdateGasNowLoop(bt, gasoracle.NewOracle(client), utils.Config.Indexer.GasNowFrequency)
+	}
+
 	if *enableFullBalanceUpdater {
 		ProcessMetadataUpdates(bt, client, balanceUpdaterPrefix, *balanceUpdaterBatchSize, -1)
 		return
 	}
 
+	availableTransforms := map[string]func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error){
+		"TransformBlock":             bt.TransformBlock,
+		"TransformTx":                bt.TransformTx,
+		"TransformItx":               bt.TransformItx,
+		"TransformBlobTx":            bt.TransformBlobTx,
+		"TransformERC20":             bt.TransformERC20,
+		"TransformERC721":            bt.TransformERC721,
+		"TransformERC1155":           bt.TransformERC1155,
+		"TransformUncle":             bt.TransformUncle,
+		"TransformWithdrawals":       bt.TransformWithdrawals,
+		"TransformEnsNameRegistered": bt.TransformEnsNameRegistered,
+		"TransformContract":          bt.TransformContract,
+		"TransformEventLogs":         bt.TransformEventLogs,
+		"TransformSafe":              bt.TransformSafe,
+		"TransformLayer2":            bt.TransformLayer2,
+		"TransformUserOperations":    bt.TransformUserOperations,
+	}
 	transforms := make([]func(blk *types.Eth1Block, cache *freecache.Cache) (*types.BulkMutations, *types.BulkMutations, error), 0)
-	transforms = append(transforms,
-		bt.TransformBlock,
-		bt.TransformTx,
-		bt.TransformItx,
-		bt.TransformBlobTx,
-		bt.TransformERC20,
-		bt.TransformERC721,
-		bt.TransformERC1155,
-		bt.TransformUncle,
-		bt.TransformWithdrawals,
-		bt.TransformEnsNameRegistered,
-		bt.TransformContract)
+	if *dataTransformers == "" {
+		transforms = append(transforms,
+			bt.TransformBlock,
+			bt.TransformTx,
+			bt.TransformItx,
+			bt.TransformBlobTx,
+			bt.TransformERC20,
+			bt.TransformERC721,
+			bt.TransformERC1155,
+			bt.TransformUncle,
+			bt.TransformWithdrawals,
+			bt.TransformEnsNameRegistered,
+			bt.TransformContract,
+			bt.TransformSafe,
+			bt.TransformLayer2,
+			bt.TransformUserOperations)
+		if *enableEventLogs {
+			transforms = append(transforms, bt.TransformEventLogs)
+		}
+	} else {
+		for _, name := range strings.Split(*dataTransformers, ",") {
+			transform, ok := availableTransforms[strings.TrimSpace(name)]
+			if !ok {
+				log.Fatal(fmt.Errorf("unknown transformer %v", name), "", 0)
+			}
+			transforms = append(transforms, transform)
+		}
+	}
 
 	cache := freecache.NewCache(100 * 1024 * 1024) // 100 MB limit
 
@@ -260,6 +307,9 @@ func Run() {
 			log.Fatal(err, "error indexing from bigtable", 0)
 		}
 		cache.Clear()
+		if *enableBalanceSnapshots {
+			ProcessSnapshotUpdates(bt, client, *balanceUpdaterBatchSize, -1)
+		}
 		return
 	}
 
@@ -379,6 +429,10 @@ func Run() {
 			ProcessMetadataUpdates(bt, client, balanceUpdaterPrefix, *balanceUpdaterBatchSize, 10)
 		}
 
+		if *enableBalanceSnapshots {
+			ProcessSnapshotUpdates(bt, client, *balanceUpdaterBatchSize, 10)
+		}
+
 		log.Infof("index run completed")
 		services.ReportStatus("eth1indexer", "Running", nil)
 	}
@@ -399,6 +453,24 @@ func ImportEnsUpdatesLoop(bt *db.Bigtable, client *rpc.ErigonClient, batchSize i
 	}
 }
 
+func UpdateGasNowLoop(bt *db.Bigtable, oracle *gasoracle.Oracle, frequency time.Duration) {
+	for ; ; time.Sleep(frequency) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+		estimate, err := oracle.Estimate(ctx)
+		cancel()
+		if err != nil {
+			log.Error(err, "error estimating gas prices", 0)
+			continue
+		}
+		err = bt.SaveGasNowHistory(estimate.Slow, estimate.Standard, estimate.Rapid, estimate.Fast, estimate.BaseFee)
+		if err != nil {
+			log.Error(err, "error saving gas price estimate", 0)
+			continue
+		}
+		services.ReportStatus("gasNowUpdater", "Running", nil)
+	}
+}
+
 func UpdateTokenPrices(bt *db.Bigtable, client *rpc.ErigonClient, tokenListPath string) error {
 	tokenListContent, err := os.ReadFile(tokenListPath)
 	if err != nil {
@@ -612,6 +684,53 @@ func ProcessMetadataUpdates(bt *db.Bigtable, client *rpc.ErigonClient, prefix st
 	}
 }
 
+// ProcessSnapshotUpdates records the balances and token supplies that changed in a block at the end of that block
+func ProcessSnapshotUpdates(bt *db.Bigtable, client *rpc.ErigonClient, batchSize int, iterations int) {
+	lastKey := ""
+
+	its := 0
+	for {
+		start := time.Now()
+		keys, snapshots, err := bt.GetSnapshotUpdates(lastKey, batchSize)
+		if err != nil {
+			log.Error(err, "error retrieving snapshot updates from bigtable", 0)
+			return
+		}
+
+		if len(keys) == 0 {
+			return
+		}
+
+		for b := 0; b < len(snapshots); b += batchSize {
+			end := b + batchSize
+			if len(snapshots) < end {
+				end = len(snapshots)
+			}
+
+			err := client.GetBalanceSnapshots(snapshots[b:end])
+			if err != nil {
+				log.Error(err, "error retrieving balance snapshots from node", 0)
+				return
+			}
+		}
+
+		err = bt.SaveBalanceSnapshots(snapshots, keys)
+		if err != nil {
+			log.Error(err, "error saving balance snapshots to bigtable", 0)
+			return
+		}
+
+		lastKey = keys[len(keys)-1]
+		log.Infof("retrieved %v balance snapshots in %v, currently at %v", len(snapshots), time.Since(start), lastKey)
+
+		its++
+
+		if iterations != -1 && its > iterations {
+			return
+		}
+	}
+}
+
 func IndexFromNode(bt *db.Bigtable, client *rpc.ErigonClient, start, end, concurrency int64, traceMode string) error {
 	ctx := context.Background()
 	g, gCtx := errgroup.WithContext(ctx)
@@ -203,6 +203,9 @@ func Run() {
 			modules.NewExecutionDepositsExporter(context),
 			modules.NewExecutionPayloadsExporter(context),
 		)
+		if utils.Config.SlashingRiskDetector.Enabled {
+			usedModules = append(usedModules, modules.NewSlashingRiskDetector(context))
+		}
 	}
 
 	go modules.StartAll(context, usedModules, cfg.JustV2)
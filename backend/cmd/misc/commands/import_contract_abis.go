@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gobitfly/beaconchain/cmd/misc/misctypes"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+
+	"github.com/pkg/errors"
+)
+
+// ImportContractAbisCommand imports verified contract abis from a local copy of the sourcify repository
+// (or any directory using the same `<chain id>/<address>/metadata.json` layout) into the contract registry.
+type ImportContractAbisCommand struct {
+	FlagSet *flag.FlagSet
+	Config  importContractAbisConfig
+}
+
+type importContractAbisConfig struct {
+	DryRun      bool
+	SourcifyDir string
+}
+
+func (s *ImportContractAbisCommand) ParseCommandOptions() {
+	s.FlagSet.StringVar(&s.Config.SourcifyDir, "sourcify-dir", "", "Directory containing sourcify metadata, e.g. the `contracts` directory of the sourcify repository")
+}
+
+func (s *ImportContractAbisCommand) Requires() misctypes.Requires {
+	return misctypes.Requires{
+		Bigtable: true,
+		Redis:    true,
+	}
+}
+
+func (s *ImportContractAbisCommand) Run() error {
+	if s.Config.SourcifyDir == "" {
+		s.showHelp()
+		return errors.New("Please provide a valid directory via --sourcify-dir")
+	}
+
+	chainId := strconv.FormatUint(utils.Config.Chain.ClConfig.DepositChainID, 10)
+
+	// collect the metadata files first, full matches take precedence over partial matches of the same contract
+	files := make(map[common.Address]string)
+	err := filepath.WalkDir(s.Config.SourcifyDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "metadata.json" {
+			return nil
+		}
+		addressDir := filepath.Dir(path)
+		if filepath.Base(filepath.Dir(addressDir)) != chainId {
+			return nil
+		}
+		addressHex := filepath.Base(addressDir)
+		if !utils.IsEth1Address(addressHex) {
+			log.Warnf("skipping %v: not a contract address directory", path)
+			return nil
+		}
+		address := common.HexToAddress(addressHex)
+		if existing, ok := files[address]; ok && strings.Contains(existing, "full_match") {
+			return nil
+		}
+		files[address] = path
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error walking sourcify directory")
+	}
+	log.Infof("found metadata for %v contracts of chain %v", len(files), chainId)
+
+	imported := 0
+	for address, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "Error reading %v", path)
+		}
+		metadata, err := utils.ParseSourcifyMetadata(data)
+		if err != nil {
+			log.Warnf("skipping %v: %v", path, err)
+			continue
+		}
+		if s.Config.DryRun {
+			log.Infof("would import abi of contract %v (%v)", address.Hex(), metadata.Name)
+			continue
+		}
+		err = db.BigtableClient.SaveVerifiedContractMetadata(address.Bytes(), metadata)
+		if err != nil {
+			return errors.Wrapf(err, "Error saving abi of contract %v", address.Hex())
+		}
+		imported++
+	}
+
+	log.Infof("imported %v contract abis", imported)
+	return nil
+}
+
+func (s *ImportContractAbisCommand) showHelp() {
+	log.Infof("Usage: import-contract-abis [options]")
+	log.Infof("Options:")
+	log.Infof("  --sourcify-dir string\tDirectory containing sourcify metadata, only contracts of the configured chain are imported")
+	log.Infof("  --dry-run bool\tOnly log the contracts that would be imported (Default: true)")
+}
@@ -78,7 +78,8 @@ var opts = struct {
  * By default, all commands that are not in the REQUIRES_LIST will automatically require everything.
  */
 var REQUIRES_LIST = map[string]misctypes.Requires{
-	"app-bundle": (&commands.AppBundleCommand{}).Requires(),
+	"app-bundle":           (&commands.AppBundleCommand{}).Requires(),
+	"import-contract-abis": (&commands.ImportContractAbisCommand{}).Requires(),
 }
 
 func Run() {
@@ -92,8 +93,12 @@ func Run() {
 		FlagSet: fs,
 	}
 
+	importContractAbisCommand := commands.ImportContractAbisCommand{
+		FlagSet: fs,
+	}
+
 	configPath := fs.String("config", "config/default.config.yml", "Path to the config file")
-	fs.StringVar(&opts.Command, "command", "", "command to run, available: updateAPIKey, applyDbSchema, initBigtableSchema, epoch-export, debug-rewards, debug-blocks, clear-bigtable, index-old-eth1-blocks, update-aggregation-bits, historic-prices-export, index-missing-blocks, export-epoch-missed-slots, migrate-last-attestation-slot-bigtable, export-genesis-validators, update-block-finalization-sequentially, nameValidatorsByRanges, export-stats-totals, export-sync-committee-periods, export-sync-committee-validator-stats, partition-validator-stats, migrate-app-purchases, collect-notifications, collect-user-db-notifications, verify-fcm-tokens, app-bundle")
+	fs.StringVar(&opts.Command, "command", "", "command to run, available: updateAPIKey, applyDbSchema, initBigtableSchema, epoch-export, debug-rewards, debug-blocks, clear-bigtable, index-old-eth1-blocks, update-aggregation-bits, historic-prices-export, index-missing-blocks, export-epoch-missed-slots, migrate-last-attestation-slot-bigtable, export-genesis-validators, update-block-finalization-sequentially, nameValidatorsByRanges, export-stats-totals, export-sync-committee-periods, export-sync-committee-validator-stats, partition-validator-stats, migrate-app-purchases, collect-notifications, collect-user-db-notifications, verify-fcm-tokens, app-bundle, import-contract-abis")
 	fs.Uint64Var(&opts.StartEpoch, "start-epoch", 0, "start epoch")
 	fs.Uint64Var(&opts.EndEpoch, "end-epoch", 0, "end epoch")
 	fs.Uint64Var(&opts.User, "user", 0, "user id")
@@ -109,7 +114,7 @@ func Run() {
 	fs.Uint64Var(&opts.EndBlock, "blocks.end", 0, "Block to finish indexing")
 	fs.Uint64Var(&opts.DataConcurrency, "data.concurrency", 30, "Concurrency to use when indexing data from bigtable")
 	fs.Uint64Var(&opts.BatchSize, "data.batchSize", 1000, "Batch size")
-	fs.StringVar(&opts.Transformers, "transformers", "", "Comma separated list of transformers used by the eth1 indexer")
+	fs.StringVar(&opts.Transformers, "transformers", "", "Comma separated list of transformers used by the eth1 indexer, 'all' runs the default transformers of the eth1 indexer without TransformEventLogs")
 	fs.StringVar(&opts.ValidatorNameRanges, "validator-name-ranges", "https://config.dencun-devnet-8.ethpandaops.io/api/v1/nodes/validator-ranges", "url to or json of validator-ranges (format must be: {'ranges':{'X-Y':'name'}})")
 	fs.StringVar(&opts.Addresses, "addresses", "", "Comma separated list of addresses that should be processed by the command")
 	fs.StringVar(&opts.Columns, "columns", "", "Comma separated list of columns that should be affected by the command")
@@ -118,6 +123,7 @@ func Run() {
 
 	statsPartitionCommand.ParseCommandOptions()
 	appBundleCommand.ParseCommandOptions()
+	importContractAbisCommand.ParseCommandOptions()
 	_ = fs.Parse(os.Args[2:])
 
 	if *versionFlag {
@@ -531,6 +537,9 @@ func Run() {
 	case "app-bundle":
 		appBundleCommand.Config.DryRun = opts.DryRun
 		err = appBundleCommand.Run()
+	case "import-contract-abis":
+		importContractAbisCommand.Config.DryRun = opts.DryRun
+		err = importContractAbisCommand.Run()
 	case "fix-ens":
 		err = fixEns(erigonClient)
 	case "fix-ens-addresses":
@@ -1682,7 +1691,7 @@ func indexOldEth1Blocks(startBlock uint64, endBlock uint64, batchSize uint64, co
 	log.Infof("transformerFlag: %v", transformerFlag)
 	transformerList := strings.Split(transformerFlag, ",")
 	if transformerFlag == "all" {
-		transformerList = []string{"TransformBlock", "TransformTx", "TransformBlobTx", "TransformItx", "TransformERC20", "TransformERC721", "TransformERC1155", "TransformWithdrawals", "TransformUncle", "TransformEnsNameRegistered", "TransformContract"}
+		transformerList = []string{"TransformBlock", "TransformTx", "TransformBlobTx", "TransformItx", "TransformERC20", "TransformERC721", "TransformERC1155", "TransformWithdrawals", "TransformUncle", "TransformEnsNameRegistered", "TransformContract", "TransformSafe", "TransformLayer2", "TransformUserOperations"}
 	} else if len(transformerList) == 0 {
 		log.Error(nil, "no transformer functions provided", 0)
 		return
@@ -1717,6 +1726,14 @@ func indexOldEth1Blocks(startBlock uint64, endBlock uint64, batchSize uint64, co
 			importENSChanges = true
 		case "TransformContract":
 			transforms = append(transforms, bt.TransformContract)
+		case "TransformEventLogs":
+			transforms = append(transforms, bt.TransformEventLogs)
+		case "TransformSafe":
+			transforms = append(transforms, bt.TransformSafe)
+		case "TransformLayer2":
+			transforms = append(transforms, bt.TransformLayer2)
+		case "TransformUserOperations":
+			transforms = append(transforms, bt.TransformUserOperations)
 		default:
 			log.Error(nil, "Invalid transformer flag %v", 0)
 			return
@@ -34,6 +34,8 @@ type options struct {
 	statisticsChartToggle     bool
 	statisticsGraffitiToggle  bool
 	resetStatus               bool
+	validatorsPhase           string
+	validatorsDryRun          bool
 }
 
 var opt = &options{}
@@ -47,6 +49,8 @@ func Run() {
 	fs.BoolVar(&opt.statisticsChartToggle, "charts.enabled", false, "Toggle exporting chart series")
 	fs.BoolVar(&opt.statisticsGraffitiToggle, "graffiti.enabled", false, "Toggle exporting graffiti statistics")
 	fs.BoolVar(&opt.resetStatus, "validators.reset", false, "Export stats independent if they have already been exported previously")
+	fs.StringVar(&opt.validatorsPhase, "validators.phase", "", fmt.Sprintf("Only export a single phase of the validator statistics of the given days, one of %v", db.ValidatorStatsPhases()))
+	fs.BoolVar(&opt.validatorsDryRun, "validators.dryrun", false, "Recompute the validator statistics of the given days and log the differences to the exported rows without writing anything")
 
 	versionFlag := fs.Bool("version", false, "Show version and exit")
 	_ = fs.Parse(os.Args[2:])
@@ -174,11 +178,11 @@ func Run() {
 		if opt.statisticsValidatorToggle {
 			log.Infof("exporting validator statistics for days %v-%v", firstDay, lastDay)
 			for d := firstDay; d <= lastDay; d++ {
-				if opt.resetStatus {
+				if opt.resetStatus && !opt.validatorsDryRun {
 					clearStatsStatusTable(d)
 				}
 
-				err = db.WriteValidatorStatisticsForDay(d, rpcClient)
+				err = exportValidatorStatisticsForDay(d, rpcClient)
 				if err != nil {
 					log.Error(err, fmt.Errorf("error exporting stats for day %v", d), 0)
 					break
@@ -215,11 +219,11 @@ func Run() {
 		return
 	} else if opt.statisticsDayToExport >= 0 {
 		if opt.statisticsValidatorToggle {
-			if opt.resetStatus {
+			if opt.resetStatus && !opt.validatorsDryRun {
 				clearStatsStatusTable(uint64(opt.statisticsDayToExport))
 			}
 
-			err = db.WriteValidatorStatisticsForDay(uint64(opt.statisticsDayToExport), rpcClient)
+			err = exportValidatorStatisticsForDay(uint64(opt.statisticsDayToExport), rpcClient)
 			if err != nil {
 				log.Error(err, fmt.Errorf("error exporting stats for day %v", opt.statisticsDayToExport), 0)
 			}
@@ -358,6 +362,32 @@ func statisticsLoop(client rpc.Client) {
 	}
 }
 
+// exportValidatorStatisticsForDay exports the validator statistics of a manually requested day, honoring the phase and dry run flags
+func exportValidatorStatisticsForDay(day uint64, client rpc.Client) error {
+	phase := db.ValidatorStatsPhase(opt.validatorsPhase)
+
+	if opt.validatorsDryRun {
+		diffs, err := db.DiffValidatorStatisticsForDay(day, phase, client)
+		if err != nil {
+			return err
+		}
+		const maxLoggedDiffs = 100
+		for i, diff := range diffs {
+			if i == maxLoggedDiffs {
+				log.Infof("omitting %v more differences of day %v", len(diffs)-maxLoggedDiffs, day)
+				break
+			}
+			log.Infof("day %v validator %v %v differs: exported %v, computed %v", day, diff.ValidatorIndex, diff.Column, diff.Existing, diff.Computed)
+		}
+		return nil
+	}
+
+	if phase != "" {
+		return db.WriteValidatorStatisticsPhaseForDay(day, phase, client)
+	}
+	return db.WriteValidatorStatisticsForDay(day, client)
+}
+
 func clearStatsStatusTable(day uint64) {
 	log.Infof("deleting validator_stats_status for day %v", day)
 	_, err := db.WriterDb.Exec("DELETE FROM validator_stats_status WHERE day = $1", day)
@@ -353,6 +353,58 @@ func TestInternalSearchHandler(t *testing.T) {
 	assert.Greater(t, *resp.Data[0].NumValue, uint64(0), "returned number of validators should be greater than 0")
 }
 
+func TestNetworkDepositsAndWithdrawals(t *testing.T) {
+	e := httpexpect.WithConfig(getExpectConfig(t, ts))
+
+	t.Run("test deposits", func(t *testing.T) {
+		resp := api_types.GetNetworkDepositsResponse{}
+		e.GET("/api/i/networks/{network}/deposits", 17000).
+			WithQuery("limit", "10").
+			Expect().Status(http.StatusOK).JSON().Decode(&resp)
+
+		assert.Equal(t, 10, len(resp.Data), "response data should contain 10 deposits")
+		for _, deposit := range resp.Data {
+			if deposit.IsTopUp {
+				assert.True(t, deposit.Valid, "top ups should be valid regardless of their signature")
+			}
+		}
+		require.NotEqual(t, "", resp.Paging.NextCursor, "deposits should be paged")
+
+		next := api_types.GetNetworkDepositsResponse{}
+		e.GET("/api/i/networks/{network}/deposits", 17000).
+			WithQuery("limit", "10").
+			WithQuery("cursor", resp.Paging.NextCursor).
+			Expect().Status(http.StatusOK).JSON().Decode(&next)
+
+		assert.NotEqual(t, 0, len(next.Data), "next page should not be empty")
+		assert.LessOrEqual(t, next.Data[0].Block, resp.Data[len(resp.Data)-1].Block, "next page should contain older deposits")
+	})
+
+	t.Run("test withdrawals", func(t *testing.T) {
+		resp := api_types.GetNetworkWithdrawalsResponse{}
+		e.GET("/api/i/networks/{network}/withdrawals", 17000).
+			WithQuery("limit", "10").
+			Expect().Status(http.StatusOK).JSON().Decode(&resp)
+
+		assert.Equal(t, 10, len(resp.Data), "response data should contain 10 withdrawals")
+		for i := 1; i < len(resp.Data); i++ {
+			assert.Less(t, resp.Data[i].Index, resp.Data[i-1].Index, "withdrawals should be sorted by index, latest first")
+		}
+	})
+
+	t.Run("test withdrawal credential totals", func(t *testing.T) {
+		credential := "0x0100000000000000000000000e5dda855eb1de2a212cd1f62b2a3ee49d20c444"
+		resp := api_types.GetNetworkWithdrawalCredentialTotalsResponse{}
+		e.GET("/api/i/networks/{network}/withdrawal-credentials/{credential}/totals", 17000, credential).
+			Expect().Status(http.StatusOK).JSON().Decode(&resp)
+
+		assert.Equal(t, credential, string(resp.Data.WithdrawalCredential))
+		assert.Greater(t, resp.Data.Validators, uint64(0), "withdrawal credential should be used by at least one validator")
+		assert.Equal(t, resp.Data.DepositsCount > 0, resp.Data.DepositsAmount.IsPositive(), "deposits amount should match the deposits count")
+		assert.Equal(t, resp.Data.WithdrawalsCount > 0, resp.Data.WithdrawalsAmount.IsPositive(), "withdrawals amount should match the withdrawals count")
+	})
+}
+
 func TestPublicAndSharedDashboards(t *testing.T) {
 	t.Parallel()
 	e := httpexpect.WithConfig(getExpectConfig(t, ts))
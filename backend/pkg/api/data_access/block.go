@@ -2,8 +2,12 @@ package dataaccess
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
 )
 
 type BlockRepository interface {
@@ -69,8 +73,36 @@ func (d *DataAccessService) GetBlockVoluntaryExits(ctx context.Context, chainId,
 }
 
 func (d *DataAccessService) GetBlockBlobs(ctx context.Context, chainId, block uint64) ([]t.BlockBlobTableRow, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlockBlobs(ctx, chainId, block)
+	blobs, err := d.GetNetworkBlockBlobs(ctx, chainId, block)
+	if err != nil {
+		return nil, err
+	}
+	// the sidecars don't reference the blob transaction, the transactions of the execution block do
+	transactionHashes := make(map[t.Hash]t.Hash)
+	if len(blobs) > 0 && d.bigtable != nil {
+		eth1Block, err := d.bigtable.GetBlockFromBlocksTable(block)
+		if err != nil && !errors.Is(err, db.ErrBlockNotFound) {
+			return nil, fmt.Errorf("error retrieving transactions of block %d: %w", block, err)
+		}
+		for _, tx := range eth1Block.GetTransactions() {
+			for _, versionedHash := range tx.GetBlobVersionedHashes() {
+				transactionHashes[t.Hash(hexutil.Encode(versionedHash))] = t.Hash(hexutil.Encode(tx.GetHash()))
+			}
+		}
+	}
+	result := make([]t.BlockBlobTableRow, len(blobs))
+	for i, blob := range blobs {
+		result[i] = t.BlockBlobTableRow{
+			VersionedHash:   blob.VersionedHash,
+			Commitment:      blob.KzgCommitment,
+			Proof:           blob.KzgProof,
+			Size:            blob.Size,
+			TransactionHash: transactionHashes[blob.VersionedHash],
+			Block:           block,
+			Data:            blob.Data,
+		}
+	}
+	return result, nil
 }
 
 func (d *DataAccessService) GetSlot(ctx context.Context, chainId, slot uint64) (*t.BlockSummary, error) {
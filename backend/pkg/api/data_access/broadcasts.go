@@ -0,0 +1,164 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/nodejobs"
+	"github.com/shopspring/decimal"
+)
+
+type BroadcastRepository interface {
+	// CreateNetworkBroadcast verifies the given signed operation(s) and queues them for broadcasting.
+	// Returns a types.CreateNodeJobUserError if the data is invalid.
+	CreateNetworkBroadcast(ctx context.Context, chainId uint64, data []byte) (*t.NetworkBroadcast, error)
+	GetNetworkBroadcast(ctx context.Context, chainId uint64, broadcastId string) (*t.NetworkBroadcast, error)
+}
+
+func (d *DataAccessService) CreateNetworkBroadcast(ctx context.Context, chainId uint64, data []byte) (*t.NetworkBroadcast, error) {
+	// signatures are verified against the domains of the chain this instance is configured for
+	if chainId != utils.Config.Chain.ClConfig.DepositChainID {
+		return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("broadcasts are not supported for network %d", chainId)}
+	}
+	job, err := nodejobs.CreateNodeJob(data)
+	if err != nil {
+		return nil, err
+	}
+	return d.mapNodeJobToNetworkBroadcast(ctx, job)
+}
+
+func (d *DataAccessService) GetNetworkBroadcast(ctx context.Context, chainId uint64, broadcastId string) (*t.NetworkBroadcast, error) {
+	if chainId != utils.Config.Chain.ClConfig.DepositChainID {
+		return nil, fmt.Errorf("%w: broadcast with id %s", ErrNotFound, broadcastId)
+	}
+	job, err := nodejobs.GetNodeJob(broadcastId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: broadcast with id %s", ErrNotFound, broadcastId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d.mapNodeJobToNetworkBroadcast(ctx, job)
+}
+
+func (d *DataAccessService) mapNodeJobToNetworkBroadcast(ctx context.Context, job *types.NodeJob) (*t.NetworkBroadcast, error) {
+	result := &t.NetworkBroadcast{
+		Id:        job.ID,
+		CreatedTs: job.CreatedTime.Unix(),
+	}
+	switch job.Status {
+	case types.PendingNodeJobStatus:
+		result.Status = "pending"
+	case types.SubmittedToNodeNodeJobStatus:
+		result.Status = "submitted"
+	case types.CompletedNodeJobStatus:
+		result.Status = "completed"
+	case types.FailedNodeJobStatus:
+		result.Status = "failed"
+	}
+	if job.SubmittedToNodeTime.Valid {
+		ts := job.SubmittedToNodeTime.Time.Unix()
+		result.SubmittedTs = &ts
+	}
+	if job.CompletedTime.Valid {
+		ts := job.CompletedTime.Time.Unix()
+		result.CompletedTs = &ts
+	}
+
+	switch job.Type {
+	case types.BLSToExecutionChangesNodeJobType:
+		result.Type = "bls_to_execution_changes"
+		ops, ok := job.GetBLSToExecutionChangesNodeJobData()
+		if !ok {
+			return nil, fmt.Errorf("invalid bls to execution job-data for job %s", job.ID)
+		}
+		for _, op := range ops {
+			result.Validators = append(result.Validators, uint64(op.Message.ValidatorIndex))
+		}
+	case types.VoluntaryExitsNodeJobType:
+		result.Type = "voluntary_exit"
+		op, ok := job.GetVoluntaryExitsNodeJobData()
+		if !ok {
+			return nil, fmt.Errorf("invalid voluntary exit job-data for job %s", job.ID)
+		}
+		result.Validators = []uint64{uint64(op.Message.ValidatorIndex)}
+	case types.ExecutionTransactionsNodeJobType:
+		result.Type = "execution_transaction"
+		tx, ok := job.GetExecutionTransactionsNodeJobData()
+		if !ok {
+			return nil, fmt.Errorf("invalid execution transaction job-data for job %s", job.ID)
+		}
+		txHash := t.Hash(tx.Hash().Hex())
+		result.TxHash = &txHash
+	case types.WithdrawalRequestsNodeJobType:
+		result.Type = "withdrawal_requests"
+		requests, ok := job.GetWithdrawalRequestsNodeJobData()
+		if !ok {
+			return nil, fmt.Errorf("invalid withdrawal request job-data for job %s", job.ID)
+		}
+		value := d.getExecutionRequestValue(ctx, types.WithdrawalRequestPredeployAddress)
+		for _, r := range requests {
+			result.CallData = append(result.CallData, t.NetworkBroadcastCallData{
+				From:  t.Hash(r.SourceAddress.Hex()),
+				To:    t.Hash(types.WithdrawalRequestPredeployAddress.Hex()),
+				Data:  hexutil.Encode(r.CallData()),
+				Value: value,
+			})
+		}
+	case types.ConsolidationRequestsNodeJobType:
+		result.Type = "consolidation_requests"
+		requests, ok := job.GetConsolidationRequestsNodeJobData()
+		if !ok {
+			return nil, fmt.Errorf("invalid consolidation request job-data for job %s", job.ID)
+		}
+		value := d.getExecutionRequestValue(ctx, types.ConsolidationRequestPredeployAddress)
+		for _, r := range requests {
+			result.CallData = append(result.CallData, t.NetworkBroadcastCallData{
+				From:  t.Hash(r.SourceAddress.Hex()),
+				To:    t.Hash(types.ConsolidationRequestPredeployAddress.Hex()),
+				Data:  hexutil.Encode(r.CallData()),
+				Value: value,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unknown job-type %v for job %s", job.Type, job.ID)
+	}
+	if job.Type == types.WithdrawalRequestsNodeJobType || job.Type == types.ConsolidationRequestsNodeJobType {
+		// requests reference validators by pubkey
+		infos, err := nodejobs.GetNodeJobValidatorInfos(job)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			result.Validators = append(result.Validators, info.ValidatorIndex)
+		}
+	}
+	return result, nil
+}
+
+// getExecutionRequestValue returns the value in wei a request transaction to the predeploy should send. The fee rises while requests are queued, so twice the
+// current fee is returned which covers about 12 more requests being queued before inclusion, the fee is tiny unless the queue is congested.
+// nil is returned if the execution client is unavailable, the broadcast is returned without value then.
+func (d *DataAccessService) getExecutionRequestValue(ctx context.Context, predeploy common.Address) *decimal.Decimal {
+	if d.executionClient == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	fee, err := nodejobs.GetExecutionRequestFee(ctx, d.executionClient, predeploy)
+	if err != nil {
+		log.Warnf("error reading fee of predeploy %v: %v", predeploy.Hex(), err)
+		return nil
+	}
+	value := decimal.NewFromBigInt(fee, 0).Mul(decimal.NewFromInt(2))
+	return &value
+}
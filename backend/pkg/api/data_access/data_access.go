@@ -6,9 +6,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/go-redis/redis/v8"
 	"github.com/gobitfly/beaconchain/pkg/api/services"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/blobstore"
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
@@ -32,6 +34,10 @@ type DataAccessor interface {
 	RatelimitRepository
 	HealthzRepository
 	MachineRepository
+	BroadcastRepository
+	IncomeReportRepository
+	EmailRepository
+	MultisigRepository
 
 	Close()
 
@@ -58,6 +64,8 @@ type DataAccessService struct {
 	userWriter              *sqlx.DB
 	bigtable                *db.Bigtable
 	persistentRedisDbClient *redis.Client
+	blobStore               blobstore.Store   // nil if no blob storage is configured
+	executionClient         *ethclient.Client // nil if no execution client is configured
 
 	services *services.Services
 
@@ -239,6 +247,32 @@ func createDataAccessService(cfg *types.Config) *DataAccessService {
 		dataAccessService.persistentRedisDbClient = rdc
 	}()
 
+	// Initialize the blob store, archived blobs are only served if the storage of the blobindexer is configured
+	if blobstore.IsConfigured(cfg) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store, err := blobstore.New(context.Background(), cfg)
+			if err != nil {
+				log.Fatal(err, "error initializing blob store", 0)
+			}
+			dataAccessService.blobStore = store
+		}()
+	}
+
+	// Initialize the execution client, it is used to read the fees of the execution layer request predeploys
+	if len(cfg.Eth1ErigonEndpoint) != 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client, err := ethclient.Dial(cfg.Eth1ErigonEndpoint)
+			if err != nil {
+				log.Fatal(err, "error dialing execution client", 0)
+			}
+			dataAccessService.executionClient = client
+		}()
+	}
+
 	wg.Wait()
 
 	if cfg.TieredCacheProvider != "redis" {
@@ -251,7 +285,7 @@ func createDataAccessService(cfg *types.Config) *DataAccessService {
 
 func (d *DataAccessService) StartDataAccessServices() {
 	// Create the services
-	d.services = services.NewServices(d.readerDb, d.writerDb, d.alloyReader, d.alloyWriter, d.clickhouseReader, d.bigtable, d.persistentRedisDbClient)
+	d.services = services.NewServices(d.readerDb, d.writerDb, d.alloyReader, d.alloyWriter, d.clickhouseReader, d.bigtable, d.persistentRedisDbClient, d.userWriter)
 
 	// Initialize repositories
 	d.registerNotificationInterfaceTypes()
@@ -262,6 +296,8 @@ func (d *DataAccessService) StartDataAccessServices() {
 	} else {
 		d.services.InitServices()
 	}
+
+	go d.startIncomeReportService()
 }
 
 func (d *DataAccessService) Close() {
@@ -283,6 +319,9 @@ func (d *DataAccessService) Close() {
 	if d.bigtable != nil {
 		d.bigtable.Close()
 	}
+	if d.executionClient != nil {
+		d.executionClient.Close()
+	}
 }
 
 var ErrNotFound = errors.New("not found")
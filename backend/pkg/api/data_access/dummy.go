@@ -290,6 +290,22 @@ func (d *DummyService) RemoveValidatorDashboardValidators(ctx context.Context, d
 	return nil
 }
 
+func (d *DummyService) MoveValidatorDashboardValidators(ctx context.Context, dashboardId t.VDBIdPrimary, fromGroupId *uint64, validators []t.VDBValidator, toGroupId uint64) ([]t.VDBPostValidatorsData, error) {
+	return getDummyData[[]t.VDBPostValidatorsData](ctx)
+}
+
+func (d *DummyService) GetValidatorDashboardTags(ctx context.Context, dashboardId t.VDBIdPrimary) ([]t.VDBTagKey, error) {
+	return getDummyData[[]t.VDBTagKey](ctx)
+}
+
+func (d *DummyService) SetValidatorDashboardValidatorTags(ctx context.Context, dashboardId t.VDBIdPrimary, validators []t.VDBValidator, tags []t.VDBValidatorTag) error {
+	return nil
+}
+
+func (d *DummyService) RemoveValidatorDashboardValidatorTags(ctx context.Context, dashboardId t.VDBIdPrimary, validators []t.VDBValidator, keys []string) error {
+	return nil
+}
+
 func (d *DummyService) CreateValidatorDashboardPublicId(ctx context.Context, dashboardId t.VDBIdPrimary, name string, shareGroups bool) (*t.VDBPublicId, error) {
 	return getDummyStruct[t.VDBPublicId](ctx)
 }
@@ -314,6 +330,20 @@ func (d *DummyService) GetValidatorDashboardSlotViz(ctx context.Context, dashboa
 	return r.Epochs, err
 }
 
+func (d *DummyService) SubscribeValidatorDashboardLiveUpdates(ctx context.Context, dashboardId t.VDBId, groupIds []uint64, lastEventId uint64) (<-chan t.VDBLiveUpdate, error) {
+	update, err := getDummyStruct[t.VDBLiveUpdate](ctx)
+	if err != nil {
+		return nil, err
+	}
+	updates := make(chan t.VDBLiveUpdate, 1)
+	updates <- *update
+	go func() {
+		<-ctx.Done()
+		close(updates)
+	}()
+	return updates, nil
+}
+
 func (d *DummyService) GetValidatorDashboardSummary(ctx context.Context, dashboardId t.VDBId, period enums.TimePeriod, cursor string, colSort t.Sort[enums.VDBSummaryColumn], search string, limit uint64, protocolModes t.VDBProtocolModes) ([]t.VDBSummaryTableRow, *t.Paging, error) {
 	return getDummyWithPaging[t.VDBSummaryTableRow](ctx)
 }
@@ -390,6 +420,10 @@ func (d *DummyService) GetValidatorDashboardTotalWithdrawals(ctx context.Context
 	return getDummyStruct[t.VDBTotalWithdrawalsData](ctx)
 }
 
+func (d *DummyService) GetValidatorDashboardBlsChanges(ctx context.Context, dashboardId t.VDBId) (*t.VDBBlsChangesData, error) {
+	return getDummyStruct[t.VDBBlsChangesData](ctx)
+}
+
 func (d *DummyService) GetValidatorDashboardRocketPool(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBRocketPoolColumn], search string, limit uint64) ([]t.VDBRocketPoolTableRow, *t.Paging, error) {
 	return getDummyWithPaging[t.VDBRocketPoolTableRow](ctx)
 }
@@ -422,6 +456,162 @@ func (d *DummyService) GetAllNetworks() ([]t.NetworkInfo, error) {
 	}, nil
 }
 
+func (d *DummyService) GetNetworkSlashings(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkSlashingTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkSlashingTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkValidatorSlashings(ctx context.Context, chainId uint64, validator t.VDBValidator) (*t.NetworkValidatorSlashings, error) {
+	return getDummyStruct[t.NetworkValidatorSlashings](ctx)
+}
+
+func (d *DummyService) GetNetworkVoluntaryExits(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkVoluntaryExitTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkVoluntaryExitTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkEpochVoluntaryExits(ctx context.Context, chainId, epoch uint64) ([]t.NetworkVoluntaryExitTableRow, error) {
+	return getDummyData[[]t.NetworkVoluntaryExitTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkSlotVoluntaryExits(ctx context.Context, chainId, slot uint64) ([]t.NetworkVoluntaryExitTableRow, error) {
+	return getDummyData[[]t.NetworkVoluntaryExitTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkBlockVoluntaryExits(ctx context.Context, chainId, block uint64) ([]t.NetworkVoluntaryExitTableRow, error) {
+	return getDummyData[[]t.NetworkVoluntaryExitTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkDeposits(ctx context.Context, chainId uint64, filter t.NetworkDepositsFilter, cursor string, limit uint64) ([]t.NetworkDepositTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkDepositTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkWithdrawals(ctx context.Context, chainId uint64, filter t.NetworkWithdrawalsFilter, cursor string, limit uint64) ([]t.NetworkWithdrawalTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkWithdrawalTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkWithdrawalCredentialTotals(ctx context.Context, chainId uint64, credential []byte) (*t.NetworkWithdrawalCredentialTotals, error) {
+	return getDummyStruct[t.NetworkWithdrawalCredentialTotals](ctx)
+}
+
+func (d *DummyService) GetNetworkBlsChanges(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkBlsChangeTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkBlsChangeTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkEpochBlsChanges(ctx context.Context, chainId, epoch uint64) ([]t.NetworkBlsChangeTableRow, error) {
+	return getDummyData[[]t.NetworkBlsChangeTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkSlotBlsChanges(ctx context.Context, chainId, slot uint64) ([]t.NetworkBlsChangeTableRow, error) {
+	return getDummyData[[]t.NetworkBlsChangeTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkBlockBlsChanges(ctx context.Context, chainId, block uint64) ([]t.NetworkBlsChangeTableRow, error) {
+	return getDummyData[[]t.NetworkBlsChangeTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkValidatorBlsChanges(ctx context.Context, chainId uint64, validator t.VDBValidator) ([]t.NetworkBlsChangeTableRow, error) {
+	return getDummyData[[]t.NetworkBlsChangeTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkBlob(ctx context.Context, chainId uint64, versionedHash []byte) (*t.NetworkBlob, error) {
+	return getDummyStruct[t.NetworkBlob](ctx)
+}
+
+func (d *DummyService) GetNetworkSlotBlobs(ctx context.Context, chainId, slot uint64) ([]t.NetworkBlob, error) {
+	return getDummyData[[]t.NetworkBlob](ctx)
+}
+
+func (d *DummyService) GetNetworkBlockBlobs(ctx context.Context, chainId, block uint64) ([]t.NetworkBlob, error) {
+	return getDummyData[[]t.NetworkBlob](ctx)
+}
+
+func (d *DummyService) GetNetworkBlobBlocks(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkBlobBlockTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkBlobBlockTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkBlobHistory(ctx context.Context, chainId uint64, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error) {
+	return getDummyStruct[t.ChartData[string, float64]](ctx)
+}
+
+func (d *DummyService) GetNetworkGasNow(ctx context.Context, chainId uint64) (*t.NetworkGasNow, error) {
+	return getDummyStruct[t.NetworkGasNow](ctx)
+}
+
+func (d *DummyService) GetNetworkAverageGasLimitHistory(ctx context.Context, chainId uint64, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error) {
+	return getDummyStruct[t.ChartData[string, float64]](ctx)
+}
+
+func (d *DummyService) GetNetworkGasUsedHistory(ctx context.Context, chainId uint64, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error) {
+	return getDummyStruct[t.ChartData[string, float64]](ctx)
+}
+
+func (d *DummyService) GetNetworkBlobSubmitters(ctx context.Context, chainId uint64, period enums.TimePeriod, limit uint64) ([]t.NetworkBlobSubmitter, error) {
+	return getDummyData[[]t.NetworkBlobSubmitter](ctx)
+}
+
+func (d *DummyService) GetNetworkTransaction(ctx context.Context, chainId uint64, hash []byte) (*t.NetworkTransaction, error) {
+	return getDummyStruct[t.NetworkTransaction](ctx)
+}
+
+func (d *DummyService) GetNetworkTransactions(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkTransactionTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkTransactionTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkSlotTransactions(ctx context.Context, chainId, slot uint64) ([]t.NetworkTransactionTableRow, error) {
+	return getDummyData[[]t.NetworkTransactionTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkBlockTransactions(ctx context.Context, chainId, block uint64) ([]t.NetworkTransactionTableRow, error) {
+	return getDummyData[[]t.NetworkTransactionTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkAddressTransactions(ctx context.Context, chainId uint64, address []byte, txType enums.AddressTransactionType, cursor string, limit uint64) ([]t.NetworkAddressTransactionTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkAddressTransactionTableRow](ctx)
+}
+
+func (d *DummyService) SaveNetworkContractAbi(ctx context.Context, chainId uint64, address []byte, name string, abiJson []byte) (*t.NetworkContractAbi, error) {
+	return getDummyStruct[t.NetworkContractAbi](ctx)
+}
+
+func (d *DummyService) GetNetworkAddressBalanceHistory(ctx context.Context, chainId uint64, address, token []byte, cursor string, limit uint64) ([]t.NetworkAddressBalanceSnapshot, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkAddressBalanceSnapshot](ctx)
+}
+
+func (d *DummyService) GetNetworkAddressTokenSupplyHistory(ctx context.Context, chainId uint64, token []byte, cursor string, limit uint64) ([]t.NetworkTokenSupplySnapshot, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkTokenSupplySnapshot](ctx)
+}
+
+func (d *DummyService) GetNetworkAddressEventLogs(ctx context.Context, chainId uint64, address []byte, cursor string, limit uint64) ([]t.NetworkAddressEventLog, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkAddressEventLog](ctx)
+}
+
+func (d *DummyService) GetNetworkEns(ctx context.Context, name string) (*t.NetworkEnsName, error) {
+	return getDummyStruct[t.NetworkEnsName](ctx)
+}
+
+func (d *DummyService) GetNetworkAddressEns(ctx context.Context, address []byte) (*t.NetworkAddressEns, error) {
+	return getDummyStruct[t.NetworkAddressEns](ctx)
+}
+
+func (d *DummyService) GetNetworkBatches(ctx context.Context, layer2ChainId uint64, cursor string, limit uint64) ([]t.NetworkBatchTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkBatchTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkLayer1ToLayer2Transactions(ctx context.Context, layer2ChainId uint64, cursor string, limit uint64) ([]t.NetworkLayer1ToLayer2TransactionTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkLayer1ToLayer2TransactionTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkLayer2ToLayer1Transactions(ctx context.Context, layer2ChainId uint64, cursor string, limit uint64) ([]t.NetworkLayer2ToLayer1TransactionTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkLayer2ToLayer1TransactionTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkAddressUserOperations(ctx context.Context, chainId uint64, address []byte, role enums.UserOperationRole, cursor string, limit uint64) ([]t.NetworkUserOperationTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NetworkUserOperationTableRow](ctx)
+}
+
+func (d *DummyService) GetNetworkAddressSponsorship(ctx context.Context, chainId uint64, address []byte, period enums.TimePeriod) (*t.NetworkPaymasterSponsorship, error) {
+	return getDummyStruct[t.NetworkPaymasterSponsorship](ctx)
+}
+
 func (d *DummyService) GetAllClients() ([]t.ClientInfo, error) {
 	return []t.ClientInfo{
 		// execution_layer
@@ -546,6 +736,14 @@ func (d *DummyService) GetValidatorDashboardPublicIdCount(ctx context.Context, d
 	return getDummyData[uint64](ctx)
 }
 
+func (d *DummyService) GetValidatorDashboardExport(ctx context.Context, dashboardId t.VDBIdPrimary) (*t.VDBExport, error) {
+	return getDummyStruct[t.VDBExport](ctx)
+}
+
+func (d *DummyService) ImportValidatorDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, data t.VDBExport, maxGroups, maxValidators uint64, dryRun bool) (*t.VDBImportResult, error) {
+	return getDummyStruct[t.VDBImportResult](ctx)
+}
+
 func (d *DummyService) GetNotificationOverview(ctx context.Context, userId uint64) (*t.NotificationOverviewData, error) {
 	return getDummyStruct[t.NotificationOverviewData](ctx)
 }
@@ -611,6 +809,14 @@ func (d *DummyService) GetNotificationSettingsDashboards(ctx context.Context, us
 func (d *DummyService) UpdateNotificationSettingsValidatorDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsValidatorDashboard) error {
 	return nil
 }
+
+func (d *DummyService) GetNotificationSettingsValidatorDashboardTags(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary) ([]t.NotificationSettingsValidatorDashboardTag, error) {
+	return getDummyData[[]t.NotificationSettingsValidatorDashboardTag](ctx)
+}
+
+func (d *DummyService) UpdateNotificationSettingsValidatorDashboardTag(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, tag t.VDBValidatorTag, settings t.NotificationSettingsValidatorDashboard) error {
+	return nil
+}
 func (d *DummyService) UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsAccountDashboard) error {
 	return nil
 }
@@ -746,6 +952,10 @@ func (d *DummyService) GetApiWeights(ctx context.Context) ([]t.ApiWeightItem, er
 	return getDummyData[[]t.ApiWeightItem](ctx)
 }
 
+func (d *DummyService) GetUserApiUsage(ctx context.Context, userId uint64, start, end time.Time) (*t.UserApiUsage, error) {
+	return getDummyStruct[t.UserApiUsage](ctx)
+}
+
 func (d *DummyService) GetHealthz(ctx context.Context, showAll bool) t.HealthzData {
 	r, _ := getDummyData[t.HealthzData](ctx)
 	return r
@@ -801,3 +1011,43 @@ func (d *DummyService) QueueTestWebhookNotification(ctx context.Context, userId
 func (d *DummyService) GetPairedDeviceUserId(ctx context.Context, pairedDeviceId uint64) (uint64, error) {
 	return getDummyData[uint64](ctx)
 }
+
+func (d *DummyService) CreateNetworkBroadcast(ctx context.Context, chainId uint64, data []byte) (*t.NetworkBroadcast, error) {
+	return getDummyStruct[t.NetworkBroadcast](ctx)
+}
+
+func (d *DummyService) GetNetworkBroadcast(ctx context.Context, chainId uint64, broadcastId string) (*t.NetworkBroadcast, error) {
+	return getDummyStruct[t.NetworkBroadcast](ctx)
+}
+
+func (d *DummyService) CreateValidatorDashboardIncomeReport(ctx context.Context, dashboardId t.VDBId, groupId int64, aggregation string, startTs, endTs int64, currency, format string) (*t.VDBIncomeReport, error) {
+	return getDummyStruct[t.VDBIncomeReport](ctx)
+}
+
+func (d *DummyService) GetValidatorDashboardIncomeReport(ctx context.Context, dashboardId t.VDBId, reportId string) (*t.VDBIncomeReport, error) {
+	return getDummyStruct[t.VDBIncomeReport](ctx)
+}
+
+func (d *DummyService) GetValidatorDashboardIncomeReportFile(ctx context.Context, dashboardId t.VDBId, reportId string) (*t.VDBIncomeReportFile, error) {
+	return getDummyStruct[t.VDBIncomeReportFile](ctx)
+}
+
+func (d *DummyService) QueueEmail(ctx context.Context, recipient, subject, message string, timeout time.Duration) error {
+	return nil
+}
+
+func (d *DummyService) MarkEmailUndeliverable(ctx context.Context, email, reason, details string) error {
+	return nil
+}
+
+func (d *DummyService) GetMultisigSafe(ctx context.Context, address []byte) (*t.MultisigSafe, error) {
+	return getDummyStruct[t.MultisigSafe](ctx)
+}
+
+func (d *DummyService) GetMultisigSafeTransactions(ctx context.Context, address []byte, cursor string, limit uint64) ([]t.MultisigSafeTransactionTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.MultisigSafeTransactionTableRow](ctx)
+}
+
+func (d *DummyService) GetMultisigTransactionConfirmations(ctx context.Context, safeTxHash []byte) (*t.MultisigTransactionConfirmations, error) {
+	return getDummyStruct[t.MultisigTransactionConfirmations](ctx)
+}
@@ -0,0 +1,28 @@
+package dataaccess
+
+import (
+	"context"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/services"
+)
+
+var ErrEmailUndeliverable = services.ErrEmailUndeliverable
+
+type EmailRepository interface {
+	// QueueEmail queues a plain text email, it is dropped if it could not be sent before the timeout. Returns ErrEmailUndeliverable if the address bounced before.
+	QueueEmail(ctx context.Context, recipient, subject, message string, timeout time.Duration) error
+	MarkEmailUndeliverable(ctx context.Context, email, reason, details string) error
+}
+
+func (d *DataAccessService) QueueEmail(ctx context.Context, recipient, subject, message string, timeout time.Duration) error {
+	return d.services.QueueEmail(ctx, services.EMail{
+		Recipient: recipient,
+		Subject:   subject,
+		Message:   message,
+	}, timeout)
+}
+
+func (d *DataAccessService) MarkEmailUndeliverable(ctx context.Context, email, reason, details string) error {
+	return d.services.MarkEmailUndeliverable(ctx, email, reason, details)
+}
@@ -0,0 +1,208 @@
+package dataaccess
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/contracts/safe"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/shopspring/decimal"
+)
+
+type MultisigRepository interface {
+	GetMultisigSafe(ctx context.Context, address []byte) (*t.MultisigSafe, error)
+	GetMultisigSafeTransactions(ctx context.Context, address []byte, cursor string, limit uint64) ([]t.MultisigSafeTransactionTableRow, *t.Paging, error)
+	GetMultisigTransactionConfirmations(ctx context.Context, safeTxHash []byte) (*t.MultisigTransactionConfirmations, error)
+}
+
+func (d *DataAccessService) GetMultisigSafe(ctx context.Context, address []byte) (*t.MultisigSafe, error) {
+	data, err := d.bigtable.GetSafe(address)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving safe %#x: %w", address, err)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("%w: safe %#x", ErrNotFound, address)
+	}
+
+	result := &t.MultisigSafe{
+		Address:   t.Address{Hash: t.Hash(hexutil.Encode(data.Address)), IsContract: true},
+		Owners:    make([]t.Address, len(data.Owners)),
+		Threshold: data.Threshold,
+	}
+	if data.SetupTxHash != nil {
+		setupTxHash := t.Hash(hexutil.Encode(data.SetupTxHash))
+		setupTimestamp := int64(data.SetupTime)
+		result.SetupTxHash = &setupTxHash
+		result.SetupBlock = &data.SetupBlock
+		result.SetupTimestamp = &setupTimestamp
+	}
+
+	addressMapping := map[string]*t.Address{string(result.Address.Hash): &result.Address}
+	for i, owner := range data.Owners {
+		result.Owners[i] = t.Address{Hash: t.Hash(hexutil.Encode(owner))}
+		addressMapping[string(result.Owners[i].Hash)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	result.Address = *addressMapping[string(result.Address.Hash)]
+	for i := range result.Owners {
+		result.Owners[i] = *addressMapping[string(result.Owners[i].Hash)]
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetMultisigSafeTransactions(ctx context.Context, address []byte, cursor string, limit uint64) ([]t.MultisigSafeTransactionTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.MultisigSafeTransactionsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.MultisigSafeTransactionsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as MultisigSafeTransactionsCursor: %w", err)
+		}
+	}
+	var executionCursor *types.Eth1SafeExecution
+	if currentCursor.IsValid() {
+		executionCursor = &types.Eth1SafeExecution{
+			BlockNumber: currentCursor.Block,
+			TxIndex:     currentCursor.TransactionIndex,
+			LogIndex:    currentCursor.LogIndex,
+		}
+	}
+
+	data, err := d.bigtable.GetSafeExecutions(address, executionCursor, currentCursor.IsReverse(), int64(limit+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving transactions of safe %#x: %w", address, err)
+	}
+
+	moreDataFlag := len(data) > int(limit)
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	result := make([]t.MultisigSafeTransactionTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, execution := range data {
+		result[i] = t.MultisigSafeTransactionTableRow{
+			SafeTxHash:        t.Hash(hexutil.Encode(execution.SafeTxHash)),
+			TxHash:            t.Hash(hexutil.Encode(execution.TxHash)),
+			Block:             execution.BlockNumber,
+			TransactionIndex:  execution.TxIndex,
+			LogIndex:          execution.LogIndex,
+			Timestamp:         int64(execution.Time),
+			Status:            "success",
+			Payment:           decimal.NewFromBigInt(new(big.Int).SetBytes(execution.Payment), 0),
+			ConfirmationCount: uint64(len(execution.Confirmations)),
+		}
+		if !execution.Success {
+			result[i].Status = "failed"
+		}
+		if execution.To != nil {
+			value := decimal.NewFromBigInt(new(big.Int).SetBytes(execution.Value), 0)
+			data := hexutil.Encode(execution.Data)
+			operation := "call"
+			if execution.Operation == 1 {
+				operation = "delegate_call"
+			}
+			result[i].To = &t.Address{Hash: t.Hash(hexutil.Encode(execution.To))}
+			result[i].Value = &value
+			result[i].Data = &data
+			result[i].Operation = &operation
+			addressMapping[string(result[i].To.Hash)] = nil
+		}
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range result {
+		if result[i].To != nil {
+			result[i].To = addressMapping[string(result[i].To.Hash)]
+		}
+	}
+
+	if len(result) == 0 || !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetMultisigTransactionConfirmations(ctx context.Context, safeTxHash []byte) (*t.MultisigTransactionConfirmations, error) {
+	execution, approvals, err := d.bigtable.GetSafeTransactionConfirmations(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if execution == nil && len(approvals) == 0 {
+		return nil, fmt.Errorf("%w: safe transaction %#x", ErrNotFound, safeTxHash)
+	}
+
+	result := &t.MultisigTransactionConfirmations{
+		SafeTxHash: t.Hash(hexutil.Encode(safeTxHash)),
+		Status:     "pending",
+	}
+	// on-chain approvals replace the corresponding approved hash signatures of the execution
+	confirmations := approvals
+	approvedBy := make(map[string]bool, len(approvals))
+	for _, approval := range approvals {
+		approvedBy[string(approval.Owner)] = true
+	}
+	if execution != nil {
+		txHash := t.Hash(hexutil.Encode(execution.TxHash))
+		result.Safe = &t.Address{Hash: t.Hash(hexutil.Encode(execution.Safe)), IsContract: true}
+		result.TxHash = &txHash
+		result.Status = "success"
+		if !execution.Success {
+			result.Status = "failed"
+		}
+		for _, confirmation := range execution.Confirmations {
+			if confirmation.Type == safe.ConfirmationApprovedHash && approvedBy[string(confirmation.Owner)] {
+				continue
+			}
+			confirmations = append(confirmations, confirmation)
+		}
+	}
+
+	addressMapping := make(map[string]*t.Address)
+	if result.Safe != nil {
+		addressMapping[string(result.Safe.Hash)] = result.Safe
+	}
+	result.Confirmations = make([]t.MultisigConfirmation, len(confirmations))
+	for i, confirmation := range confirmations {
+		result.Confirmations[i] = t.MultisigConfirmation{
+			Owner: t.Address{Hash: t.Hash(hexutil.Encode(confirmation.Owner))},
+			Type:  confirmation.Type,
+		}
+		if confirmation.TxHash != nil {
+			txHash := t.Hash(hexutil.Encode(confirmation.TxHash))
+			timestamp := int64(confirmation.Time)
+			result.Confirmations[i].TxHash = &txHash
+			result.Confirmations[i].Block = &confirmation.BlockNumber
+			result.Confirmations[i].Timestamp = &timestamp
+		}
+		addressMapping[string(result.Confirmations[i].Owner.Hash)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	if result.Safe != nil {
+		result.Safe = addressMapping[string(result.Safe.Hash)]
+	}
+	for i := range result.Confirmations {
+		result.Confirmations[i].Owner = *addressMapping[string(result.Confirmations[i].Owner.Hash)]
+	}
+	return result, nil
+}
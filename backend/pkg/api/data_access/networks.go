@@ -1,16 +1,96 @@
 package dataaccess
 
-import "github.com/gobitfly/beaconchain/pkg/api/types"
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/services"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/blobstore"
+	"github.com/gobitfly/beaconchain/pkg/commons/cache"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/gasoracle"
+	"github.com/gobitfly/beaconchain/pkg/commons/layer2"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
+)
 
 type NetworkRepository interface {
-	GetAllNetworks() ([]types.NetworkInfo, error)
+	GetAllNetworks() ([]t.NetworkInfo, error)
+
+	GetNetworkSlashings(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkSlashingTableRow, *t.Paging, error)
+	GetNetworkValidatorSlashings(ctx context.Context, chainId uint64, validator t.VDBValidator) (*t.NetworkValidatorSlashings, error)
+
+	GetNetworkVoluntaryExits(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkVoluntaryExitTableRow, *t.Paging, error)
+	GetNetworkEpochVoluntaryExits(ctx context.Context, chainId, epoch uint64) ([]t.NetworkVoluntaryExitTableRow, error)
+	GetNetworkSlotVoluntaryExits(ctx context.Context, chainId, slot uint64) ([]t.NetworkVoluntaryExitTableRow, error)
+	GetNetworkBlockVoluntaryExits(ctx context.Context, chainId, block uint64) ([]t.NetworkVoluntaryExitTableRow, error)
+
+	GetNetworkDeposits(ctx context.Context, chainId uint64, filter t.NetworkDepositsFilter, cursor string, limit uint64) ([]t.NetworkDepositTableRow, *t.Paging, error)
+	GetNetworkWithdrawals(ctx context.Context, chainId uint64, filter t.NetworkWithdrawalsFilter, cursor string, limit uint64) ([]t.NetworkWithdrawalTableRow, *t.Paging, error)
+	GetNetworkWithdrawalCredentialTotals(ctx context.Context, chainId uint64, credential []byte) (*t.NetworkWithdrawalCredentialTotals, error)
+
+	GetNetworkBlsChanges(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkBlsChangeTableRow, *t.Paging, error)
+	GetNetworkEpochBlsChanges(ctx context.Context, chainId, epoch uint64) ([]t.NetworkBlsChangeTableRow, error)
+	GetNetworkSlotBlsChanges(ctx context.Context, chainId, slot uint64) ([]t.NetworkBlsChangeTableRow, error)
+	GetNetworkBlockBlsChanges(ctx context.Context, chainId, block uint64) ([]t.NetworkBlsChangeTableRow, error)
+	GetNetworkValidatorBlsChanges(ctx context.Context, chainId uint64, validator t.VDBValidator) ([]t.NetworkBlsChangeTableRow, error)
+
+	GetNetworkBlob(ctx context.Context, chainId uint64, versionedHash []byte) (*t.NetworkBlob, error)
+	GetNetworkSlotBlobs(ctx context.Context, chainId, slot uint64) ([]t.NetworkBlob, error)
+	GetNetworkBlockBlobs(ctx context.Context, chainId, block uint64) ([]t.NetworkBlob, error)
+
+	GetNetworkBlobBlocks(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkBlobBlockTableRow, *t.Paging, error)
+	GetNetworkBlobHistory(ctx context.Context, chainId uint64, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error)
+	GetNetworkBlobSubmitters(ctx context.Context, chainId uint64, period enums.TimePeriod, limit uint64) ([]t.NetworkBlobSubmitter, error)
+	GetNetworkGasNow(ctx context.Context, chainId uint64) (*t.NetworkGasNow, error)
+	GetNetworkAverageGasLimitHistory(ctx context.Context, chainId uint64, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error)
+	GetNetworkGasUsedHistory(ctx context.Context, chainId uint64, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error)
+
+	GetNetworkTransaction(ctx context.Context, chainId uint64, hash []byte) (*t.NetworkTransaction, error)
+	GetNetworkTransactions(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkTransactionTableRow, *t.Paging, error)
+	GetNetworkSlotTransactions(ctx context.Context, chainId, slot uint64) ([]t.NetworkTransactionTableRow, error)
+	GetNetworkBlockTransactions(ctx context.Context, chainId, block uint64) ([]t.NetworkTransactionTableRow, error)
+	GetNetworkAddressTransactions(ctx context.Context, chainId uint64, address []byte, txType enums.AddressTransactionType, cursor string, limit uint64) ([]t.NetworkAddressTransactionTableRow, *t.Paging, error)
+	SaveNetworkContractAbi(ctx context.Context, chainId uint64, address []byte, name string, abiJson []byte) (*t.NetworkContractAbi, error)
+
+	GetNetworkAddressBalanceHistory(ctx context.Context, chainId uint64, address, token []byte, cursor string, limit uint64) ([]t.NetworkAddressBalanceSnapshot, *t.Paging, error)
+	GetNetworkAddressTokenSupplyHistory(ctx context.Context, chainId uint64, token []byte, cursor string, limit uint64) ([]t.NetworkTokenSupplySnapshot, *t.Paging, error)
+	GetNetworkAddressEventLogs(ctx context.Context, chainId uint64, address []byte, cursor string, limit uint64) ([]t.NetworkAddressEventLog, *t.Paging, error)
+
+	GetNetworkEns(ctx context.Context, name string) (*t.NetworkEnsName, error)
+	GetNetworkAddressEns(ctx context.Context, address []byte) (*t.NetworkAddressEns, error)
+
+	GetNetworkBatches(ctx context.Context, layer2ChainId uint64, cursor string, limit uint64) ([]t.NetworkBatchTableRow, *t.Paging, error)
+	GetNetworkLayer1ToLayer2Transactions(ctx context.Context, layer2ChainId uint64, cursor string, limit uint64) ([]t.NetworkLayer1ToLayer2TransactionTableRow, *t.Paging, error)
+	GetNetworkLayer2ToLayer1Transactions(ctx context.Context, layer2ChainId uint64, cursor string, limit uint64) ([]t.NetworkLayer2ToLayer1TransactionTableRow, *t.Paging, error)
+
+	GetNetworkAddressUserOperations(ctx context.Context, chainId uint64, address []byte, role enums.UserOperationRole, cursor string, limit uint64) ([]t.NetworkUserOperationTableRow, *t.Paging, error)
+	GetNetworkAddressSponsorship(ctx context.Context, chainId uint64, address []byte, period enums.TimePeriod) (*t.NetworkPaymasterSponsorship, error)
 }
 
-func (d *DataAccessService) GetAllNetworks() ([]types.NetworkInfo, error) {
+func (d *DataAccessService) GetAllNetworks() ([]t.NetworkInfo, error) {
 	// TODO @recy21
 	// probably should load the networks into mem from some config when the service is created
 
-	return []types.NetworkInfo{
+	return []t.NetworkInfo{
 		{
 			ChainId:           1,
 			Name:              "ethereum",
@@ -28,3 +108,2371 @@ func (d *DataAccessService) GetAllNetworks() ([]types.NetworkInfo, error) {
 		},
 	}, nil
 }
+
+// ------------------------------------------------------------
+// Slashings
+
+type networkSlashingRow struct {
+	Slot           uint64 `db:"slot"`
+	Epoch          uint64 `db:"epoch"`
+	Type           string `db:"type"`
+	BlockIndex     uint64 `db:"block_index"`
+	ValidatorIndex uint64 `db:"validator_index"`
+	Slasher        uint64 `db:"slasher"`
+	SlasherTotal   uint64 `db:"slasher_total"`
+}
+
+// the first slashing of every validator is materialized together with the slasher totals (refreshed by the dashboard data exporter),
+// so paging does not have to deduplicate all slashings of the chain on every request
+const networkSlashingsQuery = `
+	SELECT
+		s.slot,
+		s.epoch,
+		s.type,
+		s.block_index,
+		s.validator_index,
+		s.slasher,
+		s.slasher_total
+	FROM network_slashings s`
+
+func (d *DataAccessService) GetNetworkSlashings(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkSlashingTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkSlashingsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkSlashingsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkSlashingsCursor: %w", err)
+		}
+	}
+
+	params := []interface{}{}
+	filterFragment := ` ORDER BY s.slot DESC, s.type DESC, s.block_index DESC, s.validator_index DESC`
+	if currentCursor.IsValid() {
+		filterFragment = ` WHERE (s.slot, s.type, s.block_index, s.validator_index) < ($1, $2, $3, $4)` + filterFragment
+		params = append(params, currentCursor.Slot, currentCursor.Type, currentCursor.BlockIndex, currentCursor.ValidatorIndex)
+	}
+	if currentCursor.IsReverse() {
+		filterFragment = strings.Replace(strings.Replace(filterFragment, "<", ">", -1), "DESC", "ASC", -1)
+	}
+	params = append(params, limit+1)
+	filterFragment += fmt.Sprintf(" LIMIT $%d", len(params))
+
+	var data []networkSlashingRow
+	err = d.alloyReader.SelectContext(ctx, &data, networkSlashingsQuery+filterFragment, params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving slashings: %w", err)
+	}
+
+	var paging t.Paging
+	moreDataFlag := len(data) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		result, err := d.convertNetworkSlashings(ctx, data)
+		return result, &paging, err
+	}
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	result, err := d.convertNetworkSlashings(ctx, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetNetworkValidatorSlashings(ctx context.Context, chainId uint64, validator t.VDBValidator) (*t.NetworkValidatorSlashings, error) {
+	var data []networkSlashingRow
+	err := d.alloyReader.SelectContext(ctx, &data, networkSlashingsQuery+`
+		WHERE s.validator_index = $1 OR s.slasher = $1
+		ORDER BY s.slot DESC, s.type DESC, s.block_index DESC, s.validator_index DESC`, validator)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving slashings of validator %d: %w", validator, err)
+	}
+	rows, err := d.convertNetworkSlashings(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &t.NetworkValidatorSlashings{
+		PenaltyHistory: []t.NetworkSlashingPenaltyHistoryEntry{},
+		Slashed:        []t.NetworkSlashingTableRow{},
+	}
+	for i := range rows {
+		if rows[i].Index == validator {
+			result.Slashing = &rows[i]
+		}
+		if rows[i].Slasher.Index == validator {
+			result.Slashed = append(result.Slashed, rows[i])
+		}
+	}
+	if result.Slashing == nil {
+		return result, nil
+	}
+
+	var history []struct {
+		Day     time.Time `db:"day"`
+		Penalty int64     `db:"penalty"`
+	}
+	err = d.clickhouseReader.SelectContext(ctx, &history, `
+		SELECT
+			day,
+			greatest(0, COALESCE(balance_start, 0) + COALESCE(deposits_amount, 0) - COALESCE(balance_end, 0) - COALESCE(withdrawals_amount, 0)) AS penalty
+		FROM validator_dashboard_data_daily
+		WHERE validator_index = $1 AND slashed = true
+		ORDER BY day`, validator)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving penalty history of validator %d: %w", validator, err)
+	}
+	for _, entry := range history {
+		result.PenaltyHistory = append(result.PenaltyHistory, t.NetworkSlashingPenaltyHistoryEntry{
+			Timestamp: entry.Day.Unix(),
+			Penalty:   utils.GWeiToWei(big.NewInt(entry.Penalty)),
+		})
+	}
+	return result, nil
+}
+
+// convertNetworkSlashings adds the penalties the slashed validators incurred since their slashing and the reward the
+// slasher received for including it.
+func (d *DataAccessService) convertNetworkSlashings(ctx context.Context, data []networkSlashingRow) ([]t.NetworkSlashingTableRow, error) {
+	result := make([]t.NetworkSlashingTableRow, 0, len(data))
+	if len(data) == 0 {
+		return result, nil
+	}
+
+	validators := make([]uint64, 0, len(data))
+	for _, row := range data {
+		validators = append(validators, row.ValidatorIndex)
+	}
+	var penalties []struct {
+		ValidatorIndex    uint64 `db:"validator_index"`
+		Penalty           int64  `db:"penalty"`
+		BalanceAtSlashing int64  `db:"balance_at_slashing"`
+	}
+	err := d.clickhouseReader.SelectContext(ctx, &penalties, `
+		SELECT
+			validator_index,
+			SUM(greatest(0, COALESCE(balance_start, 0) + COALESCE(deposits_amount, 0) - COALESCE(balance_end, 0) - COALESCE(withdrawals_amount, 0))) AS penalty,
+			argMin(COALESCE(balance_start, 0), day) AS balance_at_slashing
+		FROM validator_dashboard_data_daily
+		WHERE validator_index IN ($1) AND slashed = true
+		GROUP BY validator_index`, validators)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving slashing penalties: %w", err)
+	}
+	penaltyMap := make(map[uint64]int, len(penalties))
+	for i, penalty := range penalties {
+		penaltyMap[penalty.ValidatorIndex] = i
+	}
+
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current validator mapping: %w", err)
+	}
+
+	clConfig := utils.Config.Chain.ClConfig
+	for _, row := range data {
+		entry := t.NetworkSlashingTableRow{
+			Slot:      row.Slot,
+			Epoch:     row.Epoch,
+			Timestamp: utils.SlotToTime(row.Slot).Unix(),
+			Type:      row.Type,
+			Index:     row.ValidatorIndex,
+			Slasher: t.NetworkSlasher{
+				Index:          row.Slasher,
+				TotalSlashings: row.SlasherTotal,
+			},
+			Penalty:             decimal.Zero,
+			WhistleblowerReward: decimal.Zero,
+		}
+
+		// the whistleblower reward depends on the effective balance at the time of the slashing, which is derived from
+		// the balance at the start of the slashing day; fall back to the current effective balance if that day is not exported yet
+		var effectiveBalance uint64
+		var withdrawalCredentials []byte
+		if row.ValidatorIndex < uint64(len(mapping.ValidatorMetadata)) {
+			withdrawalCredentials = mapping.ValidatorMetadata[row.ValidatorIndex].WithdrawalCredentials
+			effectiveBalance = mapping.ValidatorMetadata[row.ValidatorIndex].EffectiveBalance
+		}
+		if i, ok := penaltyMap[row.ValidatorIndex]; ok {
+			entry.Penalty = utils.GWeiToWei(big.NewInt(penalties[i].Penalty))
+			effectiveBalance = uint64(max(penalties[i].BalanceAtSlashing, 0))
+		}
+		if clConfig.EffectiveBalanceIncrement > 0 {
+			effectiveBalance -= effectiveBalance % clConfig.EffectiveBalanceIncrement
+		}
+		// since electra compounding validators can have a higher effective balance, which is why the quotient was raised
+		maxEffectiveBalance, whistleblowerRewardQuotient := clConfig.MaxEffectiveBalance, clConfig.WhistleblowerRewardQuotient
+		if utils.IsElectraEpoch(row.Epoch) {
+			maxEffectiveBalance, whistleblowerRewardQuotient = utils.GetMaxEffectiveBalance(withdrawalCredentials), clConfig.WhistleblowerRewardQuotientElectra
+		}
+		if maxEffectiveBalance > 0 {
+			effectiveBalance = min(effectiveBalance, maxEffectiveBalance)
+		}
+		if whistleblowerRewardQuotient > 0 {
+			entry.WhistleblowerReward = utils.GWeiToWei(new(big.Int).SetUint64(effectiveBalance / whistleblowerRewardQuotient))
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// ------------------------------------------------------------
+// Voluntary Exits
+
+type networkVoluntaryExitRow struct {
+	Slot           uint64 `db:"slot"`
+	BlockIndex     uint64 `db:"block_index"`
+	Epoch          uint64 `db:"epoch"`
+	ValidatorIndex uint64 `db:"validator_index"`
+	Signature      []byte `db:"signature"`
+}
+
+const networkVoluntaryExitsQuery = `
+	SELECT
+		ve.block_slot AS slot,
+		ve.block_index,
+		ve.epoch,
+		ve.validatorindex AS validator_index,
+		ve.signature
+	FROM blocks_voluntaryexits ve
+	INNER JOIN blocks b ON b.slot = ve.block_slot AND b.status = '1'`
+
+func (d *DataAccessService) GetNetworkVoluntaryExits(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkVoluntaryExitTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkVoluntaryExitsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkVoluntaryExitsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkVoluntaryExitsCursor: %w", err)
+		}
+	}
+
+	params := []interface{}{}
+	filterFragment := ` ORDER BY ve.block_slot DESC, ve.block_index DESC`
+	if currentCursor.IsValid() {
+		filterFragment = ` WHERE (ve.block_slot, ve.block_index) < ($1, $2)` + filterFragment
+		params = append(params, currentCursor.Slot, currentCursor.BlockIndex)
+	}
+	if currentCursor.IsReverse() {
+		filterFragment = strings.Replace(strings.Replace(filterFragment, "<", ">", -1), "DESC", "ASC", -1)
+	}
+	params = append(params, limit+1)
+	filterFragment += fmt.Sprintf(" LIMIT $%d", len(params))
+
+	var data []networkVoluntaryExitRow
+	err = d.alloyReader.SelectContext(ctx, &data, networkVoluntaryExitsQuery+filterFragment, params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving voluntary exits: %w", err)
+	}
+
+	var paging t.Paging
+	moreDataFlag := len(data) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		result, err := d.convertNetworkVoluntaryExits(data)
+		return result, &paging, err
+	}
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	result, err := d.convertNetworkVoluntaryExits(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetNetworkEpochVoluntaryExits(ctx context.Context, chainId, epoch uint64) ([]t.NetworkVoluntaryExitTableRow, error) {
+	return d.getNetworkVoluntaryExitsWhere(ctx, `b.epoch = $1`, epoch)
+}
+
+func (d *DataAccessService) GetNetworkSlotVoluntaryExits(ctx context.Context, chainId, slot uint64) ([]t.NetworkVoluntaryExitTableRow, error) {
+	return d.getNetworkVoluntaryExitsWhere(ctx, `ve.block_slot = $1`, slot)
+}
+
+func (d *DataAccessService) GetNetworkBlockVoluntaryExits(ctx context.Context, chainId, block uint64) ([]t.NetworkVoluntaryExitTableRow, error) {
+	return d.getNetworkVoluntaryExitsWhere(ctx, `b.exec_block_number = $1`, block)
+}
+
+func (d *DataAccessService) getNetworkVoluntaryExitsWhere(ctx context.Context, condition string, value uint64) ([]t.NetworkVoluntaryExitTableRow, error) {
+	var data []networkVoluntaryExitRow
+	err := d.alloyReader.SelectContext(ctx, &data, networkVoluntaryExitsQuery+`
+		WHERE `+condition+`
+		ORDER BY ve.block_slot, ve.block_index`, value)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving voluntary exits: %w", err)
+	}
+	return d.convertNetworkVoluntaryExits(data)
+}
+
+// exitQueuePositions caches the position of every validator waiting in the exit queue, it is rebuilt once the validator mapping or the latest epoch changed
+var exitQueuePositions struct {
+	sync.Mutex
+	mapping   *services.ValidatorMapping
+	epoch     uint64
+	positions map[uint64]uint64 // key: validator index
+}
+
+// getExitQueuePositions returns the 1-based exit queue positions of the validators that haven't exited yet.
+// Validators are dequeued in order of their exit epoch, ties are ordered by index.
+func getExitQueuePositions(mapping *services.ValidatorMapping, latestEpoch uint64) map[uint64]uint64 {
+	exitQueuePositions.Lock()
+	defer exitQueuePositions.Unlock()
+	if exitQueuePositions.mapping == mapping && exitQueuePositions.epoch == latestEpoch {
+		return exitQueuePositions.positions
+	}
+
+	type queueEntry struct {
+		exitEpoch uint64
+		index     uint64
+	}
+	var queue []queueEntry
+	for index, metadata := range mapping.ValidatorMetadata {
+		if metadata.ExitEpoch.Valid && uint64(metadata.ExitEpoch.Int64) > latestEpoch {
+			queue = append(queue, queueEntry{exitEpoch: uint64(metadata.ExitEpoch.Int64), index: uint64(index)})
+		}
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		return queue[i].exitEpoch < queue[j].exitEpoch || queue[i].exitEpoch == queue[j].exitEpoch && queue[i].index < queue[j].index
+	})
+	positions := make(map[uint64]uint64, len(queue))
+	for i, entry := range queue {
+		positions[entry.index] = uint64(i) + 1
+	}
+
+	exitQueuePositions.mapping = mapping
+	exitQueuePositions.epoch = latestEpoch
+	exitQueuePositions.positions = positions
+	return positions
+}
+
+// convertNetworkVoluntaryExits adds the exit epoch of the exiting validators and, for those still waiting in the exit queue,
+// their position in it.
+func (d *DataAccessService) convertNetworkVoluntaryExits(data []networkVoluntaryExitRow) ([]t.NetworkVoluntaryExitTableRow, error) {
+	result := make([]t.NetworkVoluntaryExitTableRow, 0, len(data))
+	if len(data) == 0 {
+		return result, nil
+	}
+
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current validator mapping: %w", err)
+	}
+	latestEpoch := cache.LatestEpoch.Get()
+	queuePositions := getExitQueuePositions(mapping, latestEpoch)
+
+	for _, row := range data {
+		entry := t.NetworkVoluntaryExitTableRow{
+			Slot:      row.Slot,
+			Epoch:     row.Epoch,
+			Timestamp: utils.SlotToTime(row.Slot).Unix(),
+			Index:     row.ValidatorIndex,
+			Signature: t.Hash(hexutil.Encode(row.Signature)),
+		}
+		if row.ValidatorIndex < uint64(len(mapping.ValidatorMetadata)) {
+			metadata := mapping.ValidatorMetadata[row.ValidatorIndex]
+			if metadata.ExitEpoch.Valid {
+				exitEpoch := uint64(metadata.ExitEpoch.Int64)
+				estimatedExit := utils.EpochToTime(exitEpoch).Unix()
+				entry.ExitEpoch = &exitEpoch
+				entry.EstimatedExit = &estimatedExit
+				if position, ok := queuePositions[row.ValidatorIndex]; ok {
+					entry.QueuePosition = &position
+				}
+			}
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// ------------------------------------------------------------
+// Deposits
+
+func (d *DataAccessService) GetNetworkDeposits(ctx context.Context, chainId uint64, filter t.NetworkDepositsFilter, cursor string, limit uint64) ([]t.NetworkDepositTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.ELDepositsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.ELDepositsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as ELDepositsCursor: %w", err)
+		}
+	}
+
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get current validator mapping: %w", err)
+	}
+	if filter.Validator != nil {
+		if *filter.Validator >= uint64(len(mapping.ValidatorPubkeys)) {
+			return nil, nil, fmt.Errorf("%w: validator %d", ErrNotFound, *filter.Validator)
+		}
+		filter.PublicKey, err = hexutil.Decode(mapping.ValidatorPubkeys[*filter.Validator])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode public key of validator %d: %w", *filter.Validator, err)
+		}
+	}
+
+	params := []interface{}{}
+	addParam := func(value interface{}) string {
+		params = append(params, value)
+		return fmt.Sprintf("$%d", len(params))
+	}
+	conditions := []string{"TRUE"}
+	if len(filter.Address) > 0 {
+		conditions = append(conditions, fmt.Sprintf("(ed.from_address = %[1]s OR ed.msg_sender = %[1]s)", addParam(filter.Address)))
+	}
+	if len(filter.Credential) > 0 {
+		conditions = append(conditions, "ed.withdrawal_credentials = "+addParam(filter.Credential))
+	}
+	if len(filter.PublicKey) > 0 {
+		conditions = append(conditions, "ed.publickey = "+addParam(filter.PublicKey))
+	}
+	if len(filter.TxHash) > 0 {
+		conditions = append(conditions, "ed.tx_hash = "+addParam(filter.TxHash))
+	}
+	if filter.AfterTs > 0 {
+		conditions = append(conditions, "ed.block_ts >= "+addParam(time.Unix(int64(filter.AfterTs), 0).UTC()))
+	}
+	if filter.BeforeTs > 0 {
+		conditions = append(conditions, "ed.block_ts <= "+addParam(time.Unix(int64(filter.BeforeTs), 0).UTC()))
+	}
+	direction, order := "<", "DESC"
+	if currentCursor.IsReverse() {
+		direction, order = ">", "ASC"
+	}
+	if currentCursor.IsValid() {
+		conditions = append(conditions, fmt.Sprintf("(ed.block_number, ed.log_index) %s (%s, %s)", direction, addParam(currentCursor.BlockNumber), addParam(currentCursor.LogIndex)))
+	}
+
+	var data []struct {
+		PublicKey             []byte    `db:"publickey"`
+		BlockNumber           int64     `db:"block_number"`
+		LogIndex              int64     `db:"log_index"`
+		Timestamp             time.Time `db:"block_ts"`
+		From                  []byte    `db:"from_address"`
+		Depositor             []byte    `db:"msg_sender"`
+		TxHash                []byte    `db:"tx_hash"`
+		WithdrawalCredentials []byte    `db:"withdrawal_credentials"`
+		Amount                int64     `db:"amount"`
+		ValidSignature        bool      `db:"valid_signature"`
+		IsTopUp               bool      `db:"is_top_up"`
+	}
+	// the signature is only verified for the first deposit of a public key, which creates the validator, top ups are valid regardless of their signature
+	query := fmt.Sprintf(`
+		SELECT
+			ed.publickey,
+			ed.block_number,
+			ed.log_index,
+			ed.block_ts,
+			ed.from_address,
+			ed.msg_sender,
+			ed.tx_hash,
+			ed.withdrawal_credentials,
+			ed.amount,
+			ed.valid_signature,
+			COALESCE((ed.block_number, ed.log_index) > (first.block_number, first.log_index), FALSE) AS is_top_up
+		FROM eth1_deposits ed
+		LEFT JOIN LATERAL (
+			SELECT f.block_number, f.log_index
+			FROM eth1_deposits f
+			WHERE f.publickey = ed.publickey AND f.valid_signature
+			ORDER BY f.block_number, f.log_index
+			LIMIT 1
+		) first ON TRUE
+		WHERE %s
+		ORDER BY ed.block_number %[2]s, ed.log_index %[2]s
+		LIMIT %s`, strings.Join(conditions, " AND "), order, addParam(limit+1))
+	err = d.alloyReader.SelectContext(ctx, &data, query, params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving deposits: %w", err)
+	}
+
+	var paging t.Paging
+	moreDataFlag := len(data) > int(limit)
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	result := make([]t.NetworkDepositTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, row := range data {
+		pubkey := hexutil.Encode(row.PublicKey)
+		result[i] = t.NetworkDepositTableRow{
+			PublicKey:            t.PubKey(pubkey),
+			Block:                uint64(row.BlockNumber),
+			Timestamp:            row.Timestamp.Unix(),
+			From:                 t.Address{Hash: t.Hash(hexutil.Encode(row.From))},
+			Depositor:            t.Address{Hash: t.Hash(hexutil.Encode(row.From))},
+			TxHash:               t.Hash(hexutil.Encode(row.TxHash)),
+			WithdrawalCredential: t.Hash(hexutil.Encode(row.WithdrawalCredentials)),
+			Amount:               utils.GWeiToWei(big.NewInt(row.Amount)),
+			Valid:                row.ValidSignature || row.IsTopUp,
+			IsTopUp:              row.IsTopUp,
+		}
+		if len(row.Depositor) > 0 {
+			result[i].Depositor = t.Address{Hash: t.Hash(hexutil.Encode(row.Depositor))}
+		}
+		addressMapping[string(result[i].From.Hash)] = nil
+		addressMapping[string(result[i].Depositor.Hash)] = nil
+		if index, ok := mapping.ValidatorIndices[pubkey]; ok {
+			result[i].Index = &index
+		}
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range result {
+		result[i].From = *addressMapping[string(result[i].From.Hash)]
+		result[i].Depositor = *addressMapping[string(result[i].Depositor.Hash)]
+	}
+
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &paging, nil
+	}
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+// ------------------------------------------------------------
+// Withdrawals
+
+// getValidatorsByWithdrawalCredential returns all validators that currently use the given withdrawal credential
+func (d *DataAccessService) getValidatorsByWithdrawalCredential(credential []byte) ([]t.VDBValidator, error) {
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current validator mapping: %w", err)
+	}
+	validators := []t.VDBValidator{}
+	for index, metadata := range mapping.ValidatorMetadata {
+		if bytes.Equal(metadata.WithdrawalCredentials, credential) {
+			validators = append(validators, t.VDBValidator(index))
+		}
+	}
+	return validators, nil
+}
+
+func (d *DataAccessService) GetNetworkWithdrawals(ctx context.Context, chainId uint64, filter t.NetworkWithdrawalsFilter, cursor string, limit uint64) ([]t.NetworkWithdrawalTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkWithdrawalsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkWithdrawalsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkWithdrawalsCursor: %w", err)
+		}
+	}
+
+	params := []interface{}{}
+	addParam := func(value interface{}) string {
+		params = append(params, value)
+		return fmt.Sprintf("$%d", len(params))
+	}
+	conditions := []string{"TRUE"}
+	if len(filter.Address) > 0 {
+		conditions = append(conditions, "w.address = "+addParam(filter.Address))
+	}
+	if len(filter.Credential) > 0 {
+		validators, err := d.getValidatorsByWithdrawalCredential(filter.Credential)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(validators) == 0 {
+			return []t.NetworkWithdrawalTableRow{}, &t.Paging{}, nil
+		}
+		conditions = append(conditions, "w.validatorindex = ANY("+addParam(pq.Array(validators))+")")
+	}
+	if filter.AfterTs > 0 {
+		conditions = append(conditions, "w.block_slot >= "+addParam(utils.TimeToSlot(filter.AfterTs)))
+	}
+	if filter.BeforeTs > 0 {
+		conditions = append(conditions, "w.block_slot <= "+addParam(utils.TimeToSlot(filter.BeforeTs)))
+	}
+	direction, order := "<", "DESC"
+	if currentCursor.IsReverse() {
+		direction, order = ">", "ASC"
+	}
+	if currentCursor.IsValid() {
+		conditions = append(conditions, fmt.Sprintf("w.withdrawalindex %s %s", direction, addParam(currentCursor.WithdrawalIndex)))
+	}
+
+	var data []struct {
+		Slot            uint64 `db:"slot"`
+		WithdrawalIndex uint64 `db:"withdrawal_index"`
+		Validator       uint64 `db:"validator_index"`
+		Address         []byte `db:"address"`
+		Amount          int64  `db:"amount"`
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			w.block_slot AS slot,
+			w.withdrawalindex AS withdrawal_index,
+			w.validatorindex AS validator_index,
+			w.address,
+			w.amount
+		FROM blocks_withdrawals w
+		INNER JOIN blocks b ON b.blockroot = w.block_root AND b.status = '1'
+		WHERE %s
+		ORDER BY w.withdrawalindex %s
+		LIMIT %s`, strings.Join(conditions, " AND "), order, addParam(limit+1))
+	err = d.alloyReader.SelectContext(ctx, &data, query, params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving withdrawals: %w", err)
+	}
+
+	var paging t.Paging
+	moreDataFlag := len(data) > int(limit)
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	result := make([]t.NetworkWithdrawalTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, row := range data {
+		result[i] = t.NetworkWithdrawalTableRow{
+			Epoch:     row.Slot / utils.Config.Chain.ClConfig.SlotsPerEpoch,
+			Slot:      row.Slot,
+			Timestamp: utils.SlotToTime(row.Slot).Unix(),
+			Index:     row.WithdrawalIndex,
+			Validator: row.Validator,
+			Recipient: t.Address{Hash: t.Hash(hexutil.Encode(row.Address))},
+			Amount:    utils.GWeiToWei(big.NewInt(row.Amount)),
+		}
+		addressMapping[string(result[i].Recipient.Hash)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range result {
+		result[i].Recipient = *addressMapping[string(result[i].Recipient.Hash)]
+	}
+
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &paging, nil
+	}
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetNetworkWithdrawalCredentialTotals(ctx context.Context, chainId uint64, credential []byte) (*t.NetworkWithdrawalCredentialTotals, error) {
+	result := &t.NetworkWithdrawalCredentialTotals{
+		WithdrawalCredential: t.Hash(hexutil.Encode(credential)),
+		DepositsAmount:       decimal.Zero,
+		WithdrawalsAmount:    decimal.Zero,
+	}
+	validators, err := d.getValidatorsByWithdrawalCredential(credential)
+	if err != nil {
+		return nil, err
+	}
+	result.Validators = uint64(len(validators))
+	if len(validators) == 0 {
+		return result, nil
+	}
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current validator mapping: %w", err)
+	}
+	pubkeys := make([][]byte, 0, len(validators))
+	for _, validator := range validators {
+		pubkeys = append(pubkeys, mapping.ValidatorMetadata[validator].PublicKey)
+	}
+
+	// deposits are matched by public key as the credential of a validator can change after its deposits,
+	// only the first deposit of a public key needs a valid signature, deposits with an invalid signature before it are ignored
+	var deposits, withdrawals struct {
+		Count  uint64 `db:"count"`
+		Amount int64  `db:"amount"`
+	}
+	wg := errgroup.Group{}
+	wg.Go(func() error {
+		err := d.alloyReader.GetContext(ctx, &deposits, `
+			SELECT COUNT(*) AS count, COALESCE(SUM(ed.amount), 0) AS amount
+			FROM eth1_deposits ed
+			INNER JOIN (
+				SELECT DISTINCT ON (publickey) publickey, block_number, log_index
+				FROM eth1_deposits
+				WHERE publickey = ANY($1) AND valid_signature
+				ORDER BY publickey, block_number, log_index
+			) first ON first.publickey = ed.publickey AND (ed.block_number, ed.log_index) >= (first.block_number, first.log_index)
+			WHERE ed.publickey = ANY($1)`, pq.ByteaArray(pubkeys))
+		if err != nil {
+			return fmt.Errorf("error retrieving deposit totals: %w", err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		err := d.alloyReader.GetContext(ctx, &withdrawals, `
+			SELECT COUNT(*) AS count, COALESCE(SUM(w.amount), 0) AS amount
+			FROM blocks_withdrawals w
+			INNER JOIN blocks b ON b.blockroot = w.block_root AND b.status = '1'
+			WHERE w.validatorindex = ANY($1)`, pq.Array(validators))
+		if err != nil {
+			return fmt.Errorf("error retrieving withdrawal totals: %w", err)
+		}
+		return nil
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	result.DepositsCount = deposits.Count
+	result.DepositsAmount = utils.GWeiToWei(big.NewInt(deposits.Amount))
+	result.WithdrawalsCount = withdrawals.Count
+	result.WithdrawalsAmount = utils.GWeiToWei(big.NewInt(withdrawals.Amount))
+	return result, nil
+}
+
+// ------------------------------------------------------------
+// BLS Changes
+
+type networkBlsChangeRow struct {
+	Slot           uint64 `db:"slot"`
+	ValidatorIndex uint64 `db:"validator_index"`
+	Signature      []byte `db:"signature"`
+	Pubkey         []byte `db:"pubkey"`
+	Address        []byte `db:"address"`
+}
+
+const networkBlsChangesQuery = `
+	SELECT
+		bls.block_slot AS slot,
+		bls.validatorindex AS validator_index,
+		bls.signature,
+		bls.pubkey,
+		bls.address
+	FROM blocks_bls_change bls
+	INNER JOIN blocks b ON b.blockroot = bls.block_root AND b.status = '1'`
+
+func (d *DataAccessService) GetNetworkBlsChanges(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkBlsChangeTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkBlsChangesCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkBlsChangesCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkBlsChangesCursor: %w", err)
+		}
+	}
+
+	params := []interface{}{}
+	filterFragment := ` ORDER BY bls.block_slot DESC, bls.validatorindex DESC`
+	if currentCursor.IsValid() {
+		filterFragment = ` WHERE (bls.block_slot, bls.validatorindex) < ($1, $2)` + filterFragment
+		params = append(params, currentCursor.Slot, currentCursor.ValidatorIndex)
+	}
+	if currentCursor.IsReverse() {
+		filterFragment = strings.Replace(strings.Replace(filterFragment, "<", ">", -1), "DESC", "ASC", -1)
+	}
+	params = append(params, limit+1)
+	filterFragment += fmt.Sprintf(" LIMIT $%d", len(params))
+
+	var data []networkBlsChangeRow
+	err = d.alloyReader.SelectContext(ctx, &data, networkBlsChangesQuery+filterFragment, params...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving bls changes: %w", err)
+	}
+
+	var paging t.Paging
+	moreDataFlag := len(data) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		result, err := d.convertNetworkBlsChanges(ctx, data)
+		return result, &paging, err
+	}
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	result, err := d.convertNetworkBlsChanges(ctx, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetNetworkEpochBlsChanges(ctx context.Context, chainId, epoch uint64) ([]t.NetworkBlsChangeTableRow, error) {
+	return d.getNetworkBlsChangesWhere(ctx, `b.epoch = $1`, epoch)
+}
+
+func (d *DataAccessService) GetNetworkSlotBlsChanges(ctx context.Context, chainId, slot uint64) ([]t.NetworkBlsChangeTableRow, error) {
+	return d.getNetworkBlsChangesWhere(ctx, `bls.block_slot = $1`, slot)
+}
+
+func (d *DataAccessService) GetNetworkBlockBlsChanges(ctx context.Context, chainId, block uint64) ([]t.NetworkBlsChangeTableRow, error) {
+	return d.getNetworkBlsChangesWhere(ctx, `b.exec_block_number = $1`, block)
+}
+
+func (d *DataAccessService) GetNetworkValidatorBlsChanges(ctx context.Context, chainId uint64, validator t.VDBValidator) ([]t.NetworkBlsChangeTableRow, error) {
+	return d.getNetworkBlsChangesWhere(ctx, `bls.validatorindex = $1`, validator)
+}
+
+func (d *DataAccessService) getNetworkBlsChangesWhere(ctx context.Context, condition string, value uint64) ([]t.NetworkBlsChangeTableRow, error) {
+	var data []networkBlsChangeRow
+	err := d.alloyReader.SelectContext(ctx, &data, networkBlsChangesQuery+`
+		WHERE `+condition+`
+		ORDER BY bls.block_slot, bls.validatorindex`, value)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bls changes: %w", err)
+	}
+	return d.convertNetworkBlsChanges(ctx, data)
+}
+
+func (d *DataAccessService) convertNetworkBlsChanges(ctx context.Context, data []networkBlsChangeRow) ([]t.NetworkBlsChangeTableRow, error) {
+	result := make([]t.NetworkBlsChangeTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, row := range data {
+		result[i] = t.NetworkBlsChangeTableRow{
+			Slot:                 row.Slot,
+			Epoch:                row.Slot / utils.Config.Chain.ClConfig.SlotsPerEpoch,
+			Timestamp:            utils.SlotToTime(row.Slot).Unix(),
+			Index:                row.ValidatorIndex,
+			Signature:            t.Hash(hexutil.Encode(row.Signature)),
+			BlsPubkey:            t.Hash(hexutil.Encode(row.Pubkey)),
+			NewWithdrawalAddress: t.Address{Hash: t.Hash(hexutil.Encode(row.Address))},
+		}
+		addressMapping[string(result[i].NewWithdrawalAddress.Hash)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].NewWithdrawalAddress = *addressMapping[string(result[i].NewWithdrawalAddress.Hash)]
+	}
+	return result, nil
+}
+
+// ------------------------------------------------------------
+// Blobs
+
+func (d *DataAccessService) blobNetworkId() string {
+	return fmt.Sprintf("%d", utils.Config.Chain.ClConfig.DepositNetworkID)
+}
+
+// getArchivedBlob returns nil if the blob has not been archived by the blobindexer
+func (d *DataAccessService) getArchivedBlob(ctx context.Context, versionedHash []byte) (*blobstore.BlobSidecar, error) {
+	if d.blobStore == nil {
+		return nil, nil
+	}
+	sidecar, err := blobstore.GetBlobSidecar(ctx, d.blobStore, d.blobNetworkId(), versionedHash)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving blob %#x: %w", versionedHash, err)
+	}
+	return sidecar, nil
+}
+
+// GetNetworkBlob returns the sidecar metadata of a blob, the data is only included if the blob has been archived by the blobindexer
+func (d *DataAccessService) GetNetworkBlob(ctx context.Context, chainId uint64, versionedHash []byte) (*t.NetworkBlob, error) {
+	blobs, err := d.getNetworkBlobsWhere(ctx, `bs.blob_versioned_hash = $1`, versionedHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(blobs) == 0 {
+		return nil, fmt.Errorf("%w: blob %#x", ErrNotFound, versionedHash)
+	}
+	return &blobs[0], nil
+}
+
+func (d *DataAccessService) GetNetworkSlotBlobs(ctx context.Context, chainId, slot uint64) ([]t.NetworkBlob, error) {
+	return d.getNetworkBlobsWhere(ctx, `b.slot = $1`, slot)
+}
+
+func (d *DataAccessService) GetNetworkBlockBlobs(ctx context.Context, chainId, block uint64) ([]t.NetworkBlob, error) {
+	return d.getNetworkBlobsWhere(ctx, `b.exec_block_number = $1`, block)
+}
+
+func (d *DataAccessService) getNetworkBlobsWhere(ctx context.Context, condition string, value any) ([]t.NetworkBlob, error) {
+	var data []struct {
+		Slot          uint64 `db:"slot"`
+		Index         uint64 `db:"index"`
+		BlockRoot     []byte `db:"blockroot"`
+		ProposerIndex uint64 `db:"proposer"`
+		ParentRoot    []byte `db:"parentroot"`
+		StateRoot     []byte `db:"stateroot"`
+		KzgCommitment []byte `db:"kzg_commitment"`
+		KzgProof      []byte `db:"kzg_proof"`
+		VersionedHash []byte `db:"blob_versioned_hash"`
+	}
+	err := d.alloyReader.SelectContext(ctx, &data, `
+		SELECT
+			b.slot,
+			bs.index,
+			b.blockroot,
+			b.proposer,
+			b.parentroot,
+			b.stateroot,
+			bs.kzg_commitment,
+			bs.kzg_proof,
+			bs.blob_versioned_hash
+		FROM blocks_blob_sidecars bs
+		INNER JOIN blocks b ON b.blockroot = bs.block_root AND b.status = '1'
+		WHERE `+condition+`
+		ORDER BY bs.index`, value)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving blob sidecars: %w", err)
+	}
+
+	result := make([]t.NetworkBlob, len(data))
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(4)
+	for i, row := range data {
+		result[i] = t.NetworkBlob{
+			VersionedHash: t.Hash(hexutil.Encode(row.VersionedHash)),
+			Slot:          row.Slot,
+			Index:         row.Index,
+			BlockRoot:     t.Hash(hexutil.Encode(row.BlockRoot)),
+			ProposerIndex: row.ProposerIndex,
+			ParentRoot:    t.Hash(hexutil.Encode(row.ParentRoot)),
+			StateRoot:     t.Hash(hexutil.Encode(row.StateRoot)),
+			KzgCommitment: t.Hash(hexutil.Encode(row.KzgCommitment)),
+			KzgProof:      t.Hash(hexutil.Encode(row.KzgProof)),
+		}
+		g.Go(func() error {
+			sidecar, err := d.getArchivedBlob(gCtx, row.VersionedHash)
+			if err != nil || sidecar == nil {
+				return err
+			}
+			result[i].Size = uint64(len(sidecar.Blob))
+			result[i].Data = hexutil.Encode(sidecar.Blob)
+			return nil
+		})
+	}
+	err = g.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ------------------------------------------------------------
+// Blob Analytics
+
+func (d *DataAccessService) GetNetworkBlobBlocks(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkBlobBlockTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkBlobBlocksCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkBlobBlocksCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkBlobBlocksCursor: %w", err)
+		}
+	}
+
+	denebSlot := utils.Config.Chain.ClConfig.DenebForkEpoch * utils.Config.Chain.ClConfig.SlotsPerEpoch
+	queryParams := []interface{}{denebSlot}
+	filterFragment := ` ORDER BY slot DESC`
+	if currentCursor.IsValid() {
+		filterFragment = ` AND slot < $2` + filterFragment
+		queryParams = append(queryParams, currentCursor.Slot)
+	}
+	if currentCursor.IsReverse() {
+		filterFragment = strings.Replace(strings.Replace(filterFragment, "<", ">", -1), "DESC", "ASC", -1)
+	}
+	queryParams = append(queryParams, limit+1)
+	filterFragment += fmt.Sprintf(" LIMIT $%d", len(queryParams))
+
+	var data []struct {
+		Slot          uint64 `db:"slot"`
+		Block         uint64 `db:"exec_block_number"`
+		BlobGasUsed   uint64 `db:"exec_blob_gas_used"`
+		ExcessBlobGas uint64 `db:"exec_excess_blob_gas"`
+	}
+	err = d.alloyReader.SelectContext(ctx, &data, `
+		SELECT
+			slot,
+			exec_block_number,
+			COALESCE(exec_blob_gas_used, 0) AS exec_blob_gas_used,
+			COALESCE(exec_excess_blob_gas, 0) AS exec_excess_blob_gas
+		FROM blocks
+		WHERE status = '1' AND exec_block_number IS NOT NULL AND slot >= $1`+filterFragment, queryParams...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving blob blocks: %w", err)
+	}
+
+	var paging t.Paging
+	moreDataFlag := len(data) > int(limit)
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	result := make([]t.NetworkBlobBlockTableRow, len(data))
+	for i, row := range data {
+		result[i] = t.NetworkBlobBlockTableRow{
+			Slot:          row.Slot,
+			Block:         row.Block,
+			Timestamp:     utils.SlotToTime(row.Slot).Unix(),
+			BlobCount:     row.BlobGasUsed / params.BlobTxBlobGasPerBlob,
+			BlobGasUsed:   row.BlobGasUsed,
+			ExcessBlobGas: row.ExcessBlobGas,
+			BlobBaseFee:   decimal.NewFromBigInt(utils.CalcBlobBaseFee(row.ExcessBlobGas, utils.EpochOfSlot(row.Slot)), 0),
+		}
+	}
+
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &paging, nil
+	}
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+// chart_series indicators written by the statistics exporter, mapped to the series ids of the blob history
+var blobHistoryIndicators = map[string]string{
+	"BLOB_COUNT":          "blob_count",
+	"TOTAL_BLOB_GASUSED":  "blob_gas_used",
+	"BURNED_BLOB_FEES":    "burned_blob_fees",
+	"AVG_BLOB_GASUSED":    "avg_blob_gas_used",
+	"AVG_EXCESS_BLOB_GAS": "avg_excess_blob_gas",
+	"AVG_BLOB_BASE_FEE":   "avg_blob_base_fee",
+	"AVG_BLOBS_PER_BLOCK": "avg_blobs_per_block",
+}
+
+func (d *DataAccessService) GetNetworkBlobHistory(ctx context.Context, chainId uint64, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error) {
+	return d.getChartSeriesHistory(ctx, blobHistoryIndicators, afterTs, beforeTs)
+}
+
+// getChartSeriesHistory returns the daily values of the given chart_series indicators as series with the mapped ids,
+// days missing an indicator are filled with 0
+func (d *DataAccessService) getChartSeriesHistory(ctx context.Context, seriesIds map[string]string, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error) {
+	indicators := make([]string, 0, len(seriesIds))
+	for indicator := range seriesIds {
+		indicators = append(indicators, indicator)
+	}
+	sort.Strings(indicators)
+
+	params := []interface{}{pq.Array(indicators)}
+	conditions := "indicator = ANY($1)"
+	if afterTs > 0 {
+		params = append(params, time.Unix(int64(afterTs), 0).UTC())
+		conditions += fmt.Sprintf(" AND time >= $%d", len(params))
+	}
+	if beforeTs > 0 {
+		params = append(params, time.Unix(int64(beforeTs), 0).UTC())
+		conditions += fmt.Sprintf(" AND time <= $%d", len(params))
+	}
+
+	var data []struct {
+		Time      time.Time `db:"time"`
+		Indicator string    `db:"indicator"`
+		Value     float64   `db:"value"`
+	}
+	err := d.readerDb.SelectContext(ctx, &data, `
+		SELECT time, indicator, value
+		FROM chart_series
+		WHERE `+conditions+`
+		ORDER BY time`, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving chart series %v: %w", indicators, err)
+	}
+
+	result := &t.ChartData[string, float64]{
+		Categories: []uint64{},
+		Series:     make([]t.ChartSeries[string, float64], len(indicators)),
+	}
+	seriesIndex := make(map[string]int, len(indicators))
+	for i, indicator := range indicators {
+		result.Series[i] = t.ChartSeries[string, float64]{Id: seriesIds[indicator], Data: []float64{}}
+		seriesIndex[indicator] = i
+	}
+	for _, row := range data {
+		ts := uint64(row.Time.Unix())
+		if len(result.Categories) == 0 || result.Categories[len(result.Categories)-1] != ts {
+			result.Categories = append(result.Categories, ts)
+			for i := range result.Series {
+				result.Series[i].Data = append(result.Series[i].Data, 0)
+			}
+		}
+		series := &result.Series[seriesIndex[row.Indicator]]
+		series.Data[len(series.Data)-1] = row.Value
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetNetworkBlobSubmitters(ctx context.Context, chainId uint64, period enums.TimePeriod, limit uint64) ([]t.NetworkBlobSubmitter, error) {
+	// stats are aggregated per finished day, so periods are counted back from the latest exported day
+	params := []interface{}{limit}
+	dayFilter := ""
+	if period != enums.TimePeriods.AllTime {
+		days := max(int64(period.Duration()/utils.Day), 1)
+		params = append(params, days)
+		dayFilter = `WHERE day > (SELECT COALESCE(MAX(day), 0) FROM blob_submitter_stats) - $2`
+	}
+
+	var data []struct {
+		Address     []byte          `db:"address"`
+		TxCount     uint64          `db:"tx_count"`
+		BlobCount   uint64          `db:"blob_count"`
+		BlobGasUsed uint64          `db:"blob_gas_used"`
+		BlobFees    decimal.Decimal `db:"blob_fees"`
+		TotalBlobs  uint64          `db:"total_blobs"`
+	}
+	err := d.readerDb.SelectContext(ctx, &data, `
+		WITH submitters AS (
+			SELECT
+				address,
+				SUM(tx_count) AS tx_count,
+				SUM(blob_count) AS blob_count,
+				SUM(blob_gas_used) AS blob_gas_used,
+				SUM(blob_fees) AS blob_fees
+			FROM blob_submitter_stats
+			`+dayFilter+`
+			GROUP BY address
+		)
+		SELECT
+			address,
+			tx_count,
+			blob_count,
+			blob_gas_used,
+			blob_fees,
+			SUM(blob_count) OVER () AS total_blobs
+		FROM submitters
+		ORDER BY blob_count DESC, address
+		LIMIT $1`, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving blob submitters: %w", err)
+	}
+
+	result := make([]t.NetworkBlobSubmitter, len(data))
+	addressMapping := make(map[string]*t.Address, len(data))
+	for i, row := range data {
+		result[i] = t.NetworkBlobSubmitter{
+			Address:          t.Address{Hash: t.Hash(hexutil.Encode(row.Address))},
+			TransactionCount: row.TxCount,
+			BlobCount:        row.BlobCount,
+			BlobGasUsed:      row.BlobGasUsed,
+			BlobFees:         row.BlobFees,
+		}
+		if row.TotalBlobs > 0 {
+			result[i].Share = float64(row.BlobCount) / float64(row.TotalBlobs)
+		}
+		addressMapping[string(result[i].Address.Hash)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Address = *addressMapping[string(result[i].Address.Hash)]
+	}
+	return result, nil
+}
+
+// ------------------------------------------------------------
+// Transactions
+
+func (d *DataAccessService) GetNetworkTransaction(ctx context.Context, chainId uint64, hash []byte) (*t.NetworkTransaction, error) {
+	indexedTx, err := d.bigtable.GetIndexedEth1Transaction(hash)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving indexed transaction %#x: %w", hash, err)
+	}
+	if indexedTx == nil {
+		return nil, fmt.Errorf("%w: transaction %#x", ErrNotFound, hash)
+	}
+	// the full transaction including input and logs is only stored with its block
+	block, err := d.bigtable.GetBlockFromBlocksTable(indexedTx.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving block %v of transaction %#x: %w", indexedTx.BlockNumber, hash, err)
+	}
+	txIndex := slices.IndexFunc(block.Transactions, func(blockTx *types.Eth1Transaction) bool {
+		return bytes.Equal(blockTx.Hash, hash)
+	})
+	if txIndex == -1 {
+		return nil, fmt.Errorf("%w: transaction %#x in block %v", ErrNotFound, hash, indexedTx.BlockNumber)
+	}
+	tx := block.Transactions[txIndex]
+	fees, gasPrice := getTransactionFees(tx, new(big.Int).SetBytes(block.BaseFee))
+	interaction := types.CONTRACT_NONE
+	if indexedTx.IsContractCreation {
+		interaction = types.CONTRACT_CREATION
+	} else if indexedTx.InvokesContract {
+		interaction = types.CONTRACT_PRESENT
+	}
+
+	result := &t.NetworkTransaction{
+		Hash:             t.Hash(hexutil.Encode(tx.Hash)),
+		Block:            block.Number,
+		TransactionIndex: uint64(txIndex),
+		Timestamp:        block.Time.AsTime().Unix(),
+		Status:           "success",
+		ErrorMessage:     tx.ErrorMsg,
+		Type:             tx.Type,
+		Nonce:            tx.Nonce,
+		Method:           d.bigtable.GetMethodLabel(tx.Data, interaction),
+		From:             t.Address{Hash: t.Hash(hexutil.Encode(tx.From))},
+		Value:            decimal.NewFromBigInt(new(big.Int).SetBytes(tx.Value), 0),
+		GasLimit:         tx.Gas,
+		GasUsed:          tx.GasUsed,
+		GasPrice:         gasPrice,
+		Fees:             fees,
+		Input:            hexutil.Encode(tx.Data),
+		Logs:             make([]t.NetworkTransactionLog, len(tx.Logs)),
+	}
+	if tx.Status != 1 {
+		result.Status = "failed"
+	}
+	// entries that are already set keep their contract flag when names and ens are resolved
+	addressMapping := map[string]*t.Address{string(result.From.Hash): nil}
+	if len(tx.To) > 0 {
+		result.To = &t.Address{Hash: t.Hash(hexutil.Encode(tx.To)), IsContract: indexedTx.InvokesContract}
+		addressMapping[string(result.To.Hash)] = result.To
+	}
+	if len(tx.ContractAddress) > 0 && !bytes.Equal(tx.ContractAddress, make([]byte, 20)) {
+		result.ContractCreated = &t.Address{Hash: t.Hash(hexutil.Encode(tx.ContractAddress)), IsContract: true}
+		addressMapping[string(result.ContractCreated.Hash)] = result.ContractCreated
+	}
+	if indexedTx.InvokesContract {
+		result.DecodedInput = convertDecodedCall(d.bigtable.DecodeTransactionInput(tx.To, tx.Data))
+	}
+
+	for i, eventLog := range tx.Logs {
+		result.Logs[i] = t.NetworkTransactionLog{
+			Index:   uint64(i),
+			Address: t.Address{Hash: t.Hash(hexutil.Encode(eventLog.Address)), IsContract: true},
+			Topics:  make([]t.Hash, len(eventLog.Topics)),
+			Data:    hexutil.Encode(eventLog.Data),
+			Decoded: convertDecodedCall(d.bigtable.DecodeEventLog(eventLog)),
+		}
+		for j, topic := range eventLog.Topics {
+			result.Logs[i].Topics[j] = t.Hash(hexutil.Encode(topic))
+		}
+		addressMapping[string(result.Logs[i].Address.Hash)] = &t.Address{Hash: result.Logs[i].Address.Hash, IsContract: true}
+	}
+
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	result.From = *addressMapping[string(result.From.Hash)]
+	if result.To != nil {
+		result.To = addressMapping[string(result.To.Hash)]
+	}
+	if result.ContractCreated != nil {
+		result.ContractCreated = addressMapping[string(result.ContractCreated.Hash)]
+	}
+	for i := range result.Logs {
+		result.Logs[i].Address = *addressMapping[string(result.Logs[i].Address.Hash)]
+	}
+	return result, nil
+}
+
+// the network wide transaction list is read from the blocks table, blocks are read in batches and the search for
+// transactions is given up after a bounded number of (empty) blocks
+const (
+	networkTransactionsBlockBatch = 20
+	networkTransactionsMaxBlocks  = 1000
+)
+
+func (d *DataAccessService) GetNetworkTransactions(ctx context.Context, chainId uint64, cursor string, limit uint64) ([]t.NetworkTransactionTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkTransactionsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkTransactionsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkTransactionsCursor: %w", err)
+		}
+	}
+
+	lastBlock, err := d.bigtable.GetLastBlockInBlocksTable()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving last block: %w", err)
+	}
+	latest := uint64(lastBlock)
+
+	// select the transactions first, blocks are only converted if they contribute to the result
+	type selection struct {
+		block   *types.Eth1Block
+		indexes []int
+	}
+	var selected []selection
+	selectedCount := uint64(0)
+	scanned := uint64(0)
+	next := latest
+	if currentCursor.IsValid() {
+		next = min(currentCursor.Block, latest)
+	}
+	reachedEnd := false
+	for selectedCount <= limit && !reachedEnd && scanned < networkTransactionsMaxBlocks {
+		var low, high uint64
+		low, high, next, reachedEnd = networkTransactionsBlockRange(next, latest, currentCursor.IsReverse())
+		stream := make(chan *types.Eth1Block, high-low+1)
+		if err := d.bigtable.GetFullBlocksDescending(stream, high, low); err != nil {
+			return nil, nil, fmt.Errorf("error retrieving blocks %v to %v: %w", low, high, err)
+		}
+		close(stream)
+		blocks := make([]*types.Eth1Block, 0, high-low+1)
+		for block := range stream {
+			blocks = append(blocks, block)
+		}
+		if currentCursor.IsReverse() {
+			slices.Reverse(blocks)
+		}
+		scanned += high - low + 1
+
+		for _, block := range blocks {
+			indexes := selectBlockTransactions(block.Number, len(block.Transactions), currentCursor, limit+1-selectedCount)
+			if len(indexes) > 0 {
+				selected = append(selected, selection{block: block, indexes: indexes})
+				selectedCount += uint64(len(indexes))
+			}
+			if selectedCount > limit {
+				break
+			}
+		}
+	}
+
+	data := make([]t.NetworkTransactionTableRow, 0, selectedCount)
+	for _, s := range selected {
+		rows, err := d.convertBlockTransactions(s.block, s.indexes)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = append(data, rows...)
+	}
+
+	// the search was given up before the end of the chain was reached, so there may be more transactions
+	moreDataFlag := len(data) > int(limit) || !reachedEnd && len(data) > 0
+	if len(data) > int(limit) {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+	if err := d.enrichNetworkTransactionAddresses(ctx, data); err != nil {
+		return nil, nil, err
+	}
+
+	if len(data) == 0 || !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return data, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return data, p, nil
+}
+
+// networkTransactionsBlockRange returns the next batch of blocks to read, starting at next in the requested direction,
+// the block to continue with afterwards and whether the batch reaches the end of the chain in that direction
+func networkTransactionsBlockRange(next, latest uint64, reverse bool) (low, high, following uint64, reachedEnd bool) {
+	if reverse {
+		high = min(next+networkTransactionsBlockBatch-1, latest)
+		return next, high, high + 1, high == latest
+	}
+	low = next - min(next, networkTransactionsBlockBatch-1)
+	return low, next, low - min(low, 1), low == 0
+}
+
+// selectBlockTransactions returns the indexes of at most maxCount transactions of a block that follow the cursor in the requested
+// direction, newest first when paging forward and oldest first when paging back
+func selectBlockTransactions(blockNumber uint64, transactionCount int, cursor t.NetworkTransactionsCursor, maxCount uint64) []int {
+	indexes := make([]int, 0, transactionCount)
+	for i := 0; i < transactionCount; i++ {
+		if cursor.IsValid() && blockNumber == cursor.Block &&
+			(cursor.IsReverse() && uint64(i) <= cursor.TransactionIndex || !cursor.IsReverse() && uint64(i) >= cursor.TransactionIndex) {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	if !cursor.IsReverse() {
+		// newest transactions first
+		slices.Reverse(indexes)
+	}
+	if uint64(len(indexes)) > maxCount {
+		indexes = indexes[:maxCount]
+	}
+	return indexes
+}
+
+func (d *DataAccessService) GetNetworkSlotTransactions(ctx context.Context, chainId, slot uint64) ([]t.NetworkTransactionTableRow, error) {
+	var block sql.NullInt64
+	err := d.alloyReader.GetContext(ctx, &block, `SELECT exec_block_number FROM blocks WHERE slot = $1 AND status = '1'`, slot)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: slot %v", ErrNotFound, slot)
+		}
+		return nil, fmt.Errorf("error retrieving execution block of slot %v: %w", slot, err)
+	}
+	if !block.Valid {
+		// pre-merge slots don't contain an execution block
+		return []t.NetworkTransactionTableRow{}, nil
+	}
+	return d.GetNetworkBlockTransactions(ctx, chainId, uint64(block.Int64))
+}
+
+func (d *DataAccessService) GetNetworkBlockTransactions(ctx context.Context, chainId, blockNumber uint64) ([]t.NetworkTransactionTableRow, error) {
+	block, err := d.bigtable.GetBlockFromBlocksTable(blockNumber)
+	if err != nil {
+		if errors.Is(err, db.ErrBlockNotFound) {
+			return nil, fmt.Errorf("%w: block %v", ErrNotFound, blockNumber)
+		}
+		return nil, fmt.Errorf("error retrieving block %v: %w", blockNumber, err)
+	}
+	indexes := make([]int, len(block.Transactions))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	result, err := d.convertBlockTransactions(block, indexes)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.enrichNetworkTransactionAddresses(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// convertBlockTransactions converts the transactions with the given indexes of a block, addresses are not enriched yet
+func (d *DataAccessService) convertBlockTransactions(block *types.Eth1Block, indexes []int) ([]t.NetworkTransactionTableRow, error) {
+	interactions, err := d.bigtable.GetAddressContractInteractionsAtBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contract interactions of block %v: %w", block.Number, err)
+	}
+	baseFee := new(big.Int).SetBytes(block.BaseFee)
+
+	result := make([]t.NetworkTransactionTableRow, len(indexes))
+	for i, txIndex := range indexes {
+		tx := block.Transactions[txIndex]
+		fees, gasPrice := getTransactionFees(tx, baseFee)
+		result[i] = t.NetworkTransactionTableRow{
+			Hash:             t.Hash(hexutil.Encode(tx.Hash)),
+			Block:            block.Number,
+			TransactionIndex: uint64(txIndex),
+			Timestamp:        block.Time.AsTime().Unix(),
+			Status:           "success",
+			Method:           d.bigtable.GetMethodLabel(tx.Data, interactions[txIndex]),
+			From:             t.Address{Hash: t.Hash(hexutil.Encode(tx.From))},
+			Value:            decimal.NewFromBigInt(new(big.Int).SetBytes(tx.Value), 0),
+			GasPrice:         gasPrice,
+			Fees:             fees,
+		}
+		if tx.Status != 1 {
+			result[i].Status = "failed"
+		}
+		if len(tx.To) > 0 {
+			result[i].To = &t.Address{Hash: t.Hash(hexutil.Encode(tx.To)), IsContract: interactions[txIndex] == types.CONTRACT_PRESENT}
+		}
+		if interactions[txIndex] == types.CONTRACT_CREATION {
+			result[i].ContractCreated = &t.Address{Hash: t.Hash(hexutil.Encode(tx.ContractAddress)), IsContract: true}
+		}
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) enrichNetworkTransactionAddresses(ctx context.Context, rows []t.NetworkTransactionTableRow) error {
+	// entries that are already set keep their contract flag when names and ens are resolved
+	addressMapping := make(map[string]*t.Address)
+	for _, row := range rows {
+		addressMapping[string(row.From.Hash)] = nil
+		if row.To != nil {
+			addressMapping[string(row.To.Hash)] = row.To
+		}
+		if row.ContractCreated != nil {
+			addressMapping[string(row.ContractCreated.Hash)] = row.ContractCreated
+		}
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return err
+	}
+	for i := range rows {
+		rows[i].From = *addressMapping[string(rows[i].From.Hash)]
+		if rows[i].To != nil {
+			rows[i].To = addressMapping[string(rows[i].To.Hash)]
+		}
+		if rows[i].ContractCreated != nil {
+			rows[i].ContractCreated = addressMapping[string(rows[i].ContractCreated.Hash)]
+		}
+	}
+	return nil
+}
+
+var addressTransactionIndexTypes = map[enums.AddressTransactionType][]string{
+	enums.AddressTransactionTypes.All:      {"TX", "ITX", "ERC20", "ERC721", "ERC1155"},
+	enums.AddressTransactionTypes.General:  {"TX"},
+	enums.AddressTransactionTypes.Internal: {"ITX"},
+	enums.AddressTransactionTypes.Erc20:    {"ERC20"},
+	enums.AddressTransactionTypes.Erc721:   {"ERC721"},
+	enums.AddressTransactionTypes.Erc1155:  {"ERC1155"},
+}
+
+func (d *DataAccessService) GetNetworkAddressTransactions(ctx context.Context, chainId uint64, address []byte, txType enums.AddressTransactionType, cursor string, limit uint64) ([]t.NetworkAddressTransactionTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkAddressTransactionsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkAddressTransactionsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkAddressTransactionsCursor: %w", err)
+		}
+	}
+
+	data, nextPositions, prevPositions, moreDataFlag, err := d.bigtable.GetEth1ActivityForAddress(address, addressTransactionIndexTypes[txType], currentCursor.Positions, currentCursor.IsReverse(), int64(limit))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving transactions of address %#x: %w", address, err)
+	}
+
+	result := make([]t.NetworkAddressTransactionTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, activity := range data {
+		var from, to []byte
+		row := &result[i]
+		switch {
+		case activity.Tx != nil:
+			tx := activity.Tx
+			interaction := types.CONTRACT_NONE
+			if tx.IsContractCreation {
+				interaction = types.CONTRACT_CREATION
+			} else if tx.InvokesContract {
+				interaction = types.CONTRACT_PRESENT
+			}
+			fee := decimal.NewFromBigInt(new(big.Int).SetBytes(tx.TxFee), 0)
+			*row = t.NetworkAddressTransactionTableRow{
+				Type:      "general",
+				Hash:      t.Hash(hexutil.Encode(tx.Hash)),
+				Block:     tx.BlockNumber,
+				Timestamp: tx.Time.AsTime().Unix(),
+				Status:    "success",
+				Method:    d.bigtable.GetMethodLabel(tx.MethodId, interaction),
+				Value:     decimal.NewFromBigInt(new(big.Int).SetBytes(tx.Value), 0),
+				Fee:       &fee,
+			}
+			if tx.ErrorMsg != "" {
+				row.Status = "failed"
+			}
+			from, to = tx.From, tx.To
+		case activity.Itx != nil:
+			itx := activity.Itx
+			*row = t.NetworkAddressTransactionTableRow{
+				Type:      "internal",
+				Hash:      t.Hash(hexutil.Encode(itx.ParentHash)),
+				Block:     itx.BlockNumber,
+				Timestamp: itx.Time.AsTime().Unix(),
+				Method:    itx.Type,
+				Value:     decimal.NewFromBigInt(new(big.Int).SetBytes(itx.Value), 0),
+			}
+			from, to = itx.From, itx.To
+		case activity.ERC20 != nil:
+			transfer := activity.ERC20
+			*row = t.NetworkAddressTransactionTableRow{
+				Type:      "erc20",
+				Hash:      t.Hash(hexutil.Encode(transfer.ParentHash)),
+				Block:     transfer.BlockNumber,
+				Timestamp: transfer.Time.AsTime().Unix(),
+				Value:     decimal.NewFromBigInt(new(big.Int).SetBytes(transfer.Value), 0),
+				Token:     &t.Address{Hash: t.Hash(hexutil.Encode(transfer.TokenAddress)), IsContract: true},
+			}
+			from, to = transfer.From, transfer.To
+		case activity.ERC721 != nil:
+			transfer := activity.ERC721
+			*row = t.NetworkAddressTransactionTableRow{
+				Type:      "erc721",
+				Hash:      t.Hash(hexutil.Encode(transfer.ParentHash)),
+				Block:     transfer.BlockNumber,
+				Timestamp: transfer.Time.AsTime().Unix(),
+				Value:     decimal.NewFromInt(1),
+				Token:     &t.Address{Hash: t.Hash(hexutil.Encode(transfer.TokenAddress)), IsContract: true},
+				TokenId:   new(big.Int).SetBytes(transfer.TokenId).String(),
+			}
+			from, to = transfer.From, transfer.To
+		case activity.ERC1155 != nil:
+			transfer := activity.ERC1155
+			*row = t.NetworkAddressTransactionTableRow{
+				Type:      "erc1155",
+				Hash:      t.Hash(hexutil.Encode(transfer.ParentHash)),
+				Block:     transfer.BlockNumber,
+				Timestamp: transfer.Time.AsTime().Unix(),
+				Value:     decimal.NewFromBigInt(new(big.Int).SetBytes(transfer.Value), 0),
+				Token:     &t.Address{Hash: t.Hash(hexutil.Encode(transfer.TokenAddress)), IsContract: true},
+				TokenId:   new(big.Int).SetBytes(transfer.TokenId).String(),
+			}
+			from, to = transfer.From, transfer.To
+		}
+
+		switch {
+		case bytes.Equal(from, address) && bytes.Equal(to, address):
+			row.Direction = "self"
+		case bytes.Equal(from, address):
+			row.Direction = "out"
+		default:
+			row.Direction = "in"
+		}
+		row.From = t.Address{Hash: t.Hash(hexutil.Encode(from))}
+		addressMapping[string(row.From.Hash)] = nil
+		if len(to) > 0 {
+			row.To = &t.Address{Hash: t.Hash(hexutil.Encode(to))}
+			addressMapping[string(row.To.Hash)] = nil
+		}
+		if row.Token != nil {
+			addressMapping[string(row.Token.Hash)] = row.Token
+		}
+	}
+
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range result {
+		result[i].From = *addressMapping[string(result[i].From.Hash)]
+		if result[i].To != nil {
+			result[i].To = addressMapping[string(result[i].To.Hash)]
+		}
+		if result[i].Token != nil {
+			result[i].Token = addressMapping[string(result[i].Token.Hash)]
+		}
+	}
+
+	// the positions can't be derived from the rows, so the cursors are built here instead of using GetPagingFromData
+	paging := &t.Paging{}
+	if moreDataFlag && !currentCursor.IsReverse() || currentCursor.IsReverse() {
+		paging.NextCursor, err = utils.CursorToString(t.NetworkAddressTransactionsCursor{Positions: nextPositions})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+		}
+	}
+	if currentCursor.IsValid() && !currentCursor.IsReverse() || moreDataFlag && currentCursor.IsReverse() {
+		paging.PrevCursor, err = utils.CursorToString(t.NetworkAddressTransactionsCursor{GenericCursor: t.GenericCursor{Reverse: true}, Positions: prevPositions})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+		}
+	}
+	return result, paging, nil
+}
+
+// getTransactionFees splits the fee of a transaction into the burned base fee and the priority fee received by the fee recipient,
+// the effective gas price is returned as well
+func getTransactionFees(tx *types.Eth1Transaction, baseFee *big.Int) (t.NetworkTransactionFees, decimal.Decimal) {
+	total := db.CalculateTxFeeFromTransaction(tx, baseFee)
+	burned := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(tx.GasUsed))
+	fees := t.NetworkTransactionFees{
+		Total:    decimal.NewFromBigInt(total, 0),
+		Burned:   decimal.NewFromBigInt(burned, 0),
+		Priority: decimal.NewFromBigInt(new(big.Int).Sub(total, burned), 0),
+	}
+	if tx.BlobGasUsed > 0 {
+		blobFee := decimal.NewFromBigInt(new(big.Int).Mul(new(big.Int).SetUint64(tx.BlobGasUsed), new(big.Int).SetBytes(tx.BlobGasPrice)), 0)
+		fees.BlobFee = &blobFee
+	}
+
+	gasPrice := decimal.NewFromBigInt(new(big.Int).SetBytes(tx.GasPrice), 0)
+	if tx.GasUsed > 0 {
+		gasPrice = fees.Total.Div(decimal.NewFromUint64(tx.GasUsed))
+	}
+	return fees, gasPrice
+}
+
+func convertDecodedCall(decoded *types.DecodedCall) *t.NetworkDecodedCall {
+	if decoded == nil {
+		return nil
+	}
+	result := &t.NetworkDecodedCall{
+		Name:      decoded.Name,
+		Signature: decoded.Signature,
+		Source:    decoded.Source,
+		Arguments: make([]t.NetworkDecodedArgument, len(decoded.Arguments)),
+	}
+	for i, arg := range decoded.Arguments {
+		result.Arguments[i] = t.NetworkDecodedArgument{
+			Name:    arg.Name,
+			Type:    arg.Type,
+			Value:   arg.Value,
+			Indexed: arg.Indexed,
+		}
+	}
+	return result
+}
+
+func (d *DataAccessService) SaveNetworkContractAbi(ctx context.Context, chainId uint64, address []byte, name string, abiJson []byte) (*t.NetworkContractAbi, error) {
+	metadata, err := utils.NewContractMetadata(name, abiJson)
+	if err != nil {
+		return nil, err
+	}
+	err = d.bigtable.SaveVerifiedContractMetadata(address, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error saving abi of contract %#x: %w", address, err)
+	}
+	return &t.NetworkContractAbi{
+		Address: t.Hash(hexutil.Encode(address)),
+		Name:    metadata.Name,
+		Methods: uint64(len(metadata.ABI.Methods)),
+		Events:  uint64(len(metadata.ABI.Events)),
+	}, nil
+}
+
+// ------------------------------------------------------------
+// Address History
+
+// getBalanceSnapshots retrieves the snapshots following the cursor, a nil address selects the total supply of the token
+func (d *DataAccessService) getBalanceSnapshots(address, token []byte, currentCursor t.NetworkAddressBalanceHistoryCursor, limit uint64) ([]*types.Eth1BalanceSnapshot, bool, error) {
+	var snapshotCursor *types.Eth1BalanceSnapshot
+	if currentCursor.IsValid() {
+		snapshotCursor = &types.Eth1BalanceSnapshot{BlockNumber: currentCursor.Block}
+	}
+
+	var data []*types.Eth1BalanceSnapshot
+	var err error
+	if address == nil {
+		data, err = d.bigtable.GetTotalSupplyHistory(token, snapshotCursor, currentCursor.IsReverse(), int64(limit+1))
+	} else {
+		data, err = d.bigtable.GetBalanceHistory(address, token, snapshotCursor, currentCursor.IsReverse(), int64(limit+1))
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error retrieving balance snapshots of %#x for token %#x: %w", address, token, err)
+	}
+
+	moreDataFlag := len(data) > int(limit)
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+	return data, moreDataFlag, nil
+}
+
+// getSnapshotBalance returns the balance (or total supply) of the snapshot, nil if its call reverted
+func getSnapshotBalance(snapshot *types.Eth1BalanceSnapshot) *decimal.Decimal {
+	if snapshot.Reverted {
+		return nil
+	}
+	balance := decimal.NewFromBigInt(new(big.Int).SetBytes(snapshot.Balance), 0)
+	return &balance
+}
+
+func (d *DataAccessService) GetNetworkAddressBalanceHistory(ctx context.Context, chainId uint64, address, token []byte, cursor string, limit uint64) ([]t.NetworkAddressBalanceSnapshot, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkAddressBalanceHistoryCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkAddressBalanceHistoryCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkAddressBalanceHistoryCursor: %w", err)
+		}
+	}
+
+	data, moreDataFlag, err := d.getBalanceSnapshots(address, token, currentCursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := make([]t.NetworkAddressBalanceSnapshot, len(data))
+	for i, snapshot := range data {
+		result[i] = t.NetworkAddressBalanceSnapshot{
+			Block:     snapshot.BlockNumber,
+			Timestamp: int64(snapshot.Time),
+			Balance:   getSnapshotBalance(snapshot),
+		}
+	}
+
+	if len(result) == 0 || !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetNetworkAddressTokenSupplyHistory(ctx context.Context, chainId uint64, token []byte, cursor string, limit uint64) ([]t.NetworkTokenSupplySnapshot, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkAddressBalanceHistoryCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkAddressBalanceHistoryCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkAddressBalanceHistoryCursor: %w", err)
+		}
+	}
+
+	data, moreDataFlag, err := d.getBalanceSnapshots(nil, token, currentCursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := make([]t.NetworkTokenSupplySnapshot, len(data))
+	for i, snapshot := range data {
+		result[i] = t.NetworkTokenSupplySnapshot{
+			Block:       snapshot.BlockNumber,
+			Timestamp:   int64(snapshot.Time),
+			TotalSupply: getSnapshotBalance(snapshot),
+		}
+	}
+
+	if len(result) == 0 || !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+// GetNetworkAddressEventLogs returns the event logs of a contract, they are only indexed if the eth1 indexer runs with -eventlogs.enabled
+func (d *DataAccessService) GetNetworkAddressEventLogs(ctx context.Context, chainId uint64, address []byte, cursor string, limit uint64) ([]t.NetworkAddressEventLog, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkAddressEventLogsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkAddressEventLogsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkAddressEventLogsCursor: %w", err)
+		}
+	}
+	var logCursor *types.Eth1EventLogIndexed
+	if currentCursor.IsValid() {
+		logCursor = &types.Eth1EventLogIndexed{
+			BlockNumber: currentCursor.Block,
+			TxIndex:     currentCursor.TransactionIndex,
+			LogIndex:    currentCursor.LogIndex,
+		}
+	}
+
+	data, err := d.bigtable.GetEventLogsForAddress(address, logCursor, currentCursor.IsReverse(), int64(limit+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving event logs of %#x: %w", address, err)
+	}
+
+	moreDataFlag := len(data) > int(limit)
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	result := make([]t.NetworkAddressEventLog, len(data))
+	for i, eventLog := range data {
+		result[i] = t.NetworkAddressEventLog{
+			TransactionHash:  t.Hash(hexutil.Encode(eventLog.TxHash)),
+			Block:            eventLog.BlockNumber,
+			Timestamp:        int64(eventLog.Time),
+			TransactionIndex: eventLog.TxIndex,
+			LogIndex:         eventLog.LogIndex,
+			Topics:           make([]t.Hash, len(eventLog.Log.Topics)),
+			Data:             hexutil.Encode(eventLog.Log.Data),
+			Decoded:          convertDecodedCall(d.bigtable.DecodeEventLog(eventLog.Log)),
+		}
+		for j, topic := range eventLog.Log.Topics {
+			result[i].Topics[j] = t.Hash(hexutil.Encode(topic))
+		}
+	}
+
+	if len(result) == 0 || !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+// number of blocks the base fee is predicted for
+const baseFeePredictionBlocks = 5
+
+// number of gas oracle updates that may be missed before the latest recommendation is considered stale
+const gasNowStaleUpdates = 5
+
+func (d *DataAccessService) GetNetworkGasNow(ctx context.Context, chainId uint64) (*t.NetworkGasNow, error) {
+	// the gas oracle stores a recommendation per configured interval, older ones are considered stale
+	ts := time.Now()
+	history, err := d.bigtable.GetGasNowHistory(ts, ts.Add(-utils.Config.Indexer.GasNowFrequency*gasNowStaleUpdates))
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("%w: no recent gas price recommendation", ErrNotFound)
+	}
+
+	// rows are sorted by descending timestamp
+	latest := history[0]
+	result := &t.NetworkGasNow{
+		Timestamp: latest.Ts.Unix(),
+		BaseFee:   decimal.NewFromBigInt(latest.BaseFee, 0),
+		Slow:      decimal.NewFromBigInt(latest.Slow, 0),
+		Standard:  decimal.NewFromBigInt(latest.Standard, 0),
+		Fast:      decimal.NewFromBigInt(latest.Fast, 0),
+		Rapid:     decimal.NewFromBigInt(latest.Rapid, 0),
+	}
+	// the base fee of the next block is known, predictions start at the block after
+	predictions := gasoracle.PredictBaseFees(latest.BaseFee, baseFeePredictionBlocks)
+	result.BaseFeePredictions = make([]t.NetworkBaseFeePrediction, len(predictions))
+	for i, prediction := range predictions {
+		result.BaseFeePredictions[i] = t.NetworkBaseFeePrediction{
+			BlocksAhead: prediction.BlocksAhead + 1,
+			Min:         decimal.NewFromBigInt(prediction.Min, 0),
+			Max:         decimal.NewFromBigInt(prediction.Max, 0),
+		}
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetNetworkAverageGasLimitHistory(ctx context.Context, chainId uint64, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error) {
+	return d.getChartSeriesHistory(ctx, map[string]string{"AVG_GASLIMIT": "avg_gas_limit"}, afterTs, beforeTs)
+}
+
+func (d *DataAccessService) GetNetworkGasUsedHistory(ctx context.Context, chainId uint64, afterTs, beforeTs uint64) (*t.ChartData[string, float64], error) {
+	return d.getChartSeriesHistory(ctx, map[string]string{
+		"TOTAL_GASUSED":           "total_gas_used",
+		"AVG_GASUSED":             "avg_gas_used",
+		"NON_FAILED_TX_GAS_USAGE": "non_failed_tx_gas_used",
+		"AVG_BLOCK_UTIL":          "avg_block_utilization",
+	}, afterTs, beforeTs)
+}
+
+func (d *DataAccessService) GetNetworkBatches(ctx context.Context, layer2ChainId uint64, cursor string, limit uint64) ([]t.NetworkBatchTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkBatchesCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkBatchesCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkBatchesCursor: %w", err)
+		}
+	}
+	var batchCursor *types.Eth1Layer2Batch
+	if currentCursor.IsValid() {
+		batchCursor = &types.Eth1Layer2Batch{
+			BlockNumber: currentCursor.Block,
+			TxIndex:     currentCursor.TransactionIndex,
+		}
+	}
+
+	data, err := d.bigtable.GetLayer2Batches(layer2ChainId, batchCursor, currentCursor.IsReverse(), int64(limit+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving batches of layer 2 network %d: %w", layer2ChainId, err)
+	}
+
+	moreDataFlag := len(data) > int(limit)
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	result := make([]t.NetworkBatchTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, batch := range data {
+		result[i] = t.NetworkBatchTableRow{
+			Index:            batch.Index,
+			TxHash:           t.Hash(hexutil.Encode(batch.TxHash)),
+			Block:            batch.BlockNumber,
+			TransactionIndex: batch.TxIndex,
+			Timestamp:        int64(batch.Time),
+			Submitter:        t.Address{Hash: t.Hash(hexutil.Encode(batch.Submitter))},
+			DataLocation:     batch.DataLocation,
+			Size:             batch.Size,
+			Fee:              decimal.NewFromBigInt(new(big.Int).SetBytes(batch.Fee), 0),
+		}
+		for _, versionedHash := range batch.BlobVersionedHashes {
+			result[i].BlobVersionedHashes = append(result[i].BlobVersionedHashes, t.Hash(hexutil.Encode(versionedHash)))
+		}
+		addressMapping[string(result[i].Submitter.Hash)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range result {
+		result[i].Submitter = *addressMapping[string(result[i].Submitter.Hash)]
+	}
+
+	if len(result) == 0 || !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetNetworkLayer1ToLayer2Transactions(ctx context.Context, layer2ChainId uint64, cursor string, limit uint64) ([]t.NetworkLayer1ToLayer2TransactionTableRow, *t.Paging, error) {
+	currentCursor, data, moreDataFlag, err := d.getNetworkLayer2Transfers(layer2ChainId, layer2.TransferDeposit, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]t.NetworkLayer1ToLayer2TransactionTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, transfer := range data {
+		result[i] = t.NetworkLayer1ToLayer2TransactionTableRow{
+			TxHash:           t.Hash(hexutil.Encode(transfer.TxHash)),
+			Block:            transfer.BlockNumber,
+			TransactionIndex: transfer.TxIndex,
+			LogIndex:         transfer.LogIndex,
+			Timestamp:        int64(transfer.Time),
+			MessageId:        convertLayer2MessageId(transfer.MessageId),
+			From:             convertLayer2Address(transfer.From, addressMapping),
+			To:               convertLayer2Address(transfer.To, addressMapping),
+			Value:            convertLayer2Value(transfer.Value),
+		}
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range result {
+		result[i].From = resolveLayer2Address(result[i].From, addressMapping)
+		result[i].To = resolveLayer2Address(result[i].To, addressMapping)
+	}
+
+	if len(result) == 0 || !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetNetworkLayer2ToLayer1Transactions(ctx context.Context, layer2ChainId uint64, cursor string, limit uint64) ([]t.NetworkLayer2ToLayer1TransactionTableRow, *t.Paging, error) {
+	currentCursor, data, moreDataFlag, err := d.getNetworkLayer2Transfers(layer2ChainId, layer2.TransferWithdrawal, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]t.NetworkLayer2ToLayer1TransactionTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, transfer := range data {
+		result[i] = t.NetworkLayer2ToLayer1TransactionTableRow{
+			TxHash:           t.Hash(hexutil.Encode(transfer.TxHash)),
+			Block:            transfer.BlockNumber,
+			TransactionIndex: transfer.TxIndex,
+			LogIndex:         transfer.LogIndex,
+			Timestamp:        int64(transfer.Time),
+			Status:           transfer.Status,
+			MessageId:        convertLayer2MessageId(transfer.MessageId),
+			From:             convertLayer2Address(transfer.From, addressMapping),
+			To:               convertLayer2Address(transfer.To, addressMapping),
+			Value:            convertLayer2Value(transfer.Value),
+		}
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range result {
+		result[i].From = resolveLayer2Address(result[i].From, addressMapping)
+		result[i].To = resolveLayer2Address(result[i].To, addressMapping)
+	}
+
+	if len(result) == 0 || !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+// getNetworkLayer2Transfers returns a page of the deposits or withdrawals of a layer 2 network in the direction of the cursor
+func (d *DataAccessService) getNetworkLayer2Transfers(layer2ChainId uint64, direction string, cursor string, limit uint64) (t.NetworkLayer2TransactionsCursor, []*types.Eth1Layer2Transfer, bool, error) {
+	var err error
+	var currentCursor t.NetworkLayer2TransactionsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkLayer2TransactionsCursor](cursor)
+		if err != nil {
+			return currentCursor, nil, false, fmt.Errorf("failed to parse passed cursor as NetworkLayer2TransactionsCursor: %w", err)
+		}
+	}
+	var transferCursor *types.Eth1Layer2Transfer
+	if currentCursor.IsValid() {
+		transferCursor = &types.Eth1Layer2Transfer{
+			BlockNumber: currentCursor.Block,
+			TxIndex:     currentCursor.TransactionIndex,
+			LogIndex:    currentCursor.LogIndex,
+		}
+	}
+
+	data, err := d.bigtable.GetLayer2Transfers(layer2ChainId, direction, transferCursor, currentCursor.IsReverse(), int64(limit+1))
+	if err != nil {
+		return currentCursor, nil, false, fmt.Errorf("error retrieving %ss of layer 2 network %d: %w", direction, layer2ChainId, err)
+	}
+
+	moreDataFlag := len(data) > int(limit)
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+	return currentCursor, data, moreDataFlag, nil
+}
+
+func convertLayer2MessageId(messageId []byte) *t.Hash {
+	if messageId == nil {
+		return nil
+	}
+	hash := t.Hash(hexutil.Encode(messageId))
+	return &hash
+}
+
+func convertLayer2Value(value []byte) *decimal.Decimal {
+	if value == nil {
+		return nil
+	}
+	result := decimal.NewFromBigInt(new(big.Int).SetBytes(value), 0)
+	return &result
+}
+
+// convertLayer2Address adds the address to the mapping, the names of the mapping are resolved by resolveLayer2Address
+func convertLayer2Address(address []byte, addressMapping map[string]*t.Address) *t.Address {
+	if address == nil {
+		return nil
+	}
+	result := &t.Address{Hash: t.Hash(hexutil.Encode(address))}
+	addressMapping[string(result.Hash)] = nil
+	return result
+}
+
+func resolveLayer2Address(address *t.Address, addressMapping map[string]*t.Address) *t.Address {
+	if address == nil {
+		return nil
+	}
+	return addressMapping[string(address.Hash)]
+}
+
+func (d *DataAccessService) GetNetworkEns(ctx context.Context, name string) (*t.NetworkEnsName, error) {
+	data, err := db.GetEnsName(name)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ens name %v: %w", name, err)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("%w: ens name %v", ErrNotFound, name)
+	}
+	result, err := d.convertEnsNames(ctx, []*types.EnsName{data})
+	if err != nil {
+		return nil, err
+	}
+	return &result[0], nil
+}
+
+func (d *DataAccessService) GetNetworkAddressEns(ctx context.Context, address []byte) (*t.NetworkAddressEns, error) {
+	data, err := db.GetEnsNamesOfAddress(common.BytesToAddress(address))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ens names of %#x: %w", address, err)
+	}
+	names, err := d.convertEnsNames(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	result := &t.NetworkAddressEns{Names: names}
+	// the primary name is sorted first
+	if len(names) > 0 && names[0].IsPrimary {
+		result.PrimaryName = &names[0]
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) convertEnsNames(ctx context.Context, data []*types.EnsName) ([]t.NetworkEnsName, error) {
+	result := make([]t.NetworkEnsName, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, name := range data {
+		result[i] = t.NetworkEnsName{
+			Name:        name.Name,
+			Address:     t.Address{Hash: t.Hash(hexutil.Encode(name.Address))},
+			IsPrimary:   name.IsPrimary,
+			Expiry:      name.ValidTo.Unix(),
+			TextRecords: make(map[string]string),
+		}
+		if len(name.Resolver) > 0 {
+			result[i].Resolver = &t.Address{Hash: t.Hash(hexutil.Encode(name.Resolver)), IsContract: true}
+		}
+		// names validated before text records were indexed have none
+		if len(name.TextRecords) > 0 {
+			if err := json.Unmarshal(name.TextRecords, &result[i].TextRecords); err != nil {
+				return nil, fmt.Errorf("error parsing text records of ens name %v: %w", name.Name, err)
+			}
+		}
+		addressMapping[string(result[i].Address.Hash)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Address = *addressMapping[string(result[i].Address.Hash)]
+	}
+	return result, nil
+}
+
+var userOperationRoles = map[enums.UserOperationRole]string{
+	enums.UserOperationRoles.Sender:    db.UserOperationRoleSender,
+	enums.UserOperationRoles.Paymaster: db.UserOperationRolePaymaster,
+	enums.UserOperationRoles.Bundler:   db.UserOperationRoleBundler,
+}
+
+func (d *DataAccessService) GetNetworkAddressUserOperations(ctx context.Context, chainId uint64, address []byte, role enums.UserOperationRole, cursor string, limit uint64) ([]t.NetworkUserOperationTableRow, *t.Paging, error) {
+	var err error
+	var currentCursor t.NetworkUserOperationsCursor
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NetworkUserOperationsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NetworkUserOperationsCursor: %w", err)
+		}
+	}
+	var opCursor *types.Eth1UserOperation
+	if currentCursor.IsValid() {
+		opCursor = &types.Eth1UserOperation{
+			BlockNumber: currentCursor.Block,
+			TxIndex:     currentCursor.TransactionIndex,
+			LogIndex:    currentCursor.LogIndex,
+		}
+	}
+
+	data, err := d.bigtable.GetUserOperations(address, userOperationRoles[role], opCursor, currentCursor.IsReverse(), int64(limit+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving user operations of %#x: %w", address, err)
+	}
+
+	moreDataFlag := len(data) > int(limit)
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		data = data[:len(data)-1]
+	}
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(data)
+	}
+
+	result := make([]t.NetworkUserOperationTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, op := range data {
+		result[i] = t.NetworkUserOperationTableRow{
+			Hash:             t.Hash(hexutil.Encode(op.Hash)),
+			TxHash:           t.Hash(hexutil.Encode(op.TxHash)),
+			Block:            op.BlockNumber,
+			TransactionIndex: op.TxIndex,
+			LogIndex:         op.LogIndex,
+			Timestamp:        int64(op.Time),
+			EntryPoint:       t.Address{Hash: t.Hash(hexutil.Encode(op.EntryPoint)), IsContract: true},
+			Sender:           t.Address{Hash: t.Hash(hexutil.Encode(op.Sender))},
+			Bundler:          t.Address{Hash: t.Hash(hexutil.Encode(op.Bundler))},
+			Nonce:            decimal.NewFromBigInt(new(big.Int).SetBytes(op.Nonce), 0),
+			Success:          op.Success,
+			GasUsed:          op.ActualGasUsed,
+			GasCost:          decimal.NewFromBigInt(new(big.Int).SetBytes(op.ActualGasCost), 0),
+		}
+		if op.Paymaster != nil {
+			result[i].Paymaster = &t.Address{Hash: t.Hash(hexutil.Encode(op.Paymaster))}
+			addressMapping[string(result[i].Paymaster.Hash)] = nil
+		}
+		addressMapping[string(result[i].Sender.Hash)] = nil
+		addressMapping[string(result[i].Bundler.Hash)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range result {
+		result[i].Sender = *addressMapping[string(result[i].Sender.Hash)]
+		if result[i].Paymaster != nil {
+			result[i].Paymaster = addressMapping[string(result[i].Paymaster.Hash)]
+		}
+		result[i].Bundler = *addressMapping[string(result[i].Bundler.Hash)]
+	}
+
+	if len(result) == 0 || !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &t.Paging{}, nil
+	}
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetNetworkAddressSponsorship(ctx context.Context, chainId uint64, address []byte, period enums.TimePeriod) (*t.NetworkPaymasterSponsorship, error) {
+	// stats are aggregated per finished day, so periods are counted back from the latest exported day
+	params := []interface{}{address}
+	dayFilter := ""
+	if period != enums.TimePeriods.AllTime {
+		days := max(int64(period.Duration()/utils.Day), 1)
+		params = append(params, days)
+		dayFilter = `AND day > (SELECT COALESCE(MAX(day), 0) FROM paymaster_stats) - $2`
+	}
+
+	var data struct {
+		OpCount uint64          `db:"op_count"`
+		GasUsed uint64          `db:"gas_used"`
+		GasCost decimal.Decimal `db:"gas_cost"`
+	}
+	err := d.readerDb.GetContext(ctx, &data, `
+		SELECT
+			COALESCE(SUM(op_count), 0) AS op_count,
+			COALESCE(SUM(gas_used), 0) AS gas_used,
+			COALESCE(SUM(gas_cost), 0) AS gas_cost
+		FROM paymaster_stats
+		WHERE address = $1 `+dayFilter, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving sponsorship of paymaster %#x: %w", address, err)
+	}
+
+	return &t.NetworkPaymasterSponsorship{
+		OperationCount: data.OpCount,
+		GasUsed:        data.GasUsed,
+		GasCost:        data.GasCost,
+	}, nil
+}
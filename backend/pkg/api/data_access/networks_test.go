@@ -0,0 +1,39 @@
+package dataaccess
+
+import (
+	"testing"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkTransactionsBlockRange(tt *testing.T) {
+	// forward paging walks down to the genesis block
+	low, high, following, reachedEnd := networkTransactionsBlockRange(100, 100, false)
+	assert.Equal(tt, []uint64{81, 100, 80}, []uint64{low, high, following})
+	assert.False(tt, reachedEnd)
+	low, high, following, reachedEnd = networkTransactionsBlockRange(10, 100, false)
+	assert.Equal(tt, []uint64{0, 10, 0}, []uint64{low, high, following})
+	assert.True(tt, reachedEnd)
+
+	// reverse paging walks up to the latest block
+	low, high, following, reachedEnd = networkTransactionsBlockRange(50, 100, true)
+	assert.Equal(tt, []uint64{50, 69, 70}, []uint64{low, high, following})
+	assert.False(tt, reachedEnd)
+	low, high, following, reachedEnd = networkTransactionsBlockRange(90, 100, true)
+	assert.Equal(tt, []uint64{90, 100, 101}, []uint64{low, high, following})
+	assert.True(tt, reachedEnd)
+}
+
+func TestSelectBlockTransactions(tt *testing.T) {
+	assert.Equal(tt, []int{4, 3, 2, 1, 0}, selectBlockTransactions(10, 5, t.NetworkTransactionsCursor{}, 10), "without a cursor the newest transactions come first")
+	assert.Equal(tt, []int{4, 3}, selectBlockTransactions(10, 5, t.NetworkTransactionsCursor{}, 2))
+
+	forward := t.NetworkTransactionsCursor{GenericCursor: t.GenericCursor{Valid: true}, Block: 10, TransactionIndex: 2}
+	assert.Equal(tt, []int{1, 0}, selectBlockTransactions(10, 5, forward, 10), "forward paging continues with the older transactions of the cursor block")
+	assert.Equal(tt, []int{4, 3, 2, 1, 0}, selectBlockTransactions(9, 5, forward, 10), "the cursor only applies to its own block")
+
+	reverse := t.NetworkTransactionsCursor{GenericCursor: t.GenericCursor{Valid: true, Reverse: true}, Block: 10, TransactionIndex: 2}
+	assert.Equal(tt, []int{3, 4}, selectBlockTransactions(10, 5, reverse, 10), "reverse paging continues with the newer transactions, oldest first")
+	assert.Equal(tt, []int{0, 1}, selectBlockTransactions(11, 5, reverse, 2))
+}
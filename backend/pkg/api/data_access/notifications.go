@@ -2,6 +2,7 @@ package dataaccess
 
 import (
 	"bytes"
+	"cmp"
 	"compress/gzip"
 	"context"
 	"database/sql"
@@ -29,6 +30,7 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/gobitfly/beaconchain/pkg/notification"
 	n "github.com/gobitfly/beaconchain/pkg/notification"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/shopspring/decimal"
 	"golang.org/x/sync/errgroup"
@@ -56,6 +58,8 @@ type NotificationsRepository interface {
 	UpdateNotificationSettingsClients(ctx context.Context, userId uint64, clientId uint64, IsSubscribed bool) (*t.NotificationSettingsClient, error)
 	GetNotificationSettingsDashboards(ctx context.Context, userId uint64, cursor string, colSort t.Sort[enums.NotificationSettingsDashboardColumn], search string, limit uint64) ([]t.NotificationSettingsDashboardsTableRow, *t.Paging, error)
 	UpdateNotificationSettingsValidatorDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsValidatorDashboard) error
+	GetNotificationSettingsValidatorDashboardTags(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary) ([]t.NotificationSettingsValidatorDashboardTag, error)
+	UpdateNotificationSettingsValidatorDashboardTag(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, tag t.VDBValidatorTag, settings t.NotificationSettingsValidatorDashboard) error
 	UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsAccountDashboard) error
 
 	QueueTestEmailNotification(ctx context.Context, userId uint64) error
@@ -69,10 +73,12 @@ func (*DataAccessService) registerNotificationInterfaceTypes() {
 		gob.Register(&n.ValidatorProposalNotification{})
 		gob.Register(&n.ValidatorUpcomingProposalNotification{})
 		gob.Register(&n.ValidatorGroupEfficiencyNotification{})
+		gob.Register(&n.ValidatorPerformanceAnomalyNotification{})
 		gob.Register(&n.ValidatorAttestationNotification{})
 		gob.Register(&n.ValidatorIsOfflineNotification{})
 		gob.Register(&n.ValidatorIsOnlineNotification{})
 		gob.Register(&n.ValidatorGotSlashedNotification{})
+		gob.Register(&n.ValidatorSlashingRiskNotification{})
 		gob.Register(&n.ValidatorWithdrawalNotification{})
 		gob.Register(&n.NetworkNotification{})
 		gob.Register(&n.RocketpoolNotification{})
@@ -404,10 +410,12 @@ func (d *DataAccessService) GetDashboardNotifications(ctx context.Context, userI
 func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, epoch uint64, search string) (*t.NotificationValidatorDashboardDetail, error) {
 	notificationDetails := t.NotificationValidatorDashboardDetail{
 		ValidatorOffline:         []uint64{},
+		PerformanceAnomalies:     []t.NotificationEventPerformanceAnomaly{},
 		ProposalMissed:           []t.IndexSlots{},
 		ProposalDone:             []t.IndexBlocks{},
 		UpcomingProposals:        []t.IndexSlots{},
 		Slashed:                  []uint64{},
+		SlashingRisks:            []t.NotificationEventSlashingRisk{},
 		SyncCommittee:            []uint64{},
 		AttestationMissed:        []t.IndexEpoch{},
 		Withdrawal:               []t.NotificationEventWithdrawal{},
@@ -513,6 +521,19 @@ func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context
 					return nil, fmt.Errorf("failed to cast notification to ValidatorGroupEfficiencyNotification")
 				}
 				notificationDetails.GroupEfficiencyBelow = curNotification.Threshold
+			case types.ValidatorPerformanceAnomalyEventName:
+				curNotification, ok := notification.(*n.ValidatorPerformanceAnomalyNotification)
+				if !ok {
+					return nil, fmt.Errorf("failed to cast notification to ValidatorPerformanceAnomalyNotification")
+				}
+				for _, anomaly := range curNotification.Anomalies {
+					notificationDetails.PerformanceAnomalies = append(notificationDetails.PerformanceAnomalies, t.NotificationEventPerformanceAnomaly{
+						Duty:     string(anomaly.Duty),
+						Value:    anomaly.Value,
+						Baseline: anomaly.Baseline,
+						ZScore:   anomaly.ZScore,
+					})
+				}
 			case types.ValidatorMissedProposalEventName, types.ValidatorExecutedProposalEventName /*, types.ValidatorScheduledProposalEventName*/ :
 				// aggregate proposals
 				curNotification, ok := notification.(*n.ValidatorProposalNotification)
@@ -564,6 +585,19 @@ func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context
 					continue
 				}
 				notificationDetails.Slashed = append(notificationDetails.Slashed, curNotification.ValidatorIndex)
+			case types.ValidatorSlashingRiskEventName:
+				curNotification, ok := notification.(*n.ValidatorSlashingRiskNotification)
+				if !ok {
+					return nil, fmt.Errorf("failed to cast notification to ValidatorSlashingRiskNotification")
+				}
+				if searchEnabled && !searchIndexSet[curNotification.ValidatorIndex] {
+					continue
+				}
+				notificationDetails.SlashingRisks = append(notificationDetails.SlashingRisks, t.NotificationEventSlashingRisk{
+					Index: curNotification.ValidatorIndex,
+					Slot:  curNotification.Slot,
+					Type:  curNotification.RiskType,
+				})
 			case types.ValidatorIsOfflineEventName:
 				curNotification, ok := notification.(*n.ValidatorIsOfflineNotification)
 				if !ok {
@@ -1285,6 +1319,7 @@ func (d *DataAccessService) GetNotificationSettings(ctx context.Context, userId
 func (d *DataAccessService) GetNotificationSettingsDefaultValues(ctx context.Context) (*t.NotificationSettingsDefaultValues, error) {
 	return &t.NotificationSettingsDefaultValues{
 		GroupEfficiencyBelowThreshold:     GroupEfficiencyBelowThresholdDefault,
+		PerformanceAnomalyThreshold:       types.PerformanceAnomalyThresholdDefault,
 		MaxCollateralThreshold:            MaxCollateralThresholdDefault,
 		MinCollateralThreshold:            MinCollateralThresholdDefault,
 		ERC20TokenTransfersValueThreshold: ERC20TokenTransfersValueThresholdDefault,
@@ -1705,6 +1740,7 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 				resultMap[event.Filter] = &t.NotificationSettingsDashboardsTableRow{
 					Settings: t.NotificationSettingsValidatorDashboard{
 						GroupEfficiencyBelowThreshold: GroupEfficiencyBelowThresholdDefault,
+						PerformanceAnomalyThreshold:   types.PerformanceAnomalyThresholdDefault,
 						MaxCollateralThreshold:        MaxCollateralThresholdDefault,
 						MinCollateralThreshold:        MinCollateralThresholdDefault,
 					},
@@ -1720,31 +1756,7 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 
 		switch settings := resultMap[event.Filter].Settings.(type) {
 		case t.NotificationSettingsValidatorDashboard:
-			switch eventName {
-			case types.ValidatorIsOfflineEventName:
-				settings.IsValidatorOfflineSubscribed = true
-			case types.ValidatorGroupEfficiencyEventName:
-				settings.IsGroupEfficiencyBelowSubscribed = true
-				settings.GroupEfficiencyBelowThreshold = event.Threshold
-			case types.ValidatorMissedAttestationEventName:
-				settings.IsAttestationsMissedSubscribed = true
-			case types.ValidatorMissedProposalEventName, types.ValidatorExecutedProposalEventName:
-				settings.IsBlockProposalSubscribed = true
-			case types.ValidatorUpcomingProposalEventName:
-				settings.IsUpcomingBlockProposalSubscribed = true
-			case types.SyncCommitteeSoonEventName:
-				settings.IsSyncSubscribed = true
-			case types.ValidatorReceivedWithdrawalEventName:
-				settings.IsWithdrawalProcessedSubscribed = true
-			case types.ValidatorGotSlashedEventName:
-				settings.IsSlashedSubscribed = true
-			case types.RocketpoolCollateralMinReachedEventName:
-				settings.IsMinCollateralSubscribed = true
-				settings.MinCollateralThreshold = event.Threshold
-			case types.RocketpoolCollateralMaxReachedEventName:
-				settings.IsMaxCollateralSubscribed = true
-				settings.MaxCollateralThreshold = event.Threshold
-			}
+			setValidatorDashboardSettingsEvent(&settings, eventName, event.Threshold)
 			resultMap[event.Filter].Settings = settings
 		case t.NotificationSettingsAccountDashboard:
 			switch eventName {
@@ -1772,6 +1784,7 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 			resultMap[key] = &t.NotificationSettingsDashboardsTableRow{
 				Settings: t.NotificationSettingsValidatorDashboard{
 					GroupEfficiencyBelowThreshold: GroupEfficiencyBelowThresholdDefault,
+					PerformanceAnomalyThreshold:   types.PerformanceAnomalyThresholdDefault,
 					MaxCollateralThreshold:        MaxCollateralThresholdDefault,
 					MinCollateralThreshold:        MinCollateralThresholdDefault,
 				},
@@ -1920,47 +1933,199 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 
 	return result, p, nil
 }
+
+// setValidatorDashboardSettingsEvent enables the setting that corresponds to a subscribed validator dashboard event
+func setValidatorDashboardSettingsEvent(settings *t.NotificationSettingsValidatorDashboard, eventName types.EventName, threshold float64) {
+	switch eventName {
+	case types.ValidatorIsOfflineEventName:
+		settings.IsValidatorOfflineSubscribed = true
+	case types.ValidatorGroupEfficiencyEventName:
+		settings.IsGroupEfficiencyBelowSubscribed = true
+		settings.GroupEfficiencyBelowThreshold = threshold
+	case types.ValidatorPerformanceAnomalyEventName:
+		settings.IsPerformanceAnomalySubscribed = true
+		settings.PerformanceAnomalyThreshold = threshold
+	case types.ValidatorMissedAttestationEventName:
+		settings.IsAttestationsMissedSubscribed = true
+	case types.ValidatorMissedProposalEventName, types.ValidatorExecutedProposalEventName:
+		settings.IsBlockProposalSubscribed = true
+	case types.ValidatorUpcomingProposalEventName:
+		settings.IsUpcomingBlockProposalSubscribed = true
+	case types.SyncCommitteeSoonEventName:
+		settings.IsSyncSubscribed = true
+	case types.ValidatorReceivedWithdrawalEventName:
+		settings.IsWithdrawalProcessedSubscribed = true
+	case types.ValidatorGotSlashedEventName:
+		settings.IsSlashedSubscribed = true
+	case types.RocketpoolCollateralMinReachedEventName:
+		settings.IsMinCollateralSubscribed = true
+		settings.MinCollateralThreshold = threshold
+	case types.RocketpoolCollateralMaxReachedEventName:
+		settings.IsMaxCollateralSubscribed = true
+		settings.MaxCollateralThreshold = threshold
+	}
+}
+
 func (d *DataAccessService) UpdateNotificationSettingsValidatorDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsValidatorDashboard) error {
 	// For the given dashboardId and groupId update users_subscriptions and users_val_dashboards_groups with the given settings
-	epoch := utils.TimeToEpoch(time.Now())
-
-	var eventsToInsert []goqu.Record
-	var eventsToDelete []goqu.Expression
+	networkName, err := d.getValidatorDashboardNotificationsName(ctx, userId, dashboardId)
+	if err != nil {
+		return err
+	}
 
-	// Get the network for the validator dashboard
-	var chainId uint64
-	err := d.alloyReader.GetContext(ctx, &chainId, `SELECT network FROM users_val_dashboards WHERE id = $1 AND user_id = $2`, dashboardId, userId)
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("error getting network for validator dashboard: %w", err)
+		return fmt.Errorf("error starting db transactions to update validator dashboard notification settings: %w", err)
 	}
+	defer utils.Rollback(tx)
 
-	networks, err := d.GetAllNetworks()
+	eventFilter := fmt.Sprintf("%s:%d:%d", ValidatorDashboardEventPrefix, dashboardId, groupId)
+	err = d.updateValidatorDashboardSubscriptions(ctx, tx, userId, networkName, eventFilter, settings)
 	if err != nil {
 		return err
 	}
 
-	networkName := ""
-	for _, network := range networks {
-		if network.ChainId == chainId {
-			networkName = network.NotificationsName
-			break
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to update validator dashboard notification settings: %w", err)
+	}
+
+	// Set non-event settings
+	return updateValidatorDashboardGroupWebhook(ctx, d.alloyWriter, dashboardId, groupId, settings)
+}
+
+// updateValidatorDashboardGroupWebhook sets the webhook of a group, the webhook is stored with the group instead of the subscriptions
+func updateValidatorDashboardGroupWebhook(ctx context.Context, e sqlx.ExecerContext, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsValidatorDashboard) error {
+	var webhookFormat sql.NullString
+	if settings.WebhookUrl != "" {
+		webhookFormat.String = string(types.WebhookNotificationChannel)
+		webhookFormat.Valid = true
+		if settings.IsWebhookDiscordEnabled {
+			webhookFormat.String = string(types.WebhookDiscordNotificationChannel)
 		}
 	}
-	if networkName == "" {
-		return fmt.Errorf("network with chain id %d to update general notification settings not found", chainId)
+
+	_, err := e.ExecContext(ctx, `
+		UPDATE users_val_dashboards_groups 
+		SET 
+			webhook_target = NULLIF($1, ''),
+			webhook_format = $2
+		WHERE dashboard_id = $3 AND id = $4`, settings.WebhookUrl, webhookFormat, dashboardId, groupId)
+	return err
+}
+
+// validatorDashboardTagEventFilter returns the event filter of settings that only apply to the validators of a group with the given tag.
+// Tag keys and values can't contain ':' or '=' so the filter can be parsed unambiguously.
+func validatorDashboardTagEventFilter(dashboardId t.VDBIdPrimary, groupId uint64, tag t.VDBValidatorTag) string {
+	return fmt.Sprintf("%s:%d:%d:%s=%s", ValidatorDashboardEventPrefix, dashboardId, groupId, tag.Key, tag.Value)
+}
+
+func (d *DataAccessService) GetNotificationSettingsValidatorDashboardTags(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary) ([]t.NotificationSettingsValidatorDashboardTag, error) {
+	events := []struct {
+		Name      types.EventName `db:"event_name"`
+		Filter    string          `db:"event_filter"`
+		Threshold float64         `db:"event_threshold"`
+	}{}
+	err := d.userReader.SelectContext(ctx, &events, `
+		SELECT event_name, event_filter, event_threshold
+		FROM users_subscriptions
+		WHERE user_id = $1 AND event_filter LIKE $2 AND event_filter LIKE '%=%'`, userId, fmt.Sprintf("%s:%d:%%", ValidatorDashboardEventPrefix, dashboardId))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving tag notification settings of validator dashboard: %w", err)
+	}
+
+	result := []t.NotificationSettingsValidatorDashboardTag{}
+	resultIndices := make(map[string]int)
+	for _, event := range events {
+		// event filter format is vdb:{dashboard_id}:{group_id}:{tag_key}={tag_value}, event name format is {network}:{event_name}
+		filterSplit := strings.Split(event.Filter, ":")
+		nameSplit := strings.Split(string(event.Name), ":")
+		if len(filterSplit) != 4 || len(nameSplit) != 2 {
+			continue
+		}
+		groupId, err := strconv.ParseUint(filterSplit[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		tagKey, tagValue, ok := strings.Cut(filterSplit[3], "=")
+		if !ok {
+			continue
+		}
+		i, ok := resultIndices[event.Filter]
+		if !ok {
+			i = len(result)
+			resultIndices[event.Filter] = i
+			result = append(result, t.NotificationSettingsValidatorDashboardTag{
+				GroupId:  groupId,
+				Tag:      t.VDBValidatorTag{Key: tagKey, Value: tagValue},
+				Settings: *newValidatorDashboardNotificationSettings(),
+			})
+		}
+		setValidatorDashboardSettingsEvent(&result[i].Settings, types.EventName(nameSplit[1]), event.Threshold)
+	}
+	slices.SortFunc(result, func(a, b t.NotificationSettingsValidatorDashboardTag) int {
+		return cmp.Or(cmp.Compare(a.GroupId, b.GroupId), strings.Compare(a.Tag.Key, b.Tag.Key), strings.Compare(a.Tag.Value, b.Tag.Value))
+	})
+	return result, nil
+}
+
+// UpdateNotificationSettingsValidatorDashboardTag updates the settings that only apply to the validators of a group with the given tag, webhook settings are ignored
+func (d *DataAccessService) UpdateNotificationSettingsValidatorDashboardTag(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, tag t.VDBValidatorTag, settings t.NotificationSettingsValidatorDashboard) error {
+	networkName, err := d.getValidatorDashboardNotificationsName(ctx, userId, dashboardId)
+	if err != nil {
+		return err
 	}
 
-	// Add and remove the events in users_subscriptions
 	tx, err := d.userWriter.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("error starting db transactions to update validator dashboard notification settings: %w", err)
 	}
 	defer utils.Rollback(tx)
 
-	eventFilter := fmt.Sprintf("%s:%d:%d", ValidatorDashboardEventPrefix, dashboardId, groupId)
+	err = d.updateValidatorDashboardSubscriptions(ctx, tx, userId, networkName, validatorDashboardTagEventFilter(dashboardId, groupId, tag), settings)
+	if err != nil {
+		return err
+	}
 
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to update validator dashboard notification settings: %w", err)
+	}
+	return nil
+}
+
+// getValidatorDashboardNotificationsName returns the name the network of a validator dashboard is referred to by in users_subscriptions
+func (d *DataAccessService) getValidatorDashboardNotificationsName(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary) (string, error) {
+	var chainId uint64
+	err := d.alloyReader.GetContext(ctx, &chainId, `SELECT network FROM users_val_dashboards WHERE id = $1 AND user_id = $2`, dashboardId, userId)
+	if err != nil {
+		return "", fmt.Errorf("error getting network for validator dashboard: %w", err)
+	}
+
+	networks, err := d.GetAllNetworks()
+	if err != nil {
+		return "", err
+	}
+
+	for _, network := range networks {
+		if network.ChainId == chainId {
+			return network.NotificationsName, nil
+		}
+	}
+	return "", fmt.Errorf("network with chain id %d to update general notification settings not found", chainId)
+}
+
+// updateValidatorDashboardSubscriptions adds and removes the events in users_subscriptions for the given event filter within the given transaction
+func (d *DataAccessService) updateValidatorDashboardSubscriptions(ctx context.Context, tx *sqlx.Tx, userId uint64, networkName string, eventFilter string, settings t.NotificationSettingsValidatorDashboard) error {
+	epoch := utils.TimeToEpoch(time.Now())
+
+	var eventsToInsert []goqu.Record
+	var eventsToDelete []goqu.Expression
+
+	// Add and remove the events in users_subscriptions
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsValidatorOfflineSubscribed, userId, types.ValidatorIsOfflineEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsGroupEfficiencyBelowSubscribed, userId, types.ValidatorGroupEfficiencyEventName, networkName, eventFilter, epoch, settings.GroupEfficiencyBelowThreshold)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsPerformanceAnomalySubscribed, userId, types.ValidatorPerformanceAnomalyEventName, networkName, eventFilter, epoch, settings.PerformanceAnomalyThreshold)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsAttestationsMissedSubscribed, userId, types.ValidatorMissedAttestationEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsUpcomingBlockProposalSubscribed, userId, types.ValidatorUpcomingProposalEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsSyncSubscribed, userId, types.SyncCommitteeSoonEventName, networkName, eventFilter, epoch, 0)
@@ -2011,31 +2176,6 @@ func (d *DataAccessService) UpdateNotificationSettingsValidatorDashboard(ctx con
 		}
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return fmt.Errorf("error committing tx to update validator dashboard notification settings: %w", err)
-	}
-
-	// Set non-event settings
-	var webhookFormat sql.NullString
-	if settings.WebhookUrl != "" {
-		webhookFormat.String = string(types.WebhookNotificationChannel)
-		webhookFormat.Valid = true
-		if settings.IsWebhookDiscordEnabled {
-			webhookFormat.String = string(types.WebhookDiscordNotificationChannel)
-		}
-	}
-
-	_, err = d.alloyWriter.ExecContext(ctx, `
-		UPDATE users_val_dashboards_groups 
-		SET 
-			webhook_target = NULLIF($1, ''),
-			webhook_format = $2
-		WHERE dashboard_id = $3 AND id = $4`, settings.WebhookUrl, webhookFormat, dashboardId, groupId)
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 func (d *DataAccessService) UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsAccountDashboard) error {
@@ -2,12 +2,16 @@ package dataaccess
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/api/types"
 )
 
 type RatelimitRepository interface {
 	GetApiWeights(ctx context.Context) ([]types.ApiWeightItem, error)
+	GetUserApiUsage(ctx context.Context, userId uint64, start, end time.Time) (*types.UserApiUsage, error)
 	// TODO @patrick: move queries from commons/ratelimit/ratelimit.go to here
 }
 
@@ -20,3 +24,98 @@ func (d *DataAccessService) GetApiWeights(ctx context.Context) ([]types.ApiWeigh
 	`)
 	return result, err
 }
+
+// GetUserApiUsage returns the api usage of all api keys of a user per bucket and route, based on the request stats that are persisted by the ratelimit db-updater
+func (d *DataAccessService) GetUserApiUsage(ctx context.Context, userId uint64, start, end time.Time) (*types.UserApiUsage, error) {
+	var limits []struct {
+		Bucket string `db:"bucket"`
+		Second int64  `db:"second"`
+		Hour   int64  `db:"hour"`
+		Month  int64  `db:"month"`
+	}
+	err := d.userReader.SelectContext(ctx, &limits, `
+		WITH current_api_products AS (
+			SELECT DISTINCT ON (name, bucket) name, bucket, second, hour, month
+			FROM api_products
+			WHERE valid_from <= NOW()
+			ORDER BY name, bucket, valid_from DESC
+		)
+		SELECT
+			COALESCE(rl.bucket, cap.bucket) AS bucket,
+			COALESCE(rl.second, cap.second) AS second,
+			COALESCE(rl.hour, cap.hour) AS hour,
+			COALESCE(rl.month, cap.month) AS month
+		FROM (SELECT * FROM api_ratelimits WHERE user_id = $1 AND valid_until > NOW()) rl
+		FULL OUTER JOIN (SELECT * FROM current_api_products WHERE name = 'free') cap ON cap.bucket = rl.bucket
+	`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving api ratelimits of user %d: %w", userId, err)
+	}
+
+	var stats []struct {
+		Bucket   string `db:"bucket"`
+		Endpoint string `db:"endpoint"`
+		Requests int64  `db:"requests"`
+		Consumed int64  `db:"consumed"`
+	}
+	err = d.userReader.SelectContext(ctx, &stats, `
+		WITH current_weights AS (
+			SELECT DISTINCT ON (endpoint) endpoint, weight
+			FROM api_weights
+			WHERE valid_from <= NOW()
+			ORDER BY endpoint, valid_from DESC
+		)
+		SELECT
+			s.bucket,
+			s.endpoint,
+			SUM(s.count) AS requests,
+			SUM(s.count * COALESCE(w.weight, 1)) AS consumed
+		FROM api_statistics s
+		LEFT JOIN current_weights w ON w.endpoint = s.endpoint
+		WHERE s.apikey IN (SELECT api_key FROM api_keys WHERE user_id = $1) AND s.ts >= $2 AND s.ts < $3
+		GROUP BY s.bucket, s.endpoint
+	`, userId, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving api statistics of user %d: %w", userId, err)
+	}
+
+	buckets := make(map[string]*types.ApiUsageBucket)
+	getBucket := func(bucket string) *types.ApiUsageBucket {
+		if _, ok := buckets[bucket]; !ok {
+			buckets[bucket] = &types.ApiUsageBucket{Bucket: bucket, Routes: []types.ApiUsageRoute{}}
+		}
+		return buckets[bucket]
+	}
+	for _, limit := range limits {
+		bucket := getBucket(limit.Bucket)
+		bucket.LimitSecond = limit.Second
+		bucket.LimitHour = limit.Hour
+		bucket.LimitMonth = limit.Month
+	}
+	for _, stat := range stats {
+		bucket := getBucket(stat.Bucket)
+		bucket.Requests += stat.Requests
+		bucket.Consumed += stat.Consumed
+		bucket.Routes = append(bucket.Routes, types.ApiUsageRoute{
+			Route:    stat.Endpoint,
+			Requests: stat.Requests,
+			Consumed: stat.Consumed,
+		})
+	}
+
+	result := &types.UserApiUsage{
+		StartTs: start.Unix(),
+		EndTs:   end.Unix(),
+		Buckets: make([]types.ApiUsageBucket, 0, len(buckets)),
+	}
+	for _, bucket := range buckets {
+		sort.Slice(bucket.Routes, func(i, j int) bool {
+			return bucket.Routes[i].Consumed > bucket.Routes[j].Consumed
+		})
+		result.Buckets = append(result.Buckets, *bucket)
+	}
+	sort.Slice(result.Buckets, func(i, j int) bool {
+		return result.Buckets[i].Bucket < result.Buckets[j].Bucket
+	})
+	return result, nil
+}
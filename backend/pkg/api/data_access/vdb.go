@@ -35,6 +35,11 @@ type ValidatorDashboardRepository interface {
 	AddValidatorDashboardValidatorsByGraffiti(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, graffiti string, limit uint64) ([]t.VDBPostValidatorsData, error)
 
 	RemoveValidatorDashboardValidators(ctx context.Context, dashboardId t.VDBIdPrimary, validators []t.VDBValidator) error
+	MoveValidatorDashboardValidators(ctx context.Context, dashboardId t.VDBIdPrimary, fromGroupId *uint64, validators []t.VDBValidator, toGroupId uint64) ([]t.VDBPostValidatorsData, error)
+
+	GetValidatorDashboardTags(ctx context.Context, dashboardId t.VDBIdPrimary) ([]t.VDBTagKey, error)
+	SetValidatorDashboardValidatorTags(ctx context.Context, dashboardId t.VDBIdPrimary, validators []t.VDBValidator, tags []t.VDBValidatorTag) error
+	RemoveValidatorDashboardValidatorTags(ctx context.Context, dashboardId t.VDBIdPrimary, validators []t.VDBValidator, keys []string) error
 	GetValidatorDashboardValidators(ctx context.Context, dashboardId t.VDBId, groupId int64, cursor string, colSort t.Sort[enums.VDBManageValidatorsColumn], search string, limit uint64) ([]t.VDBManageValidatorsTableRow, *t.Paging, error)
 	GetValidatorDashboardValidatorsCount(ctx context.Context, dashboardId t.VDBIdPrimary) (uint64, error)
 
@@ -44,7 +49,11 @@ type ValidatorDashboardRepository interface {
 	RemoveValidatorDashboardPublicId(ctx context.Context, publicDashboardId t.VDBIdPublic) error
 	GetValidatorDashboardPublicIdCount(ctx context.Context, dashboardId t.VDBIdPrimary) (uint64, error)
 
+	GetValidatorDashboardExport(ctx context.Context, dashboardId t.VDBIdPrimary) (*t.VDBExport, error)
+	ImportValidatorDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, data t.VDBExport, maxGroups, maxValidators uint64, dryRun bool) (*t.VDBImportResult, error)
+
 	GetValidatorDashboardSlotViz(ctx context.Context, dashboardId t.VDBId, groupIds []uint64) ([]t.SlotVizEpoch, error)
+	SubscribeValidatorDashboardLiveUpdates(ctx context.Context, dashboardId t.VDBId, groupIds []uint64, lastEventId uint64) (<-chan t.VDBLiveUpdate, error)
 
 	GetLatestExportedChartTs(ctx context.Context, aggregation enums.ChartAggregation) (uint64, error)
 
@@ -74,6 +83,7 @@ type ValidatorDashboardRepository interface {
 
 	GetValidatorDashboardWithdrawals(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBWithdrawalsColumn], search string, limit uint64, protocolModes t.VDBProtocolModes) ([]t.VDBWithdrawalsTableRow, *t.Paging, error)
 	GetValidatorDashboardTotalWithdrawals(ctx context.Context, dashboardId t.VDBId, search string, protocolModes t.VDBProtocolModes) (*t.VDBTotalWithdrawalsData, error)
+	GetValidatorDashboardBlsChanges(ctx context.Context, dashboardId t.VDBId) (*t.VDBBlsChangesData, error)
 
 	GetValidatorDashboardRocketPool(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBRocketPoolColumn], search string, limit uint64) ([]t.VDBRocketPoolTableRow, *t.Paging, error)
 	GetValidatorDashboardTotalRocketPool(ctx context.Context, dashboardId t.VDBId, search string) (*t.VDBRocketPoolTableRow, error)
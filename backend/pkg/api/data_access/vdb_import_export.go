@@ -0,0 +1,458 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const ValidatorDashboardExportVersion = 1
+
+func (d *DataAccessService) GetValidatorDashboardExport(ctx context.Context, dashboardId t.VDBIdPrimary) (*t.VDBExport, error) {
+	dashboard := struct {
+		Name    string `db:"name"`
+		Network uint64 `db:"network"`
+		UserId  uint64 `db:"user_id"`
+	}{}
+	err := d.alloyReader.GetContext(ctx, &dashboard, `SELECT name, network, user_id FROM users_val_dashboards WHERE id = $1`, dashboardId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
+		}
+		return nil, err
+	}
+
+	groups := []struct {
+		Id            uint64         `db:"id"`
+		Name          string         `db:"name"`
+		WebhookUrl    sql.NullString `db:"webhook_target"`
+		WebhookFormat sql.NullString `db:"webhook_format"`
+	}{}
+	err = d.alloyReader.SelectContext(ctx, &groups, `
+		SELECT id, name, webhook_target, webhook_format
+		FROM users_val_dashboards_groups
+		WHERE dashboard_id = $1
+		ORDER BY id`, dashboardId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving groups of validator dashboard: %w", err)
+	}
+
+	validators := []struct {
+		GroupId        uint64         `db:"group_id"`
+		ValidatorIndex t.VDBValidator `db:"validator_index"`
+	}{}
+	err = d.alloyReader.SelectContext(ctx, &validators, `
+		SELECT group_id, validator_index
+		FROM users_val_dashboards_validators
+		WHERE dashboard_id = $1
+		ORDER BY group_id, validator_index`, dashboardId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving validators of validator dashboard: %w", err)
+	}
+
+	publicIds := []t.VDBExportPublicId{}
+	err = d.alloyReader.SelectContext(ctx, &publicIds, `
+		SELECT name, shared_groups AS share_groups
+		FROM users_val_dashboards_sharing
+		WHERE dashboard_id = $1`, dashboardId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving public ids of validator dashboard: %w", err)
+	}
+
+	events := []struct {
+		Name      types.EventName `db:"event_name"`
+		Filter    string          `db:"event_filter"`
+		Threshold float64         `db:"event_threshold"`
+	}{}
+	err = d.userReader.SelectContext(ctx, &events, `
+		SELECT event_name, event_filter, event_threshold
+		FROM users_subscriptions
+		WHERE user_id = $1 AND event_filter LIKE $2`, dashboard.UserId, fmt.Sprintf("%s:%d:%%", ValidatorDashboardEventPrefix, dashboardId))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving notification settings of validator dashboard: %w", err)
+	}
+
+	tags, err := d.getValidatorDashboardTags(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagSettings, err := d.GetNotificationSettingsValidatorDashboardTags(ctx, dashboard.UserId, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &t.VDBExport{
+		Version:   ValidatorDashboardExportVersion,
+		Name:      dashboard.Name,
+		Network:   dashboard.Network,
+		Groups:    make([]t.VDBExportGroup, len(groups)),
+		PublicIds: publicIds,
+	}
+	groupIndices := make(map[uint64]int, len(groups))
+	for i, group := range groups {
+		groupIndices[group.Id] = i
+		result.Groups[i] = t.VDBExportGroup{
+			Id:         group.Id,
+			Name:       group.Name,
+			Validators: []t.VDBExportValidator{},
+		}
+		if group.WebhookUrl.Valid {
+			result.Groups[i].NotificationSettings = newValidatorDashboardNotificationSettings()
+			result.Groups[i].NotificationSettings.WebhookUrl = group.WebhookUrl.String
+			result.Groups[i].NotificationSettings.IsWebhookDiscordEnabled = group.WebhookFormat.Valid &&
+				types.NotificationChannel(group.WebhookFormat.String) == types.WebhookDiscordNotificationChannel
+		}
+	}
+	for _, validator := range validators {
+		i, ok := groupIndices[validator.GroupId]
+		if !ok {
+			continue
+		}
+		exportValidator := t.VDBExportValidator{Index: validator.ValidatorIndex, Tags: tags[validator.ValidatorIndex]}
+		if validator.ValidatorIndex < t.VDBValidator(len(mapping.ValidatorPubkeys)) {
+			exportValidator.PublicKey = t.PubKey(mapping.ValidatorPubkeys[validator.ValidatorIndex])
+		}
+		result.Groups[i].Validators = append(result.Groups[i].Validators, exportValidator)
+	}
+	for _, event := range events {
+		// event filter format is vdb:{dashboard_id}:{group_id}, event name format is {network}:{event_name}
+		filterSplit := strings.Split(event.Filter, ":")
+		nameSplit := strings.Split(string(event.Name), ":")
+		if len(filterSplit) != 3 || len(nameSplit) != 2 {
+			continue
+		}
+		groupId, err := strconv.ParseUint(filterSplit[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		i, ok := groupIndices[groupId]
+		if !ok {
+			continue
+		}
+		if result.Groups[i].NotificationSettings == nil {
+			result.Groups[i].NotificationSettings = newValidatorDashboardNotificationSettings()
+		}
+		setValidatorDashboardSettingsEvent(result.Groups[i].NotificationSettings, types.EventName(nameSplit[1]), event.Threshold)
+	}
+	for _, settings := range tagSettings {
+		i, ok := groupIndices[settings.GroupId]
+		if !ok {
+			continue
+		}
+		result.Groups[i].TagNotificationSettings = append(result.Groups[i].TagNotificationSettings, t.VDBExportTagNotificationSettings{
+			Tag:      settings.Tag,
+			Settings: settings.Settings,
+		})
+	}
+
+	return result, nil
+}
+
+func newValidatorDashboardNotificationSettings() *t.NotificationSettingsValidatorDashboard {
+	return &t.NotificationSettingsValidatorDashboard{
+		GroupEfficiencyBelowThreshold: GroupEfficiencyBelowThresholdDefault,
+		PerformanceAnomalyThreshold:   types.PerformanceAnomalyThresholdDefault,
+		MaxCollateralThreshold:        MaxCollateralThresholdDefault,
+		MinCollateralThreshold:        MinCollateralThresholdDefault,
+	}
+}
+
+// ImportValidatorDashboard adds the groups, validators, notification settings and public ids of an exported dashboard to an existing dashboard.
+// Groups are matched by name, missing groups are created. Validators are resolved by public key on the network of the dashboard, indices are ignored as
+// they differ between networks, so exports of other networks can be imported too. Public keys that can't be found are reported in the result.
+// Validators already in the dashboard are moved to the imported group.
+// Groups and validators exceeding maxGroups and maxValidators are skipped and reported in the result. Nothing is written if dryRun is set.
+func (d *DataAccessService) ImportValidatorDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, data t.VDBExport, maxGroups, maxValidators uint64, dryRun bool) (*t.VDBImportResult, error) {
+	var exists bool
+	err := d.alloyReader.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM users_val_dashboards WHERE id = $1)`, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
+	}
+
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	existingGroups := []t.VDBPostCreateGroupData{}
+	err = d.alloyReader.SelectContext(ctx, &existingGroups, `SELECT id, name FROM users_val_dashboards_groups WHERE dashboard_id = $1`, dashboardId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving groups of validator dashboard: %w", err)
+	}
+	existingGroupIds := make(map[string]uint64, len(existingGroups))
+	for _, group := range existingGroups {
+		existingGroupIds[group.Name] = group.Id
+	}
+
+	var existingValidatorsList []struct {
+		Index   t.VDBValidator `db:"validator_index"`
+		GroupId uint64         `db:"group_id"`
+	}
+	err = d.alloyReader.SelectContext(ctx, &existingValidatorsList, `SELECT validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = $1`, dashboardId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving validators of validator dashboard: %w", err)
+	}
+	existingValidators := make(map[t.VDBValidator]uint64, len(existingValidatorsList))
+	for _, validator := range existingValidatorsList {
+		existingValidators[validator.Index] = validator.GroupId
+	}
+
+	var publicIdCount uint64
+	err = d.alloyReader.GetContext(ctx, &publicIdCount, `SELECT COUNT(*) FROM users_val_dashboards_sharing WHERE dashboard_id = $1`, dashboardId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving public ids of validator dashboard: %w", err)
+	}
+
+	result := &t.VDBImportResult{
+		DryRun:            dryRun,
+		Groups:            []t.VDBImportGroupResult{},
+		UnknownValidators: []string{},
+		SkippedValidators: []string{},
+		SkippedGroups:     []string{},
+	}
+
+	// resolve groups and validators
+	var tagValidators []t.VDBValidator
+	var tagKeys, tagValues []string
+	importGroups := []importGroup{}
+	importGroupsByName := make(map[string]int)
+	imported := make(map[t.VDBValidator]bool)
+	groupCount := uint64(len(existingGroups))
+	validatorCount := uint64(len(existingValidators))
+	for i := range data.Groups {
+		group := &data.Groups[i]
+		idx, ok := importGroupsByName[group.Name]
+		if !ok {
+			groupResult := &t.VDBImportGroupResult{Name: group.Name}
+			if id, ok := existingGroupIds[group.Name]; ok {
+				groupResult.Id = &id
+			} else if groupCount >= maxGroups {
+				result.SkippedGroups = append(result.SkippedGroups, group.Name)
+				continue
+			} else {
+				groupResult.IsNew = true
+				groupCount++
+			}
+			idx = len(importGroups)
+			importGroupsByName[group.Name] = idx
+			importGroups = append(importGroups, importGroup{result: groupResult, data: group})
+		}
+
+		for _, validator := range group.Validators {
+			name := normalizeExportPublicKey(validator.PublicKey)
+			index, ok := mapping.ValidatorIndices[name]
+			if !ok {
+				result.UnknownValidators = append(result.UnknownValidators, name)
+				continue
+			}
+			if imported[index] {
+				continue // first group wins
+			}
+			existingGroupId, exists := existingValidators[index]
+			if !exists && validatorCount >= maxValidators {
+				result.SkippedValidators = append(result.SkippedValidators, name)
+				continue
+			}
+			imported[index] = true
+			importGroups[idx].validators = append(importGroups[idx].validators, index)
+			for key, value := range validator.Tags {
+				tagValidators = append(tagValidators, index)
+				tagKeys = append(tagKeys, key)
+				tagValues = append(tagValues, value)
+			}
+			switch {
+			case !exists:
+				validatorCount++
+				importGroups[idx].result.ValidatorsAdded++
+				result.ValidatorsAdded++
+			case importGroups[idx].result.Id == nil || *importGroups[idx].result.Id != existingGroupId:
+				importGroups[idx].result.ValidatorsMoved++
+				result.ValidatorsMoved++
+			}
+		}
+	}
+	if publicIdCount == 0 && len(data.PublicIds) > 0 {
+		result.PublicIdsCreated = 1 // only one public id per dashboard is allowed
+	}
+
+	if !dryRun {
+		if err := d.writeValidatorDashboardImport(ctx, userId, dashboardId, data, importGroups, tagValidators, tagKeys, tagValues, result.PublicIdsCreated > 0); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, group := range importGroups {
+		result.Groups = append(result.Groups, *group.result)
+	}
+	return result, nil
+}
+
+type importGroup struct {
+	result     *t.VDBImportGroupResult
+	data       *t.VDBExportGroup
+	validators []t.VDBValidator
+}
+
+// writeValidatorDashboardImport writes the resolved groups, validators, tags, public id and notification settings of an import.
+// Notification subscriptions are stored in the user db, they are written once the dashboard data has been committed as they reference the ids of the created groups.
+// All writes are idempotent and groups are matched by name, so importing the same export again completes an import whose subscriptions failed to be written.
+func (d *DataAccessService) writeValidatorDashboardImport(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, data t.VDBExport, importGroups []importGroup, tagValidators []t.VDBValidator, tagKeys, tagValues []string, createPublicId bool) error {
+	networkName, err := d.getValidatorDashboardNotificationsName(ctx, userId, dashboardId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.alloyWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transactions to import validator dashboard: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	var groupIds, validatorIndices []int64
+	for _, group := range importGroups {
+		if group.result.IsNew {
+			created, err := createValidatorDashboardGroup(ctx, tx, dashboardId, group.result.Name)
+			if err != nil {
+				return fmt.Errorf("error creating group %s: %w", group.result.Name, err)
+			}
+			group.result.Id = &created.Id
+		}
+		for _, validator := range group.validators {
+			groupIds = append(groupIds, int64(*group.result.Id))
+			validatorIndices = append(validatorIndices, int64(validator))
+		}
+
+		if group.data.NotificationSettings != nil {
+			err = updateValidatorDashboardGroupWebhook(ctx, tx, dashboardId, *group.result.Id, *group.data.NotificationSettings)
+			if err != nil {
+				return fmt.Errorf("error importing webhook of group %s: %w", group.result.Name, err)
+			}
+		}
+	}
+	if len(validatorIndices) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO users_val_dashboards_validators (dashboard_id, group_id, validator_index)
+				SELECT $1, group_id, validator_index FROM UNNEST($2::smallint[], $3::bigint[]) AS v(group_id, validator_index)
+			ON CONFLICT (dashboard_id, validator_index) DO UPDATE SET
+				group_id = EXCLUDED.group_id
+		`, dashboardId, pq.Array(groupIds), pq.Array(validatorIndices))
+		if err != nil {
+			return fmt.Errorf("error adding validators to validator dashboard: %w", err)
+		}
+	}
+	if len(tagValidators) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO users_val_dashboards_validator_tags (dashboard_id, validator_index, tag_key, tag_value)
+				SELECT $1, validator_index, tag_key, tag_value FROM UNNEST($2::bigint[], $3::text[], $4::text[]) AS t(validator_index, tag_key, tag_value)
+			ON CONFLICT (dashboard_id, validator_index, tag_key) DO UPDATE SET
+				tag_value = EXCLUDED.tag_value
+		`, dashboardId, pq.Array(tagValidators), pq.Array(tagKeys), pq.Array(tagValues))
+		if err != nil {
+			return fmt.Errorf("error adding tags to validator dashboard: %w", err)
+		}
+	}
+	if createPublicId {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO users_val_dashboards_sharing (dashboard_id, name, shared_groups)
+				VALUES ($1, $2, $3)
+		`, dashboardId, data.PublicIds[0].Name, data.PublicIds[0].ShareGroups)
+		if err != nil {
+			return fmt.Errorf("error creating public id of validator dashboard: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to import validator dashboard: %w", err)
+	}
+
+	userTx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transaction to import validator dashboard notification settings: %w", err)
+	}
+	defer utils.Rollback(userTx)
+
+	for _, group := range importGroups {
+		if group.data.NotificationSettings != nil {
+			eventFilter := fmt.Sprintf("%s:%d:%d", ValidatorDashboardEventPrefix, dashboardId, *group.result.Id)
+			err = d.updateValidatorDashboardSubscriptions(ctx, userTx, userId, networkName, eventFilter, *group.data.NotificationSettings)
+			if err != nil {
+				return fmt.Errorf("error importing notification settings of group %s: %w", group.result.Name, err)
+			}
+		}
+		for _, tagSettings := range group.data.TagNotificationSettings {
+			eventFilter := validatorDashboardTagEventFilter(dashboardId, *group.result.Id, tagSettings.Tag)
+			err = d.updateValidatorDashboardSubscriptions(ctx, userTx, userId, networkName, eventFilter, tagSettings.Settings)
+			if err != nil {
+				return fmt.Errorf("error importing notification settings of tag %s=%s of group %s: %w", tagSettings.Tag.Key, tagSettings.Tag.Value, group.result.Name, err)
+			}
+		}
+	}
+
+	err = userTx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to import validator dashboard notification settings: %w", err)
+	}
+	return nil
+}
+
+// normalizeExportPublicKey returns the public key of an exported validator in the format of the validator mapping
+func normalizeExportPublicKey(publicKey t.PubKey) string {
+	pubkey := strings.ToLower(string(publicKey))
+	if !strings.HasPrefix(pubkey, "0x") {
+		pubkey = "0x" + pubkey
+	}
+	return pubkey
+}
+
+// MoveValidatorDashboardValidators moves validators that are already in the dashboard to another group, either all validators of fromGroupId or the given validators
+func (d *DataAccessService) MoveValidatorDashboardValidators(ctx context.Context, dashboardId t.VDBIdPrimary, fromGroupId *uint64, validators []t.VDBValidator, toGroupId uint64) ([]t.VDBPostValidatorsData, error) {
+	var moved []t.VDBValidator
+	var err error
+	if fromGroupId != nil {
+		err = d.alloyWriter.SelectContext(ctx, &moved, `
+			UPDATE users_val_dashboards_validators SET group_id = $2
+			WHERE dashboard_id = $1 AND group_id = $3
+			RETURNING validator_index
+		`, dashboardId, toGroupId, *fromGroupId)
+	} else {
+		err = d.alloyWriter.SelectContext(ctx, &moved, `
+			UPDATE users_val_dashboards_validators SET group_id = $2
+			WHERE dashboard_id = $1 AND validator_index = ANY($3)
+			RETURNING validator_index
+		`, dashboardId, toGroupId, pq.Array(validators))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pubkeys, err := d.services.GetPubkeySliceFromIndexSlice(moved)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.VDBPostValidatorsData, 0, len(moved))
+	for _, pubkey := range pubkeys {
+		result = append(result, t.VDBPostValidatorsData{
+			PublicKey: pubkey,
+			GroupId:   toGroupId,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,620 @@
+package dataaccess
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/google/uuid"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
+)
+
+type IncomeReportRepository interface {
+	// CreateValidatorDashboardIncomeReport queues the generation of an income report, groupId can be t.AllGroups
+	CreateValidatorDashboardIncomeReport(ctx context.Context, dashboardId t.VDBId, groupId int64, aggregation string, startTs, endTs int64, currency, format string) (*t.VDBIncomeReport, error)
+	GetValidatorDashboardIncomeReport(ctx context.Context, dashboardId t.VDBId, reportId string) (*t.VDBIncomeReport, error)
+	GetValidatorDashboardIncomeReportFile(ctx context.Context, dashboardId t.VDBId, reportId string) (*t.VDBIncomeReportFile, error)
+}
+
+const (
+	incomeReportStatusPending   = "pending"
+	incomeReportStatusRunning   = "running"
+	incomeReportStatusCompleted = "completed"
+	incomeReportStatusFailed    = "failed"
+
+	IncomeReportAggregationValidator = "validator"
+	IncomeReportAggregationGroup     = "group"
+
+	IncomeReportFormatCsv = "csv"
+	IncomeReportFormatPdf = "pdf"
+
+	// reports that have been running for longer than this are considered abandoned (e.g. api restart) and are picked up again
+	incomeReportTimeout = 30 * time.Minute
+)
+
+// currencies of the price table
+var IncomeReportCurrencies = []string{"usd", "eur", "gbp", "cad", "jpy", "cny", "aud"}
+
+type incomeReportDbRow struct {
+	Id              string         `db:"id"`
+	DashboardId     sql.NullInt64  `db:"dashboard_id"`
+	GroupId         sql.NullInt64  `db:"group_id"`
+	Aggregation     string         `db:"aggregation"`
+	Validators      pq.Int64Array  `db:"validators"`
+	ValidatorGroups pq.Int64Array  `db:"validator_groups"`
+	StartTs         time.Time      `db:"start_ts"`
+	EndTs           time.Time      `db:"end_ts"`
+	Currency        string         `db:"currency"`
+	Format          string         `db:"format"`
+	Status          string         `db:"status"`
+	Error           sql.NullString `db:"error"`
+	CreatedTs       time.Time      `db:"created_ts"`
+	CompletedTs     sql.NullTime   `db:"completed_ts"`
+}
+
+const incomeReportColumns = `id, dashboard_id, group_id, aggregation, validators, validator_groups, start_ts, end_ts, currency, format, status, error, created_ts, completed_ts`
+
+func (r *incomeReportDbRow) toApiType() *t.VDBIncomeReport {
+	result := &t.VDBIncomeReport{
+		Id:          r.Id,
+		Status:      r.Status,
+		Format:      r.Format,
+		Aggregation: r.Aggregation,
+		StartTs:     r.StartTs.Unix(),
+		EndTs:       r.EndTs.Unix(),
+		Currency:    r.Currency,
+		CreatedTs:   r.CreatedTs.Unix(),
+	}
+	if r.GroupId.Valid {
+		result.GroupId = &r.GroupId.Int64
+	}
+	if r.CompletedTs.Valid {
+		ts := r.CompletedTs.Time.Unix()
+		result.CompletedTs = &ts
+	}
+	if r.Error.Valid {
+		result.Error = &r.Error.String
+	}
+	return result
+}
+
+func (d *DataAccessService) CreateValidatorDashboardIncomeReport(ctx context.Context, dashboardId t.VDBId, groupId int64, aggregation string, startTs, endTs int64, currency, format string) (*t.VDBIncomeReport, error) {
+	var dashboardIdParam sql.NullInt64
+	var groupIdParam sql.NullInt64
+	var validators, validatorGroups pq.Int64Array
+
+	if dashboardId.Validators == nil {
+		dashboardIdParam = sql.NullInt64{Int64: int64(dashboardId.Id), Valid: true}
+		if groupId != t.AllGroups {
+			groupIdParam = sql.NullInt64{Int64: groupId, Valid: true}
+		}
+		rows := []struct {
+			ValidatorIndex uint64 `db:"validator_index"`
+			GroupId        int64  `db:"group_id"`
+		}{}
+		err := d.alloyReader.SelectContext(ctx, &rows, `
+			SELECT validator_index, group_id
+			FROM users_val_dashboards_validators
+			WHERE dashboard_id = $1 AND (group_id = $2 OR $3)
+			ORDER BY validator_index`, dashboardId.Id, groupId, groupId == t.AllGroups)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving validators of dashboard %d: %w", dashboardId.Id, err)
+		}
+		for _, row := range rows {
+			validators = append(validators, int64(row.ValidatorIndex))
+			validatorGroups = append(validatorGroups, row.GroupId)
+		}
+	} else {
+		for _, validator := range dashboardId.Validators {
+			validators = append(validators, int64(validator))
+			validatorGroups = append(validatorGroups, t.DefaultGroupId)
+		}
+	}
+
+	row := &incomeReportDbRow{}
+	err := d.alloyWriter.GetContext(ctx, row, `
+		INSERT INTO validator_income_reports (id, dashboard_id, group_id, aggregation, validators, validator_groups, start_ts, end_ts, currency, format, status)
+		VALUES ($1, $2, $3, $4, $5, $6, TO_TIMESTAMP($7), TO_TIMESTAMP($8), $9, $10, $11)
+		RETURNING `+incomeReportColumns,
+		uuid.New().String(), dashboardIdParam, groupIdParam, aggregation, validators, validatorGroups, startTs, endTs, currency, format, incomeReportStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("error creating income report: %w", err)
+	}
+	return row.toApiType(), nil
+}
+
+func (d *DataAccessService) getIncomeReport(ctx context.Context, dashboardId t.VDBId, reportId string, columns string, dest interface{}) error {
+	// reports of validator set dashboards are only protected by their id
+	err := d.alloyReader.GetContext(ctx, dest, `
+		SELECT `+columns+`
+		FROM validator_income_reports
+		WHERE id = $1 AND dashboard_id IS NOT DISTINCT FROM $2`,
+		reportId, sql.NullInt64{Int64: int64(dashboardId.Id), Valid: dashboardId.Validators == nil})
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: income report with id %s", ErrNotFound, reportId)
+	}
+	return err
+}
+
+func (d *DataAccessService) GetValidatorDashboardIncomeReport(ctx context.Context, dashboardId t.VDBId, reportId string) (*t.VDBIncomeReport, error) {
+	row := &incomeReportDbRow{}
+	if err := d.getIncomeReport(ctx, dashboardId, reportId, incomeReportColumns, row); err != nil {
+		return nil, err
+	}
+	return row.toApiType(), nil
+}
+
+func (d *DataAccessService) GetValidatorDashboardIncomeReportFile(ctx context.Context, dashboardId t.VDBId, reportId string) (*t.VDBIncomeReportFile, error) {
+	row := struct {
+		StartTs time.Time `db:"start_ts"`
+		EndTs   time.Time `db:"end_ts"`
+		Format  string    `db:"format"`
+		Status  string    `db:"status"`
+		Result  []byte    `db:"result"`
+	}{}
+	if err := d.getIncomeReport(ctx, dashboardId, reportId, "start_ts, end_ts, format, status, result", &row); err != nil {
+		return nil, err
+	}
+	if row.Status != incomeReportStatusCompleted {
+		return nil, fmt.Errorf("%w: income report with id %s has not been completed", ErrNotFound, reportId)
+	}
+
+	file := &t.VDBIncomeReportFile{
+		Name: fmt.Sprintf("income_report_%s_%s.%s", row.StartTs.Format("20060102"), row.EndTs.Format("20060102"), row.Format),
+		Data: row.Result,
+	}
+	switch row.Format {
+	case IncomeReportFormatCsv:
+		file.ContentType = "text/csv"
+	case IncomeReportFormatPdf:
+		file.ContentType = "application/pdf"
+	}
+	return file, nil
+}
+
+// startIncomeReportService generates queued income reports, multiple api instances can run it concurrently
+func (d *DataAccessService) startIncomeReportService() {
+	for {
+		startTime := time.Now()
+		processed, err := d.processNextIncomeReport()
+		if err != nil {
+			log.Error(err, "error processing income report", 0)
+		}
+		if !processed {
+			utils.ConstantTimeDelay(startTime, 10*time.Second)
+		}
+	}
+}
+
+// processNextIncomeReport claims and generates the oldest queued report, returns false if there was nothing to do
+func (d *DataAccessService) processNextIncomeReport() (bool, error) {
+	ctx := context.Background()
+
+	report := &incomeReportDbRow{}
+	err := d.alloyWriter.GetContext(ctx, report, `
+		UPDATE validator_income_reports
+		SET status = $1, started_ts = NOW()
+		WHERE id = (
+			SELECT id FROM validator_income_reports
+			WHERE status = $2 OR (status = $1 AND started_ts < NOW() - $3::INTERVAL)
+			ORDER BY created_ts
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+incomeReportColumns,
+		incomeReportStatusRunning, incomeReportStatusPending, fmt.Sprintf("%d seconds", int(incomeReportTimeout.Seconds())))
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error claiming income report: %w", err)
+	}
+
+	start := time.Now()
+	result, genErr := d.generateIncomeReport(ctx, report)
+	if genErr != nil {
+		_, err = d.alloyWriter.ExecContext(ctx, `UPDATE validator_income_reports SET status = $2, error = $3, completed_ts = NOW() WHERE id = $1`,
+			report.Id, incomeReportStatusFailed, "the report could not be generated")
+		if err != nil {
+			return true, fmt.Errorf("error marking income report %s as failed: %w", report.Id, err)
+		}
+		return true, fmt.Errorf("error generating income report %s: %w", report.Id, genErr)
+	}
+
+	_, err = d.alloyWriter.ExecContext(ctx, `UPDATE validator_income_reports SET status = $2, result = $3, completed_ts = NOW() WHERE id = $1`,
+		report.Id, incomeReportStatusCompleted, result)
+	if err != nil {
+		return true, fmt.Errorf("error storing income report %s: %w", report.Id, err)
+	}
+	log.Infof("generated income report %s for %d validators, took %v", report.Id, len(report.Validators), time.Since(start))
+	return true, nil
+}
+
+type incomeReportRow struct {
+	Day    time.Time
+	Entity int64 // validator index or group id
+
+	ClRewards    decimal.Decimal // wei
+	PriorityFees decimal.Decimal // wei, fee recipient rewards of blocks not built by a relay
+	Mev          decimal.Decimal // wei, relay payments
+	Withdrawals  decimal.Decimal // wei
+
+	Price *decimal.Decimal // nil if there is no price for the day
+}
+
+func (r *incomeReportRow) income() decimal.Decimal {
+	return r.ClRewards.Add(r.PriorityFees).Add(r.Mev)
+}
+
+// fiat converts a wei amount to the report currency using the price of the day the amount was received, zero is returned if the price is missing
+func (r *incomeReportRow) fiat(wei decimal.Decimal) decimal.Decimal {
+	if r.Price == nil {
+		return decimal.Zero
+	}
+	return wei.Div(decimal.NewFromInt(1e18)).Mul(*r.Price)
+}
+
+// formatFiat formats a wei amount in the report currency, days without price are marked as such instead of reporting zero
+func (r *incomeReportRow) formatFiat(wei decimal.Decimal) string {
+	if r.Price == nil {
+		return incomeReportMissingPrice
+	}
+	return r.fiat(wei).StringFixed(2)
+}
+
+func (r *incomeReportRow) formatPrice() string {
+	if r.Price == nil {
+		return incomeReportMissingPrice
+	}
+	return r.Price.StringFixed(2)
+}
+
+func (d *DataAccessService) generateIncomeReport(ctx context.Context, report *incomeReportDbRow) ([]byte, error) {
+	startDay := report.StartTs.UTC().Truncate(24 * time.Hour)
+	endDay := report.EndTs.UTC().Truncate(24 * time.Hour)
+
+	validators := make([]uint64, 0, len(report.Validators))
+	entities := make(map[uint64]int64, len(report.Validators))
+	for i, validator := range report.Validators {
+		validators = append(validators, uint64(validator))
+		if report.Aggregation == IncomeReportAggregationGroup {
+			entities[uint64(validator)] = report.ValidatorGroups[i]
+		} else {
+			entities[uint64(validator)] = validator
+		}
+	}
+
+	rows := make(map[time.Time]map[int64]*incomeReportRow)
+	getRow := func(day time.Time, validator uint64) *incomeReportRow {
+		day = day.UTC().Truncate(24 * time.Hour)
+		entity := entities[validator]
+		if rows[day] == nil {
+			rows[day] = make(map[int64]*incomeReportRow)
+		}
+		if rows[day][entity] == nil {
+			rows[day][entity] = &incomeReportRow{Day: day, Entity: entity}
+		}
+		return rows[day][entity]
+	}
+
+	if len(validators) > 0 {
+		clRows := []struct {
+			ValidatorIndex    uint64    `db:"validator_index"`
+			Day               time.Time `db:"day"`
+			ClRewards         int64     `db:"cl_rewards"`
+			WithdrawalsAmount int64     `db:"withdrawals_amount"`
+		}{}
+		elRows := []struct {
+			Proposer     uint64          `db:"proposer"`
+			Slot         uint64          `db:"slot"`
+			PriorityFees decimal.Decimal `db:"priority_fees"`
+			Mev          sql.NullString  `db:"mev"`
+		}{}
+
+		wg := errgroup.Group{}
+		wg.Go(func() error {
+			err := d.clickhouseReader.SelectContext(ctx, &clRows, `
+				SELECT
+					validator_index,
+					day,
+					COALESCE(balance_end, 0) + COALESCE(withdrawals_amount, 0) - COALESCE(deposits_amount, 0) - COALESCE(balance_start, 0) AS cl_rewards,
+					COALESCE(withdrawals_amount, 0) AS withdrawals_amount
+				FROM validator_dashboard_data_daily
+				WHERE day >= toDate($1) AND day <= toDate($2) AND validator_index IN ($3)`,
+				startDay, endDay, validators)
+			if err != nil {
+				return fmt.Errorf("error retrieving cl rewards: %w", err)
+			}
+			return nil
+		})
+		wg.Go(func() error {
+			startSlot := utils.TimeToSlot(uint64(startDay.Unix()))
+			endSlot := utils.TimeToSlot(uint64(endDay.Add(24*time.Hour).Unix() - 1))
+			err := d.readerDb.SelectContext(ctx, &elRows, `
+				SELECT
+					b.proposer,
+					b.slot,
+					COALESCE(ep.fee_recipient_reward * 1e18, 0) AS priority_fees,
+					rb.value AS mev
+				FROM blocks b
+				LEFT JOIN execution_payloads ep ON ep.block_hash = b.exec_block_hash
+				LEFT JOIN LATERAL (
+					SELECT MAX(value) AS value FROM relays_blocks WHERE relays_blocks.exec_block_hash = b.exec_block_hash
+				) rb ON TRUE
+				WHERE b.proposer = ANY($1) AND b.status = '1' AND b.slot >= $2 AND b.slot <= $3`,
+				pq.Array(validators), startSlot, endSlot)
+			if err != nil {
+				return fmt.Errorf("error retrieving el rewards: %w", err)
+			}
+			return nil
+		})
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+
+		gWei := decimal.NewFromInt(1e9)
+		for _, clRow := range clRows {
+			row := getRow(clRow.Day, clRow.ValidatorIndex)
+			row.ClRewards = row.ClRewards.Add(decimal.NewFromInt(clRow.ClRewards).Mul(gWei))
+			row.Withdrawals = row.Withdrawals.Add(decimal.NewFromInt(clRow.WithdrawalsAmount).Mul(gWei))
+		}
+		for _, elRow := range elRows {
+			row := getRow(utils.SlotToTime(elRow.Slot), elRow.Proposer)
+			// the fee recipient of relay blocks is the builder, the proposer only receives the relay payment
+			if elRow.Mev.Valid {
+				mev, err := decimal.NewFromString(elRow.Mev.String)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing mev reward of slot %d: %w", elRow.Slot, err)
+				}
+				row.Mev = row.Mev.Add(mev)
+			} else {
+				row.PriorityFees = row.PriorityFees.Add(elRow.PriorityFees)
+			}
+		}
+	}
+
+	prices, err := d.getIncomeReportPrices(ctx, startDay, endDay, report.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*incomeReportRow, 0, len(rows))
+	for day, dayRows := range rows {
+		price, ok := prices[day]
+		for _, row := range dayRows {
+			if ok {
+				row.Price = &price
+			}
+			result = append(result, row)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Day.Equal(result[j].Day) {
+			return result[i].Entity < result[j].Entity
+		}
+		return result[i].Day.Before(result[j].Day)
+	})
+
+	groupNames := map[int64]string{}
+	if report.Aggregation == IncomeReportAggregationGroup {
+		groupNames[t.DefaultGroupId] = t.DefaultGroupName
+		if report.DashboardId.Valid {
+			groups := []struct {
+				Id   int64  `db:"id"`
+				Name string `db:"name"`
+			}{}
+			err := d.alloyReader.SelectContext(ctx, &groups, `SELECT id, name FROM users_val_dashboards_groups WHERE dashboard_id = $1`, report.DashboardId.Int64)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving group names: %w", err)
+			}
+			for _, group := range groups {
+				groupNames[group.Id] = group.Name
+			}
+		}
+	}
+	entityName := func(entity int64) string {
+		if report.Aggregation == IncomeReportAggregationGroup {
+			if name, ok := groupNames[entity]; ok {
+				return name
+			}
+		}
+		return fmt.Sprintf("%d", entity)
+	}
+
+	switch report.Format {
+	case IncomeReportFormatCsv:
+		return generateIncomeReportCsv(report, result, entityName)
+	case IncomeReportFormatPdf:
+		return generateIncomeReportPdf(report, result, entityName)
+	}
+	return nil, fmt.Errorf("unknown income report format %s", report.Format)
+}
+
+// getIncomeReportPrices returns the eth price of each day in the given currency
+func (d *DataAccessService) getIncomeReportPrices(ctx context.Context, startDay, endDay time.Time, currency string) (map[time.Time]decimal.Decimal, error) {
+	var pricesDb []types.Price
+	err := d.readerDb.SelectContext(ctx, &pricesDb, `SELECT ts, eur, usd, gbp, cad, jpy, cny, aud FROM price WHERE ts >= $1 AND ts < $2 ORDER BY ts`, startDay, endDay.Add(24*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving prices: %w", err)
+	}
+
+	prices := make(map[time.Time]decimal.Decimal, len(pricesDb))
+	for _, item := range pricesDb {
+		var price float64
+		switch currency {
+		case "eur":
+			price = item.EUR
+		case "gbp":
+			price = item.GBP
+		case "cad":
+			price = item.CAD
+		case "jpy":
+			price = item.JPY
+		case "cny":
+			price = item.CNY
+		case "aud":
+			price = item.AUD
+		default:
+			price = item.USD
+		}
+		prices[item.TS.UTC().Truncate(24*time.Hour)] = decimal.NewFromFloat(price)
+	}
+	return prices, nil
+}
+
+const incomeReportMissingPrice = "n/a"
+
+// incomeReportCostBasisHeader labels the income valued at the price of the day it was received, which is also its cost basis
+func incomeReportCostBasisHeader(currency string) string {
+	return fmt.Sprintf("Income / Cost Basis (%s)", currency)
+}
+
+func formatIncomeReportEth(wei decimal.Decimal) string {
+	return wei.Div(decimal.NewFromInt(1e18)).StringFixed(9)
+}
+
+func incomeReportEntityHeader(report *incomeReportDbRow) string {
+	if report.Aggregation == IncomeReportAggregationGroup {
+		return "Group"
+	}
+	return "Validator"
+}
+
+func generateIncomeReportCsv(report *incomeReportDbRow, rows []*incomeReportRow, entityName func(int64) string) ([]byte, error) {
+	currency := strings.ToUpper(report.Currency)
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+
+	records := [][]string{{
+		"Date",
+		incomeReportEntityHeader(report),
+		"CL Rewards (ETH)",
+		"EL Priority Fees (ETH)",
+		"EL MEV (ETH)",
+		"Withdrawals (ETH)",
+		fmt.Sprintf("ETH Price (%s)", currency),
+		fmt.Sprintf("CL Rewards (%s)", currency),
+		fmt.Sprintf("EL Rewards (%s)", currency),
+		fmt.Sprintf("Withdrawals (%s)", currency),
+		incomeReportCostBasisHeader(currency),
+	}}
+	for _, row := range rows {
+		records = append(records, []string{
+			row.Day.Format("2006-01-02"),
+			entityName(row.Entity),
+			formatIncomeReportEth(row.ClRewards),
+			formatIncomeReportEth(row.PriorityFees),
+			formatIncomeReportEth(row.Mev),
+			formatIncomeReportEth(row.Withdrawals),
+			row.formatPrice(),
+			row.formatFiat(row.ClRewards),
+			row.formatFiat(row.PriorityFees.Add(row.Mev)),
+			row.formatFiat(row.Withdrawals),
+			row.formatFiat(row.income()),
+		})
+	}
+	if err := w.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("error writing csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func generateIncomeReportPdf(report *incomeReportDbRow, rows []*incomeReportRow, entityName func(int64) string) ([]byte, error) {
+	currency := strings.ToUpper(report.Currency)
+
+	totalIncome := decimal.Zero
+	totalFiat := decimal.Zero
+	missingPriceDays := make(map[time.Time]bool)
+	for _, row := range rows {
+		totalIncome = totalIncome.Add(row.income())
+		totalFiat = totalFiat.Add(row.fiat(row.income()))
+		if row.Price == nil {
+			missingPriceDays[row.Day] = true
+		}
+	}
+
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.SetTopMargin(15)
+	pdf.SetHeaderFuncMode(func() {
+		pdf.SetY(5)
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Beaconcha.in Income Report (%s - %s)", report.StartTs.Format("2006-01-02"), report.EndTs.Format("2006-01-02")), "", 0, "C", false, 0, "")
+	}, true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+	pdf.SetFont("Times", "", 9)
+	pdf.SetTextColor(24, 24, 24)
+	pdf.CellFormat(0, 5, fmt.Sprintf("Income For Timeframe %s ETH | %s %s", formatIncomeReportEth(totalIncome), currency, totalFiat.StringFixed(2)), "", 0, "CM", false, 0, "")
+	if len(missingPriceDays) > 0 {
+		pdf.Ln(5)
+		pdf.CellFormat(0, 5, fmt.Sprintf("The ETH price is missing for %d day(s), their income is not included in the %s total", len(missingPriceDays), currency), "", 0, "CM", false, 0, "")
+	}
+	pdf.Ln(10)
+
+	const (
+		colWd  = 30.0
+		lineHt = 5.0
+	)
+	header := []string{"Date", incomeReportEntityHeader(report), "CL Rewards", "EL Priority Fees", "EL MEV", "Withdrawals", fmt.Sprintf("ETH Price (%s)", currency), incomeReportCostBasisHeader(currency)}
+	writeHeader := func() {
+		pdf.SetTextColor(224, 224, 224)
+		pdf.SetFillColor(64, 64, 64)
+		for _, col := range header {
+			pdf.CellFormat(colWd, lineHt, col, "1", 0, "CM", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetTextColor(24, 24, 24)
+	}
+	writeHeader()
+
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottomMargin := pdf.GetMargins()
+	for i, row := range rows {
+		if pdf.GetY()+lineHt > pageHeight-bottomMargin-10 {
+			pdf.AddPage()
+			writeHeader()
+		}
+		pdf.SetFillColor(255, 255, 255)
+		if i%2 != 0 {
+			pdf.SetFillColor(191, 191, 191)
+		}
+		for _, col := range []string{
+			row.Day.Format("2006-01-02"),
+			entityName(row.Entity),
+			formatIncomeReportEth(row.ClRewards),
+			formatIncomeReportEth(row.PriorityFees),
+			formatIncomeReportEth(row.Mev),
+			formatIncomeReportEth(row.Withdrawals),
+			row.formatPrice(),
+			row.formatFiat(row.income()),
+		} {
+			pdf.CellFormat(colWd, lineHt, col, "1", 0, "LM", true, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := pdf.Output(buf); err != nil {
+		return nil, fmt.Errorf("error generating pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
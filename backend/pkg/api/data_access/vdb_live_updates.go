@@ -0,0 +1,265 @@
+package dataaccess
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gobitfly/beaconchain/pkg/api/services"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	liveDutyProposal    = "proposal"
+	liveDutyAttestation = "attestation"
+	liveDutySync        = "sync"
+	liveDutySlashing    = "slashing"
+
+	liveDutyResultSuccess = "success"
+	liveDutyResultFailed  = "failed"
+)
+
+// SubscribeValidatorDashboardLiveUpdates streams updates whenever the slot viz data changes until ctx is done.
+// The first update contains the full slot viz unless lastEventId refers to a recent update, in that case only the changes since then are sent.
+// A receiver that falls behind skips intermediate updates, the next update it receives contains all changes since the last one it received.
+func (d *DataAccessService) SubscribeValidatorDashboardLiveUpdates(ctx context.Context, dashboardId t.VDBId, groupIds []uint64, lastEventId uint64) (<-chan t.VDBLiveUpdate, error) {
+	// fail early if the dashboard can't be resolved
+	if _, err := d.getDashboardValidators(ctx, dashboardId, groupIds); err != nil {
+		return nil, err
+	}
+
+	notifications, unsubscribe := d.services.SubscribeDutiesInfo()
+	updates := make(chan t.VDBLiveUpdate)
+
+	go func() {
+		defer close(updates)
+		defer unsubscribe()
+
+		var last *services.SyncData
+		if lastEventId > 0 {
+			last = d.services.GetDutiesInfoByUpdateId(lastEventId)
+		}
+		for {
+			current, err := d.services.GetCurrentDutiesInfo()
+			if err != nil && !errors.Is(err, services.ErrWaiting) {
+				log.Error(err, "error getting duties info for live updates", 0)
+				return
+			}
+			if err == nil && (last == nil || current.UpdateId != last.UpdateId) {
+				// dashboard validators can change while the stream is open
+				validators, err := d.getLiveUpdateValidators(ctx, dashboardId, groupIds, current.UpdateId)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Error(err, "error getting dashboard validators for live updates", 0)
+					}
+					return
+				}
+				update := getLiveUpdate(last, current, validators)
+				if update.SlotViz != nil || len(update.Duties) > 0 {
+					select {
+					case updates <- update:
+					case <-ctx.Done():
+						return
+					}
+				}
+				last = current
+			}
+
+			select {
+			case <-notifications:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+// liveUpdateValidators caches the validators of dashboards with open live update streams for the latest duties info update,
+// all streams of a dashboard share a single query per update
+var liveUpdateValidators = struct {
+	sync.Mutex
+	updateId   uint64
+	validators map[string][]t.VDBValidator // key: <dashboardId>:<groupIds>
+	group      singleflight.Group
+}{
+	validators: make(map[string][]t.VDBValidator),
+}
+
+// getLiveUpdateValidators returns the validators of the dashboard for the given duties info update, they are fetched once per update and dashboard
+func (d *DataAccessService) getLiveUpdateValidators(ctx context.Context, dashboardId t.VDBId, groupIds []uint64, updateId uint64) ([]t.VDBValidator, error) {
+	if len(dashboardId.Validators) > 0 {
+		return dashboardId.Validators, nil
+	}
+	key := fmt.Sprintf("%d:%v", dashboardId.Id, groupIds)
+
+	liveUpdateValidators.Lock()
+	if liveUpdateValidators.updateId == updateId {
+		if validators, ok := liveUpdateValidators.validators[key]; ok {
+			liveUpdateValidators.Unlock()
+			return validators, nil
+		}
+	}
+	liveUpdateValidators.Unlock()
+
+	res, err, _ := liveUpdateValidators.group.Do(fmt.Sprintf("%d:%s", updateId, key), func() (interface{}, error) {
+		// the query is shared with other streams, so it must not be canceled if this stream is closed
+		validators, err := d.getDashboardValidators(context.WithoutCancel(ctx), dashboardId, groupIds)
+		if err != nil {
+			return nil, err
+		}
+		liveUpdateValidators.Lock()
+		defer liveUpdateValidators.Unlock()
+		if updateId > liveUpdateValidators.updateId {
+			liveUpdateValidators.updateId = updateId
+			clear(liveUpdateValidators.validators)
+		}
+		if updateId == liveUpdateValidators.updateId {
+			liveUpdateValidators.validators[key] = validators
+		}
+		return validators, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]t.VDBValidator), nil
+}
+
+func getLiveUpdate(last, current *services.SyncData, validators []t.VDBValidator) t.VDBLiveUpdate {
+	update := t.VDBLiveUpdate{Id: current.UpdateId}
+	currentSlotViz := getSlotViz(current, validators)
+	if last == nil {
+		update.SlotViz = &t.VDBLiveSlotVizUpdate{Reset: true, Epochs: currentSlotViz}
+		return update
+	}
+
+	// only send slots that changed
+	lastSlots := make(map[uint64]t.VDBSlotVizSlot)
+	lastStates := make(map[uint64]string)
+	for _, epoch := range getSlotViz(last, validators) {
+		lastStates[epoch.Epoch] = epoch.State
+		for _, slot := range epoch.Slots {
+			lastSlots[slot.Slot] = slot
+		}
+	}
+	var changedEpochs []t.SlotVizEpoch
+	for _, epoch := range currentSlotViz {
+		changed := t.SlotVizEpoch{Epoch: epoch.Epoch, State: epoch.State}
+		for _, slot := range epoch.Slots {
+			if lastSlot, ok := lastSlots[slot.Slot]; !ok || !reflect.DeepEqual(lastSlot, slot) {
+				changed.Slots = append(changed.Slots, slot)
+			}
+		}
+		if state, ok := lastStates[epoch.Epoch]; len(changed.Slots) > 0 || !ok || state != epoch.State {
+			changedEpochs = append(changedEpochs, changed)
+		}
+	}
+	if len(changedEpochs) > 0 {
+		update.SlotViz = &t.VDBLiveSlotVizUpdate{Epochs: changedEpochs}
+	}
+
+	update.Duties = getNewDutyResults(last, current, validators)
+	return update
+}
+
+type liveDutyKey struct {
+	Type      string
+	Slot      uint64
+	Validator t.VDBValidator
+}
+
+// getDutyResults returns the results of all duties of the given validators that are final
+func getDutyResults(dutiesInfo *services.SyncData, validatorsMap map[t.VDBValidator]bool) map[liveDutyKey]string {
+	results := make(map[liveDutyKey]string)
+	result := func(success bool) string {
+		if success {
+			return liveDutyResultSuccess
+		}
+		return liveDutyResultFailed
+	}
+
+	for slot, status := range dutiesInfo.SlotStatus {
+		// proposals
+		if proposer, ok := dutiesInfo.PropAssignmentsForSlot[slot]; ok && validatorsMap[proposer] {
+			results[liveDutyKey{liveDutyProposal, slot, proposer}] = result(status == 1)
+		}
+		// syncs, a missed slot means missed sync duties
+		for validator := range dutiesInfo.SyncAssignmentsForEpoch[utils.EpochOfSlot(slot)] {
+			if validatorsMap[validator] {
+				results[liveDutyKey{liveDutySync, slot, validator}] = result(dutiesInfo.SlotSyncParticipated[slot][validator])
+			}
+		}
+	}
+
+	// attestations can be included until the end of the next epoch, so they are only missed once that has passed
+	headEpoch := utils.EpochOfSlot(dutiesInfo.LatestSlot)
+	for validator := range validatorsMap {
+		for slot, attested := range dutiesInfo.EpochAttestationDuties[validator] {
+			if attested || utils.EpochOfSlot(uint64(slot))+1 < headEpoch {
+				results[liveDutyKey{liveDutyAttestation, uint64(slot), validator}] = result(attested)
+			}
+		}
+	}
+
+	// slashings, success if a dashboard validator included the slashing, failed if a dashboard validator got slashed
+	for _, slashedBySlot := range []map[uint64][]uint64{dutiesInfo.SlotValiPropSlashed, dutiesInfo.SlotValiAttSlashed} {
+		for slot, slashed := range slashedBySlot {
+			if proposer, ok := dutiesInfo.PropAssignmentsForSlot[slot]; ok && validatorsMap[proposer] {
+				results[liveDutyKey{liveDutySlashing, slot, proposer}] = liveDutyResultSuccess
+			}
+			for _, validator := range slashed {
+				if validatorsMap[validator] {
+					results[liveDutyKey{liveDutySlashing, slot, validator}] = liveDutyResultFailed
+				}
+			}
+		}
+	}
+	return results
+}
+
+// getNewDutyResults returns the duty results that are available in current but not in last, grouped by slot, type and result
+func getNewDutyResults(last, current *services.SyncData, validators []t.VDBValidator) []t.VDBLiveDutyResult {
+	validatorsMap := make(map[t.VDBValidator]bool, len(validators))
+	for _, validator := range validators {
+		validatorsMap[validator] = true
+	}
+	lastResults := getDutyResults(last, validatorsMap)
+
+	type group struct {
+		Type   string
+		Slot   uint64
+		Result string
+	}
+	groups := make(map[group][]uint64)
+	for key, result := range getDutyResults(current, validatorsMap) {
+		if lastResult, ok := lastResults[key]; ok && lastResult == result {
+			continue
+		}
+		g := group{key.Type, key.Slot, result}
+		groups[g] = append(groups[g], key.Validator)
+	}
+
+	dutyResults := make([]t.VDBLiveDutyResult, 0, len(groups))
+	for g, groupValidators := range groups {
+		slices.Sort(groupValidators)
+		dutyResults = append(dutyResults, t.VDBLiveDutyResult{
+			Slot:       g.Slot,
+			Type:       g.Type,
+			Result:     g.Result,
+			Validators: groupValidators,
+		})
+	}
+	slices.SortFunc(dutyResults, func(a, b t.VDBLiveDutyResult) int {
+		return cmp.Or(cmp.Compare(a.Slot, b.Slot), strings.Compare(a.Type, b.Type), strings.Compare(a.Result, b.Result))
+	})
+	return dutyResults
+}
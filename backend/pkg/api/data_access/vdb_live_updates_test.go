@@ -0,0 +1,112 @@
+package dataaccess
+
+import (
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/api/services"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLiveUpdatesTest(tt *testing.T) {
+	tt.Helper()
+	previous := utils.Config
+	utils.Config = &types.Config{}
+	utils.Config.Chain.ClConfig.SlotsPerEpoch = 4
+	tt.Cleanup(func() { utils.Config = previous })
+}
+
+func TestGetLiveUpdate(tt *testing.T) {
+	setupLiveUpdatesTest(tt)
+	validators := []t.VDBValidator{1, 2}
+
+	last := &services.SyncData{
+		LatestSlot:             8,
+		SlotStatus:             map[uint64]int8{8: 1},
+		SlotBlock:              map[uint64]uint64{8: 100},
+		PropAssignmentsForSlot: map[uint64]uint64{8: 1, 9: 2, 10: 3},
+		UpdateId:               1,
+	}
+	current := &services.SyncData{
+		LatestSlot:             9,
+		SlotStatus:             map[uint64]int8{8: 1, 9: 0},
+		SlotBlock:              map[uint64]uint64{8: 100},
+		PropAssignmentsForSlot: map[uint64]uint64{8: 1, 9: 2, 10: 3},
+		UpdateId:               2,
+	}
+
+	// without a previous update the full slot viz is sent
+	update := getLiveUpdate(nil, current, validators)
+	assert.Equal(tt, uint64(2), update.Id)
+	require.NotNil(tt, update.SlotViz)
+	assert.True(tt, update.SlotViz.Reset)
+	assert.Equal(tt, getSlotViz(current, validators), update.SlotViz.Epochs)
+	assert.Empty(tt, update.Duties)
+
+	// otherwise only the changed slots and the new duty results are sent
+	update = getLiveUpdate(last, current, validators)
+	require.NotNil(tt, update.SlotViz)
+	assert.False(tt, update.SlotViz.Reset)
+	require.Len(tt, update.SlotViz.Epochs, 1)
+	assert.Equal(tt, uint64(2), update.SlotViz.Epochs[0].Epoch)
+	require.Len(tt, update.SlotViz.Epochs[0].Slots, 1)
+	assert.Equal(tt, uint64(9), update.SlotViz.Epochs[0].Slots[0].Slot)
+	assert.Equal(tt, "missed", update.SlotViz.Epochs[0].Slots[0].Status)
+	assert.Equal(tt, []t.VDBLiveDutyResult{{Slot: 9, Type: liveDutyProposal, Result: liveDutyResultFailed, Validators: []uint64{2}}}, update.Duties)
+
+	// nothing is sent if nothing changed
+	update = getLiveUpdate(current, current, validators)
+	assert.Nil(tt, update.SlotViz)
+	assert.Empty(tt, update.Duties)
+}
+
+func TestGetNewDutyResults(tt *testing.T) {
+	setupLiveUpdatesTest(tt)
+	validators := []t.VDBValidator{1, 2, 3}
+
+	last := &services.SyncData{
+		LatestSlot: 9,
+		SlotStatus: map[uint64]int8{4: 1, 5: 1},
+		SlotSyncParticipated: map[uint64]map[uint64]bool{
+			4: {1: true},
+			5: {1: true},
+		},
+		SyncAssignmentsForEpoch: map[uint64]map[uint64]bool{1: {1: true, 4: true}},
+		EpochAttestationDuties: map[uint64]map[uint32]bool{
+			2: {5: true},
+			3: {6: false},
+		},
+	}
+	current := &services.SyncData{
+		LatestSlot: 12,
+		SlotStatus: map[uint64]int8{4: 1, 5: 1, 6: 1, 7: 2},
+		SlotSyncParticipated: map[uint64]map[uint64]bool{
+			4: {1: true},
+			5: {1: true},
+			6: {1: false},
+		},
+		SyncAssignmentsForEpoch: map[uint64]map[uint64]bool{1: {1: true, 4: true}},
+		EpochAttestationDuties: map[uint64]map[uint32]bool{
+			2: {5: true},
+			3: {6: false},
+		},
+		SlotValiAttSlashed:     map[uint64][]uint64{6: {3, 5}},
+		PropAssignmentsForSlot: map[uint64]uint64{6: 2},
+	}
+
+	assert.Equal(tt, []t.VDBLiveDutyResult{
+		// the attestation of slot 6 is missed once the head is two epochs ahead, the one of slot 5 was reported before
+		{Slot: 6, Type: liveDutyAttestation, Result: liveDutyResultFailed, Validators: []uint64{3}},
+		// the proposal of the block that includes the slashing is a success for the dashboard
+		{Slot: 6, Type: liveDutyProposal, Result: liveDutyResultSuccess, Validators: []uint64{2}},
+		{Slot: 6, Type: liveDutySlashing, Result: liveDutyResultFailed, Validators: []uint64{3}},
+		{Slot: 6, Type: liveDutySlashing, Result: liveDutyResultSuccess, Validators: []uint64{2}},
+		// validators that are not on the dashboard are ignored
+		{Slot: 6, Type: liveDutySync, Result: liveDutyResultFailed, Validators: []uint64{1}},
+		// a missed slot means missed sync duties
+		{Slot: 7, Type: liveDutySync, Result: liveDutyResultFailed, Validators: []uint64{1}},
+	}, getNewDutyResults(last, current, validators))
+}
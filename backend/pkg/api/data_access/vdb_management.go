@@ -13,11 +13,13 @@ import (
 	"sync"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	utilMath "github.com/protolambda/zrnt/eth2/util/math"
@@ -541,10 +543,14 @@ func (d *DataAccessService) GetValidatorDashboardOverview(ctx context.Context, d
 }
 
 func (d *DataAccessService) CreateValidatorDashboardGroup(ctx context.Context, dashboardId t.VDBIdPrimary, name string) (*t.VDBPostCreateGroupData, error) {
+	return createValidatorDashboardGroup(ctx, d.alloyWriter, dashboardId, name)
+}
+
+func createValidatorDashboardGroup(ctx context.Context, q sqlx.QueryerContext, dashboardId t.VDBIdPrimary, name string) (*t.VDBPostCreateGroupData, error) {
 	result := &t.VDBPostCreateGroupData{}
 
 	// Create a new group that has the smallest unique id possible
-	err := d.alloyWriter.GetContext(ctx, result, `
+	err := sqlx.GetContext(ctx, q, result, `
 		WITH NextAvailableId AS (
 		    SELECT COALESCE(MIN(uvdg1.id) + 1, 0) AS next_id
 		    FROM users_val_dashboards_groups uvdg1
@@ -599,9 +605,9 @@ func (d *DataAccessService) RemoveValidatorDashboardGroup(ctx context.Context, d
 
 	prefix := fmt.Sprintf("%s:%d:%d", ValidatorDashboardEventPrefix, dashboardId, groupId)
 
-	// Remove all events related to the group
+	// Remove all events related to the group, including the ones scoped to a tag
 	_, err = d.userWriter.ExecContext(ctx, `
-		DELETE FROM users_subscriptions WHERE event_filter = $1
+		DELETE FROM users_subscriptions WHERE event_filter = $1 OR event_filter LIKE ($1 || ':%')
 	`, prefix)
 	return err
 }
@@ -644,6 +650,7 @@ func (d *DataAccessService) GetValidatorDashboardValidators(ctx context.Context,
 	}
 	validatorGroupMap := make(map[t.VDBValidator]ValidatorGroupInfo)
 	var validators []t.VDBValidator
+	var validatorTags map[t.VDBValidator]map[string]string
 	if dashboardId.Validators == nil {
 		// Get the validators and their groups in case a dashboard id is provided
 		queryResult := []struct {
@@ -671,8 +678,18 @@ func (d *DataAccessService) GetValidatorDashboardValidators(ctx context.Context,
 		if err != nil {
 			return nil, nil, err
 		}
+		if !dashboardId.AggregateGroups {
+			// tags are only shared along with the groups
+			validatorTags, err = d.getValidatorDashboardTags(ctx, dashboardId.Id)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
 
 		for _, res := range queryResult {
+			if dashboardId.Tag != nil && validatorTags[res.ValidatorIndex][dashboardId.Tag.Key] != dashboardId.Tag.Value {
+				continue
+			}
 			validatorGroupMap[res.ValidatorIndex] = ValidatorGroupInfo{
 				GroupId:   res.GroupId,
 				GroupName: res.GroupName,
@@ -714,6 +731,7 @@ func (d *DataAccessService) GetValidatorDashboardValidators(ctx context.Context,
 			Balance:              utils.GWeiToWei(big.NewInt(int64(metadata.Balance))),
 			Status:               metadata.Status,
 			WithdrawalCredential: t.Hash(hexutil.Encode(metadata.WithdrawalCredentials)),
+			Tags:                 validatorTags[validator],
 		}
 
 		if constypes.ValidatorDbStatus(metadata.Status) == constypes.DbPending && metadata.Queues.ActivationIndex.Valid {
@@ -732,7 +750,9 @@ func (d *DataAccessService) GetValidatorDashboardValidators(ctx context.Context,
 
 			groupNameSearch := search == validatorGroupMap[validator].GroupName
 
-			if indexSearch || pubkeySearch || groupNameSearch {
+			tagSearch := validatorHasTag(row.Tags, search)
+
+			if indexSearch || pubkeySearch || groupNameSearch || tagSearch {
 				data = append(data, row)
 			}
 		}
@@ -797,6 +817,10 @@ func (d *DataAccessService) GetValidatorDashboardValidators(ctx context.Context,
 		result = data[cursorIndex:limitCutoff]
 	}
 
+	if err := d.setSafeWithdrawals(result); err != nil {
+		return nil, nil, err
+	}
+
 	// flag if above limit
 	moreDataFlag := len(result) > int(limit)
 	if !moreDataFlag && !currentCursor.IsValid() {
@@ -821,6 +845,28 @@ func (d *DataAccessService) GetValidatorDashboardValidators(ctx context.Context,
 	return result, p, nil
 }
 
+// setSafeWithdrawals flags the rows whose withdrawal address is a Safe multisig
+func (d *DataAccessService) setSafeWithdrawals(rows []t.VDBManageValidatorsTableRow) error {
+	addresses := make([][]byte, 0, len(rows))
+	for _, row := range rows {
+		credential := common.FromHex(string(row.WithdrawalCredential))
+		if len(credential) == 32 && credential[0] != 0x00 {
+			addresses = append(addresses, credential[12:])
+		}
+	}
+	safes, err := d.bigtable.GetSafes(addresses)
+	if err != nil {
+		return err
+	}
+	for i, row := range rows {
+		credential := common.FromHex(string(row.WithdrawalCredential))
+		if len(credential) == 32 && credential[0] != 0x00 {
+			rows[i].IsSafeWithdrawal = safes[hex.EncodeToString(credential[12:])]
+		}
+	}
+	return nil
+}
+
 func (d *DataAccessService) GetValidatorDashboardGroupExists(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) (bool, error) {
 	groupExists := false
 	err := d.alloyReader.GetContext(ctx, &groupExists, `
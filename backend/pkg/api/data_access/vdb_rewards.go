@@ -1022,6 +1022,17 @@ func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, das
 			rewardsDs = rewardsDs.Where(goqu.L("e.validator_index = ?", indexSearch))
 			elDs = elDs.Where(goqu.L("b.proposer = ?", indexSearch))
 		}
+
+		if tagValidators, ok, err := d.getTagFilteredValidators(ctx, dashboardId); err != nil {
+			return nil, nil, err
+		} else if ok {
+			if len(tagValidators) == 0 {
+				// No validator has the tag
+				return result, &paging, nil
+			}
+			rewardsDs = rewardsDs.Where(goqu.L("e.validator_index IN ?", tagValidators))
+			elDs = elDs.Where(goqu.L("b.proposer = ANY(?)", pq.Array(tagValidators)))
+		}
 	} else {
 		// In case a list of validators is provided set the group to the default id
 		validators := make([]t.VDBValidator, 0)
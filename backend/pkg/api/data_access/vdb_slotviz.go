@@ -3,6 +3,7 @@ package dataaccess
 import (
 	"context"
 
+	"github.com/gobitfly/beaconchain/pkg/api/services"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 )
@@ -14,15 +15,19 @@ func (d *DataAccessService) GetValidatorDashboardSlotViz(ctx context.Context, da
 		return nil, err
 	}
 
-	validatorsMap := utils.SliceToMap(validatorsArray)
-
-	maxValidatorsInResponse := 6
-
 	dutiesInfo, err := d.services.GetCurrentDutiesInfo()
 	if err != nil {
 		return nil, err
 	}
 
+	return getSlotViz(dutiesInfo, validatorsArray), nil
+}
+
+func getSlotViz(dutiesInfo *services.SyncData, validatorsArray []t.VDBValidator) []t.SlotVizEpoch {
+	validatorsMap := utils.SliceToMap(validatorsArray)
+
+	maxValidatorsInResponse := 6
+
 	// Get min/max slot/epoch
 	headEpoch := utils.EpochOfSlot(dutiesInfo.LatestSlot)
 
@@ -229,5 +234,5 @@ func (d *DataAccessService) GetValidatorDashboardSlotViz(ctx context.Context, da
 		}
 	}
 
-	return slotVizEpochs, nil
+	return slotVizEpochs
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
 	"slices"
@@ -85,6 +86,30 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 		}
 	}
 
+	// ------------------------------------------------------------------------------------------------------------------
+	// Resolve the tag filter and the tag groups, tag groups replace the dashboard groups
+	var tagValidators []t.VDBValidator
+	var tagGroups map[t.VDBValidator]int64
+	var tagGroupTags []t.VDBValidatorTag
+	if dashboardId.Validators == nil {
+		if dashboardId.GroupByTag != "" {
+			tagGroups, tagGroupTags, err = d.getValidatorDashboardTagGroups(ctx, dashboardId.Id, dashboardId.GroupByTag, dashboardId.Tag)
+			if err != nil {
+				return nil, nil, err
+			}
+			tagValidators = slices.Collect(maps.Keys(tagGroups))
+		} else if dashboardId.Tag != nil {
+			tagValidators, err = d.getValidatorDashboardTagValidators(ctx, dashboardId.Id, *dashboardId.Tag)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		if (dashboardId.GroupByTag != "" || dashboardId.Tag != nil) && len(tagValidators) == 0 {
+			// No validator has the tag
+			return result, &paging, nil
+		}
+	}
+
 	// ------------------------------------------------------------------------------------------------------------------
 	// Get the average network efficiency
 	efficiency, err := d.services.GetCurrentEfficiencyInfo()
@@ -113,9 +138,13 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 		MaxEpochEnd            int64           `db:"max_epoch_end"`
 	}
 
+	validatorsCte := goqu.L("(SELECT dashboard_id, group_id, validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)
+	if len(tagValidators) > 0 {
+		validatorsCte = goqu.L("(SELECT dashboard_id, group_id, validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ? AND validator_index IN ?)", dashboardId.Id, tagValidators)
+	}
 	ds := goqu.Dialect("postgres").
 		From(goqu.L(fmt.Sprintf(`%s AS r FINAL`, clickhouseTable))).
-		With("validators", goqu.L("(SELECT dashboard_id, group_id, validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
+		With("validators", validatorsCte).
 		Select(
 			goqu.L("ARRAY_AGG(r.validator_index) AS validator_indices"),
 			goqu.L("(SUM(COALESCE(r.balance_end,0)) + SUM(COALESCE(r.withdrawals_amount,0)) - SUM(COALESCE(r.deposits_amount,0)) - SUM(COALESCE(r.balance_start,0))) AS cl_rewards"),
@@ -136,7 +165,11 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 			SelectAppend(goqu.L("?::smallint AS result_group_id", t.DefaultGroupId)).
 			Where(goqu.L("r.validator_index IN ?", validators))
 	} else {
-		if dashboardId.AggregateGroups {
+		if tagGroups != nil {
+			// Aggregated per tag value after the query
+			ds = ds.
+				SelectAppend(goqu.L("r.validator_index::bigint AS result_group_id"))
+		} else if dashboardId.AggregateGroups {
 			ds = ds.
 				SelectAppend(goqu.L("?::smallint AS result_group_id", t.DefaultGroupId))
 		} else {
@@ -148,7 +181,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 			InnerJoin(goqu.L("validators v"), goqu.On(goqu.L("r.validator_index = v.validator_index"))).
 			Where(goqu.L("r.validator_index IN (SELECT validator_index FROM validators)"))
 
-		if groupNameSearchEnabled && (search != "" || colSort.Column == enums.VDBSummaryColumns.Group) {
+		if groupNameSearchEnabled && tagGroups == nil && (search != "" || colSort.Column == enums.VDBSummaryColumns.Group) {
 			// Get the group names since we can filter and/or sort for them
 			ds = ds.
 				SelectAppend(goqu.L("g.name AS group_name")).
@@ -172,6 +205,37 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 		return result, &paging, nil
 	}
 
+	if tagGroups != nil {
+		// Fold the validator rows into one row per tag value, the tag value is used as group name
+		tagGroupRows := make(map[int64]int)
+		folded := queryResult[:0:0]
+		for _, row := range queryResult {
+			groupId := tagGroups[t.VDBValidator(row.GroupId)]
+			i, ok := tagGroupRows[groupId]
+			if !ok {
+				tagGroupRows[groupId] = len(folded)
+				row.GroupId = groupId
+				row.GroupName = tagGroupTags[groupId].Value
+				folded = append(folded, row)
+				continue
+			}
+			entry := &folded[i]
+			entry.ValidatorIndices = append(entry.ValidatorIndices, row.ValidatorIndices...)
+			entry.ClRewards += row.ClRewards
+			entry.AttestationReward = entry.AttestationReward.Add(row.AttestationReward)
+			entry.AttestationIdealReward = entry.AttestationIdealReward.Add(row.AttestationIdealReward)
+			entry.AttestationsExecuted += row.AttestationsExecuted
+			entry.AttestationsScheduled += row.AttestationsScheduled
+			entry.BlocksProposed += row.BlocksProposed
+			entry.BlocksScheduled += row.BlocksScheduled
+			entry.SyncExecuted += row.SyncExecuted
+			entry.SyncScheduled += row.SyncScheduled
+			entry.MinEpochStart = min(entry.MinEpochStart, row.MinEpochStart)
+			entry.MaxEpochEnd = max(entry.MaxEpochEnd, row.MaxEpochEnd)
+		}
+		queryResult = folded
+	}
+
 	epochMin := int64(math.MaxInt32)
 	epochMax := int64(0)
 
@@ -209,7 +273,10 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 			SelectAppend(goqu.L("?::smallint AS result_group_id", t.DefaultGroupId)).
 			Where(goqu.L("b.proposer = ANY(?)", pq.Array(validators)))
 	} else {
-		if dashboardId.AggregateGroups {
+		if tagGroups != nil {
+			ds = ds.
+				SelectAppend(goqu.L("b.proposer::bigint AS result_group_id"))
+		} else if dashboardId.AggregateGroups {
 			ds = ds.
 				SelectAppend(goqu.L("?::smallint AS result_group_id", t.DefaultGroupId))
 		} else {
@@ -220,6 +287,10 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 		ds = ds.
 			InnerJoin(goqu.L("users_val_dashboards_validators v"), goqu.On(goqu.L("b.proposer = v.validator_index"))).
 			Where(goqu.L("v.dashboard_id = ?", dashboardId.Id))
+
+		if len(tagValidators) > 0 {
+			ds = ds.Where(goqu.L("b.proposer = ANY(?)", pq.Array(tagValidators)))
+		}
 	}
 
 	var elRewardsQueryResult []struct {
@@ -238,6 +309,11 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 	}
 
 	for _, entry := range elRewardsQueryResult {
+		if tagGroups != nil {
+			groupId := tagGroups[t.VDBValidator(entry.GroupId)]
+			elRewards[groupId] = elRewards[groupId].Add(entry.ElRewards)
+			continue
+		}
 		elRewards[entry.GroupId] = entry.ElRewards
 	}
 
@@ -293,6 +369,9 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 			GroupId:                  queryEntry.GroupId,
 			AverageNetworkEfficiency: averageNetworkEfficiency,
 		}
+		if tagGroups != nil {
+			resultEntry.Tag = &tagGroupTags[queryEntry.GroupId]
+		}
 
 		// Status
 		for _, validatorIndex := range queryEntry.ValidatorIndices {
@@ -845,6 +924,7 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 		EpochEnd       uint64        `db:"epoch_end"`
 		ValidatorCount uint64        `db:"validator_count"`
 		Reward         sql.NullInt64 `db:"reward"`
+		Stake          sql.NullInt64 `db:"stake"`
 	}
 
 	var rewardsResultTable RewardsResult
@@ -857,7 +937,8 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 			goqu.L("MIN(epoch_start) AS epoch_start"),
 			goqu.L("MAX(epoch_end) AS epoch_end"),
 			goqu.L("COUNT(*) AS validator_count"),
-			goqu.L("(SUM(COALESCE(r.balance_end,0)) + SUM(COALESCE(r.withdrawals_amount,0)) - SUM(COALESCE(r.deposits_amount,0)) - SUM(COALESCE(r.balance_start,0))) AS reward"))
+			goqu.L("(SUM(COALESCE(r.balance_end,0)) + SUM(COALESCE(r.withdrawals_amount,0)) - SUM(COALESCE(r.deposits_amount,0)) - SUM(COALESCE(r.balance_start,0))) AS reward"),
+			goqu.L("SUM(COALESCE(r.balance_start,0) + COALESCE(r.deposits_amount,0)) AS stake"))
 
 	if len(dashboardId.Validators) > 0 {
 		rewardsDs = rewardsDs.
@@ -891,7 +972,12 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 	if hours == -1 { // for all time APR
 		aprDivisor = 90 * 24
 	}
-	clAPR = ((float64(rewardsResultTable.Reward.Int64) / float64(aprDivisor)) / (float64(32e9) * float64(rewardsResultTable.ValidatorCount))) * 24.0 * 365.0 * 100.0
+	// since electra validators can hold more than the activation balance (EIP-7251), their actual balance is used as stake
+	stake := float64(utils.Config.Chain.ClConfig.MinActivationBalance) * float64(rewardsResultTable.ValidatorCount)
+	if utils.IsElectraEpoch(rewardsResultTable.EpochEnd) && rewardsResultTable.Stake.Valid && rewardsResultTable.Stake.Int64 > 0 {
+		stake = float64(rewardsResultTable.Stake.Int64)
+	}
+	clAPR = ((float64(rewardsResultTable.Reward.Int64) / float64(aprDivisor)) / stake) * 24.0 * 365.0 * 100.0
 	if math.IsNaN(clAPR) {
 		clAPR = 0
 	}
@@ -958,7 +1044,7 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 		return decimal.Zero, 0, decimal.Zero, 0, err
 	}
 	elIncomeFloat, _ := elIncome.Float64() // EL income is in ETH
-	elAPR = ((elIncomeFloat / float64(aprDivisor)) / (float64(32) * float64(rewardsResultTable.ValidatorCount))) * 24.0 * 365.0 * 100.0
+	elAPR = ((elIncomeFloat / float64(aprDivisor)) / (stake / 1e9)) * 24.0 * 365.0 * 100.0
 	if math.IsNaN(elAPR) {
 		elAPR = 0
 	}
@@ -0,0 +1,178 @@
+package dataaccess
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/lib/pq"
+)
+
+func (d *DataAccessService) GetValidatorDashboardTags(ctx context.Context, dashboardId t.VDBIdPrimary) ([]t.VDBTagKey, error) {
+	queryResult := []struct {
+		Key            string         `db:"tag_key"`
+		Values         pq.StringArray `db:"tag_values"`
+		ValidatorCount uint64         `db:"validator_count"`
+	}{}
+	err := d.alloyReader.SelectContext(ctx, &queryResult, `
+		SELECT
+			tag_key,
+			ARRAY_AGG(DISTINCT tag_value ORDER BY tag_value) AS tag_values,
+			COUNT(*) AS validator_count
+		FROM users_val_dashboards_validator_tags
+		WHERE dashboard_id = $1
+		GROUP BY tag_key
+		ORDER BY tag_key
+	`, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]t.VDBTagKey, 0, len(queryResult))
+	for _, row := range queryResult {
+		result = append(result, t.VDBTagKey{
+			Key:            row.Key,
+			Values:         row.Values,
+			ValidatorCount: row.ValidatorCount,
+		})
+	}
+	return result, nil
+}
+
+// SetValidatorDashboardValidatorTags adds the tags to the validators or updates the value if a validator has a tag with the same key already.
+// Validators that are not part of the dashboard are ignored.
+func (d *DataAccessService) SetValidatorDashboardValidatorTags(ctx context.Context, dashboardId t.VDBIdPrimary, validators []t.VDBValidator, tags []t.VDBValidatorTag) error {
+	if len(validators) == 0 || len(tags) == 0 {
+		return nil
+	}
+	keys := make([]string, len(tags))
+	values := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = tag.Key
+		values[i] = tag.Value
+	}
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		INSERT INTO users_val_dashboards_validator_tags (dashboard_id, validator_index, tag_key, tag_value)
+			SELECT v.dashboard_id, v.validator_index, t.tag_key, t.tag_value
+			FROM users_val_dashboards_validators v
+			CROSS JOIN UNNEST($3::text[], $4::text[]) AS t(tag_key, tag_value)
+			WHERE v.dashboard_id = $1 AND v.validator_index = ANY($2)
+		ON CONFLICT (dashboard_id, validator_index, tag_key) DO UPDATE SET
+			tag_value = EXCLUDED.tag_value
+	`, dashboardId, pq.Array(validators), pq.Array(keys), pq.Array(values))
+	if err != nil {
+		return fmt.Errorf("error setting tags of validator dashboard validators: %w", err)
+	}
+	return nil
+}
+
+// RemoveValidatorDashboardValidatorTags removes the tags with the given keys, from all validators of the dashboard if validators is empty
+func (d *DataAccessService) RemoveValidatorDashboardValidatorTags(ctx context.Context, dashboardId t.VDBIdPrimary, validators []t.VDBValidator, keys []string) error {
+	var err error
+	if len(validators) == 0 {
+		_, err = d.alloyWriter.ExecContext(ctx, `
+			DELETE FROM users_val_dashboards_validator_tags
+			WHERE dashboard_id = $1 AND tag_key = ANY($2)
+		`, dashboardId, pq.Array(keys))
+	} else {
+		_, err = d.alloyWriter.ExecContext(ctx, `
+			DELETE FROM users_val_dashboards_validator_tags
+			WHERE dashboard_id = $1 AND validator_index = ANY($2) AND tag_key = ANY($3)
+		`, dashboardId, pq.Array(validators), pq.Array(keys))
+	}
+	return err
+}
+
+// getValidatorDashboardTags returns all tags of the validators of a dashboard
+func (d *DataAccessService) getValidatorDashboardTags(ctx context.Context, dashboardId t.VDBIdPrimary) (map[t.VDBValidator]map[string]string, error) {
+	queryResult := []struct {
+		ValidatorIndex t.VDBValidator `db:"validator_index"`
+		Key            string         `db:"tag_key"`
+		Value          string         `db:"tag_value"`
+	}{}
+	err := d.alloyReader.SelectContext(ctx, &queryResult, `
+		SELECT validator_index, tag_key, tag_value
+		FROM users_val_dashboards_validator_tags
+		WHERE dashboard_id = $1
+	`, dashboardId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving tags of validator dashboard: %w", err)
+	}
+
+	tags := make(map[t.VDBValidator]map[string]string)
+	for _, row := range queryResult {
+		if tags[row.ValidatorIndex] == nil {
+			tags[row.ValidatorIndex] = make(map[string]string)
+		}
+		tags[row.ValidatorIndex][row.Key] = row.Value
+	}
+	return tags, nil
+}
+
+// getValidatorDashboardTagValidators returns the validators of a dashboard that have the tag
+func (d *DataAccessService) getValidatorDashboardTagValidators(ctx context.Context, dashboardId t.VDBIdPrimary, tag t.VDBValidatorTag) ([]t.VDBValidator, error) {
+	var validators []t.VDBValidator
+	err := d.alloyReader.SelectContext(ctx, &validators, `
+		SELECT validator_index
+		FROM users_val_dashboards_validator_tags
+		WHERE dashboard_id = $1 AND tag_key = $2 AND tag_value = $3
+		ORDER BY validator_index
+	`, dashboardId, tag.Key, tag.Value)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving validators with tag %s: %w", tag.Key, err)
+	}
+	return validators, nil
+}
+
+// getValidatorDashboardTagGroups assigns the validators of a dashboard that have a tag with the given key to synthetic groups, one per tag value.
+// Group ids are assigned in order of the tag values so they are stable as long as the values don't change. Validators without the tag are not included.
+// If filter is set, only validators with that tag are included.
+func (d *DataAccessService) getValidatorDashboardTagGroups(ctx context.Context, dashboardId t.VDBIdPrimary, key string, filter *t.VDBValidatorTag) (map[t.VDBValidator]int64, []t.VDBValidatorTag, error) {
+	tags, err := d.getValidatorDashboardTags(ctx, dashboardId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var values []string
+	for _, validatorTags := range tags {
+		if value, ok := validatorTags[key]; ok && !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	slices.Sort(values)
+
+	groups := make(map[t.VDBValidator]int64)
+	for validator, validatorTags := range tags {
+		value, ok := validatorTags[key]
+		if !ok || (filter != nil && validatorTags[filter.Key] != filter.Value) {
+			continue
+		}
+		i, _ := slices.BinarySearch(values, value)
+		groups[validator] = int64(i)
+	}
+	groupTags := make([]t.VDBValidatorTag, len(values))
+	for i, value := range values {
+		groupTags[i] = t.VDBValidatorTag{Key: key, Value: value}
+	}
+	return groups, groupTags, nil
+}
+
+// getTagFilteredValidators returns the validators of the dashboard that match its tag filter, ok is false if there is no tag filter
+func (d *DataAccessService) getTagFilteredValidators(ctx context.Context, dashboardId t.VDBId) (validators []t.VDBValidator, ok bool, err error) {
+	if dashboardId.Tag == nil || dashboardId.Validators != nil {
+		return nil, false, nil
+	}
+	validators, err = d.getValidatorDashboardTagValidators(ctx, dashboardId.Id, *dashboardId.Tag)
+	return validators, true, err
+}
+
+// validatorHasTag checks if the validator has the tag, either as `key:value` or just the value
+func validatorHasTag(tags map[string]string, search string) bool {
+	for key, value := range tags {
+		if search == value || search == key+":"+value {
+			return true
+		}
+	}
+	return false
+}
@@ -270,8 +270,9 @@ func (d *DataAccessService) GetValidatorDashboardWithdrawals(ctx context.Context
 		if nextData != nil {
 			// Complete the next data
 			nextData.GroupId = validatorGroupMap[nextData.Index]
-			// TODO integrate label/ens data for "next" row
-			// nextData.Recipient.Ens = addressEns[string(nextData.Recipient.Hash)]
+			if err := d.GetNamesAndEnsForAddresses(ctx, map[string]*t.Address{string(nextData.Recipient.Hash): &nextData.Recipient}); err != nil {
+				return nil, nil, err
+			}
 		} else {
 			// If there is no next data, add a missing estimate row
 			nextData = &t.VDBWithdrawalsTableRow{
@@ -345,8 +346,9 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 			continue
 		}
 
+		maxEffectiveBalance := utils.GetMaxEffectiveBalance(metadata.WithdrawalCredentials)
 		if (metadata.Balance > 0 && metadata.WithdrawableEpoch.Valid && metadata.WithdrawableEpoch.Int64 <= int64(epoch)) ||
-			(metadata.EffectiveBalance == utils.Config.Chain.ClConfig.MaxEffectiveBalance && metadata.Balance > utils.Config.Chain.ClConfig.MaxEffectiveBalance) {
+			(metadata.EffectiveBalance == maxEffectiveBalance && metadata.Balance > maxEffectiveBalance) {
 			// this validator is eligible for withdrawal, check if it is the next one
 			if nextValidator == nil || validator > *stats.LatestValidatorWithdrawalIndex {
 				distance, err := d.getWithdrawableCountFromCursor(validator, *stats.LatestValidatorWithdrawalIndex)
@@ -395,24 +397,20 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		return nil, err
 	}
 
+	maxEffectiveBalance := utils.GetMaxEffectiveBalance(nextValidatorData.WithdrawalCredentials)
 	var withdrawalAmount uint64
 	if nextValidatorData.WithdrawableEpoch.Valid && nextValidatorData.WithdrawableEpoch.Int64 <= int64(epoch) {
 		// full withdrawal
 		withdrawalAmount = nextValidatorData.Balance
-	} else {
+	} else if nextValidatorData.Balance > maxEffectiveBalance {
 		// partial withdrawal
-		withdrawalAmount = nextValidatorData.Balance - utils.Config.Chain.ClConfig.MaxEffectiveBalance
+		withdrawalAmount = nextValidatorData.Balance - maxEffectiveBalance
 	}
 
-	if lastWithdrawnEpoch == epoch || nextValidatorData.Balance < utils.Config.Chain.ClConfig.MaxEffectiveBalance {
+	if lastWithdrawnEpoch == epoch {
 		withdrawalAmount = 0
 	}
 
-	ens_name, err := db.GetEnsNameForAddress(*address, utils.SlotToTime(nextWithdrawalSlot))
-	if err != sql.ErrNoRows {
-		return nil, err
-	}
-
 	contractStatusReq := []db.ContractInteractionAtRequest{{
 		Address: fmt.Sprintf("%x", address),
 		Block:   -1,
@@ -427,8 +425,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		Slot:  nextWithdrawalSlot,
 		Index: *nextValidator,
 		Recipient: t.Address{
-			Hash:       t.Hash(address.String()),
-			Ens:        ens_name,
+			Hash:       t.Hash(hexutil.Encode(address.Bytes())),
 			IsContract: contractStatus[0] == types.CONTRACT_CREATION || contractStatus[0] == types.CONTRACT_PRESENT,
 		},
 		Amount: utils.GWeiToWei(big.NewInt(int64(withdrawalAmount))),
@@ -536,6 +533,115 @@ func (d *DataAccessService) GetValidatorDashboardTotalWithdrawals(ctx context.Co
 	return result, nil
 }
 
+// GetValidatorDashboardBlsChanges tracks the migration of the dashboard validators away from BLS (0x00) withdrawal credentials.
+// A change counts as completed once it is included in a canonical block, even if the validator mapping has not caught up yet.
+func (d *DataAccessService) GetValidatorDashboardBlsChanges(ctx context.Context, dashboardId t.VDBId) (*t.VDBBlsChangesData, error) {
+	result := &t.VDBBlsChangesData{
+		Bls:       []uint64{},
+		Pending:   []t.VDBPendingBlsChange{},
+		Completed: []t.VDBCompletedBlsChange{},
+	}
+
+	validators, err := d.getDashboardValidators(ctx, dashboardId, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting dashboard validators: %w", err)
+	}
+	if len(validators) == 0 {
+		return result, nil
+	}
+
+	var completed []struct {
+		ValidatorIndex uint64 `db:"validator_index"`
+		Slot           uint64 `db:"slot"`
+		Address        []byte `db:"address"`
+	}
+	err = d.alloyReader.SelectContext(ctx, &completed, `
+		SELECT
+			bls.validatorindex AS validator_index,
+			bls.block_slot AS slot,
+			bls.address
+		FROM blocks_bls_change bls
+		INNER JOIN blocks b ON b.blockroot = bls.block_root AND b.status = '1'
+		WHERE bls.validatorindex = ANY($1)
+		ORDER BY bls.block_slot DESC, bls.validatorindex`, pq.Array(validators))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bls changes of validators: %w", err)
+	}
+
+	var pending []struct {
+		ValidatorIndex uint64              `db:"validatorindex"`
+		NodeJobId      string              `db:"node_job_id"`
+		Status         types.NodeJobStatus `db:"status"`
+	}
+	err = d.readerDb.SelectContext(ctx, &pending, `
+		SELECT
+			v.validatorindex,
+			v.node_job_id,
+			nj.status
+		FROM node_jobs_bls_changes_validators v
+		INNER JOIN node_jobs nj ON nj.id = v.node_job_id
+		WHERE v.validatorindex = ANY($1) AND nj.status IN ($2, $3)`,
+		pq.Array(validators), types.PendingNodeJobStatus, types.SubmittedToNodeNodeJobStatus)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving pending bls change node jobs of validators: %w", err)
+	}
+
+	addressMapping := make(map[string]*t.Address)
+	completedValidators := make(map[uint64]bool, len(completed))
+	for _, row := range completed {
+		completedValidators[row.ValidatorIndex] = true
+		entry := t.VDBCompletedBlsChange{
+			Index:                row.ValidatorIndex,
+			Slot:                 row.Slot,
+			Timestamp:            utils.SlotToTime(row.Slot).Unix(),
+			NewWithdrawalAddress: t.Address{Hash: t.Hash(hexutil.Encode(row.Address))},
+		}
+		addressMapping[string(entry.NewWithdrawalAddress.Hash)] = nil
+		result.Completed = append(result.Completed, entry)
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	for i := range result.Completed {
+		result.Completed[i].NewWithdrawalAddress = *addressMapping[string(result.Completed[i].NewWithdrawalAddress.Hash)]
+	}
+
+	pendingValidators := make(map[uint64]bool, len(pending))
+	for _, row := range pending {
+		if completedValidators[row.ValidatorIndex] {
+			// the node job has not been updated yet
+			continue
+		}
+		pendingValidators[row.ValidatorIndex] = true
+		entry := t.VDBPendingBlsChange{
+			Index:       row.ValidatorIndex,
+			BroadcastId: row.NodeJobId,
+			Status:      "pending",
+		}
+		if row.Status == types.SubmittedToNodeNodeJobStatus {
+			entry.Status = "submitted"
+		}
+		result.Pending = append(result.Pending, entry)
+	}
+	sort.Slice(result.Pending, func(i, j int) bool { return result.Pending[i].Index < result.Pending[j].Index })
+
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current validator mapping: %w", err)
+	}
+	for _, validator := range validators {
+		if completedValidators[validator] || pendingValidators[validator] || validator >= uint64(len(mapping.ValidatorMetadata)) {
+			continue
+		}
+		if utils.HasBLSWithdrawalCredential(mapping.ValidatorMetadata[validator].WithdrawalCredentials) {
+			result.Bls = append(result.Bls, validator)
+		}
+	}
+	slices.Sort(result.Bls)
+
+	return result, nil
+}
+
 func (d *DataAccessService) getValidatorSearch(search string) ([]t.VDBValidator, error) {
 	validatorSearch := make([]t.VDBValidator, 0)
 
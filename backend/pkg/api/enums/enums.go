@@ -261,3 +261,98 @@ func (c ChartAggregation) Duration(secondsPerEpoch uint64) time.Duration {
 		return 0
 	}
 }
+
+// ----------------
+// Address Transaction Types
+
+type AddressTransactionType int
+
+var _ EnumFactory[AddressTransactionType] = AddressTransactionType(0)
+
+const (
+	AddressTransactionAll AddressTransactionType = iota
+	AddressTransactionGeneral
+	AddressTransactionInternal
+	AddressTransactionErc20
+	AddressTransactionErc721
+	AddressTransactionErc1155
+)
+
+func (a AddressTransactionType) Int() int {
+	return int(a)
+}
+
+func (AddressTransactionType) NewFromString(s string) AddressTransactionType {
+	switch s {
+	case "", "all":
+		return AddressTransactionAll
+	case "general":
+		return AddressTransactionGeneral
+	case "internal":
+		return AddressTransactionInternal
+	case "erc20":
+		return AddressTransactionErc20
+	case "erc721":
+		return AddressTransactionErc721
+	case "erc1155":
+		return AddressTransactionErc1155
+	default:
+		return AddressTransactionType(-1)
+	}
+}
+
+var AddressTransactionTypes = struct {
+	All      AddressTransactionType
+	General  AddressTransactionType
+	Internal AddressTransactionType
+	Erc20    AddressTransactionType
+	Erc721   AddressTransactionType
+	Erc1155  AddressTransactionType
+}{
+	AddressTransactionAll,
+	AddressTransactionGeneral,
+	AddressTransactionInternal,
+	AddressTransactionErc20,
+	AddressTransactionErc721,
+	AddressTransactionErc1155,
+}
+
+// ----------------
+// User Operation Roles
+
+type UserOperationRole int
+
+var _ EnumFactory[UserOperationRole] = UserOperationRole(0)
+
+const (
+	UserOperationRoleSender UserOperationRole = iota
+	UserOperationRolePaymaster
+	UserOperationRoleBundler
+)
+
+func (r UserOperationRole) Int() int {
+	return int(r)
+}
+
+func (UserOperationRole) NewFromString(s string) UserOperationRole {
+	switch s {
+	case "", "sender":
+		return UserOperationRoleSender
+	case "paymaster":
+		return UserOperationRolePaymaster
+	case "bundler":
+		return UserOperationRoleBundler
+	default:
+		return UserOperationRole(-1)
+	}
+}
+
+var UserOperationRoles = struct {
+	Sender    UserOperationRole
+	Paymaster UserOperationRole
+	Bundler   UserOperationRole
+}{
+	UserOperationRoleSender,
+	UserOperationRolePaymaster,
+	UserOperationRoleBundler,
+}
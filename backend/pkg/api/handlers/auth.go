@@ -13,7 +13,6 @@ import (
 	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
-	"github.com/gobitfly/beaconchain/pkg/commons/mail"
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
 	commonTypes "github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
@@ -102,12 +101,15 @@ Best regards,
 
 %[1]s
 `, utils.Config.Frontend.SiteDomain, confirmationHash)
-	err = mail.SendTextMail(email, subject, msg, []commonTypes.EmailAttachment{})
+	err = h.daService.QueueEmail(ctx, email, subject, msg, authEmailExpireTime)
+	if errors.Is(err, dataaccess.ErrEmailUndeliverable) {
+		return newBadRequestErr("email address is undeliverable, please use a different one")
+	}
 	if err != nil {
 		return errors.New("error sending confirmation email, try again later")
 	}
 
-	// 4. update confirmation time (only after mail was sent)
+	// 4. update confirmation time (only after mail was queued)
 	err = h.daService.UpdateEmailConfirmationTime(ctx, userId)
 	if err != nil {
 		// shouldn't present this as error to user, confirmation works fine
@@ -154,12 +156,15 @@ Best regards,
 
 %[1]s
 `, utils.Config.Frontend.SiteDomain, resetHash)
-	err = mail.SendTextMail(email, subject, msg, []commonTypes.EmailAttachment{})
+	err = h.daService.QueueEmail(ctx, email, subject, msg, authEmailExpireTime)
+	if errors.Is(err, dataaccess.ErrEmailUndeliverable) {
+		return newBadRequestErr("email address is undeliverable, please use a different one")
+	}
 	if err != nil {
 		return errors.New("error sending reset email, try again later")
 	}
 
-	// 4. update reset time (only after mail was sent)
+	// 4. update reset time (only after mail was queued)
 	err = h.daService.UpdatePasswordResetTime(ctx, userId)
 	if err != nil {
 		// shouldn't present this as error to user, reset works fine
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/services"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+// webhooks older than this are rejected to prevent replays
+const mailgunWebhookMaxAge = 15 * time.Minute
+
+type mailgunWebhook struct {
+	Signature struct {
+		Timestamp string `json:"timestamp"`
+		Token     string `json:"token"`
+		Signature string `json:"signature"`
+	} `json:"signature"`
+	EventData struct {
+		Event          string `json:"event"`
+		Severity       string `json:"severity"`
+		Reason         string `json:"reason"`
+		Recipient      string `json:"recipient"`
+		DeliveryStatus struct {
+			Code        int    `json:"code"`
+			Description string `json:"description"`
+			Message     string `json:"message"`
+		} `json:"delivery-status"`
+	} `json:"event-data"`
+}
+
+func verifyMailgunWebhookSignature(signingKey, timestamp, token, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(timestamp + token))
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// WebhookPostMailgunEvents marks email addresses as undeliverable on permanent bounces and spam complaints reported by mailgun.
+func (h *HandlerService) WebhookPostMailgunEvents(w http.ResponseWriter, r *http.Request) {
+	signingKey := utils.Config.Frontend.Mail.Mailgun.WebhookSigningKey
+	if signingKey == "" {
+		returnError(w, r, http.StatusServiceUnavailable, fmt.Errorf("mailgun webhooks are not configured"))
+		return
+	}
+
+	var req mailgunWebhook
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		returnBadRequest(w, r, fmt.Errorf("error decoding request body"))
+		return
+	}
+	if !verifyMailgunWebhookSignature(signingKey, req.Signature.Timestamp, req.Signature.Token, req.Signature.Signature) {
+		returnUnauthorized(w, r, fmt.Errorf("invalid webhook signature"))
+		return
+	}
+	ts, err := strconv.ParseInt(req.Signature.Timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(ts, 0)).Abs() > mailgunWebhookMaxAge {
+		returnUnauthorized(w, r, fmt.Errorf("webhook timestamp is invalid or too old"))
+		return
+	}
+
+	event := req.EventData
+	var reason string
+	switch {
+	case event.Event == "failed" && event.Severity == "permanent":
+		reason = services.EmailUndeliverableReasonBounce
+	case event.Event == "complained":
+		reason = services.EmailUndeliverableReasonComplaint
+	default:
+		// temporary failures are retried by mailgun, other events are not of interest
+		returnNoContent(w, r)
+		return
+	}
+	if event.Recipient == "" {
+		returnBadRequest(w, r, fmt.Errorf("missing recipient"))
+		return
+	}
+
+	details := event.Reason
+	if event.DeliveryStatus.Code != 0 {
+		description := event.DeliveryStatus.Description
+		if description == "" {
+			description = event.DeliveryStatus.Message
+		}
+		details = fmt.Sprintf("%s: %d %s", event.Reason, event.DeliveryStatus.Code, description)
+	}
+	err = h.daService.MarkEmailUndeliverable(r.Context(), event.Recipient, reason, details)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	log.Infof("marked email address as undeliverable after mailgun %s event (%s)", event.Event, reason)
+	returnNoContent(w, r)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// comments are sent periodically so proxies don't close idle streams
+	eventStreamHeartbeatInterval = 15 * time.Second
+	// clients that don't read within this time are disconnected, they can resume with Last-Event-ID
+	eventStreamWriteTimeout = 10 * time.Second
+	// tells clients how long to wait before reconnecting
+	eventStreamRetry = 3 * time.Second
+)
+
+type serverSentEvent struct {
+	Id    string
+	Event string
+	Data  interface{}
+}
+
+// writeEventStream streams the updates as server-sent events until the client disconnects or the updates channel is closed
+func writeEventStream[T any](w http.ResponseWriter, r *http.Request, updates <-chan T, toEvents func(T) []serverSentEvent) {
+	rc := http.NewResponseController(w)
+	write := func(data []byte) error {
+		// the server write timeout is meant for regular requests, instead every write gets its own deadline
+		if err := rc.SetWriteDeadline(time.Now().Add(eventStreamWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disable response buffering of nginx
+	if err := write([]byte(fmt.Sprintf("retry: %d\n\n", eventStreamRetry.Milliseconds()))); err != nil {
+		logApiError(r, fmt.Errorf("error starting event stream: %w", err), 0)
+		return
+	}
+
+	heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		var buf bytes.Buffer
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			buf.WriteString(": heartbeat\n\n")
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			for _, event := range toEvents(update) {
+				data, err := json.Marshal(event.Data)
+				if err != nil {
+					logApiError(r, fmt.Errorf("error encoding event data: %w", err), 0)
+					return
+				}
+				fmt.Fprintf(&buf, "id: %s\nevent: %s\ndata: %s\n\n", event.Id, event.Event, data)
+			}
+		}
+		if err := write(buf.Bytes()); err != nil {
+			// client is gone or too slow
+			return
+		}
+	}
+}
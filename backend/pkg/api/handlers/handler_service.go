@@ -511,8 +511,10 @@ var dbEventToResponse = map[string]string{
 	string(commontypes.SyncCommitteeSoonEventName):                 "sync",
 	string(commontypes.ValidatorReceivedWithdrawalEventName):       "withdrawal",
 	string(commontypes.ValidatorGotSlashedEventName):               "validator_got_slashed",
+	string(commontypes.ValidatorSlashingRiskEventName):             "slashing_risk",
 	string(commontypes.ValidatorDidSlashEventName):                 "validator_has_slashed",
 	string(commontypes.ValidatorGroupEfficiencyEventName):          "group_efficiency_below",
+	string(commontypes.ValidatorPerformanceAnomalyEventName):       "performance_anomaly",
 	string(commontypes.RocketpoolCollateralMinReachedEventName):    "min_collateral",
 	string(commontypes.RocketpoolCollateralMaxReachedEventName):    "max_collateral",
 	string(commontypes.IncomingTransactionEventName):               "incoming_tx",
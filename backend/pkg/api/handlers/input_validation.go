@@ -13,11 +13,15 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/layer2"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/gorilla/mux"
 	"github.com/invopop/jsonschema"
 	"github.com/shopspring/decimal"
+	go_ens "github.com/wealdtech/go-ens/v3"
 	"github.com/xeipuuv/gojsonschema"
 )
 
@@ -40,11 +44,13 @@ var (
 	rePassword                     = regexp.MustCompile(`^.{5,}$`)
 	reEmailUserToken               = regexp.MustCompile(`^[a-z0-9]{40}$`)
 	reJsonContentType              = regexp.MustCompile(`^application\/json(;.*)?$`)
+	reUuid                         = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 )
 
 const (
 	maxNameLength                     = 50
 	maxValidatorsInList               = 20
+	maxValidatorTagsPerRequest        = 10
 	maxQueryLimit              uint64 = 100
 	defaultReturnLimit         uint64 = 10
 	sortOrderAscending                = "asc"
@@ -246,6 +252,58 @@ func (v *validationError) checkBool(param, paramName string) bool {
 	return boolVar
 }
 
+// checkTag checks the key and value of a validator tag, neither may contain ':' or '=' as they are used in notification event filters
+func (v *validationError) checkTag(key, value string) types.VDBValidatorTag {
+	key = v.checkRegex(reName, v.checkLength(key, "tag key", 1), "tag key")
+	value = v.checkRegex(reName, v.checkLength(value, "tag value", 1), "tag value")
+	return types.VDBValidatorTag{Key: key, Value: value}
+}
+
+// checkTagNotificationSettings checks notification settings that only apply to the validators of a group with a tag,
+// group wide events and webhooks can only be configured for the whole group
+func (v *validationError) checkTagNotificationSettings(settings types.NotificationSettingsValidatorDashboard) {
+	if settings.IsGroupEfficiencyBelowSubscribed {
+		v.add("is_group_efficiency_below_subscribed", "group efficiency notifications are not available for tags")
+	}
+	if settings.IsPerformanceAnomalySubscribed {
+		v.add("is_performance_anomaly_subscribed", "performance anomaly notifications are not available for tags")
+	}
+	if settings.WebhookUrl != "" || settings.IsWebhookDiscordEnabled {
+		v.add("webhook_url", "webhooks are configured per group, use the group notification settings instead")
+	}
+	checkMinMax(v, settings.MaxCollateralThreshold, 0, 1, "max_collateral_threshold")
+	checkMinMax(v, settings.MinCollateralThreshold, 0, 1, "min_collateral_threshold")
+}
+
+// checkTagFilter parses a tag filter in the format `key:value`, tags can't be accessed through validator lists or public ids that don't share groups
+func (v *validationError) checkTagFilter(dashboardId *types.VDBId, param string) *types.VDBValidatorTag {
+	if param == "" {
+		return nil
+	}
+	if dashboardId.Validators != nil || dashboardId.AggregateGroups {
+		v.add("tag", "tags are not available for this dashboard")
+		return nil
+	}
+	key, value, ok := strings.Cut(param, ":")
+	if !ok {
+		v.add("tag", fmt.Sprintf("given value '%s' has an invalid format, must be 'key:value'", param))
+		return nil
+	}
+	tag := v.checkTag(key, value)
+	return &tag
+}
+
+func (v *validationError) checkGroupByTag(dashboardId *types.VDBId, key string) string {
+	if key == "" {
+		return ""
+	}
+	if dashboardId.Validators != nil || dashboardId.AggregateGroups {
+		v.add("group_by_tag", "tags are not available for this dashboard")
+		return ""
+	}
+	return v.checkRegex(reName, v.checkLength(key, "group_by_tag", 1), "group_by_tag")
+}
+
 func (v *validationError) checkAdConfigurationKeys(keysString string) []string {
 	if keysString == "" {
 		return []string{}
@@ -288,6 +346,89 @@ func (h *HandlerService) validateBlockRequest(r *http.Request, paramName string)
 	return chainId, value, nil
 }
 
+// checkValidatorParameter validates a single validator given either by its index or its public key.
+// Exactly one of the return values is set if the parameter is valid.
+func (v *validationError) checkValidatorParameter(param string) (*types.VDBValidator, string) {
+	switch {
+	case reInteger.MatchString(param):
+		index := v.checkUint(param, "validator")
+		return &index, ""
+	case reValidatorPublicKeyWithPrefix.MatchString(param):
+		return nil, strings.ToLower(param)
+	default:
+		v.add("validator", fmt.Sprintf("given value '%s' is not a valid validator index or public key", param))
+		return nil, ""
+	}
+}
+
+// checkNetworkTimeRange validates the optional `after_ts` and `before_ts` query parameters, 0 means no limit.
+func (v *validationError) checkNetworkTimeRange(q url.Values) (after uint64, before uint64) {
+	if afterParam := q.Get("after_ts"); afterParam != "" {
+		after = v.checkUint(afterParam, "after_ts")
+	}
+	if beforeParam := q.Get("before_ts"); beforeParam != "" {
+		before = v.checkUint(beforeParam, "before_ts")
+	}
+	if after > 0 && before > 0 && after > before {
+		v.add("after_ts", "parameter `after_ts` must not be greater than `before_ts`")
+	}
+	return after, before
+}
+
+// checkTransactionHashParameter validates a 32 byte execution layer transaction hash and returns it decoded.
+func (v *validationError) checkTransactionHashParameter(param string) []byte {
+	txHash, err := hexutil.Decode(param)
+	if err != nil || len(txHash) != 32 {
+		v.add("hash", fmt.Sprintf("given value '%s' is not a valid transaction hash", param))
+		return nil
+	}
+	return txHash
+}
+
+// checkEnsName validates a .eth ENS name and returns it normalised.
+func (v *validationError) checkEnsName(param string) string {
+	name, err := go_ens.NormaliseDomain(param)
+	if err != nil || !reEnsName.MatchString(name) {
+		v.add("ens_name", fmt.Sprintf("given value '%s' is not a valid ENS name", param))
+		return ""
+	}
+	return name
+}
+
+// checkContractAbi validates a contract abi given as json array and returns it encoded.
+func (v *validationError) checkContractAbi(contractAbi []any) []byte {
+	abiJson, err := json.Marshal(contractAbi)
+	if err != nil {
+		v.add("abi", "given value is not a valid contract abi")
+		return nil
+	}
+	if _, err := utils.NewContractMetadata("", abiJson); err != nil {
+		v.add("abi", fmt.Sprintf("given value is not a valid contract abi: %v", err))
+		return nil
+	}
+	return abiJson
+}
+
+// checkWithdrawalCredentialParameter validates a 32 byte withdrawal credential and returns it decoded.
+func (v *validationError) checkWithdrawalCredentialParameter(param, paramName string) []byte {
+	credential, err := hexutil.Decode(param)
+	if err != nil || len(credential) != 32 {
+		v.add(paramName, fmt.Sprintf("given value '%s' is not a valid withdrawal credential", param))
+		return nil
+	}
+	return credential
+}
+
+// checkVersionedHashParameter validates a blob versioned hash (EIP-4844), it must be 32 bytes long and use version 0x01
+func (v *validationError) checkVersionedHashParameter(param string) []byte {
+	versionedHash, err := hexutil.Decode(param)
+	if err != nil || !kzg4844.IsValidVersionedHash(versionedHash) {
+		v.add("versioned_hash", fmt.Sprintf("given value '%s' is not a valid versioned hash", param))
+		return nil
+	}
+	return versionedHash
+}
+
 // checkGroupId validates the given group id and returns it as an int64.
 // If the given group id is empty and allowEmpty is true, it returns -1 (all groups).
 func (v *validationError) checkGroupId(param string, allowEmpty bool) int64 {
@@ -512,6 +653,16 @@ func (v *validationError) checkNetworksParameter(param string) []uint64 {
 	return chainIds
 }
 
+// checkLayer2NetworkParameter validates a layer 2 network name or chain id and returns the chain id.
+// Only layer 2 networks that settle on the network of this instance are valid.
+func (v *validationError) checkLayer2NetworkParameter(param string) uint64 {
+	network, ok := layer2.FindNetwork(utils.Config.Chain.ClConfig.DepositChainID, param)
+	if !ok {
+		v.add("layer_2_network", fmt.Sprintf("given value '%s' is not a valid layer 2 network", param))
+	}
+	return network.ChainId
+}
+
 // isValidNetwork checks if the given network is a valid network.
 // It returns the chain id of the network and true if it is valid, otherwise 0 and false.
 func isValidNetwork(network intOrString) (uint64, bool) {
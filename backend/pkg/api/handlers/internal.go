@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	types "github.com/gobitfly/beaconchain/pkg/api/types"
 
@@ -270,6 +271,10 @@ func (h *HandlerService) InternalGetUserDashboards(w http.ResponseWriter, r *htt
 	h.PublicGetUserDashboards(w, r)
 }
 
+func (h *HandlerService) InternalGetUserApiUsage(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserApiUsage(w, r)
+}
+
 // --------------------------------------
 // Account Dashboards
 
@@ -404,6 +409,30 @@ func (h *HandlerService) InternalDeleteValidatorDashboardValidators(w http.Respo
 	h.PublicDeleteValidatorDashboardValidators(w, r)
 }
 
+func (h *HandlerService) InternalPostValidatorDashboardValidatorsBulkMoves(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostValidatorDashboardValidatorsBulkMoves(w, r)
+}
+
+func (h *HandlerService) InternalGetValidatorDashboardExport(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardExport(w, r)
+}
+
+func (h *HandlerService) InternalPostValidatorDashboardImport(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostValidatorDashboardImport(w, r)
+}
+
+func (h *HandlerService) InternalGetValidatorDashboardTags(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardTags(w, r)
+}
+
+func (h *HandlerService) InternalPostValidatorDashboardValidatorTags(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostValidatorDashboardValidatorTags(w, r)
+}
+
+func (h *HandlerService) InternalDeleteValidatorDashboardValidatorTags(w http.ResponseWriter, r *http.Request) {
+	h.PublicDeleteValidatorDashboardValidatorTags(w, r)
+}
+
 func (h *HandlerService) InternalPostValidatorDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
 	h.PublicPostValidatorDashboardPublicIds(w, r)
 }
@@ -424,6 +453,10 @@ func (h *HandlerService) InternalGetValidatorDashboardSlotViz(w http.ResponseWri
 	h.PublicGetValidatorDashboardSlotViz(w, r)
 }
 
+func (h *HandlerService) InternalGetValidatorDashboardLiveUpdates(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardLiveUpdates(w, r)
+}
+
 func (h *HandlerService) InternalGetValidatorDashboardSummary(w http.ResponseWriter, r *http.Request) {
 	h.PublicGetValidatorDashboardSummary(w, r)
 }
@@ -492,6 +525,10 @@ func (h *HandlerService) InternalGetValidatorDashboardTotalWithdrawals(w http.Re
 	h.PublicGetValidatorDashboardTotalWithdrawals(w, r)
 }
 
+func (h *HandlerService) InternalGetValidatorDashboardBlsChanges(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardBlsChanges(w, r)
+}
+
 func (h *HandlerService) InternalGetValidatorDashboardRocketPool(w http.ResponseWriter, r *http.Request) {
 	h.PublicGetValidatorDashboardRocketPool(w, r)
 }
@@ -504,6 +541,18 @@ func (h *HandlerService) InternalGetValidatorDashboardRocketPoolMinipools(w http
 	h.PublicGetValidatorDashboardRocketPoolMinipools(w, r)
 }
 
+func (h *HandlerService) InternalPostValidatorDashboardIncomeReports(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostValidatorDashboardIncomeReports(w, r)
+}
+
+func (h *HandlerService) InternalGetValidatorDashboardIncomeReport(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardIncomeReport(w, r)
+}
+
+func (h *HandlerService) InternalGetValidatorDashboardIncomeReportFile(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardIncomeReportFile(w, r)
+}
+
 // even though this endpoint is internal only, it should still not be broken since it is used by the mobile app
 func (h *HandlerService) InternalGetValidatorDashboardMobileWidget(w http.ResponseWriter, r *http.Request) {
 	var v validationError
@@ -646,6 +695,14 @@ func (h *HandlerService) InternalPutUserNotificationSettingsValidatorDashboard(w
 	h.PublicPutUserNotificationSettingsValidatorDashboard(w, r)
 }
 
+func (h *HandlerService) InternalGetUserNotificationSettingsValidatorDashboardTags(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserNotificationSettingsValidatorDashboardTags(w, r)
+}
+
+func (h *HandlerService) InternalPutUserNotificationSettingsValidatorDashboardTag(w http.ResponseWriter, r *http.Request) {
+	h.PublicPutUserNotificationSettingsValidatorDashboardTag(w, r)
+}
+
 func (h *HandlerService) InternalPutUserNotificationSettingsAccountDashboard(w http.ResponseWriter, r *http.Request) {
 	h.PublicPutUserNotificationSettingsAccountDashboard(w, r)
 }
@@ -1008,6 +1065,167 @@ func (h *HandlerService) InternalGetSlotBlobs(w http.ResponseWriter, r *http.Req
 	returnOk(w, r, response)
 }
 
+// --------------------------------------
+// Network
+
+func (h *HandlerService) InternalGetNetworkDeposits(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkDeposits(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkValidatorDeposits(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkValidatorDeposits(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkTransactionDeposits(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkTransactionDeposits(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkWithdrawals(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkWithdrawals(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkWithdrawalCredentialWithdrawals(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkWithdrawalCredentialWithdrawals(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkWithdrawalCredentialTotals(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkWithdrawalCredentialTotals(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkBlob(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkBlob(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkBlobBlocks(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkBlobBlocks(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkBlobHistory(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkBlobHistory(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkBlobSubmitters(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkBlobSubmitters(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkGasNow(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkGasNow(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkAverageGasLimitHistory(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkAverageGasLimitHistory(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkGasUsedHistory(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkGasUsedHistory(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkAddressEns(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkAddressEns(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkEns(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkEns(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkBatches(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkBatches(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkLayer1ToLayer2Transactions(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkLayer1ToLayer2Transactions(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkLayer2ToLayer1Transactions(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkLayer2ToLayer1Transactions(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkAddressUserOperations(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkAddressUserOperations(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkAddressSponsorship(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkAddressSponsorship(w, r)
+}
+
+func (h *HandlerService) InternalGetMultisigSafe(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetMultisigSafe(w, r)
+}
+
+func (h *HandlerService) InternalGetMultisigSafeTransactions(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetMultisigSafeTransactions(w, r)
+}
+
+func (h *HandlerService) InternalGetMultisigTransactionConfirmations(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetMultisigTransactionConfirmations(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkAddressBalanceHistory(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkAddressBalanceHistory(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkAddressTokenSupplyHistory(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkAddressTokenSupplyHistory(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkAddressEventLogs(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkAddressEventLogs(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkTransactions(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkTransactions(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkTransaction(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkTransaction(w, r)
+}
+
+func (h *HandlerService) InternalGetNetworkAddressTransactions(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetNetworkAddressTransactions(w, r)
+}
+
+// InternalPutNetworkContractAbi adds a verified contract abi to the registry used to decode transactions and event logs, admins only.
+func (h *HandlerService) InternalPutNetworkContractAbi(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if user.UserGroup != types.UserGroupAdmin {
+		returnForbidden(w, r, errors.New("user is not an admin"))
+		return
+	}
+
+	type request struct {
+		Name string `json:"name"`
+		Abi  []any  `json:"abi"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	address := v.checkAddress(vars["address"])
+	abiJson := v.checkContractAbi(req.Abi)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).SaveNetworkContractAbi(r.Context(), chainId, common.FromHex(address), req.Name, abiJson)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.PutNetworkContractAbiResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
 func (h *HandlerService) ReturnOk(w http.ResponseWriter, r *http.Request) {
 	returnOk(w, r, nil)
 }
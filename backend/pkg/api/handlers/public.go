@@ -2,14 +2,22 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
+	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gorilla/mux"
 	"github.com/shopspring/decimal"
 )
@@ -85,6 +93,35 @@ func (h *HandlerService) PublicGetUserDashboards(w http.ResponseWriter, r *http.
 	returnOk(w, r, response)
 }
 
+// PublicGetUserApiUsage godoc
+//
+//	@Description	Get the API usage of all API keys of the authenticated user in the current month, per rate limit bucket and route. The usage is updated periodically and may lag behind for a few minutes.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Users
+//	@Produce		json
+//	@Success		200	{object}	types.GetUserApiUsageResponse
+//	@Router			/users/me/api-usage [get]
+func (h *HandlerService) PublicGetUserApiUsage(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	// monthly ratelimits are reset at the start of each month (UTC)
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	data, err := h.getDataAccessor(r).GetUserApiUsage(r.Context(), userId, start, end)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetUserApiUsageResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
 func (h *HandlerService) PublicPostAccountDashboards(w http.ResponseWriter, r *http.Request) {
 	returnCreated(w, r, nil)
 }
@@ -681,7 +718,8 @@ func (h *HandlerService) PublicPostValidatorDashboardValidators(w http.ResponseW
 //	@Param			group_id		query		integer	false	"The ID of the group."
 //	@Param			limit			query		string	false	"The maximum number of results that may be returned."
 //	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(index, public_key, balance, status, withdrawal_credentials)
-//	@Param			search			query		string	false	"Search for Address, ENS."
+//	@Param			search			query		string	false	"Search for Address, ENS, Tag."
+//	@Param			tag				query		string	false	"Only return validators with this tag, in the format `key:value`."
 //	@Success		200				{object}	types.GetValidatorDashboardValidatorsResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
 //	@Router			/validator-dashboards/{dashboard_id}/validators [get]
@@ -693,6 +731,7 @@ func (h *HandlerService) PublicGetValidatorDashboardValidators(w http.ResponseWr
 		return
 	}
 	q := r.URL.Query()
+	dashboardId.Tag = v.checkTagFilter(dashboardId, q.Get("tag"))
 	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
 	pagingParams := v.checkPagingParams(q)
 	sort := checkSort[enums.VDBManageValidatorsColumn](&v, q.Get("sort"))
@@ -755,6 +794,365 @@ func (h *HandlerService) PublicDeleteValidatorDashboardValidators(w http.Respons
 	returnNoContent(w, r)
 }
 
+// PublicPostValidatorDashboardValidatorsBulkMoves godoc
+//
+//	@Description	Move validators of a specified dashboard to another group. Either all validators of a group or a list of validators can be moved, validators that are not part of the dashboard are ignored.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		integer																true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPostValidatorDashboardValidatorsBulkMoves.request	true	"`group_id`: The group the validators should be moved to.<br><br>Exactly one of the following fields must be set:<ul><li>`from_group_id`: Move all validators of this group.</li><li>`validators`: Provide a list of validator indices or public keys.</li></ul>"
+//	@Success		200				{object}	types.ApiDataResponse[[]types.VDBPostValidatorsData]	"Returns a list of moved validators."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		404				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/validators/bulk-moves [post]
+func (h *HandlerService) PublicPostValidatorDashboardValidatorsBulkMoves(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		GroupId     uint64        `json:"group_id"`
+		FromGroupId *uint64       `json:"from_group_id,omitempty"`
+		Validators  []intOrString `json:"validators,omitempty"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if (req.FromGroupId == nil) == (req.Validators == nil) {
+		v.add("request body", "exactly one of `from_group_id`, `validators` must be set. please check the API documentation for more information")
+	}
+	var indices []uint64
+	var pubkeys []string
+	if req.Validators != nil {
+		indices, pubkeys = v.checkValidators(req.Validators, forbidEmpty)
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	ctx := r.Context()
+	groupIds := []uint64{req.GroupId}
+	if req.FromGroupId != nil {
+		groupIds = append(groupIds, *req.FromGroupId)
+	}
+	for _, groupId := range groupIds {
+		groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(ctx, dashboardId, groupId)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if !groupExists {
+			returnNotFound(w, r, fmt.Errorf("group %d not found", groupId))
+			return
+		}
+	}
+	var validators []types.VDBValidator
+	if req.Validators != nil {
+		var err error
+		validators, err = h.getDataAccessor(r).GetValidatorsFromSlices(ctx, indices, pubkeys)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	}
+	data, err := h.getDataAccessor(r).MoveValidatorDashboardValidators(ctx, dashboardId, req.FromGroupId, validators, req.GroupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.ApiDataResponse[[]types.VDBPostValidatorsData]{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardExport godoc
+//
+//	@Description	Export the groups, validators, public IDs and notification settings of a specified dashboard. The JSON export can be imported into another dashboard, the CSV export only contains groups and validators.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Produce		json,text/csv
+//	@Param			dashboard_id	path		integer	true	"The ID of the dashboard."
+//	@Param			format			query		string	false	"The format of the export. Defaults to `json`."	Enums(json, csv)
+//	@Success		200				{object}	types.GetValidatorDashboardExportResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/export [get]
+func (h *HandlerService) PublicGetValidatorDashboardExport(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		v.add("format", "must be either 'json' or 'csv'")
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetValidatorDashboardExport(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if format != "csv" {
+		response := types.GetValidatorDashboardExportResponse{
+			Data: *data,
+		}
+		returnOk(w, r, response)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"validator-dashboard-%d.csv\"", dashboardId))
+	w.WriteHeader(http.StatusOK)
+	if err := writeValidatorDashboardExportCsv(w, data); err != nil {
+		logApiError(r, fmt.Errorf("error writing dashboard export: %w", err), 0)
+	}
+}
+
+// PublicPostValidatorDashboardImport godoc
+//
+//	@Description	Import groups, validators, public IDs and notification settings into a specified dashboard, e.g. from an export of a dashboard of another account or network. Groups are matched by name and created if they don't exist, validators are matched by public key on the network of the dashboard (indices are ignored) and moved to the imported group if they are part of the dashboard already. Groups and validators exceeding the limits of the subscription plan are skipped. Use `dry_run` to validate an import without changing the dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json,text/csv
+//	@Produce		json
+//	@Param			dashboard_id	path		integer				true	"The ID of the dashboard."
+//	@Param			dry_run			query		boolean				false	"If set to `true`, only the result of the import is returned without changing the dashboard."
+//	@Param			request			body		types.VDBExport		true	"A dashboard export in JSON format or CSV with the columns `group_id,group_name,validator_index,public_key`. The `group_id` and `validator_index` columns are ignored."
+//	@Success		200				{object}	types.PostValidatorDashboardImportResponse	"Returned for dry runs."
+//	@Success		201				{object}	types.PostValidatorDashboardImportResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		403				{object}	types.ApiErrorResponse	"Forbidden. The import requires the 'Bulk adding' perk of a subscription plan."
+//	@Router			/validator-dashboards/{dashboard_id}/import [post]
+func (h *HandlerService) PublicPostValidatorDashboardImport(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	dryRun := v.checkBool(r.URL.Query().Get("dry_run"), "dry_run")
+	var req types.VDBExport
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		req = parseValidatorDashboardImportCsv(&v, r.Body)
+	} else if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	for i, group := range req.Groups {
+		v.checkNameNotEmpty(group.Name)
+		for _, validator := range group.Validators {
+			// validators are resolved by public key as indices differ between networks
+			v.checkRegex(reValidatorPublicKey, string(validator.PublicKey), fmt.Sprintf("groups[%d].validators", i))
+			for key, value := range validator.Tags {
+				v.checkTag(key, value)
+			}
+		}
+		if settings := group.NotificationSettings; settings != nil {
+			checkMinMax(&v, settings.GroupEfficiencyBelowThreshold, 0, 1, "group_offline_threshold")
+			if settings.IsPerformanceAnomalySubscribed {
+				checkMinMax(&v, settings.PerformanceAnomalyThreshold, 1, 10, "performance_anomaly_threshold")
+			}
+			checkMinMax(&v, settings.MaxCollateralThreshold, 0, 1, "max_collateral_threshold")
+			checkMinMax(&v, settings.MinCollateralThreshold, 0, 1, "min_collateral_threshold")
+		}
+		for _, tagSettings := range group.TagNotificationSettings {
+			v.checkTag(tagSettings.Tag.Key, tagSettings.Tag.Value)
+			v.checkTagNotificationSettings(tagSettings.Settings)
+		}
+	}
+	for _, publicId := range req.PublicIds {
+		v.checkName(publicId.Name, 0)
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	ctx := r.Context()
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !userInfo.PremiumPerks.BulkAdding && !isUserAdmin(userInfo) {
+		returnForbidden(w, r, errors.New("importing dashboards not allowed with current subscription plan"))
+		return
+	}
+	maxGroups, maxValidators := userInfo.PremiumPerks.ValidatorGroupsPerDashboard, userInfo.PremiumPerks.ValidatorsPerDashboard
+	if isUserAdmin(userInfo) {
+		maxGroups, maxValidators = math.MaxUint32, math.MaxUint32 // no limit for admins
+	}
+	if !userInfo.PremiumPerks.NotificationsValidatorDashboardGroupEfficiency {
+		// same as PublicPutUserNotificationSettingsValidatorDashboard, but the rest of the settings can still be imported
+		for i := range req.Groups {
+			if req.Groups[i].NotificationSettings != nil {
+				req.Groups[i].NotificationSettings.IsGroupEfficiencyBelowSubscribed = false
+				req.Groups[i].NotificationSettings.IsPerformanceAnomalySubscribed = false
+			}
+		}
+	}
+
+	data, err := h.getDataAccessor(r).ImportValidatorDashboard(ctx, userId, dashboardId, req, maxGroups, maxValidators, dryRun)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.PostValidatorDashboardImportResponse{
+		Data: *data,
+	}
+	if dryRun {
+		returnOk(w, r, response)
+		return
+	}
+	returnCreated(w, r, response)
+}
+
+// PublicGetValidatorDashboardTags godoc
+//
+//	@Description	Get the tag keys used in a specified dashboard along with their values.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Produce		json
+//	@Param			dashboard_id	path		integer	true	"The ID of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardTagsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/tags [get]
+func (h *HandlerService) PublicGetValidatorDashboardTags(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetValidatorDashboardTags(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardTagsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicPostValidatorDashboardValidatorTags godoc
+//
+//	@Description	Add tags to validators of a specified dashboard. If a validator has a tag with the same key already, its value gets updated. Validators that are not part of the dashboard are ignored.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Param			dashboard_id	path	integer														true	"The ID of the dashboard."
+//	@Param			request			body	handlers.PublicPostValidatorDashboardValidatorTags.request	true	"`validators`: Provide a list of validator indices or public keys.<br>`tags`: Provide up to 10 tags, each with a `key` and a `value`."
+//	@Success		204				"Tags added successfully."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/validators/tags [post]
+func (h *HandlerService) PublicPostValidatorDashboardValidatorTags(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		Validators []intOrString           `json:"validators"`
+		Tags       []types.VDBValidatorTag `json:"tags"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	indices, pubkeys := v.checkValidators(req.Validators, forbidEmpty)
+	if len(req.Tags) == 0 || len(req.Tags) > maxValidatorTagsPerRequest {
+		v.add("tags", fmt.Sprintf("must contain between 1 and %d tags", maxValidatorTagsPerRequest))
+	}
+	tags := make([]types.VDBValidatorTag, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		tags = append(tags, v.checkTag(tag.Key, tag.Value))
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	ctx := r.Context()
+	validators, err := h.getDataAccessor(r).GetValidatorsFromSlices(ctx, indices, pubkeys)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.getDataAccessor(r).SetValidatorDashboardValidatorTags(ctx, dashboardId, validators, tags)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// PublicDeleteValidatorDashboardValidatorTags godoc
+//
+//	@Description	Remove tags from validators of a specified dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Param			dashboard_id	path	integer															true	"The ID of the dashboard."
+//	@Param			request			body	handlers.PublicDeleteValidatorDashboardValidatorTags.request	true	"`keys`: Provide the keys of the tags that should get removed.<br>`validators`: (optional) Provide a list of validator indices or public keys. If omitted, the tags are removed from all validators of the dashboard."
+//	@Success		204				"Tags removed successfully."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/validators/tags/bulk-deletions [post]
+func (h *HandlerService) PublicDeleteValidatorDashboardValidatorTags(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		Keys       []string      `json:"keys"`
+		Validators []intOrString `json:"validators,omitempty"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if len(req.Keys) == 0 {
+		v.add("keys", "must not be empty")
+	}
+	for _, key := range req.Keys {
+		v.checkRegex(reName, v.checkLength(key, "keys", 1), "keys")
+	}
+	var indices []uint64
+	var pubkeys []string
+	if req.Validators != nil {
+		indices, pubkeys = v.checkValidators(req.Validators, forbidEmpty)
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	ctx := r.Context()
+	var validators []types.VDBValidator
+	if req.Validators != nil {
+		var err error
+		validators, err = h.getDataAccessor(r).GetValidatorsFromSlices(ctx, indices, pubkeys)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if len(validators) == 0 {
+			returnNoContent(w, r)
+			return
+		}
+	}
+	err := h.getDataAccessor(r).RemoveValidatorDashboardValidatorTags(ctx, dashboardId, validators, req.Keys)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
 // PublicPostValidatorDashboardPublicIds godoc
 //
 //	@Description	Create a new public ID for a specified dashboard. This can be used as an ID by other users for non-modyfing (i.e. GET) endpoints only. Currently limited to one per dashboard.
@@ -1034,6 +1432,54 @@ func (h *HandlerService) PublicGetValidatorDashboardSlotViz(w http.ResponseWrite
 	returnOk(w, r, response)
 }
 
+// PublicGetValidatorDashboardLiveUpdates godoc
+//
+//	@Description	Stream live updates for a specified dashboard as server-sent events. A `slot-viz` event (types.VDBLiveSlotVizUpdate) contains the slot viz changes, the first one contains the full slot viz. A `duties` event (list of types.VDBLiveDutyResult) contains new duty results like missed attestations and proposals.
+//	@Description	If the connection is lost, pass the last received event id in the `Last-Event-ID` header to only receive the changes since then.
+//	@Tags			Validator Dashboard
+//	@Produce		text/event-stream
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_ids		query		string	false	"Provide a comma separated list of group IDs to filter the results by. If omitted, all groups will be included."
+//	@Param			Last-Event-ID	header		string	false	"The id of the last received event."
+//	@Success		200				{object}	types.VDBLiveUpdate
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/live-updates [get]
+func (h *HandlerService) PublicGetValidatorDashboardLiveUpdates(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	groupIds := v.checkExistingGroupIdList(r.URL.Query().Get("group_ids"))
+	var lastEventId uint64
+	if lastEventIdHeader := r.Header.Get("Last-Event-ID"); lastEventIdHeader != "" {
+		lastEventId = v.checkUint(lastEventIdHeader, "Last-Event-ID")
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	updates, err := h.getDataAccessor(r).SubscribeValidatorDashboardLiveUpdates(r.Context(), *dashboardId, groupIds, lastEventId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	writeEventStream(w, r, updates, func(update types.VDBLiveUpdate) []serverSentEvent {
+		id := strconv.FormatUint(update.Id, 10)
+		var events []serverSentEvent
+		if update.SlotViz != nil {
+			events = append(events, serverSentEvent{Id: id, Event: "slot-viz", Data: update.SlotViz})
+		}
+		if len(update.Duties) > 0 {
+			events = append(events, serverSentEvent{Id: id, Event: "duties", Data: update.Duties})
+		}
+		return events
+	})
+}
+
 // PublicGetValidatorDashboardSummary godoc
 //
 //	@Description	Get summary information for a specified dashboard
@@ -1044,8 +1490,10 @@ func (h *HandlerService) PublicGetValidatorDashboardSlotViz(w http.ResponseWrite
 //	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
 //	@Param			limit			query		string	false	"The maximum number of results that may be returned."
 //	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(group_id, validators, efficiency, attestations, proposals, reward)
-//	@Param			search			query		string	false	"Search for Index, Public Key, Group."
+//	@Param			search			query		string	false	"Search for Index, Public Key, Group. Searches for the tag value instead of the group if `group_by_tag` is set."
 //	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
+//	@Param			tag				query		string	false	"Only include validators with this tag, in the format `key:value`."
+//	@Param			group_by_tag	query		string	false	"Group the validators by the values of this tag key instead of their groups. Validators without the tag are not included."
 //	@Success		200				{object}	types.GetValidatorDashboardSummaryResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
 //	@Router			/validator-dashboards/{dashboard_id}/summary [get]
@@ -1057,6 +1505,8 @@ func (h *HandlerService) PublicGetValidatorDashboardSummary(w http.ResponseWrite
 		return
 	}
 	q := r.URL.Query()
+	dashboardId.Tag = v.checkTagFilter(dashboardId, q.Get("tag"))
+	dashboardId.GroupByTag = v.checkGroupByTag(dashboardId, q.Get("group_by_tag"))
 	pagingParams := v.checkPagingParams(q)
 	sort := checkSort[enums.VDBSummaryColumn](&v, q.Get("sort"))
 	protocolModes := v.checkProtocolModes(q.Get("modes"))
@@ -1366,6 +1816,7 @@ func (h *HandlerService) PublicGetValidatorDashboardRewardsChart(w http.Response
 //	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(validator, reward)
 //	@Param			search			query		string	false	"Search for Index, Public Key."
 //	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
+//	@Param			tag				query		string	false	"Only include validators with this tag, in the format `key:value`."
 //	@Success		200				{object}	types.GetValidatorDashboardDutiesResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
 //	@Router			/validator-dashboards/{dashboard_id}/duties/{epoch} [get]
@@ -1378,6 +1829,7 @@ func (h *HandlerService) PublicGetValidatorDashboardDuties(w http.ResponseWriter
 		return
 	}
 	q := r.URL.Query()
+	dashboardId.Tag = v.checkTagFilter(dashboardId, q.Get("tag"))
 	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
 	epoch := v.checkUint(vars["epoch"], "epoch")
 	pagingParams := v.checkPagingParams(q)
@@ -1749,9 +2201,37 @@ func (h *HandlerService) PublicGetValidatorDashboardTotalWithdrawals(w http.Resp
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardRocketPool godoc
+// PublicGetValidatorDashboardBlsChanges godoc
 //
-//	@Description	Get an aggregated list of the Rocket Pool nodes details associated with a specified dashboard.
+//	@Description	Get the progress of migrating the validators of a specified dashboard away from BLS (0x00) withdrawal credentials. Validators are split into those that still need a BLS to execution change, those with a broadcast change that is not included yet and those that have completed the change.
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardBlsChangesResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/bls-changes [get]
+func (h *HandlerService) PublicGetValidatorDashboardBlsChanges(w http.ResponseWriter, r *http.Request) {
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetValidatorDashboardBlsChanges(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	response := types.GetValidatorDashboardBlsChangesResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardRocketPool godoc
+//
+//	@Description	Get an aggregated list of the Rocket Pool nodes details associated with a specified dashboard.
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
@@ -1867,6 +2347,181 @@ func (h *HandlerService) PublicGetValidatorDashboardRocketPoolMinipools(w http.R
 	returnOk(w, r, response)
 }
 
+const maxIncomeReportTimeframe = 366 * 24 * 60 * 60
+
+// PublicPostValidatorDashboardIncomeReports godoc
+//
+//	@Description	Request an income report for a specified dashboard. The report contains the consensus layer rewards, execution layer rewards (priority fees and MEV) and withdrawals per day, valued at the historic price of the day they were received. Reports are generated asynchronously, use the returned `id` to poll the status and download the file once it is completed.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		string														true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPostValidatorDashboardIncomeReports.request	true	"`group_id`: (optional) Only include validators of the given group. If omitted, all validators of the dashboard are included.<br>`aggregation`: Report income per `validator` or per `group`. Defaults to `validator`.<br>`start_ts`, `end_ts`: Unix timestamps of the timeframe, at most 366 days.<br>`currency`: Fiat currency of the report. Defaults to `usd`.<br>`format`: File format of the report. Defaults to `csv`."
+//	@Success		201				{object}	types.PostValidatorDashboardIncomeReportsResponse				"Returns the report including its `id`, which can be used to poll the status."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/income-reports [post]
+func (h *HandlerService) PublicPostValidatorDashboardIncomeReports(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	ctx := r.Context()
+	dashboardId, err := h.handleDashboardId(ctx, mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	type request struct {
+		GroupId     *int64 `json:"group_id,omitempty" x-nullable:"true"`
+		Aggregation string `json:"aggregation,omitempty" enums:"validator,group"`
+		StartTs     int64  `json:"start_ts"`
+		EndTs       int64  `json:"end_ts"`
+		Currency    string `json:"currency,omitempty" enums:"usd,eur,gbp,cad,jpy,cny,aud"`
+		Format      string `json:"format,omitempty" enums:"csv,pdf"`
+	}
+	req := request{
+		Aggregation: dataaccess.IncomeReportAggregationValidator,
+		Currency:    "usd",
+		Format:      dataaccess.IncomeReportFormatCsv,
+	}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	groupId := int64(types.AllGroups)
+	if req.GroupId != nil {
+		groupId = *req.GroupId
+		if groupId < 0 {
+			v.add("group_id", "must be a positive integer")
+		}
+	}
+	if req.Aggregation != dataaccess.IncomeReportAggregationValidator && req.Aggregation != dataaccess.IncomeReportAggregationGroup {
+		v.add("aggregation", fmt.Sprintf("given value '%s' is not valid", req.Aggregation))
+	}
+	if req.Format != dataaccess.IncomeReportFormatCsv && req.Format != dataaccess.IncomeReportFormatPdf {
+		v.add("format", fmt.Sprintf("given value '%s' is not valid", req.Format))
+	}
+	if !slices.Contains(dataaccess.IncomeReportCurrencies, req.Currency) {
+		v.add("currency", fmt.Sprintf("given value '%s' is not valid", req.Currency))
+	}
+	if req.StartTs < 0 || req.EndTs <= req.StartTs {
+		v.add("end_ts", "must be greater than start_ts")
+	} else if req.EndTs-req.StartTs > maxIncomeReportTimeframe {
+		v.add("end_ts", "timeframe must not exceed 366 days")
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	if groupId != types.AllGroups {
+		groupExists := groupId == types.DefaultGroupId
+		if dashboardId.Validators == nil {
+			groupExists, err = h.getDataAccessor(r).GetValidatorDashboardGroupExists(ctx, types.VDBIdPrimary(dashboardId.Id), uint64(groupId))
+			if err != nil {
+				handleErr(w, r, err)
+				return
+			}
+		}
+		if !groupExists {
+			returnNotFound(w, r, errors.New("group not found"))
+			return
+		}
+	}
+
+	data, err := h.getDataAccessor(r).CreateValidatorDashboardIncomeReport(ctx, *dashboardId, groupId, req.Aggregation, req.StartTs, req.EndTs, req.Currency, req.Format)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.PostValidatorDashboardIncomeReportsResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
+}
+
+// PublicGetValidatorDashboardIncomeReport godoc
+//
+//	@Description	Get the status of an income report of a specified dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			report_id		path		string	true	"The ID of the report."
+//	@Success		200				{object}	types.GetValidatorDashboardIncomeReportResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		404				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/income-reports/{report_id} [get]
+func (h *HandlerService) PublicGetValidatorDashboardIncomeReport(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	reportId := v.checkRegex(reUuid, vars["report_id"], "report_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetValidatorDashboardIncomeReport(r.Context(), *dashboardId, reportId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardIncomeReportResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardIncomeReportFile godoc
+//
+//	@Description	Download a completed income report of a specified dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard
+//	@Produce		text/csv,application/pdf
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			report_id		path		string	true	"The ID of the report."
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		404				{object}	types.ApiErrorResponse
+//	@Failure		409				{object}	types.ApiErrorResponse	"The report has not been completed yet."
+//	@Router			/validator-dashboards/{dashboard_id}/income-reports/{report_id}/file [get]
+func (h *HandlerService) PublicGetValidatorDashboardIncomeReportFile(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	ctx := r.Context()
+	dashboardId, err := h.handleDashboardId(ctx, vars["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	reportId := v.checkRegex(reUuid, vars["report_id"], "report_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	report, err := h.getDataAccessor(r).GetValidatorDashboardIncomeReport(ctx, *dashboardId, reportId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if report.Status != "completed" {
+		handleErr(w, r, newConflictErr("report is %s", report.Status))
+		return
+	}
+	file, err := h.getDataAccessor(r).GetValidatorDashboardIncomeReportFile(ctx, *dashboardId, reportId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(file.Data); err != nil {
+		logApiError(r, fmt.Errorf("error writing report file: %w", err), 0)
+	}
+}
+
 // ----------------------------------------------
 // Notifications
 // ----------------------------------------------
@@ -2519,6 +3174,10 @@ func (h *HandlerService) PublicGetUserNotificationSettingsDashboards(w http.Resp
 			settings.IsGroupEfficiencyBelowSubscribed = false
 			settings.GroupEfficiencyBelowThreshold = defaultSettings.GroupEfficiencyBelowThreshold
 		}
+		if !userInfo.PremiumPerks.NotificationsValidatorDashboardGroupEfficiency && settings.IsPerformanceAnomalySubscribed {
+			settings.IsPerformanceAnomalySubscribed = false
+			settings.PerformanceAnomalyThreshold = defaultSettings.PerformanceAnomalyThreshold
+		}
 		data[i].Settings = settings
 	}
 	response := types.InternalGetUserNotificationSettingsDashboardsResponse{
@@ -2555,6 +3214,9 @@ func (h *HandlerService) PublicPutUserNotificationSettingsValidatorDashboard(w h
 		return
 	}
 	checkMinMax(&v, req.GroupEfficiencyBelowThreshold, 0, 1, "group_offline_threshold")
+	if req.IsPerformanceAnomalySubscribed {
+		checkMinMax(&v, req.PerformanceAnomalyThreshold, 1, 10, "performance_anomaly_threshold")
+	}
 	vars := mux.Vars(r)
 	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
 	groupId := v.checkExistingGroupId(vars["group_id"])
@@ -2574,6 +3236,10 @@ func (h *HandlerService) PublicPutUserNotificationSettingsValidatorDashboard(w h
 		returnForbidden(w, r, errors.New("user does not have premium perks to subscribe group efficiency event"))
 		return
 	}
+	if !userInfo.PremiumPerks.NotificationsValidatorDashboardGroupEfficiency && req.IsPerformanceAnomalySubscribed {
+		returnForbidden(w, r, errors.New("user does not have premium perks to subscribe performance anomaly event"))
+		return
+	}
 
 	err = h.getDataAccessor(r).UpdateNotificationSettingsValidatorDashboard(r.Context(), userId, dashboardId, groupId, req)
 	if err != nil {
@@ -2586,6 +3252,97 @@ func (h *HandlerService) PublicPutUserNotificationSettingsValidatorDashboard(w h
 	returnOk(w, r, response)
 }
 
+// PublicGetUserNotificationSettingsValidatorDashboardTags godoc
+//
+//	@Description	Get the notification settings of a validator dashboard that only apply to validators with a specific tag for the authenticated user.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Success		200				{object}	types.InternalGetUserNotificationSettingsValidatorDashboardTagsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/tags [get]
+func (h *HandlerService) PublicGetUserNotificationSettingsValidatorDashboardTags(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetNotificationSettingsValidatorDashboardTags(r.Context(), userId, dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserNotificationSettingsValidatorDashboardTagsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicPutUserNotificationSettingsValidatorDashboardTag godoc
+//
+//	@Description	Update the notification settings that only apply to the validators of a specific group of a validator dashboard that have a specific tag for the authenticated user. Webhook settings are configured per group, group efficiency and performance anomaly notifications are not available for tags.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		string											true	"The ID of the dashboard."
+//	@Param			group_id		path		integer											true	"The ID of the group."
+//	@Param			tag_key			path		string											true	"The key of the tag."
+//	@Param			tag_value		path		string											true	"The value of the tag."
+//	@Param			request			body		types.NotificationSettingsValidatorDashboard	true	"Notification settings"
+//	@Success		200				{object}	types.InternalPutUserNotificationSettingsValidatorDashboardResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/tags/{tag_key}/{tag_value} [put]
+func (h *HandlerService) PublicPutUserNotificationSettingsValidatorDashboardTag(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	var req types.NotificationSettingsValidatorDashboard
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	tag := v.checkTag(vars["tag_key"], vars["tag_value"])
+	v.checkTagNotificationSettings(req)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, r, errors.New("group not found"))
+		return
+	}
+
+	err = h.getDataAccessor(r).UpdateNotificationSettingsValidatorDashboardTag(r.Context(), userId, dashboardId, groupId, tag, req)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPutUserNotificationSettingsValidatorDashboardResponse{
+		Data: req,
+	}
+	returnOk(w, r, response)
+}
+
 // PublicPutUserNotificationSettingsAccountDashboard godoc
 //
 //	@Description	Update the notification settings for a specific group of an account dashboard for the authenticated user.
@@ -2870,28 +3627,242 @@ func (h *HandlerService) PublicGetNetworkValidatorPerformanceHistory(w http.Resp
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkSlashings godoc
+//
+//	@Description	Get the slashings of a specified network, latest first. Each slashed validator is listed once, together with the validator that included the slashing, the penalties the slashed validator incurred since and the whistleblower reward.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkSlashingsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slashings [get]
 func (h *HandlerService) PublicGetNetworkSlashings(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkSlashings(r.Context(), chainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkSlashingsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkValidatorSlashings godoc
+//
+//	@Description	Get the slashing of a specified validator including the penalties it incurred per day since, as well as the slashings the validator included as block proposer.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			validator	path		string	true	"The index or public key of the validator."
+//	@Success		200			{object}	types.GetNetworkValidatorSlashingsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Failure		404			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validators/{validator}/slashings [get]
 func (h *HandlerService) PublicGetNetworkValidatorSlashings(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	index, pubkey := v.checkValidatorParameter(vars["validator"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	validator, err := h.getNetworkValidator(r, index, pubkey)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkValidatorSlashings(r.Context(), chainId, validator)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkValidatorSlashingsResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
-func (h *HandlerService) PublicGetNetworkDeposits(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+// getNetworkValidator resolves the index or public key returned by checkValidatorParameter to a validator index
+func (h *HandlerService) getNetworkValidator(r *http.Request, index *types.VDBValidator, pubkey string) (types.VDBValidator, error) {
+	var indices []types.VDBValidator
+	var pubkeys []string
+	if index != nil {
+		indices = append(indices, *index)
+	} else {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	validators, err := h.getDataAccessor(r).GetValidatorsFromSlices(r.Context(), indices, pubkeys)
+	if err != nil {
+		return 0, err
+	}
+	if len(validators) == 0 {
+		return 0, newNotFoundErr("validator not found")
+	}
+	return validators[0], nil
 }
 
-func (h *HandlerService) PublicGetNetworkValidatorDeposits(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+func (h *HandlerService) getNetworkDeposits(w http.ResponseWriter, r *http.Request, v *validationError, chainId uint64, filter types.NetworkDepositsFilter) {
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, paging, err := h.getDataAccessor(r).GetNetworkDeposits(r.Context(), chainId, filter, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkDepositsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkDeposits godoc
+//
+//	@Description	Get the execution layer deposits of a specified network, latest first. Deposits with an invalid signature are flagged, as they are ignored by the consensus layer, and deposits for already existing validators are marked as top-ups.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			address		query		string	false	"Only return deposits sent by or on behalf of this address."
+//	@Param			credential	query		string	false	"Only return deposits with this withdrawal credential."
+//	@Param			after_ts	query		string	false	"Only return deposits made at or after this timestamp."
+//	@Param			before_ts	query		string	false	"Only return deposits made at or before this timestamp."
+//	@Param			cursor		query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit		query		string	false	"The maximum number of results that may be returned."
+//	@Success		200			{object}	types.GetNetworkDepositsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/deposits [get]
+func (h *HandlerService) PublicGetNetworkDeposits(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	q := r.URL.Query()
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	var filter types.NetworkDepositsFilter
+	if address := q.Get("address"); address != "" {
+		filter.Address = common.FromHex(v.checkAddress(address))
+	}
+	if credential := q.Get("credential"); credential != "" {
+		filter.Credential = v.checkWithdrawalCredentialParameter(credential, "credential")
+	}
+	filter.AfterTs, filter.BeforeTs = v.checkNetworkTimeRange(q)
+	h.getNetworkDeposits(w, r, &v, chainId, filter)
+}
+
+// PublicGetNetworkValidatorDeposits godoc
+//
+//	@Description	Get the execution layer deposits of a specified validator, latest first. The validator can also be specified by the public key of a deposit that has not been processed by the consensus layer yet.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			validator	path		string	true	"The index or public key of the validator."
+//	@Param			cursor		query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit		query		string	false	"The maximum number of results that may be returned."
+//	@Success		200			{object}	types.GetNetworkDepositsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Failure		404			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validators/{validator}/deposits [get]
+func (h *HandlerService) PublicGetNetworkValidatorDeposits(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	var filter types.NetworkDepositsFilter
+	index, pubkey := v.checkValidatorParameter(vars["validator"])
+	if index != nil {
+		filter.Validator = index
+	} else if pubkey != "" {
+		filter.PublicKey = hexutil.MustDecode(pubkey)
+	}
+	h.getNetworkDeposits(w, r, &v, chainId, filter)
 }
 
+// PublicGetNetworkTransactionDeposits godoc
+//
+//	@Description	Get the deposits made in a specified execution layer transaction.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			hash	path		string	true	"The transaction hash."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkDepositsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/transactions/{hash}/deposits [get]
 func (h *HandlerService) PublicGetNetworkTransactionDeposits(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	var filter types.NetworkDepositsFilter
+	filter.TxHash = v.checkTransactionHashParameter(vars["hash"])
+	h.getNetworkDeposits(w, r, &v, chainId, filter)
+}
+
+func (h *HandlerService) getNetworkWithdrawals(w http.ResponseWriter, r *http.Request, v *validationError, chainId uint64, filter types.NetworkWithdrawalsFilter) {
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, paging, err := h.getDataAccessor(r).GetNetworkWithdrawals(r.Context(), chainId, filter, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkWithdrawalsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkWithdrawals godoc
+//
+//	@Description	Get the withdrawals of a specified network, latest first.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			address		query		string	false	"Only return withdrawals to this address."
+//	@Param			credential	query		string	false	"Only return withdrawals of validators that currently use this withdrawal credential."
+//	@Param			after_ts	query		string	false	"Only return withdrawals made at or after this timestamp."
+//	@Param			before_ts	query		string	false	"Only return withdrawals made at or before this timestamp."
+//	@Param			cursor		query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit		query		string	false	"The maximum number of results that may be returned."
+//	@Success		200			{object}	types.GetNetworkWithdrawalsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/withdrawals [get]
 func (h *HandlerService) PublicGetNetworkWithdrawals(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	q := r.URL.Query()
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	var filter types.NetworkWithdrawalsFilter
+	if address := q.Get("address"); address != "" {
+		filter.Address = common.FromHex(v.checkAddress(address))
+	}
+	if credential := q.Get("credential"); credential != "" {
+		filter.Credential = v.checkWithdrawalCredentialParameter(credential, "credential")
+	}
+	filter.AfterTs, filter.BeforeTs = v.checkNetworkTimeRange(q)
+	h.getNetworkWithdrawals(w, r, &v, chainId, filter)
 }
 
 func (h *HandlerService) PublicGetNetworkSlotWithdrawals(w http.ResponseWriter, r *http.Request) {
@@ -2905,120 +3876,1220 @@ func (h *HandlerService) PublicGetNetworkValidatorWithdrawals(w http.ResponseWri
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkWithdrawalCredentialWithdrawals godoc
+//
+//	@Description	Get the withdrawals of all validators that currently use a specified withdrawal credential, latest first.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			credential	path		string	true	"The withdrawal credential."
+//	@Param			after_ts	query		string	false	"Only return withdrawals made at or after this timestamp."
+//	@Param			before_ts	query		string	false	"Only return withdrawals made at or before this timestamp."
+//	@Param			cursor		query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit		query		string	false	"The maximum number of results that may be returned."
+//	@Success		200			{object}	types.GetNetworkWithdrawalsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/withdrawal-credentials/{credential}/withdrawals [get]
 func (h *HandlerService) PublicGetNetworkWithdrawalCredentialWithdrawals(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	var filter types.NetworkWithdrawalsFilter
+	filter.Credential = v.checkWithdrawalCredentialParameter(vars["credential"], "credential")
+	filter.AfterTs, filter.BeforeTs = v.checkNetworkTimeRange(r.URL.Query())
+	h.getNetworkWithdrawals(w, r, &v, chainId, filter)
+}
+
+// PublicGetNetworkWithdrawalCredentialTotals godoc
+//
+//	@Description	Get the number of validators that currently use a specified withdrawal credential as well as the total amounts deposited to and withdrawn from them.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			credential	path		string	true	"The withdrawal credential."
+//	@Success		200			{object}	types.GetNetworkWithdrawalCredentialTotalsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/withdrawal-credentials/{credential}/totals [get]
+func (h *HandlerService) PublicGetNetworkWithdrawalCredentialTotals(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	credential := v.checkWithdrawalCredentialParameter(vars["credential"], "credential")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetNetworkWithdrawalCredentialTotals(r.Context(), chainId, credential)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkWithdrawalCredentialTotalsResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkVoluntaryExits godoc
+//
+//	@Description	Get the voluntary exits of a specified network, latest first. For validators that are still waiting in the exit queue, their position in the queue and the estimated exit time are included.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkVoluntaryExitsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/voluntary-exits [get]
 func (h *HandlerService) PublicGetNetworkVoluntaryExits(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkVoluntaryExits(r.Context(), chainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkVoluntaryExitsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkEpochVoluntaryExits godoc
+//
+//	@Description	Get the voluntary exits included in a specified epoch.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			epoch	path		string	true	"The epoch."
+//	@Success		200		{object}	types.GetNetworkBlockVoluntaryExitsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/epochs/{epoch}/voluntary-exits [get]
 func (h *HandlerService) PublicGetNetworkEpochVoluntaryExits(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	epoch := v.checkUint(vars["epoch"], "epoch")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkEpochVoluntaryExits(r.Context(), chainId, epoch)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockVoluntaryExitsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkSlotVoluntaryExits godoc
+//
+//	@Description	Get the voluntary exits included in a specified slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or `latest`."
+//	@Success		200		{object}	types.GetNetworkBlockVoluntaryExitsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/voluntary-exits [get]
 func (h *HandlerService) PublicGetNetworkSlotVoluntaryExits(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkSlotVoluntaryExits(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockVoluntaryExitsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlockVoluntaryExits godoc
+//
+//	@Description	Get the voluntary exits included in a specified execution block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The block number or `latest`."
+//	@Success		200		{object}	types.GetNetworkBlockVoluntaryExitsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/voluntary-exits [get]
 func (h *HandlerService) PublicGetNetworkBlockVoluntaryExits(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkBlockVoluntaryExits(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockVoluntaryExitsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkAddressBalanceHistory godoc
+//
+//	@Description	Get the balance of a specified address at the end of each block it changed in, latest first.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			address	path		string	true	"The address."
+//	@Param			token	query		string	false	"The address of an ERC-20 token, the balance of the native currency is returned if omitted."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkAddressBalanceHistoryResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/addresses/{address}/balance-history [get]
 func (h *HandlerService) PublicGetNetworkAddressBalanceHistory(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+	chainId := v.checkNetworkParameter(vars["network"])
+	address := common.FromHex(v.checkAddress(vars["address"]))
+	token := []byte{0x0}
+	if tokenAddress := q.Get("token"); tokenAddress != "" {
+		token = common.FromHex(v.checkAddress(tokenAddress))
+	}
+	pagingParams := v.checkPagingParams(q)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkAddressBalanceHistory(r.Context(), chainId, address, token, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkAddressBalanceHistoryResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkAddressTokenSupplyHistory godoc
+//
+//	@Description	Get the total supply of a specified ERC-20 token at the end of each block tokens were minted or burned in, latest first.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			address	path		string	true	"The address of the token."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkAddressTokenSupplyHistoryResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/addresses/{address}/token-supply-history [get]
 func (h *HandlerService) PublicGetNetworkAddressTokenSupplyHistory(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	token := common.FromHex(v.checkAddress(vars["address"]))
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkAddressTokenSupplyHistory(r.Context(), chainId, token, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkAddressTokenSupplyHistoryResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkAddressEventLogs godoc
+//
+//	@Description	Get the event logs emitted by a specified contract, latest first. The logs are decoded with the verified abi of the contract if known, otherwise with the event signature.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			address	path		string	true	"The address of the contract."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkAddressEventLogsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/addresses/{address}/event-logs [get]
 func (h *HandlerService) PublicGetNetworkAddressEventLogs(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	address := common.FromHex(v.checkAddress(vars["address"]))
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkAddressEventLogs(r.Context(), chainId, address, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkAddressEventLogsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkTransactions godoc
+//
+//	@Description	Get the execution layer transactions of a specified network, latest first. Only a bounded number of blocks is searched per request.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkTransactionsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/transactions [get]
 func (h *HandlerService) PublicGetNetworkTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
 
-func (h *HandlerService) PublicGetNetworkTransaction(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
+	data, paging, err := h.getDataAccessor(r).GetNetworkTransactions(r.Context(), chainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkTransactionsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkTransaction godoc
+//
+//	@Description	Get a specified execution layer transaction. The input and the event logs are decoded with the verified abi of the contract if known, otherwise with the method or event signature.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			hash	path		string	true	"The transaction hash."
+//	@Success		200		{object}	types.GetNetworkTransactionResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/transactions/{hash} [get]
+func (h *HandlerService) PublicGetNetworkTransaction(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	txHash := v.checkTransactionHashParameter(vars["hash"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkTransaction(r.Context(), chainId, txHash)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkTransactionResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
 
+// PublicGetNetworkAddressTransactions godoc
+//
+//	@Description	Get the execution layer activity of a specified address, latest first. By default normal transactions, internal transactions and token transfers are combined into a single list.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			address	path		string	true	"The address."
+//	@Param			type	query		string	false	"The type of activity to return, all types are returned if omitted."	Enums(all, general, internal, erc20, erc721, erc1155)
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate forward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkAddressTransactionsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/addresses/{address}/transactions [get]
 func (h *HandlerService) PublicGetNetworkAddressTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+	chainId := v.checkNetworkParameter(vars["network"])
+	address := common.FromHex(v.checkAddress(vars["address"]))
+	txType := checkEnum[enums.AddressTransactionType](&v, q.Get("type"), "type")
+	pagingParams := v.checkPagingParams(q)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkAddressTransactions(r.Context(), chainId, address, txType, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkAddressTransactionsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkSlotTransactions godoc
+//
+//	@Description	Get the execution layer transactions included in a specified slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or `latest`."
+//	@Success		200		{object}	types.GetNetworkBlockTransactionsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/transactions [get]
 func (h *HandlerService) PublicGetNetworkSlotTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkSlotTransactions(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockTransactionsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlockTransactions godoc
+//
+//	@Description	Get the transactions of a specified execution block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The block number or `latest`."
+//	@Success		200		{object}	types.GetNetworkBlockTransactionsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/transactions [get]
 func (h *HandlerService) PublicGetNetworkBlockTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkBlockTransactions(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockTransactionsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkBlob godoc
+//
+//	@Description	Get an archived blob by its versioned hash, including the sidecar metadata. Blobs remain available after beacon nodes pruned them.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network			path		string	true	"The network name or chain id."
+//	@Param			versioned_hash	path		string	true	"The versioned hash of the blob."
+//	@Success		200				{object}	types.GetNetworkBlobResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		404				{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blobs/{versioned_hash} [get]
+func (h *HandlerService) PublicGetNetworkBlob(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	versionedHash := v.checkVersionedHashParameter(vars["versioned_hash"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkBlob(r.Context(), chainId, versionedHash)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlobResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkSlotBlobs godoc
+//
+//	@Description	Get the blobs of a specified slot, including the sidecar metadata. The blob data is omitted for blobs that have not been archived yet.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or `latest`."
+//	@Success		200		{object}	types.GetNetworkBlockBlobsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/blobs [get]
+func (h *HandlerService) PublicGetNetworkSlotBlobs(w http.ResponseWriter, r *http.Request) {
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkSlotBlobs(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockBlobsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlockBlobs godoc
+//
+//	@Description	Get the blobs of a specified execution block, including the sidecar metadata. The blob data is omitted for blobs that have not been archived yet.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The block number or `latest`."
+//	@Success		200		{object}	types.GetNetworkBlockBlobsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/blobs [get]
 func (h *HandlerService) PublicGetNetworkBlockBlobs(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkBlockBlobs(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockBlobsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkBlobBlocks godoc
+//
+//	@Description	Get the blob fee market data of the blocks of a specified network since the deneb fork, latest first.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkBlobBlocksResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blob-blocks [get]
+func (h *HandlerService) PublicGetNetworkBlobBlocks(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkBlobBlocks(r.Context(), chainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlobBlocksResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkBlobHistory godoc
+//
+//	@Description	Get the daily blob usage and blob fee market history of a specified network.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			after_ts	query		string	false	"Only return days starting at or after this timestamp."
+//	@Param			before_ts	query		string	false	"Only return days starting at or before this timestamp."
+//	@Success		200			{object}	types.GetNetworkBlobHistoryResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blob-history [get]
+func (h *HandlerService) PublicGetNetworkBlobHistory(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	afterTs, beforeTs := v.checkNetworkTimeRange(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkBlobHistory(r.Context(), chainId, afterTs, beforeTs)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlobHistoryResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlobSubmitters godoc
+//
+//	@Description	Get the addresses that submitted the most blobs on a specified network, rollups are identified by their address label. Data is aggregated per finished day, so the period is counted back from the latest exported day.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			period	query		string	true	"Time period to get data for."	Enums(all_time, last_30d, last_7d, last_24h)
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkBlobSubmittersResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blob-submitters [get]
+func (h *HandlerService) PublicGetNetworkBlobSubmitters(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	q := r.URL.Query()
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	period := checkEnum[enums.TimePeriod](&v, q.Get("period"), "period")
+	pagingParams := v.checkPagingParams(q)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkBlobSubmitters(r.Context(), chainId, period, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlobSubmittersResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkBlsChanges godoc
+//
+//	@Description	Get the BLS to execution changes of a specified network, latest first.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkBlsChangesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/bls-changes [get]
 func (h *HandlerService) PublicGetNetworkBlsChanges(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkBlsChanges(r.Context(), chainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlsChangesResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkEpochBlsChanges godoc
+//
+//	@Description	Get the BLS to execution changes included in a specified epoch.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			epoch	path		string	true	"The epoch."
+//	@Success		200		{object}	types.GetNetworkBlockBlsChangesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/epochs/{epoch}/bls-changes [get]
 func (h *HandlerService) PublicGetNetworkEpochBlsChanges(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	epoch := v.checkUint(vars["epoch"], "epoch")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkEpochBlsChanges(r.Context(), chainId, epoch)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockBlsChangesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkSlotBlsChanges godoc
+//
+//	@Description	Get the BLS to execution changes included in a specified slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or `latest`."
+//	@Success		200		{object}	types.GetNetworkBlockBlsChangesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/bls-changes [get]
+func (h *HandlerService) PublicGetNetworkSlotBlsChanges(w http.ResponseWriter, r *http.Request) {
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkSlotBlsChanges(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockBlsChangesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkBlockBlsChanges godoc
+//
+//	@Description	Get the BLS to execution changes included in a specified execution block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The block number or `latest`."
+//	@Success		200		{object}	types.GetNetworkBlockBlsChangesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/bls-changes [get]
+func (h *HandlerService) PublicGetNetworkBlockBlsChanges(w http.ResponseWriter, r *http.Request) {
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkBlockBlsChanges(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockBlsChangesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
-func (h *HandlerService) PublicGetNetworkSlotBlsChanges(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+// PublicGetNetworkValidatorBlsChanges godoc
+//
+//	@Description	Get the BLS to execution change of a specified validator. The list is empty if the validator has not changed its withdrawal credentials yet.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			validator	path		string	true	"The index or public key of the validator."
+//	@Success		200			{object}	types.GetNetworkBlockBlsChangesResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Failure		404			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validators/{validator}/bls-changes [get]
+func (h *HandlerService) PublicGetNetworkValidatorBlsChanges(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	index, pubkey := v.checkValidatorParameter(vars["validator"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	validator, err := h.getNetworkValidator(r, index, pubkey)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkValidatorBlsChanges(r.Context(), chainId, validator)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBlockBlsChangesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkAddressEns godoc
+//
+//	@Description	Get the ENS names that resolve to a specified address on Ethereum, including its primary name the address reverse resolves to. Only .eth names are indexed.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			address	path		string	true	"The address."
+//	@Success		200		{object}	types.GetNetworkAddressEnsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/ethereum/addresses/{address}/ens [get]
+func (h *HandlerService) PublicGetNetworkAddressEns(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	address := common.FromHex(v.checkAddress(mux.Vars(r)["address"]))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkAddressEns(r.Context(), address)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkAddressEnsResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkEns godoc
+//
+//	@Description	Get the address, expiry, resolver and text records of a specified ENS name on Ethereum. Only .eth names are indexed.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			ens_name	path		string	true	"The ENS name, e.g. `vitalik.eth`."
+//	@Success		200			{object}	types.GetNetworkEnsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Failure		404			{object}	types.ApiErrorResponse
+//	@Router			/networks/ethereum/ens/{ens_name} [get]
+func (h *HandlerService) PublicGetNetworkEns(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	name := v.checkEnsName(mux.Vars(r)["ens_name"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkEns(r.Context(), name)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkEnsResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkBatches godoc
+//
+//	@Description	Get the batches a specified layer 2 network submitted to layer 1, latest first. Batches stored in blobs reference the versioned hashes of their blobs.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			layer_2_network	path		string	true	"The layer 2 network name or chain id."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Success		200				{object}	types.GetNetworkBatchesResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/networks/{layer_2_network}/batches [get]
+func (h *HandlerService) PublicGetNetworkBatches(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	layer2ChainId := v.checkLayer2NetworkParameter(mux.Vars(r)["layer_2_network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkBatches(r.Context(), layer2ChainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBatchesResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkLayer2ToLayer1Transactions godoc
+//
+//	@Description	Get the withdrawals of a specified layer 2 network that were proven or finalized on layer 1, latest first.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			layer_2_network	path		string	true	"The layer 2 network name or chain id."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Success		200				{object}	types.GetNetworkLayer2ToLayer1TransactionsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/networks/{layer_2_network}/layer2-to-layer1-transactions [get]
+func (h *HandlerService) PublicGetNetworkLayer2ToLayer1Transactions(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	layer2ChainId := v.checkLayer2NetworkParameter(mux.Vars(r)["layer_2_network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetNetworkLayer2ToLayer1Transactions(r.Context(), layer2ChainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkLayer2ToLayer1TransactionsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
-func (h *HandlerService) PublicGetNetworkBlockBlsChanges(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
+// PublicGetNetworkAddressUserOperations godoc
+//
+//	@Description	Get the ERC-4337 user operations of a specified address, latest first. By default the operations of the address as smart contract account are returned.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			address	path		string	true	"The address."
+//	@Param			role	query		string	false	"The role of the address in the user operations."	Enums(sender, paymaster, bundler)
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkAddressUserOperationsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/addresses/{address}/user-operations [get]
+func (h *HandlerService) PublicGetNetworkAddressUserOperations(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+	chainId := v.checkNetworkParameter(vars["network"])
+	address := common.FromHex(v.checkAddress(vars["address"]))
+	role := checkEnum[enums.UserOperationRole](&v, q.Get("role"), "role")
+	pagingParams := v.checkPagingParams(q)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
 
-func (h *HandlerService) PublicGetNetworkValidatorBlsChanges(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	data, paging, err := h.getDataAccessor(r).GetNetworkAddressUserOperations(r.Context(), chainId, address, role, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkAddressUserOperationsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
-func (h *HandlerService) PublicGetNetworkAddressEns(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
+// PublicGetNetworkAddressSponsorship godoc
+//
+//	@Description	Get the gas a specified ERC-4337 paymaster sponsored for user operations. Data is aggregated per finished day, so the period is counted back from the latest exported day.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			address	path		string	true	"The address of the paymaster."
+//	@Param			period	query		string	true	"Time period to get data for."	Enums(all_time, last_30d, last_7d, last_24h)
+//	@Success		200		{object}	types.GetNetworkAddressSponsorshipResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/addresses/{address}/sponsorship [get]
+func (h *HandlerService) PublicGetNetworkAddressSponsorship(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	address := common.FromHex(v.checkAddress(vars["address"]))
+	period := checkEnum[enums.TimePeriod](&v, r.URL.Query().Get("period"), "period")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
 
-func (h *HandlerService) PublicGetNetworkEns(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	data, err := h.getDataAccessor(r).GetNetworkAddressSponsorship(r.Context(), chainId, address, period)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkAddressSponsorshipResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
-func (h *HandlerService) PublicGetNetworkBatches(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
+// PublicGetNetworkLayer1ToLayer2Transactions godoc
+//
+//	@Description	Get the deposits from layer 1 to a specified layer 2 network, latest first.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			layer_2_network	path		string	true	"The layer 2 network name or chain id."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Success		200				{object}	types.GetNetworkLayer1ToLayer2TransactionsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/networks/{layer_2_network}/layer1-to-layer2-transactions [get]
+func (h *HandlerService) PublicGetNetworkLayer1ToLayer2Transactions(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	layer2ChainId := v.checkLayer2NetworkParameter(mux.Vars(r)["layer_2_network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
 
-func (h *HandlerService) PublicGetNetworkLayer2ToLayer1Transactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	data, paging, err := h.getDataAccessor(r).GetNetworkLayer1ToLayer2Transactions(r.Context(), layer2ChainId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkLayer1ToLayer2TransactionsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
-func (h *HandlerService) PublicGetNetworkLayer1ToLayer2Transactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+// PublicPostNetworkBroadcasts godoc
+//
+//	@Description	Broadcast pre-signed operations to a specified network. Signatures are verified against the genesis validators root and fork domains of the network before the operations are forwarded to the beacon or execution node. Broadcasting an operation that is already queued returns the existing broadcast.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Accept			json
+//	@Produce		json
+//	@Param			network	path		string											true	"The network name or chain id."
+//	@Param			request	body		handlers.PublicPostNetworkBroadcasts.request	true	"`data`: Provide exactly one of the following:<ul><li>A list of signed BLS to execution changes.</li><li>A single signed voluntary exit.</li><li>A hex encoded raw signed execution layer transaction.</li><li>A list of withdrawal requests (EIP-7002) or consolidation requests (EIP-7251). These are validated against the current validator state and the returned `call_data` has to be sent by the withdrawal address of each validator.</li></ul>"
+//	@Success		201		{object}	types.PostNetworkBroadcastsResponse				"Returns the broadcast including its `id`, which can be used to poll the status."
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/broadcasts [post]
+func (h *HandlerService) PublicPostNetworkBroadcasts(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	type request struct {
+		Data json.RawMessage `json:"data"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if len(req.Data) == 0 {
+		v.add("data", "must not be empty")
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).CreateNetworkBroadcast(r.Context(), chainId, req.Data)
+	var userErr commontypes.CreateNodeJobUserError
+	if errors.As(err, &userErr) {
+		handleErr(w, r, newBadRequestErr("%s", userErr.Message))
+		return
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.PostNetworkBroadcastsResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
 }
 
-func (h *HandlerService) PublicPostNetworkBroadcasts(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
+// PublicGetNetworkBroadcast godoc
+//
+//	@Description	Get the status of a broadcast on a specified network.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network			path		string	true	"The network name or chain id."
+//	@Param			broadcast_id	path		string	true	"The ID of the broadcast."
+//	@Success		200				{object}	types.GetNetworkBroadcastResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		404				{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/broadcasts/{broadcast_id} [get]
+func (h *HandlerService) PublicGetNetworkBroadcast(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	broadcastId := v.checkRegex(reUuid, vars["broadcast_id"], "broadcast_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetNetworkBroadcast(r.Context(), chainId, broadcastId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBroadcastResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetEthPriceHistory(w http.ResponseWriter, r *http.Request) {
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkGasNow godoc
+//
+//	@Description	Get the current gas price recommendations of a specified network, derived from the priority fees of recent blocks and the pending mempool. Includes the base fee range of the upcoming blocks.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Success		200		{object}	types.GetNetworkGasNowResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/gasnow [get]
 func (h *HandlerService) PublicGetNetworkGasNow(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkGasNow(r.Context(), chainId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkGasNowResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkAverageGasLimitHistory godoc
+//
+//	@Description	Get the daily average block gas limit history of a specified network.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			after_ts	query		string	false	"Only return days starting at or after this timestamp."
+//	@Param			before_ts	query		string	false	"Only return days starting at or before this timestamp."
+//	@Success		200			{object}	types.GetNetworkAverageGasLimitHistoryResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/average-gas-limit-history [get]
 func (h *HandlerService) PublicGetNetworkAverageGasLimitHistory(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	afterTs, beforeTs := v.checkNetworkTimeRange(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkAverageGasLimitHistory(r.Context(), chainId, afterTs, beforeTs)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkAverageGasLimitHistoryResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkGasUsedHistory godoc
+//
+//	@Description	Get the daily gas usage and block utilization history of a specified network.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Network
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			after_ts	query		string	false	"Only return days starting at or after this timestamp."
+//	@Param			before_ts	query		string	false	"Only return days starting at or before this timestamp."
+//	@Success		200			{object}	types.GetNetworkGasUsedHistoryResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/gas-used-history [get]
 func (h *HandlerService) PublicGetNetworkGasUsedHistory(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	afterTs, beforeTs := v.checkNetworkTimeRange(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetNetworkGasUsedHistory(r.Context(), chainId, afterTs, beforeTs)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkGasUsedHistoryResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetRocketPool(w http.ResponseWriter, r *http.Request) {
@@ -3037,14 +5108,95 @@ func (h *HandlerService) PublicGetNetworkSyncCommittee(w http.ResponseWriter, r
 	returnOk(w, r, nil)
 }
 
+// PublicGetMultisigSafe godoc
+//
+//	@Description	Get the owners and the threshold of a specified Safe multisig, replayed from its indexed events.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Multisig
+//	@Produce		json
+//	@Param			address	path		string	true	"The address of the safe."
+//	@Success		200		{object}	types.GetMultisigSafeResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/multisig-safes/{address} [get]
 func (h *HandlerService) PublicGetMultisigSafe(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	address := common.FromHex(v.checkAddress(mux.Vars(r)["address"]))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetMultisigSafe(r.Context(), address)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetMultisigSafeResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetMultisigSafeTransactions godoc
+//
+//	@Description	Get the executed transactions of a specified Safe multisig, latest first. Call details and confirmations are only available if the safe was called directly.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Multisig
+//	@Produce		json
+//	@Param			address	path		string	true	"The address of the safe."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		string	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetMultisigSafeTransactionsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/multisig-safes/{address}/transactions [get]
 func (h *HandlerService) PublicGetMultisigSafeTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	address := common.FromHex(v.checkAddress(mux.Vars(r)["address"]))
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetMultisigSafeTransactions(r.Context(), address, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetMultisigSafeTransactionsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetMultisigTransactionConfirmations godoc
+//
+//	@Description	Get the owners that confirmed a specified Safe transaction, either by signature or by an on-chain approval. Pending transactions only list on-chain approvals.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Multisig
+//	@Produce		json
+//	@Param			hash	path		string	true	"The safe transaction hash."
+//	@Success		200		{object}	types.GetMultisigTransactionConfirmationsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/multisig-transactions/{hash}/confirmations [get]
 func (h *HandlerService) PublicGetMultisigTransactionConfirmations(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	safeTxHash := v.checkTransactionHashParameter(mux.Vars(r)["hash"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetMultisigTransactionConfirmations(r.Context(), safeTxHash)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetMultisigTransactionConfirmationsResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
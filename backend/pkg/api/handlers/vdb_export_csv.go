@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+)
+
+var validatorDashboardExportCsvHeader = []string{"group_id", "group_name", "validator_index", "public_key"}
+
+// writeValidatorDashboardExportCsv writes one row per validator, groups without validators are omitted
+func writeValidatorDashboardExportCsv(w io.Writer, export *types.VDBExport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(validatorDashboardExportCsvHeader); err != nil {
+		return err
+	}
+	for _, group := range export.Groups {
+		for _, validator := range group.Validators {
+			err := cw.Write([]string{
+				strconv.FormatUint(group.Id, 10),
+				group.Name,
+				strconv.FormatUint(validator.Index, 10),
+				string(validator.PublicKey),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// parseValidatorDashboardImportCsv reads the format written by writeValidatorDashboardExportCsv, validators are grouped by group name.
+// Either validator_index or public_key may be empty.
+func parseValidatorDashboardImportCsv(v *validationError, r io.Reader) types.VDBExport {
+	export := types.VDBExport{Version: 1}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(validatorDashboardExportCsvHeader)
+	header, err := cr.Read()
+	if err != nil {
+		v.add("request body", "invalid csv header")
+		return export
+	}
+	for i, column := range validatorDashboardExportCsvHeader {
+		if header[i] != column {
+			v.add("request body", fmt.Sprintf("csv header must be '%s,%s,%s,%s'", validatorDashboardExportCsvHeader[0], validatorDashboardExportCsvHeader[1], validatorDashboardExportCsvHeader[2], validatorDashboardExportCsvHeader[3]))
+			return export
+		}
+	}
+
+	groupIndices := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			v.add("request body", fmt.Sprintf("invalid csv in line %d", line))
+			return export
+		}
+		// validators are resolved by public key, the index is only exported for reference
+		if record[3] == "" {
+			v.add("request body", fmt.Sprintf("public_key must be set in line %d", line))
+			return export
+		}
+		validator := types.VDBExportValidator{PublicKey: types.PubKey(record[3])}
+		i, ok := groupIndices[record[1]]
+		if !ok {
+			i = len(export.Groups)
+			groupIndices[record[1]] = i
+			export.Groups = append(export.Groups, types.VDBExportGroup{Name: record[1]})
+		}
+		export.Groups[i].Validators = append(export.Groups[i].Validators, validator)
+	}
+	return export
+}
@@ -46,6 +46,7 @@ func NewApiRouter(dataAccessor dataaccess.DataAccessor, dummy dataaccess.DataAcc
 
 	addRoutes(handlerService, publicRouter, internalRouter, cfg)
 	addLegacyRoutes(handlerService, legacyRouter)
+	addWebhookRoutes(handlerService, apiRouter.PathPrefix("/webhooks").Subrouter())
 
 	// serve static files
 	publicRouter.PathPrefix("/docs/").Handler(http.StripPrefix("/api/v2/docs/", http.FileServer(http.FS(docs.Files))))
@@ -128,6 +129,7 @@ func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Ro
 		{http.MethodPost, "/users/me/email", nil, hs.InternalPostUserEmail},
 		{http.MethodPut, "/users/me/password", nil, hs.InternalPutUserPassword},
 		{http.MethodGet, "/users/me/dashboards", hs.PublicGetUserDashboards, hs.InternalGetUserDashboards},
+		{http.MethodGet, "/users/me/api-usage", hs.PublicGetUserApiUsage, hs.InternalGetUserApiUsage},
 		{http.MethodPut, "/users/me/notifications/settings/paired-devices/{client_id}/token", nil, hs.InternalPostUsersMeNotificationSettingsPairedDevicesToken},
 
 		{http.MethodGet, "/users/me/machine-metrics", hs.PublicGetUserMachineMetrics, hs.InternalGetUserMachineMetrics},
@@ -191,51 +193,63 @@ func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Ro
 		{http.MethodGet, "/networks/{network}/slashings", hs.PublicGetNetworkSlashings, nil},
 		{http.MethodGet, "/networks/{network}/validators/{validator}/slashings", hs.PublicGetNetworkValidatorSlashings, nil},
 
-		{http.MethodGet, "/networks/{network}/deposits", hs.PublicGetNetworkDeposits, nil},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/deposits", hs.PublicGetNetworkValidatorDeposits, nil},
-		{http.MethodGet, "/networks/{network}/transactions/{hash}/deposits", hs.PublicGetNetworkTransactionDeposits, nil},
+		{http.MethodGet, "/networks/{network}/deposits", hs.PublicGetNetworkDeposits, hs.InternalGetNetworkDeposits},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/deposits", hs.PublicGetNetworkValidatorDeposits, hs.InternalGetNetworkValidatorDeposits},
+		{http.MethodGet, "/networks/{network}/transactions/{hash}/deposits", hs.PublicGetNetworkTransactionDeposits, hs.InternalGetNetworkTransactionDeposits},
 
-		{http.MethodGet, "/networks/{network}/withdrawals", hs.PublicGetNetworkWithdrawals, nil},
+		{http.MethodGet, "/networks/{network}/withdrawals", hs.PublicGetNetworkWithdrawals, hs.InternalGetNetworkWithdrawals},
 		{http.MethodGet, "/networks/{network}/slots/{slot}/withdrawals", hs.PublicGetNetworkSlotWithdrawals, hs.InternalGetSlotWithdrawals},
 		{http.MethodGet, "/networks/{network}/blocks/{block}/withdrawals", hs.PublicGetNetworkBlockWithdrawals, hs.InternalGetBlockWithdrawals},
 		{http.MethodGet, "/networks/{network}/validators/{validator}/withdrawals", hs.PublicGetNetworkValidatorWithdrawals, nil},
-		{http.MethodGet, "/networks/{network}/withdrawal-credentials/{credential}/withdrawals", hs.PublicGetNetworkWithdrawalCredentialWithdrawals, nil},
+		{http.MethodGet, "/networks/{network}/withdrawal-credentials/{credential}/withdrawals", hs.PublicGetNetworkWithdrawalCredentialWithdrawals, hs.InternalGetNetworkWithdrawalCredentialWithdrawals},
+		{http.MethodGet, "/networks/{network}/withdrawal-credentials/{credential}/totals", hs.PublicGetNetworkWithdrawalCredentialTotals, hs.InternalGetNetworkWithdrawalCredentialTotals},
 
 		{http.MethodGet, "/networks/{network}/voluntary-exits", hs.PublicGetNetworkVoluntaryExits, nil},
 		{http.MethodGet, "/networks/{network}/epochs/{epoch}/voluntary-exits", hs.PublicGetNetworkEpochVoluntaryExits, nil},
 		{http.MethodGet, "/networks/{network}/slots/{slot}/voluntary-exits", hs.PublicGetNetworkSlotVoluntaryExits, hs.InternalGetSlotVoluntaryExits},
 		{http.MethodGet, "/networks/{network}/blocks/{block}/voluntary-exits", hs.PublicGetNetworkBlockVoluntaryExits, hs.InternalGetBlockVoluntaryExits},
 
-		{http.MethodGet, "/networks/{network}/addresses/{address}/balance-history", hs.PublicGetNetworkAddressBalanceHistory, nil},
-		{http.MethodGet, "/networks/{network}/addresses/{address}/token-supply-history", hs.PublicGetNetworkAddressTokenSupplyHistory, nil},
-		{http.MethodGet, "/networks/{network}/addresses/{address}/event-logs", hs.PublicGetNetworkAddressEventLogs, nil},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/balance-history", hs.PublicGetNetworkAddressBalanceHistory, hs.InternalGetNetworkAddressBalanceHistory},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/token-supply-history", hs.PublicGetNetworkAddressTokenSupplyHistory, hs.InternalGetNetworkAddressTokenSupplyHistory},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/event-logs", hs.PublicGetNetworkAddressEventLogs, hs.InternalGetNetworkAddressEventLogs},
 
-		{http.MethodGet, "/networks/{network}/transactions", hs.PublicGetNetworkTransactions, nil},
-		{http.MethodGet, "/networks/{network}/transactions/{hash}", hs.PublicGetNetworkTransaction, nil},
-		{http.MethodGet, "/networks/{network}/addresses/{address}/transactions", hs.PublicGetNetworkAddressTransactions, nil},
+		{http.MethodGet, "/networks/{network}/transactions", hs.PublicGetNetworkTransactions, hs.InternalGetNetworkTransactions},
+		{http.MethodGet, "/networks/{network}/transactions/{hash}", hs.PublicGetNetworkTransaction, hs.InternalGetNetworkTransaction},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/transactions", hs.PublicGetNetworkAddressTransactions, hs.InternalGetNetworkAddressTransactions},
 		{http.MethodGet, "/networks/{network}/slots/{slot}/transactions", hs.PublicGetNetworkSlotTransactions, hs.InternalGetSlotTransactions},
 		{http.MethodGet, "/networks/{network}/blocks/{block}/transactions", hs.PublicGetNetworkBlockTransactions, hs.InternalGetBlockTransactions},
+		{http.MethodGet, "/networks/{network}/blobs/{versioned_hash}", hs.PublicGetNetworkBlob, hs.InternalGetNetworkBlob},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/blobs", hs.PublicGetNetworkSlotBlobs, hs.InternalGetSlotBlobs},
 		{http.MethodGet, "/networks/{network}/blocks/{block}/blobs", hs.PublicGetNetworkBlockBlobs, hs.InternalGetBlockBlobs},
+		{http.MethodGet, "/networks/{network}/blob-blocks", hs.PublicGetNetworkBlobBlocks, hs.InternalGetNetworkBlobBlocks},
+		{http.MethodGet, "/networks/{network}/blob-history", hs.PublicGetNetworkBlobHistory, hs.InternalGetNetworkBlobHistory},
+		{http.MethodGet, "/networks/{network}/blob-submitters", hs.PublicGetNetworkBlobSubmitters, hs.InternalGetNetworkBlobSubmitters},
 
-		{http.MethodGet, "/networks/{network}/handlerService-changes", hs.PublicGetNetworkBlsChanges, nil},
-		{http.MethodGet, "/networks/{network}/epochs/{epoch}/handlerService-changes", hs.PublicGetNetworkEpochBlsChanges, nil},
-		{http.MethodGet, "/networks/{network}/slots/{slot}/handlerService-changes", hs.PublicGetNetworkSlotBlsChanges, hs.InternalGetSlotBlsChanges},
-		{http.MethodGet, "/networks/{network}/blocks/{block}/handlerService-changes", hs.PublicGetNetworkBlockBlsChanges, hs.InternalGetBlockBlsChanges},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/handlerService-changes", hs.PublicGetNetworkValidatorBlsChanges, nil},
+		{http.MethodPut, "/networks/{network}/contracts/{address}/abi", nil, hs.InternalPutNetworkContractAbi},
 
-		{http.MethodGet, "/networks/ethereum/addresses/{address}/ens", hs.PublicGetNetworkAddressEns, nil},
-		{http.MethodGet, "/networks/ethereum/ens/{ens_name}", hs.PublicGetNetworkEns, nil},
+		{http.MethodGet, "/networks/{network}/bls-changes", hs.PublicGetNetworkBlsChanges, nil},
+		{http.MethodGet, "/networks/{network}/epochs/{epoch}/bls-changes", hs.PublicGetNetworkEpochBlsChanges, nil},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/bls-changes", hs.PublicGetNetworkSlotBlsChanges, hs.InternalGetSlotBlsChanges},
+		{http.MethodGet, "/networks/{network}/blocks/{block}/bls-changes", hs.PublicGetNetworkBlockBlsChanges, hs.InternalGetBlockBlsChanges},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/bls-changes", hs.PublicGetNetworkValidatorBlsChanges, nil},
 
-		{http.MethodGet, "/networks/{layer_2_network}/batches", hs.PublicGetNetworkBatches, nil},
-		{http.MethodGet, "/networks/{layer_2_network}/layer1-to-layer2-transactions", hs.PublicGetNetworkLayer1ToLayer2Transactions, nil},
-		{http.MethodGet, "/networks/{layer_2_network}/layer2-to-layer1-transactions", hs.PublicGetNetworkLayer2ToLayer1Transactions, nil},
+		{http.MethodGet, "/networks/ethereum/addresses/{address}/ens", hs.PublicGetNetworkAddressEns, hs.InternalGetNetworkAddressEns},
+		{http.MethodGet, "/networks/ethereum/ens/{ens_name}", hs.PublicGetNetworkEns, hs.InternalGetNetworkEns},
+
+		{http.MethodGet, "/networks/{layer_2_network}/batches", hs.PublicGetNetworkBatches, hs.InternalGetNetworkBatches},
+		{http.MethodGet, "/networks/{layer_2_network}/layer1-to-layer2-transactions", hs.PublicGetNetworkLayer1ToLayer2Transactions, hs.InternalGetNetworkLayer1ToLayer2Transactions},
+		{http.MethodGet, "/networks/{layer_2_network}/layer2-to-layer1-transactions", hs.PublicGetNetworkLayer2ToLayer1Transactions, hs.InternalGetNetworkLayer2ToLayer1Transactions},
+
+		{http.MethodGet, "/networks/{network}/addresses/{address}/user-operations", hs.PublicGetNetworkAddressUserOperations, hs.InternalGetNetworkAddressUserOperations},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/sponsorship", hs.PublicGetNetworkAddressSponsorship, hs.InternalGetNetworkAddressSponsorship},
 
 		{http.MethodPost, "/networks/{network}/broadcasts", hs.PublicPostNetworkBroadcasts, nil},
+		{http.MethodGet, "/networks/{network}/broadcasts/{broadcast_id}", hs.PublicGetNetworkBroadcast, nil},
 		{http.MethodGet, "/eth-price-history", hs.PublicGetEthPriceHistory, nil},
 
-		{http.MethodGet, "/networks/{network}/gasnow", hs.PublicGetNetworkGasNow, nil},
-		{http.MethodGet, "/networks/{network}/average-gas-limit-history", hs.PublicGetNetworkAverageGasLimitHistory, nil},
-		{http.MethodGet, "/networks/{network}/gas-used-history", hs.PublicGetNetworkGasUsedHistory, nil},
+		{http.MethodGet, "/networks/{network}/gasnow", hs.PublicGetNetworkGasNow, hs.InternalGetNetworkGasNow},
+		{http.MethodGet, "/networks/{network}/average-gas-limit-history", hs.PublicGetNetworkAverageGasLimitHistory, hs.InternalGetNetworkAverageGasLimitHistory},
+		{http.MethodGet, "/networks/{network}/gas-used-history", hs.PublicGetNetworkGasUsedHistory, hs.InternalGetNetworkGasUsedHistory},
 
 		{http.MethodGet, "/rocket-pool", hs.PublicGetRocketPool, hs.InternalGetRocketPool},
 		{http.MethodGet, "/rocket-pool/nodes", hs.PublicGetRocketPoolNodes, nil},
@@ -243,9 +257,9 @@ func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Ro
 
 		{http.MethodGet, "/networks/{network}/sync-committee/{period}", hs.PublicGetNetworkSyncCommittee, nil},
 
-		{http.MethodGet, "/multisig-safes/{address}", hs.PublicGetMultisigSafe, nil},
-		{http.MethodGet, "/multisig-safes/{address}/transactions", hs.PublicGetMultisigSafeTransactions, nil},
-		{http.MethodGet, "/multisig-transactions/{hash}/confirmations", hs.PublicGetMultisigTransactionConfirmations, nil},
+		{http.MethodGet, "/multisig-safes/{address}", hs.PublicGetMultisigSafe, hs.InternalGetMultisigSafe},
+		{http.MethodGet, "/multisig-safes/{address}/transactions", hs.PublicGetMultisigSafeTransactions, hs.InternalGetMultisigSafeTransactions},
+		{http.MethodGet, "/multisig-transactions/{hash}/confirmations", hs.PublicGetMultisigTransactionConfirmations, hs.InternalGetMultisigTransactionConfirmations},
 	}
 	addEndpointsToRouters(endpoints, publicRouter, internalRouter)
 }
@@ -255,6 +269,11 @@ func addLegacyRoutes(hs *handlers.HandlerService, publicRouter *mux.Router) {
 	publicRouter.HandleFunc("/client/metrics", hs.LegacyPostUserMachineMetrics).Methods(http.MethodPost, http.MethodOptions)
 }
 
+// Webhook routes are called by third party services, they are not session based and authenticate requests themselves
+func addWebhookRoutes(hs *handlers.HandlerService, webhookRouter *mux.Router) {
+	webhookRouter.HandleFunc("/mailgun", hs.WebhookPostMailgunEvents).Methods(http.MethodPost)
+}
+
 func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Router, cfg *types.Config) {
 	vdbPath := "/validator-dashboards"
 	publicRouter.HandleFunc(vdbPath, hs.PublicPostValidatorDashboards).Methods(http.MethodPost, http.MethodOptions)
@@ -272,6 +291,8 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 	archivalEndpoints := []endpoint{
 		{http.MethodDelete, "/{dashboard_id}", hs.PublicDeleteValidatorDashboard, hs.InternalDeleteValidatorDashboard},
 		{http.MethodPut, "/{dashboard_id}/archiving", hs.PublicPutValidatorDashboardArchiving, hs.InternalPutValidatorDashboardArchiving},
+		// archived dashboards can still be exported, e.g. to import them into another dashboard
+		{http.MethodGet, "/{dashboard_id}/export", hs.PublicGetValidatorDashboardExport, hs.InternalGetValidatorDashboardExport},
 	}
 
 	addEndpointsToRouters(archivalEndpoints, publicDashboardRouter, internalDashboardRouter)
@@ -294,10 +315,16 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 		{http.MethodPost, "/{dashboard_id}/validators", hs.PublicPostValidatorDashboardValidators, hs.InternalPostValidatorDashboardValidators},
 		{http.MethodGet, "/{dashboard_id}/validators", hs.PublicGetValidatorDashboardValidators, hs.InternalGetValidatorDashboardValidators},
 		{http.MethodPost, "/{dashboard_id}/validators/bulk-deletions", hs.PublicDeleteValidatorDashboardValidators, hs.InternalDeleteValidatorDashboardValidators},
+		{http.MethodPost, "/{dashboard_id}/validators/bulk-moves", hs.PublicPostValidatorDashboardValidatorsBulkMoves, hs.InternalPostValidatorDashboardValidatorsBulkMoves},
+		{http.MethodPost, "/{dashboard_id}/import", hs.PublicPostValidatorDashboardImport, hs.InternalPostValidatorDashboardImport},
+		{http.MethodGet, "/{dashboard_id}/tags", hs.PublicGetValidatorDashboardTags, hs.InternalGetValidatorDashboardTags},
+		{http.MethodPost, "/{dashboard_id}/validators/tags", hs.PublicPostValidatorDashboardValidatorTags, hs.InternalPostValidatorDashboardValidatorTags},
+		{http.MethodPost, "/{dashboard_id}/validators/tags/bulk-deletions", hs.PublicDeleteValidatorDashboardValidatorTags, hs.InternalDeleteValidatorDashboardValidatorTags},
 		{http.MethodPost, "/{dashboard_id}/public-ids", hs.PublicPostValidatorDashboardPublicIds, hs.InternalPostValidatorDashboardPublicIds},
 		{http.MethodPut, "/{dashboard_id}/public-ids/{public_id}", hs.PublicPutValidatorDashboardPublicId, hs.InternalPutValidatorDashboardPublicId},
 		{http.MethodDelete, "/{dashboard_id}/public-ids/{public_id}", hs.PublicDeleteValidatorDashboardPublicId, hs.InternalDeleteValidatorDashboardPublicId},
 		{http.MethodGet, "/{dashboard_id}/slot-viz", hs.PublicGetValidatorDashboardSlotViz, hs.InternalGetValidatorDashboardSlotViz},
+		{http.MethodGet, "/{dashboard_id}/live-updates", hs.PublicGetValidatorDashboardLiveUpdates, hs.InternalGetValidatorDashboardLiveUpdates},
 		{http.MethodGet, "/{dashboard_id}/summary", hs.PublicGetValidatorDashboardSummary, hs.InternalGetValidatorDashboardSummary},
 		{http.MethodGet, "/{dashboard_id}/summary/validators", hs.PublicGetValidatorDashboardSummaryValidators, hs.InternalGetValidatorDashboardSummaryValidators},
 		{http.MethodGet, "/{dashboard_id}/groups/{group_id}/summary", hs.PublicGetValidatorDashboardGroupSummary, hs.InternalGetValidatorDashboardGroupSummary},
@@ -315,9 +342,13 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 		{http.MethodGet, "/{dashboard_id}/total-consensus-layer-deposits", hs.PublicGetValidatorDashboardTotalConsensusLayerDeposits, hs.InternalGetValidatorDashboardTotalConsensusLayerDeposits},
 		{http.MethodGet, "/{dashboard_id}/withdrawals", hs.PublicGetValidatorDashboardWithdrawals, hs.InternalGetValidatorDashboardWithdrawals},
 		{http.MethodGet, "/{dashboard_id}/total-withdrawals", hs.PublicGetValidatorDashboardTotalWithdrawals, hs.InternalGetValidatorDashboardTotalWithdrawals},
+		{http.MethodGet, "/{dashboard_id}/bls-changes", hs.PublicGetValidatorDashboardBlsChanges, hs.InternalGetValidatorDashboardBlsChanges},
 		{http.MethodGet, "/{dashboard_id}/rocket-pool", hs.PublicGetValidatorDashboardRocketPool, hs.InternalGetValidatorDashboardRocketPool},
 		{http.MethodGet, "/{dashboard_id}/total-rocket-pool", hs.PublicGetValidatorDashboardTotalRocketPool, hs.InternalGetValidatorDashboardTotalRocketPool},
 		{http.MethodGet, "/{dashboard_id}/rocket-pool/{node_address}/minipools", hs.PublicGetValidatorDashboardRocketPoolMinipools, hs.InternalGetValidatorDashboardRocketPoolMinipools},
+		{http.MethodPost, "/{dashboard_id}/income-reports", hs.PublicPostValidatorDashboardIncomeReports, hs.InternalPostValidatorDashboardIncomeReports},
+		{http.MethodGet, "/{dashboard_id}/income-reports/{report_id}", hs.PublicGetValidatorDashboardIncomeReport, hs.InternalGetValidatorDashboardIncomeReport},
+		{http.MethodGet, "/{dashboard_id}/income-reports/{report_id}/file", hs.PublicGetValidatorDashboardIncomeReportFile, hs.InternalGetValidatorDashboardIncomeReportFile},
 		{http.MethodGet, "/{dashboard_id}/mobile/widget", nil, hs.InternalGetValidatorDashboardMobileWidget},
 		{http.MethodGet, "/{dashboard_id}/mobile/validators", nil, hs.InternalGetValidatorDashboardMobileValidators},
 	}
@@ -362,6 +393,8 @@ func addNotificationRoutes(hs *handlers.HandlerService, publicRouter, internalRo
 		{http.MethodGet, "/validator-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch}", hs.PublicGetUserNotificationsValidatorDashboard, hs.InternalGetUserNotificationsValidatorDashboard},
 		{http.MethodGet, "/account-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch}", hs.PublicGetUserNotificationsAccountDashboard, hs.InternalGetUserNotificationsAccountDashboard},
 		{http.MethodPut, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicPutUserNotificationSettingsValidatorDashboard, hs.InternalPutUserNotificationSettingsValidatorDashboard},
+		{http.MethodGet, "/settings/validator-dashboards/{dashboard_id}/tags", hs.PublicGetUserNotificationSettingsValidatorDashboardTags, hs.InternalGetUserNotificationSettingsValidatorDashboardTags},
+		{http.MethodPut, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/tags/{tag_key}/{tag_value}", hs.PublicPutUserNotificationSettingsValidatorDashboardTag, hs.InternalPutUserNotificationSettingsValidatorDashboardTag},
 		{http.MethodPut, "/settings/account-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicPutUserNotificationSettingsAccountDashboard, hs.InternalPutUserNotificationSettingsAccountDashboard},
 	}
 	addEndpointsToRouters(dashboardSettingsEndpoints, publicDashboardNotificationSettingsRouter, internalDashboardNotificationSettingsRouter)
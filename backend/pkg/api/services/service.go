@@ -6,6 +6,7 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/mail"
 	"github.com/gobitfly/beaconchain/pkg/commons/price"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/jmoiron/sqlx"
@@ -22,9 +23,14 @@ type Services struct {
 	clickhouseReader        *sqlx.DB
 	bigtable                *db.Bigtable
 	persistentRedisDbClient *redis.Client
+	userWriter              *sqlx.DB
+
+	emailProvider    mail.Provider
+	emailProviderErr error
 }
 
-func NewServices(readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader *sqlx.DB, bigtable *db.Bigtable, persistentRedisDbClient *redis.Client) *Services {
+func NewServices(readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader *sqlx.DB, bigtable *db.Bigtable, persistentRedisDbClient *redis.Client, userWriter *sqlx.DB) *Services {
+	emailProvider, emailProviderErr := mail.NewProviderFromConfig()
 	return &Services{
 		readerDb:                readerDb,
 		writerDb:                writerDb,
@@ -33,6 +39,9 @@ func NewServices(readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader
 		clickhouseReader:        clickhouseReader,
 		bigtable:                bigtable,
 		persistentRedisDbClient: persistentRedisDbClient,
+		userWriter:              userWriter,
+		emailProvider:           emailProvider,
+		emailProviderErr:        emailProviderErr,
 	}
 }
 
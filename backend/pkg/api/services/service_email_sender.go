@@ -1,66 +1,271 @@
 package services
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/mail"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+var ErrEmailUndeliverable = errors.New("email address has been marked as undeliverable")
+
+const (
+	emailStatusPending       = "pending"
+	emailStatusSent          = "sent"
+	emailStatusFailed        = "failed"
+	emailStatusExpired       = "expired"
+	emailStatusUndeliverable = "undeliverable"
+
+	EmailUndeliverableReasonBounce    = "bounce"
+	EmailUndeliverableReasonComplaint = "complaint"
+
+	emailSenderInterval  = 5 * time.Second
+	emailSenderBatchSize = 20
+	emailSendTimeout     = 10 * time.Second
+	emailClaimTimeout    = emailSenderBatchSize * emailSendTimeout * 2 // claimed emails are retried after this, long enough to send the whole batch
+	emailMaxAttempts     = 8
+	emailMaxRetryDelay   = time.Hour
+
+	defaultMaxEmailsPerRecipientPerDay = 20
 )
 
-// structs
-type QueuedEmail struct { // or MessageQueue, if single sender service
-	Email   EMail
-	Timeout uint
-}
 type EMail struct {
-	Recipient   string
-	Subject     string
-	Message     string
-	Attachments []byte
+	Recipient string
+	Subject   string
+	Message   string // plain text
+	Html      string // optional
 }
 
-// data
-var Queue []QueuedEmail
-
-// map by recipient, last send times, etc.
-// mutex
+type queuedEmail struct {
+	Id        uint64         `db:"id"`
+	Recipient string         `db:"recipient"`
+	Subject   string         `db:"subject"`
+	TextBody  string         `db:"text_body"`
+	HtmlBody  sql.NullString `db:"html_body"`
+	Attempts  int            `db:"attempts"`
+	Expired   bool           `db:"expired"`
+}
 
-// TODO use single combined sender service? (email, webhooks, ...)
-// collects & queues mails, sends in batches regularly (possibly aggregating multiple messasages to the same user to avoid spam?)
-// TODO ratelimiting
-// TODO send via SMTP/mailgun/others?
+// sends queued emails in batches, multiple api instances can run it concurrently since each batch is locked
 func (s *Services) startEmailSenderService(wg *sync.WaitGroup) {
 	o := sync.Once{}
 	for {
 		startTime := time.Now()
-		// lock mutex
-		for _, item := range Queue {
-			if item.Timeout > 0 {
-				// drop item
-				continue
-			}
-			/*err := s.SendEmail(item.Email)
-			if err == nil {
-				// drop item
-			}*/
+		if s.emailProviderErr != nil {
+			log.Error(s.emailProviderErr, "error initializing mail provider, queued emails will not be sent", 0)
+			o.Do(func() {
+				wg.Done()
+			})
+			return
+		}
+		sent, err := s.sendQueuedEmails()
+		if err != nil {
+			log.Error(err, "error sending queued emails", 0)
+		} else if sent > 0 {
+			log.Infof("=== sent %d queued emails in %s", sent, time.Since(startTime))
 		}
-		log.Infof("=== message sending done in %s", time.Since(startTime))
 		o.Do(func() {
 			wg.Done()
 		})
-		utils.ConstantTimeDelay(startTime, 30*time.Second)
+		if sent < emailSenderBatchSize {
+			utils.ConstantTimeDelay(startTime, emailSenderInterval)
+		}
+	}
+}
+
+// claimQueuedEmails claims a batch of due emails by moving their next attempt behind the claim timeout, so concurrent senders skip them.
+// If the sender dies before updating an email it will be retried once the claim expired.
+// All timestamps of the queue are utc and taken from the database clock, so senders with a skewed clock don't affect each other.
+func (s *Services) claimQueuedEmails() ([]queuedEmail, error) {
+	var emails []queuedEmail
+	err := s.userWriter.Select(&emails, `
+		UPDATE email_queue
+		SET next_attempt_ts = (NOW() AT TIME ZONE 'utc') + $3 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id
+			FROM email_queue
+			WHERE status = $1 AND next_attempt_ts <= (NOW() AT TIME ZONE 'utc')
+			ORDER BY next_attempt_ts
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, recipient, subject, text_body, html_body, attempts, COALESCE(expires_ts <= (NOW() AT TIME ZONE 'utc'), false) AS expired`, emailStatusPending, emailSenderBatchSize, emailClaimTimeout.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("error claiming queued emails: %w", err)
+	}
+	return emails, nil
+}
+
+// sendQueuedEmails sends a batch of due emails and returns how many were sent
+func (s *Services) sendQueuedEmails() (int, error) {
+	emails, err := s.claimQueuedEmails()
+	if err != nil {
+		return 0, err
+	}
+	if len(emails) == 0 {
+		return 0, nil
+	}
+
+	recipients := make([]string, 0, len(emails))
+	for _, email := range emails {
+		recipients = append(recipients, strings.ToLower(email.Recipient))
+	}
+	var undeliverableList []string
+	err = s.userWriter.Select(&undeliverableList, `SELECT email FROM email_undeliverable_addresses WHERE email = ANY($1)`, pq.Array(recipients))
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving undeliverable addresses: %w", err)
+	}
+	undeliverable := make(map[string]bool, len(undeliverableList))
+	for _, email := range undeliverableList {
+		undeliverable[email] = true
+	}
+
+	var sentCounts []struct {
+		Recipient string `db:"recipient"`
+		Count     int    `db:"count"`
+	}
+	err = s.userWriter.Select(&sentCounts, `
+		SELECT LOWER(recipient) AS recipient, COUNT(*) AS count
+		FROM email_queue
+		WHERE LOWER(recipient) = ANY($2) AND status = $1 AND sent_ts > (NOW() AT TIME ZONE 'utc') - INTERVAL '1 day'
+		GROUP BY 1`, emailStatusSent, pq.Array(recipients))
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving sent email counts: %w", err)
+	}
+	sentToday := make(map[string]int, len(sentCounts))
+	for _, c := range sentCounts {
+		sentToday[c.Recipient] = c.Count
 	}
+	maxPerDay := utils.Config.Frontend.MaxMailsPerEmailPerDay
+	if maxPerDay <= 0 {
+		maxPerDay = defaultMaxEmailsPerRecipientPerDay
+	}
+
+	sent := 0
+	for _, email := range emails {
+		recipient := strings.ToLower(email.Recipient)
+		switch {
+		case email.Expired:
+			_, err = s.userWriter.Exec(`UPDATE email_queue SET status = $2 WHERE id = $1`, email.Id, emailStatusExpired)
+		case undeliverable[recipient]:
+			_, err = s.userWriter.Exec(`UPDATE email_queue SET status = $2 WHERE id = $1`, email.Id, emailStatusUndeliverable)
+		case sentToday[recipient] >= maxPerDay:
+			// postpone instead of dropping, the email expires if it isn't useful anymore by then
+			_, err = s.userWriter.Exec(`UPDATE email_queue SET next_attempt_ts = (NOW() AT TIME ZONE 'utc') + INTERVAL '1 hour' WHERE id = $1`, email.Id)
+		default:
+			sendErr := s.sendEmail(context.Background(), email.Recipient, email.Subject, email.TextBody, email.HtmlBody.String)
+			if sendErr == nil {
+				sent++
+				sentToday[recipient]++
+				_, err = s.userWriter.Exec(`UPDATE email_queue SET status = $2, attempts = attempts + 1, sent_ts = (NOW() AT TIME ZONE 'utc'), provider = $3, last_error = NULL WHERE id = $1`,
+					email.Id, emailStatusSent, s.emailProvider.Name())
+				break
+			}
+			log.Warnf("error sending email %d (attempt %d): %v", email.Id, email.Attempts+1, sendErr)
+			status := emailStatusPending
+			if email.Attempts+1 >= emailMaxAttempts {
+				status = emailStatusFailed
+			}
+			retryDelay := min(time.Duration(1<<email.Attempts)*30*time.Second, emailMaxRetryDelay)
+			_, err = s.userWriter.Exec(`UPDATE email_queue SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_ts = (NOW() AT TIME ZONE 'utc') + $4 * INTERVAL '1 second' WHERE id = $1`,
+				email.Id, status, sendErr.Error(), retryDelay.Seconds())
+		}
+		if err != nil {
+			// the email is retried once its claim expired
+			log.Error(err, fmt.Sprintf("error updating queued email %d", email.Id), 0)
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *Services) sendEmail(ctx context.Context, recipient, subject, text, html string) error {
+	ctx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+	defer cancel()
+	return s.emailProvider.Send(ctx, mail.Message{
+		To:      recipient,
+		Subject: subject,
+		Text:    text,
+		Html:    html,
+	})
 }
 
-// collect, try to send regularly, drop after timeout
-func (s *Services) QueueEmail(message EMail, timeout uint) {
-	// queue handling, eventually
-	// lock mutex, add to queue
+func (s *Services) isEmailUndeliverable(ctx context.Context, email string) (bool, error) {
+	var undeliverable bool
+	err := s.userWriter.GetContext(ctx, &undeliverable, `SELECT EXISTS(SELECT 1 FROM email_undeliverable_addresses WHERE email = $1)`, strings.ToLower(email))
+	return undeliverable, err
 }
 
-// send, no queueing (fire-and-forget)
-func (s *Services) SendEmail(message EMail) error {
-	// try sending via mailgun, SMTP, ...
+// QueueEmail persists the email, it will be sent by the email sender service with retries. A timeout > 0 drops the email if it could not be sent in time.
+func (s *Services) QueueEmail(ctx context.Context, message EMail, timeout time.Duration) error {
+	if s.emailProviderErr != nil {
+		// nothing would ever send the email
+		return fmt.Errorf("error queueing email: %w", s.emailProviderErr)
+	}
+	undeliverable, err := s.isEmailUndeliverable(ctx, message.Recipient)
+	if err != nil {
+		return fmt.Errorf("error checking if email address is undeliverable: %w", err)
+	}
+	if undeliverable {
+		return ErrEmailUndeliverable
+	}
+
+	var expiresIn sql.NullFloat64
+	if timeout > 0 {
+		expiresIn = sql.NullFloat64{Float64: timeout.Seconds(), Valid: true}
+	}
+	_, err = s.userWriter.ExecContext(ctx, `
+		INSERT INTO email_queue (recipient, subject, text_body, html_body, expires_ts)
+		VALUES ($1, $2, $3, NULLIF($4, ''), (NOW() AT TIME ZONE 'utc') + $5 * INTERVAL '1 second')`,
+		message.Recipient, message.Subject, message.Message, message.Html, expiresIn)
+	if err != nil {
+		return fmt.Errorf("error queueing email: %w", err)
+	}
 	return nil
 }
+
+// SendEmail sends the email right away, no queueing or retries (fire-and-forget)
+func (s *Services) SendEmail(ctx context.Context, message EMail) error {
+	if s.emailProviderErr != nil {
+		return s.emailProviderErr
+	}
+	undeliverable, err := s.isEmailUndeliverable(ctx, message.Recipient)
+	if err != nil {
+		return fmt.Errorf("error checking if email address is undeliverable: %w", err)
+	}
+	if undeliverable {
+		return ErrEmailUndeliverable
+	}
+	return s.sendEmail(ctx, message.Recipient, message.Subject, message.Message, message.Html)
+}
+
+// MarkEmailUndeliverable stops all further emails to the given address, e.g. after a permanent bounce or a spam complaint
+func (s *Services) MarkEmailUndeliverable(ctx context.Context, email, reason, details string) error {
+	tx, err := s.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO email_undeliverable_addresses (email, reason, details)
+		VALUES ($1, $2, NULLIF($3, ''))
+		ON CONFLICT (email) DO UPDATE SET reason = EXCLUDED.reason, details = EXCLUDED.details, created_ts = (NOW() AT TIME ZONE 'utc')`,
+		strings.ToLower(email), reason, details)
+	if err != nil {
+		return fmt.Errorf("error marking email address as undeliverable: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE email_queue SET status = $2 WHERE LOWER(recipient) = $1 AND status = $3`,
+		strings.ToLower(email), emailStatusUndeliverable, emailStatusPending)
+	if err != nil {
+		return fmt.Errorf("error dropping queued emails of undeliverable address: %w", err)
+	}
+	return tx.Commit()
+}
@@ -29,6 +29,7 @@ var currentDutiesInfo atomic.Pointer[SyncData]
 
 func (s *Services) startSlotVizDataService(wg *sync.WaitGroup) {
 	o := sync.Once{}
+	events := s.subscribeSlotVizEvents()
 	for {
 		startTime := time.Now()
 		delay := time.Duration(utils.Config.Chain.ClConfig.SecondsPerSlot) * time.Second
@@ -44,7 +45,7 @@ func (s *Services) startSlotVizDataService(wg *sync.WaitGroup) {
 		o.Do(func() {
 			wg.Done()
 		})
-		utils.ConstantTimeDelay(startTime, delay)
+		s.waitForSlotVizUpdate(startTime, delay, events)
 	}
 }
 
@@ -282,7 +283,9 @@ func (s *Services) updateSlotVizData() error {
 		log.Infof("== slot-viz data updater initialized ==")
 	}
 
+	dutiesInfo.UpdateId = uint64(time.Now().UnixMilli())
 	currentDutiesInfo.Store(dutiesInfo)
+	publishDutiesInfo(dutiesInfo)
 
 	return nil
 }
@@ -471,4 +474,5 @@ type SyncData struct {
 	TotalSyncAssignmentsForEpoch map[uint64][]uint64        // epoch -> list of assigned indexes
 	EpochAttestationDuties       map[uint64]map[uint32]bool // validatorindex -> slot -> attested
 	AssignmentsFetchedForEpoch   uint64
+	UpdateId                     uint64 // unique id of this snapshot, used to resume live updates
 }
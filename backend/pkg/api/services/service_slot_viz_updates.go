@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+// number of past duties info snapshots kept to resume live update streams
+const dutiesInfoHistorySize = 16
+
+// min time between two slot viz updates triggered by head events
+const slotVizMinUpdateInterval = time.Second
+
+// delay before subscribing to slot viz events again after the subscription failed
+const slotVizResubscribeDelay = 5 * time.Second
+
+var dutiesInfoUpdates = struct {
+	sync.RWMutex
+	history     []*SyncData
+	subscribers map[chan struct{}]struct{}
+}{
+	subscribers: make(map[chan struct{}]struct{}),
+}
+
+// publishDutiesInfo stores the snapshot in the history and notifies all subscribers.
+// Subscribers are only notified that something changed, slow subscribers skip intermediate snapshots instead of blocking the service.
+func publishDutiesInfo(dutiesInfo *SyncData) {
+	dutiesInfoUpdates.Lock()
+	defer dutiesInfoUpdates.Unlock()
+	dutiesInfoUpdates.history = append(dutiesInfoUpdates.history, dutiesInfo)
+	if len(dutiesInfoUpdates.history) > dutiesInfoHistorySize {
+		dutiesInfoUpdates.history = dutiesInfoUpdates.history[1:]
+	}
+	for ch := range dutiesInfoUpdates.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default: // subscriber has a pending notification already
+		}
+	}
+}
+
+// SubscribeDutiesInfo returns a channel that receives a notification whenever the duties info has been updated and a function to cancel the subscription.
+// Get the new data via GetCurrentDutiesInfo after receiving a notification.
+func (s *Services) SubscribeDutiesInfo() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	dutiesInfoUpdates.Lock()
+	dutiesInfoUpdates.subscribers[ch] = struct{}{}
+	dutiesInfoUpdates.Unlock()
+	return ch, func() {
+		dutiesInfoUpdates.Lock()
+		delete(dutiesInfoUpdates.subscribers, ch)
+		dutiesInfoUpdates.Unlock()
+	}
+}
+
+// GetDutiesInfoByUpdateId returns a recent duties info snapshot, returns nil if it is not available (anymore)
+func (s *Services) GetDutiesInfoByUpdateId(updateId uint64) *SyncData {
+	dutiesInfoUpdates.RLock()
+	defer dutiesInfoUpdates.RUnlock()
+	for _, dutiesInfo := range dutiesInfoUpdates.history {
+		if dutiesInfo.UpdateId == updateId {
+			return dutiesInfo
+		}
+	}
+	return nil
+}
+
+// waitForSlotVizUpdate blocks until the next slot viz update is due, that is after delay or if the exporter published a new head or new assignments
+func (s *Services) waitForSlotVizUpdate(startTime time.Time, delay time.Duration, events <-chan string) {
+	timer := time.NewTimer(time.Until(startTime.Add(delay)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case msg, ok := <-events:
+		if !ok {
+			utils.ConstantTimeDelay(startTime, delay)
+			return
+		}
+		log.Debugf("slotviz update triggered by event %s", msg)
+		utils.ConstantTimeDelay(startTime, slotVizMinUpdateInterval)
+	}
+}
+
+// subscribeSlotVizEvents subscribes to the events the exporter publishes on new heads and new epoch assignments.
+// The subscription is renewed if the connection to redis drops, events that are published in the meantime are missed.
+func (s *Services) subscribeSlotVizEvents() <-chan string {
+	events := make(chan string, 1)
+	go func() {
+		channel := fmt.Sprintf("%d:slotViz", utils.Config.Chain.ClConfig.DepositChainID)
+		for {
+			s.receiveSlotVizEvents(channel, events)
+			log.Warnf("slotviz event subscription ended, resubscribing in %s", slotVizResubscribeDelay)
+			time.Sleep(slotVizResubscribeDelay)
+		}
+	}()
+	return events
+}
+
+// receiveSlotVizEvents forwards the events of the channel until the subscription fails
+func (s *Services) receiveSlotVizEvents(channel string, events chan<- string) {
+	ctx := context.Background()
+	pubsub := s.persistentRedisDbClient.Subscribe(ctx, channel)
+	defer pubsub.Close()
+	// wait for the confirmation, Subscribe does not report errors
+	if _, err := pubsub.Receive(ctx); err != nil {
+		log.Error(err, "error subscribing to slotviz events", 0)
+		return
+	}
+	for {
+		msg, err := pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			log.Error(err, "error receiving slotviz event", 0)
+			return
+		}
+		select {
+		case events <- msg.Payload:
+		default: // an update is pending already
+		}
+	}
+}
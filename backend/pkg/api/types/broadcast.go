@@ -0,0 +1,30 @@
+package types
+
+import "github.com/shopspring/decimal"
+
+// ------------------------------
+// broadcasts of pre-signed operations (voluntary exits, bls changes, execution layer transactions)
+
+type NetworkBroadcast struct {
+	Id          string   `json:"id"`
+	Type        string   `json:"type" tstype:"'bls_to_execution_changes' | 'voluntary_exit' | 'execution_transaction' | 'withdrawal_requests' | 'consolidation_requests'" faker:"oneof: bls_to_execution_changes, voluntary_exit, execution_transaction, withdrawal_requests, consolidation_requests"`
+	Status      string   `json:"status" tstype:"'pending' | 'submitted' | 'completed' | 'failed'" faker:"oneof: pending, submitted, completed, failed"`
+	CreatedTs   int64    `json:"created_ts"`
+	SubmittedTs *int64   `json:"submitted_ts,omitempty"`
+	CompletedTs *int64   `json:"completed_ts,omitempty"`
+	Validators  []uint64 `json:"validators,omitempty"`
+	TxHash      *Hash    `json:"tx_hash,omitempty"`
+	// transactions that have to be sent by the withdrawal addresses for withdrawal and consolidation requests
+	CallData []NetworkBroadcastCallData `json:"call_data,omitempty"`
+}
+
+type NetworkBroadcastCallData struct {
+	From  Hash             `json:"from"`
+	To    Hash             `json:"to"`
+	Data  string           `json:"data"`
+	Value *decimal.Decimal `json:"value,omitempty"` // value to send in wei, twice the current fee of the predeploy as the fee at the time of inclusion can be higher, omitted if the fee is unavailable
+}
+
+type PostNetworkBroadcastsResponse ApiDataResponse[NetworkBroadcast]
+
+type GetNetworkBroadcastResponse ApiDataResponse[NetworkBroadcast]
@@ -38,6 +38,8 @@ type VDBId struct {
 	Validators      VDBIdValidatorSet // if this is nil, then use the id
 	Id              VDBIdPrimary
 	AggregateGroups bool
+	Tag             *VDBValidatorTag // if set, only validators with this tag are included
+	GroupByTag      string           // if set, validators are grouped by the values of this tag key instead of their groups
 }
 
 // could replace if we want the import in all files
@@ -84,6 +86,106 @@ type ELDepositsCursor struct {
 	LogIndex    int64
 }
 
+type NetworkSlashingsCursor struct {
+	GenericCursor
+	Slot           uint64
+	Type           string
+	BlockIndex     uint64
+	ValidatorIndex uint64
+}
+
+type NetworkVoluntaryExitsCursor struct {
+	GenericCursor
+	Slot       uint64
+	BlockIndex uint64
+}
+
+type NetworkBlsChangesCursor struct {
+	GenericCursor
+	Slot           uint64
+	ValidatorIndex uint64
+}
+
+type NetworkWithdrawalsCursor struct {
+	GenericCursor
+	WithdrawalIndex uint64
+}
+
+type NetworkBlobBlocksCursor struct {
+	GenericCursor
+	Slot uint64
+}
+
+type NetworkTransactionsCursor struct {
+	GenericCursor
+	Block            uint64
+	TransactionIndex uint64
+}
+
+// the address transaction views merge several indices, the position in each is tracked separately
+type NetworkAddressTransactionsCursor struct {
+	GenericCursor
+	Positions map[string]string
+}
+
+type NetworkAddressBalanceHistoryCursor struct {
+	GenericCursor
+	Block uint64
+}
+
+type NetworkAddressEventLogsCursor struct {
+	GenericCursor
+	Block            uint64
+	TransactionIndex uint64
+	LogIndex         uint64
+}
+
+type NetworkBatchesCursor struct {
+	GenericCursor
+	Block            uint64
+	TransactionIndex uint64
+}
+
+type NetworkLayer2TransactionsCursor struct {
+	GenericCursor
+	Block            uint64
+	TransactionIndex uint64
+	LogIndex         uint64
+}
+
+type NetworkUserOperationsCursor struct {
+	GenericCursor
+	Block            uint64
+	TransactionIndex uint64
+	LogIndex         uint64
+}
+
+type MultisigSafeTransactionsCursor struct {
+	GenericCursor
+	Block            uint64
+	TransactionIndex uint64
+	LogIndex         uint64
+}
+
+// empty fields are not filtered on
+type NetworkDepositsFilter struct {
+	Address    []byte // matches the sender of the transaction as well as the depositor
+	Credential []byte
+	PublicKey  []byte
+	Validator  *VDBValidator
+	TxHash     []byte
+	AfterTs    uint64
+	BeforeTs   uint64
+}
+
+// empty fields are not filtered on
+type NetworkWithdrawalsFilter struct {
+	Address    []byte
+	Credential []byte // matches all validators that currently use the withdrawal credential
+	AfterTs    uint64
+	BeforeTs   uint64
+}
+
 type ValidatorsCursor struct {
 	GenericCursor
 
@@ -310,6 +412,7 @@ type MobileAppBundleStats struct {
 
 type NotificationSettingsDefaultValues struct {
 	GroupEfficiencyBelowThreshold     float64
+	PerformanceAnomalyThreshold       float64
 	MaxCollateralThreshold            float64
 	MinCollateralThreshold            float64
 	ERC20TokenTransfersValueThreshold float64
@@ -323,6 +426,13 @@ type NotificationSettingsDefaultValues struct {
 	NetworkParticipationRateThreshold float64
 }
 
+// generated income report, served as a file instead of json
+type VDBIncomeReportFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
 // ------------------------------
 
 type CtxKey string
@@ -0,0 +1,55 @@
+package types
+
+import "github.com/shopspring/decimal"
+
+// ------------------------------------------------------------
+// Safe (formerly Gnosis Safe) multisigs, owners and threshold are replayed from the indexed events
+
+type MultisigSafe struct {
+	Address        Address   `json:"address"`
+	Owners         []Address `json:"owners"`
+	Threshold      uint64    `json:"threshold"`
+	SetupTxHash    *Hash     `json:"setup_tx_hash,omitempty"` // omitted if the safe was set up before the indexed range
+	SetupBlock     *uint64   `json:"setup_block,omitempty"`
+	SetupTimestamp *int64    `json:"setup_timestamp,omitempty"`
+}
+
+type GetMultisigSafeResponse ApiDataResponse[MultisigSafe]
+
+type MultisigConfirmation struct {
+	Owner Address `json:"owner"`
+	Type  string  `json:"type" tstype:"'ecdsa' | 'eth_sign' | 'contract' | 'approved_hash' | 'sender'" faker:"oneof: ecdsa, eth_sign, contract, approved_hash, sender"`
+	// only set for on-chain approvals
+	TxHash    *Hash   `json:"tx_hash,omitempty"`
+	Block     *uint64 `json:"block,omitempty"`
+	Timestamp *int64  `json:"timestamp,omitempty"`
+}
+
+// call details and confirmations are only known if the safe was called directly, not via another contract
+type MultisigSafeTransactionTableRow struct {
+	SafeTxHash        Hash             `json:"safe_tx_hash"`
+	TxHash            Hash             `json:"tx_hash"`
+	Block             uint64           `json:"block"`
+	TransactionIndex  uint64           `json:"transaction_index"`
+	LogIndex          uint64           `json:"log_index"`
+	Timestamp         int64            `json:"timestamp"`
+	Status            string           `json:"status" tstype:"'success' | 'failed'" faker:"oneof: success, failed"`
+	To                *Address         `json:"to,omitempty"`
+	Value             *decimal.Decimal `json:"value,omitempty"`
+	Data              *string          `json:"data,omitempty"`
+	Operation         *string          `json:"operation,omitempty" tstype:"'call' | 'delegate_call'" faker:"oneof: call, delegate_call"`
+	Payment           decimal.Decimal  `json:"payment"` // refund paid to the executor
+	ConfirmationCount uint64           `json:"confirmation_count"`
+}
+
+type GetMultisigSafeTransactionsResponse ApiPagingResponse[MultisigSafeTransactionTableRow]
+
+type MultisigTransactionConfirmations struct {
+	SafeTxHash    Hash                   `json:"safe_tx_hash"`
+	Safe          *Address               `json:"safe,omitempty"` // omitted if the transaction has not been executed yet
+	Status        string                 `json:"status" tstype:"'pending' | 'success' | 'failed'" faker:"oneof: pending, success, failed"`
+	TxHash        *Hash                  `json:"tx_hash,omitempty"`
+	Confirmations []MultisigConfirmation `json:"confirmations"`
+}
+
+type GetMultisigTransactionConfirmationsResponse ApiDataResponse[MultisigTransactionConfirmations]
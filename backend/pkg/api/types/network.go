@@ -0,0 +1,433 @@
+package types
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// ------------------------------------------------------------
+// Slashings
+
+type NetworkSlasher struct {
+	Index          uint64 `json:"index"`
+	TotalSlashings uint64 `json:"total_slashings"` // number of validators this validator has slashed so far
+}
+
+type NetworkSlashingTableRow struct {
+	Slot                uint64          `json:"slot"`
+	Epoch               uint64          `json:"epoch"`
+	Timestamp           int64           `json:"timestamp"`
+	Type                string          `json:"type" tstype:"'proposer' | 'attester'" faker:"oneof: proposer, attester"`
+	Index               uint64          `json:"index"` // slashed validator
+	Slasher             NetworkSlasher  `json:"slasher"`
+	Penalty             decimal.Decimal `json:"penalty"` // penalties incurred by the slashed validator since the slashing
+	WhistleblowerReward decimal.Decimal `json:"whistleblower_reward"`
+}
+
+type GetNetworkSlashingsResponse ApiPagingResponse[NetworkSlashingTableRow]
+
+type NetworkSlashingPenaltyHistoryEntry struct {
+	Timestamp int64           `json:"timestamp"` // start of the day
+	Penalty   decimal.Decimal `json:"penalty"`
+}
+
+type NetworkValidatorSlashings struct {
+	Slashing       *NetworkSlashingTableRow             `json:"slashing,omitempty"` // only set if the validator got slashed
+	PenaltyHistory []NetworkSlashingPenaltyHistoryEntry `json:"penalty_history"`
+	Slashed        []NetworkSlashingTableRow            `json:"slashed"` // slashings the validator included as block proposer
+}
+
+type GetNetworkValidatorSlashingsResponse ApiDataResponse[NetworkValidatorSlashings]
+
+// ------------------------------------------------------------
+// Voluntary Exits
+
+type NetworkVoluntaryExitTableRow struct {
+	Slot          uint64  `json:"slot"`
+	Epoch         uint64  `json:"epoch"` // epoch the exit message was signed for
+	Timestamp     int64   `json:"timestamp"`
+	Index         uint64  `json:"index"`
+	Signature     Hash    `json:"signature"`
+	ExitEpoch     *uint64 `json:"exit_epoch,omitempty"`
+	QueuePosition *uint64 `json:"queue_position,omitempty"` // only set while the validator is still waiting in the exit queue
+	EstimatedExit *int64  `json:"estimated_exit,omitempty"` // timestamp
+}
+
+type GetNetworkVoluntaryExitsResponse ApiPagingResponse[NetworkVoluntaryExitTableRow]
+
+type GetNetworkBlockVoluntaryExitsResponse ApiDataResponse[[]NetworkVoluntaryExitTableRow]
+
+// ------------------------------------------------------------
+// Deposits
+
+type NetworkDepositTableRow struct {
+	PublicKey            PubKey          `json:"public_key"`
+	Index                *uint64         `json:"index,omitempty"`
+	Block                uint64          `json:"block"`
+	Timestamp            int64           `json:"timestamp"`
+	From                 Address         `json:"from"`
+	Depositor            Address         `json:"depositor"`
+	TxHash               Hash            `json:"tx_hash"`
+	WithdrawalCredential Hash            `json:"withdrawal_credential"`
+	Amount               decimal.Decimal `json:"amount"`
+	Valid                bool            `json:"valid"`     // only the first deposit of a public key needs a valid signature, the consensus layer ignores invalid ones before it
+	IsTopUp              bool            `json:"is_top_up"` // an earlier deposit with a valid signature for the same public key exists
+}
+
+type GetNetworkDepositsResponse ApiPagingResponse[NetworkDepositTableRow]
+
+// ------------------------------------------------------------
+// Withdrawals
+
+type NetworkWithdrawalTableRow struct {
+	Epoch     uint64          `json:"epoch"`
+	Slot      uint64          `json:"slot"`
+	Timestamp int64           `json:"timestamp"`
+	Index     uint64          `json:"index"` // withdrawal index
+	Validator uint64          `json:"validator"`
+	Recipient Address         `json:"recipient"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+type GetNetworkWithdrawalsResponse ApiPagingResponse[NetworkWithdrawalTableRow]
+
+type NetworkWithdrawalCredentialTotals struct {
+	WithdrawalCredential Hash            `json:"withdrawal_credential"`
+	Validators           uint64          `json:"validators"` // number of validators that currently use the withdrawal credential
+	DepositsCount        uint64          `json:"deposits_count"`
+	DepositsAmount       decimal.Decimal `json:"deposits_amount"` // only valid deposits are counted
+	WithdrawalsCount     uint64          `json:"withdrawals_count"`
+	WithdrawalsAmount    decimal.Decimal `json:"withdrawals_amount"`
+}
+
+type GetNetworkWithdrawalCredentialTotalsResponse ApiDataResponse[NetworkWithdrawalCredentialTotals]
+
+// ------------------------------------------------------------
+// BLS Changes
+
+type NetworkBlsChangeTableRow struct {
+	Slot                 uint64  `json:"slot"`
+	Epoch                uint64  `json:"epoch"`
+	Timestamp            int64   `json:"timestamp"`
+	Index                uint64  `json:"index"`
+	Signature            Hash    `json:"signature"`
+	BlsPubkey            Hash    `json:"bls_pubkey"`
+	NewWithdrawalAddress Address `json:"new_withdrawal_address"`
+}
+
+type GetNetworkBlsChangesResponse ApiPagingResponse[NetworkBlsChangeTableRow]
+
+type GetNetworkBlockBlsChangesResponse ApiDataResponse[[]NetworkBlsChangeTableRow]
+
+// ------------------------------------------------------------
+// Blobs
+
+type NetworkBlob struct {
+	VersionedHash Hash   `json:"versioned_hash"`
+	Slot          uint64 `json:"slot"`
+	Index         uint64 `json:"index"`
+	BlockRoot     Hash   `json:"block_root"`
+	ProposerIndex uint64 `json:"proposer_index"`
+	ParentRoot    Hash   `json:"parent_root"`
+	StateRoot     Hash   `json:"state_root"`
+	KzgCommitment Hash   `json:"kzg_commitment"`
+	KzgProof      Hash   `json:"kzg_proof"`
+	Size          uint64 `json:"size"`
+	Data          string `json:"data,omitempty"` // hex encoded, empty if the blob has not been archived (yet)
+}
+
+type GetNetworkBlobResponse ApiDataResponse[NetworkBlob]
+
+type GetNetworkBlockBlobsResponse ApiDataResponse[[]NetworkBlob]
+
+// ------------------------------------------------------------
+// Blob Analytics
+
+type NetworkBlobBlockTableRow struct {
+	Slot          uint64          `json:"slot"`
+	Block         uint64          `json:"block"`
+	Timestamp     int64           `json:"timestamp"`
+	BlobCount     uint64          `json:"blob_count"`
+	BlobGasUsed   uint64          `json:"blob_gas_used"`
+	ExcessBlobGas uint64          `json:"excess_blob_gas"`
+	BlobBaseFee   decimal.Decimal `json:"blob_base_fee"`
+}
+
+type GetNetworkBlobBlocksResponse ApiPagingResponse[NetworkBlobBlockTableRow]
+
+// categories are the start timestamps of the days, series ids are 'blob_count', 'blob_gas_used', 'burned_blob_fees', 'avg_blob_gas_used', 'avg_excess_blob_gas', 'avg_blob_base_fee' and 'avg_blobs_per_block'
+type GetNetworkBlobHistoryResponse ApiDataResponse[ChartData[string, float64]]
+
+type NetworkBlobSubmitter struct {
+	Address          Address         `json:"address"` // rollups are identified by the address label
+	TransactionCount uint64          `json:"transaction_count"`
+	BlobCount        uint64          `json:"blob_count"`
+	BlobGasUsed      uint64          `json:"blob_gas_used"`
+	BlobFees         decimal.Decimal `json:"blob_fees"`
+	Share            float64         `json:"share"` // share of all blobs submitted in the period
+}
+
+type GetNetworkBlobSubmittersResponse ApiDataResponse[[]NetworkBlobSubmitter]
+
+// ------------------------------------------------------------
+// Transactions
+
+type NetworkDecodedArgument struct {
+	Name    string `json:"name,omitempty"` // empty if only the signature is known
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+type NetworkDecodedCall struct {
+	Name      string                   `json:"name"`
+	Signature string                   `json:"signature"`
+	Source    string                   `json:"source" tstype:"'abi' | 'signature'" faker:"oneof: abi, signature"`
+	Arguments []NetworkDecodedArgument `json:"arguments"` // arguments of events decoded by signature are not known
+}
+
+type NetworkTransactionLog struct {
+	Index   uint64              `json:"index"`
+	Address Address             `json:"address"`
+	Topics  []Hash              `json:"topics"`
+	Data    string              `json:"data"`
+	Decoded *NetworkDecodedCall `json:"decoded,omitempty"`
+}
+
+type NetworkTransactionFees struct {
+	Total    decimal.Decimal  `json:"total"`
+	Burned   decimal.Decimal  `json:"burned"`             // base fee of the used gas
+	Priority decimal.Decimal  `json:"priority"`           // received by the fee recipient of the block
+	BlobFee  *decimal.Decimal `json:"blob_fee,omitempty"` // burned for the blobs of the transaction, not part of the total
+}
+
+type NetworkTransaction struct {
+	Hash             Hash                    `json:"hash"`
+	Block            uint64                  `json:"block"`
+	TransactionIndex uint64                  `json:"transaction_index"`
+	Timestamp        int64                   `json:"timestamp"`
+	Status           string                  `json:"status" tstype:"'success' | 'failed'" faker:"oneof: success, failed"`
+	ErrorMessage     string                  `json:"error_message,omitempty"`
+	Type             uint32                  `json:"type"`
+	Nonce            uint64                  `json:"nonce"`
+	Method           string                  `json:"method"`
+	From             Address                 `json:"from"`
+	To               *Address                `json:"to,omitempty"`               // empty for contract creations
+	ContractCreated  *Address                `json:"contract_created,omitempty"` // set for contract creations
+	Value            decimal.Decimal         `json:"value"`
+	GasLimit         uint64                  `json:"gas_limit"`
+	GasUsed          uint64                  `json:"gas_used"`
+	GasPrice         decimal.Decimal         `json:"gas_price"` // effective gas price
+	Fees             NetworkTransactionFees  `json:"fees"`
+	Input            string                  `json:"input"`
+	DecodedInput     *NetworkDecodedCall     `json:"decoded_input,omitempty"`
+	Logs             []NetworkTransactionLog `json:"logs"`
+}
+
+type GetNetworkTransactionResponse ApiDataResponse[NetworkTransaction]
+
+type NetworkTransactionTableRow struct {
+	Hash             Hash                   `json:"hash"`
+	Block            uint64                 `json:"block"`
+	TransactionIndex uint64                 `json:"transaction_index"`
+	Timestamp        int64                  `json:"timestamp"`
+	Status           string                 `json:"status" tstype:"'success' | 'failed'" faker:"oneof: success, failed"`
+	Method           string                 `json:"method"`
+	From             Address                `json:"from"`
+	To               *Address               `json:"to,omitempty"`               // empty for contract creations
+	ContractCreated  *Address               `json:"contract_created,omitempty"` // set for contract creations
+	Value            decimal.Decimal        `json:"value"`
+	GasPrice         decimal.Decimal        `json:"gas_price"` // effective gas price
+	Fees             NetworkTransactionFees `json:"fees"`
+}
+
+type GetNetworkTransactionsResponse ApiPagingResponse[NetworkTransactionTableRow]
+
+type GetNetworkBlockTransactionsResponse ApiDataResponse[[]NetworkTransactionTableRow]
+
+// token transfers and internal transactions reference the transaction they are part of
+type NetworkAddressTransactionTableRow struct {
+	Type      string           `json:"type" tstype:"'general' | 'internal' | 'erc20' | 'erc721' | 'erc1155'" faker:"oneof: general, internal, erc20, erc721, erc1155"`
+	Hash      Hash             `json:"hash"`
+	Block     uint64           `json:"block"`
+	Timestamp int64            `json:"timestamp"`
+	Status    string           `json:"status,omitempty" tstype:"'success' | 'failed'" faker:"oneof: success, failed"` // general transactions only
+	Method    string           `json:"method,omitempty"`                                                              // method of general and call type of internal transactions
+	Direction string           `json:"direction" tstype:"'in' | 'out' | 'self'" faker:"oneof: in, out, self"`
+	From      Address          `json:"from"`
+	To        *Address         `json:"to,omitempty"` // empty for contract creations
+	Value     decimal.Decimal  `json:"value"`        // amount of the native currency or of the transferred tokens
+	Token     *Address         `json:"token,omitempty"`
+	TokenId   string           `json:"token_id,omitempty"`
+	Fee       *decimal.Decimal `json:"fee,omitempty"` // general transactions only
+}
+
+type GetNetworkAddressTransactionsResponse ApiPagingResponse[NetworkAddressTransactionTableRow]
+
+type NetworkContractAbi struct {
+	Address Hash   `json:"address"`
+	Name    string `json:"name"`
+	Methods uint64 `json:"methods"`
+	Events  uint64 `json:"events"`
+}
+
+type PutNetworkContractAbiResponse ApiDataResponse[NetworkContractAbi]
+
+// ------------------------------------------------------------
+// Address History
+
+type NetworkAddressBalanceSnapshot struct {
+	Block     uint64           `json:"block"`
+	Timestamp int64            `json:"timestamp"`
+	Balance   *decimal.Decimal `json:"balance,omitempty"` // missing if the balance call of the token reverted
+}
+
+type GetNetworkAddressBalanceHistoryResponse ApiPagingResponse[NetworkAddressBalanceSnapshot]
+
+type NetworkTokenSupplySnapshot struct {
+	Block       uint64           `json:"block"`
+	Timestamp   int64            `json:"timestamp"`
+	TotalSupply *decimal.Decimal `json:"total_supply,omitempty"` // missing if the total supply call of the token reverted
+}
+
+type GetNetworkAddressTokenSupplyHistoryResponse ApiPagingResponse[NetworkTokenSupplySnapshot]
+
+type NetworkAddressEventLog struct {
+	TransactionHash  Hash                `json:"transaction_hash"`
+	Block            uint64              `json:"block"`
+	Timestamp        int64               `json:"timestamp"`
+	TransactionIndex uint64              `json:"transaction_index"`
+	LogIndex         uint64              `json:"log_index"` // index of the log within its transaction
+	Topics           []Hash              `json:"topics"`
+	Data             string              `json:"data"`
+	Decoded          *NetworkDecodedCall `json:"decoded,omitempty"`
+}
+
+type GetNetworkAddressEventLogsResponse ApiPagingResponse[NetworkAddressEventLog]
+
+// ------------------------------------------------------------
+// Gas
+
+type NetworkBaseFeePrediction struct {
+	BlocksAhead uint64          `json:"blocks_ahead"`
+	Min         decimal.Decimal `json:"min"` // base fee if all blocks until then are empty
+	Max         decimal.Decimal `json:"max"` // base fee if all blocks until then are full
+}
+
+type NetworkGasNow struct {
+	Timestamp          int64                      `json:"timestamp"`
+	BaseFee            decimal.Decimal            `json:"base_fee"` // base fee of the next block
+	Slow               decimal.Decimal            `json:"slow"`
+	Standard           decimal.Decimal            `json:"standard"`
+	Fast               decimal.Decimal            `json:"fast"`
+	Rapid              decimal.Decimal            `json:"rapid"`
+	BaseFeePredictions []NetworkBaseFeePrediction `json:"base_fee_predictions"`
+}
+
+type GetNetworkGasNowResponse ApiDataResponse[NetworkGasNow]
+
+// categories are the start timestamps of the days, series id is 'avg_gas_limit'
+type GetNetworkAverageGasLimitHistoryResponse ApiDataResponse[ChartData[string, float64]]
+
+// categories are the start timestamps of the days, series ids are 'total_gas_used', 'avg_gas_used', 'non_failed_tx_gas_used' and 'avg_block_utilization'
+type GetNetworkGasUsedHistoryResponse ApiDataResponse[ChartData[string, float64]]
+
+// ------------------------------------------------------------
+// Layer 2
+
+type NetworkBatchTableRow struct {
+	Index               *uint64         `json:"index,omitempty"` // only set if the rollup numbers its batches on layer 1
+	TxHash              Hash            `json:"tx_hash"`
+	Block               uint64          `json:"block"`
+	TransactionIndex    uint64          `json:"transaction_index"`
+	Timestamp           int64           `json:"timestamp"`
+	Submitter           Address         `json:"submitter"`
+	DataLocation        string          `json:"data_location" tstype:"'calldata' | 'blob'" faker:"oneof: calldata, blob"`
+	Size                uint64          `json:"size"` // bytes
+	BlobVersionedHashes []Hash          `json:"blob_versioned_hashes,omitempty"`
+	Fee                 decimal.Decimal `json:"fee"` // execution and blob fees paid on layer 1
+}
+
+type GetNetworkBatchesResponse ApiPagingResponse[NetworkBatchTableRow]
+
+// from, to and value are empty if the bridge doesn't emit them on layer 1
+type NetworkLayer1ToLayer2TransactionTableRow struct {
+	TxHash           Hash             `json:"tx_hash"`
+	Block            uint64           `json:"block"`
+	TransactionIndex uint64           `json:"transaction_index"`
+	LogIndex         uint64           `json:"log_index"`
+	Timestamp        int64            `json:"timestamp"`
+	MessageId        *Hash            `json:"message_id,omitempty"`
+	From             *Address         `json:"from,omitempty"`
+	To               *Address         `json:"to,omitempty"` // recipient on layer 2
+	Value            *decimal.Decimal `json:"value,omitempty"`
+}
+
+type GetNetworkLayer1ToLayer2TransactionsResponse ApiPagingResponse[NetworkLayer1ToLayer2TransactionTableRow]
+
+// withdrawals of op-stack rollups are listed once they are proven and again once they are finalized
+type NetworkLayer2ToLayer1TransactionTableRow struct {
+	TxHash           Hash             `json:"tx_hash"`
+	Block            uint64           `json:"block"`
+	TransactionIndex uint64           `json:"transaction_index"`
+	LogIndex         uint64           `json:"log_index"`
+	Timestamp        int64            `json:"timestamp"`
+	Status           string           `json:"status" tstype:"'proven' | 'finalized' | 'failed'" faker:"oneof: proven, finalized, failed"`
+	MessageId        *Hash            `json:"message_id,omitempty"`
+	From             *Address         `json:"from,omitempty"` // sender on layer 2
+	To               *Address         `json:"to,omitempty"`
+	Value            *decimal.Decimal `json:"value,omitempty"`
+}
+
+type GetNetworkLayer2ToLayer1TransactionsResponse ApiPagingResponse[NetworkLayer2ToLayer1TransactionTableRow]
+
+// ------------------------------------------------------------
+// ENS
+
+type NetworkEnsName struct {
+	Name        string            `json:"name"`
+	Address     Address           `json:"address"`    // address the name resolves to
+	IsPrimary   bool              `json:"is_primary"` // the address reverse resolves to the name
+	Expiry      int64             `json:"expiry"`     // timestamp of the expiry of the registration
+	Resolver    *Address          `json:"resolver,omitempty"`
+	TextRecords map[string]string `json:"text_records"`
+}
+
+type GetNetworkEnsResponse ApiDataResponse[NetworkEnsName]
+
+type NetworkAddressEns struct {
+	PrimaryName *NetworkEnsName  `json:"primary_name,omitempty"`
+	Names       []NetworkEnsName `json:"names"` // all names that resolve to the address, including the primary name
+}
+
+type GetNetworkAddressEnsResponse ApiDataResponse[NetworkAddressEns]
+
+// ------------------------------------------------------------
+// User Operations (ERC-4337)
+
+type NetworkUserOperationTableRow struct {
+	Hash             Hash            `json:"hash"`
+	TxHash           Hash            `json:"tx_hash"`
+	Block            uint64          `json:"block"`
+	TransactionIndex uint64          `json:"transaction_index"`
+	LogIndex         uint64          `json:"log_index"`
+	Timestamp        int64           `json:"timestamp"`
+	EntryPoint       Address         `json:"entry_point"`
+	Sender           Address         `json:"sender"`
+	Paymaster        *Address        `json:"paymaster,omitempty"` // empty if the account paid for the gas itself
+	Bundler          Address         `json:"bundler"`
+	Nonce            decimal.Decimal `json:"nonce"`
+	Success          bool            `json:"success"`
+	GasUsed          uint64          `json:"gas_used"`
+	GasCost          decimal.Decimal `json:"gas_cost"`
+}
+
+type GetNetworkAddressUserOperationsResponse ApiPagingResponse[NetworkUserOperationTableRow]
+
+// sponsored gas of a paymaster, aggregated per finished day
+type NetworkPaymasterSponsorship struct {
+	OperationCount uint64          `json:"operation_count"`
+	GasUsed        uint64          `json:"gas_used"`
+	GasCost        decimal.Decimal `json:"gas_cost"`
+}
+
+type GetNetworkAddressSponsorshipResponse ApiDataResponse[NetworkPaymasterSponsorship]
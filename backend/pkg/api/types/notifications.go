@@ -41,7 +41,7 @@ type NotificationDashboardsTableRow struct {
 	GroupId            uint64         `db:"group_id" json:"group_id"`
 	GroupName          string         `db:"group_name" json:"group_name"`
 	EntityCount        uint64         `db:"entity_count" json:"entity_count"`
-	EventTypes         pq.StringArray `db:"event_types" json:"event_types" tstype:"('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[]" faker:"slice_len=2, oneof: validator_online, validator_offline, group_efficiency_below, attestation_missed, proposal_success, proposal_missed, proposal_upcoming, max_collateral, min_collateral, sync, withdrawal, validator_got_slashed, validator_has_slashed, incoming_tx, outgoing_tx, transfer_erc20, transfer_erc721, transfer_erc1155"`
+	EventTypes         pq.StringArray `db:"event_types" json:"event_types" tstype:"('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'performance_anomaly' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'slashing_risk' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[]" faker:"slice_len=2, oneof: validator_online, validator_offline, group_efficiency_below, performance_anomaly, attestation_missed, proposal_success, proposal_missed, proposal_upcoming, max_collateral, min_collateral, sync, withdrawal, validator_got_slashed, slashing_risk, validator_has_slashed, incoming_tx, outgoing_tx, transfer_erc20, transfer_erc721, transfer_erc1155"`
 }
 
 type InternalGetUserNotificationDashboardsResponse ApiPagingResponse[NotificationDashboardsTableRow]
@@ -60,15 +60,30 @@ type NotificationEventWithdrawal struct {
 	Address Address         `json:"address"`
 }
 
+type NotificationEventPerformanceAnomaly struct {
+	Duty     string  `json:"duty" tstype:"'source' | 'target' | 'head' | 'inclusion_delay' | 'sync'" faker:"oneof: source, target, head, inclusion_delay, sync"`
+	Value    float64 `json:"value"`
+	Baseline float64 `json:"baseline"`
+	ZScore   float64 `json:"z_score"`
+}
+
+type NotificationEventSlashingRisk struct {
+	Index uint64 `json:"index"`
+	Slot  uint64 `json:"slot"`
+	Type  string `json:"type" tstype:"'double_vote' | 'surround_vote' | 'double_proposal'" faker:"oneof: double_vote, surround_vote, double_proposal"`
+}
+
 type NotificationValidatorDashboardDetail struct {
 	DashboardName            string                                 `db:"dashboard_name" json:"dashboard_name"`
 	GroupName                string                                 `db:"group_name" json:"group_name"`
 	ValidatorOffline         []uint64                               `json:"validator_offline"`                // validator indices
 	GroupEfficiencyBelow     float64                                `json:"group_efficiency_below,omitempty"` // fill with the `group_efficiency_below` threshold if event is present
+	PerformanceAnomalies     []NotificationEventPerformanceAnomaly  `json:"performance_anomalies"`
 	ProposalMissed           []IndexSlots                           `json:"proposal_missed"`
 	ProposalDone             []IndexBlocks                          `json:"proposal_done"`
 	UpcomingProposals        []IndexSlots                           `json:"upcoming_proposals"`
-	Slashed                  []uint64                               `json:"slashed"`            // validator indices
+	Slashed                  []uint64                               `json:"slashed"` // validator indices
+	SlashingRisks            []NotificationEventSlashingRisk        `json:"slashing_risks"`
 	SyncCommittee            []uint64                               `json:"sync_committee"`     // validator indices
 	AttestationMissed        []IndexEpoch                           `json:"attestation_missed"` // index (epoch)
 	Withdrawal               []NotificationEventWithdrawal          `json:"withdrawal"`
@@ -194,12 +209,14 @@ type NotificationSettingsValidatorDashboard struct {
 	IsValidatorOfflineSubscribed      bool    `json:"is_validator_offline_subscribed"`
 	IsGroupEfficiencyBelowSubscribed  bool    `json:"is_group_efficiency_below_subscribed"`
 	GroupEfficiencyBelowThreshold     float64 `json:"group_efficiency_below_threshold" faker:"boundary_start=0, boundary_end=1"`
+	IsPerformanceAnomalySubscribed    bool    `json:"is_performance_anomaly_subscribed"`
+	PerformanceAnomalyThreshold       float64 `json:"performance_anomaly_threshold" faker:"boundary_start=1, boundary_end=10"` // number of standard deviations a duty has to degrade by
 	IsAttestationsMissedSubscribed    bool    `json:"is_attestations_missed_subscribed"`
 	IsBlockProposalSubscribed         bool    `json:"is_block_proposal_subscribed"`
 	IsUpcomingBlockProposalSubscribed bool    `json:"is_upcoming_block_proposal_subscribed"`
 	IsSyncSubscribed                  bool    `json:"is_sync_subscribed"`
 	IsWithdrawalProcessedSubscribed   bool    `json:"is_withdrawal_processed_subscribed"`
-	IsSlashedSubscribed               bool    `json:"is_slashed_subscribed"`
+	IsSlashedSubscribed               bool    `json:"is_slashed_subscribed"` // also enables urgent warnings if a validator signs conflicting messages that could get it slashed
 
 	IsMaxCollateralSubscribed bool    `json:"is_max_collateral_subscribed"`
 	MaxCollateralThreshold    float64 `json:"max_collateral_threshold" faker:"boundary_start=0, boundary_end=1"`
@@ -209,6 +226,15 @@ type NotificationSettingsValidatorDashboard struct {
 
 type InternalPutUserNotificationSettingsValidatorDashboardResponse ApiDataResponse[NotificationSettingsValidatorDashboard]
 
+// notification settings that only apply to the validators of a group that have a tag, webhooks are configured per group
+type NotificationSettingsValidatorDashboardTag struct {
+	GroupId  uint64                                 `json:"group_id"`
+	Tag      VDBValidatorTag                        `json:"tag"`
+	Settings NotificationSettingsValidatorDashboard `json:"settings"`
+}
+
+type InternalGetUserNotificationSettingsValidatorDashboardTagsResponse ApiDataResponse[[]NotificationSettingsValidatorDashboardTag]
+
 type NotificationSettingsAccountDashboard struct {
 	WebhookUrl                      string   `json:"webhook_url" faker:"url"`
 	IsWebhookDiscordEnabled         bool     `json:"is_webhook_discord_enabled"`
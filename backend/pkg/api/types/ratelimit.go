@@ -8,3 +8,27 @@ type ApiWeightItem struct {
 }
 
 type InternalGetRatelimitWeightsResponse ApiDataResponse[[]ApiWeightItem]
+
+type ApiUsageRoute struct {
+	Route    string `json:"route"`
+	Requests int64  `json:"requests"`
+	Consumed int64  `json:"consumed"` // requests multiplied by the current weight of the route
+}
+
+type ApiUsageBucket struct {
+	Bucket      string          `json:"bucket"`
+	Requests    int64           `json:"requests"`
+	Consumed    int64           `json:"consumed"`
+	LimitSecond int64           `json:"limit_second"` // 0 means unlimited
+	LimitHour   int64           `json:"limit_hour"`
+	LimitMonth  int64           `json:"limit_month"`
+	Routes      []ApiUsageRoute `json:"routes"` // sorted by consumption
+}
+
+type UserApiUsage struct {
+	StartTs int64            `json:"start_ts"`
+	EndTs   int64            `json:"end_ts"`
+	Buckets []ApiUsageBucket `json:"buckets"`
+}
+
+type GetUserApiUsageResponse ApiDataResponse[UserApiUsage]
@@ -43,3 +43,25 @@ type SlotVizEpoch struct {
 }
 
 type GetValidatorDashboardSlotVizResponse ApiDataResponse[[]SlotVizEpoch]
+
+// ------------------------------------------------------------
+// Live Updates
+type VDBLiveDutyResult struct {
+	Slot       uint64   `json:"slot"`
+	Type       string   `json:"type" tstype:"'proposal' | 'attestation' | 'sync' | 'slashing'" faker:"oneof: proposal, attestation, sync, slashing"`
+	Result     string   `json:"result" tstype:"'success' | 'failed'" faker:"oneof: success, failed"` // failed slashing means the validators got slashed
+	Validators []uint64 `json:"validators"`
+}
+
+// Sent as `slot-viz` event, only contains the epochs and slots that changed since the last event unless `reset` is set.
+// Clients should only keep the 4 most recent epochs.
+type VDBLiveSlotVizUpdate struct {
+	Reset  bool           `json:"reset,omitempty"` // epochs contains the full slot viz, previous data should be discarded
+	Epochs []SlotVizEpoch `json:"epochs"`
+}
+
+type VDBLiveUpdate struct {
+	Id      uint64                `json:"id"`                 // sent as event id, pass it as `Last-Event-ID` to resume a stream
+	SlotViz *VDBLiveSlotVizUpdate `json:"slot_viz,omitempty"` // nil if nothing changed
+	Duties  []VDBLiveDutyResult   `json:"duties,omitempty"`   // sent as `duties` event, duty results that became available since the last event
+}
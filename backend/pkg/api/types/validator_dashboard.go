@@ -61,6 +61,7 @@ type VDBSummaryTableRow struct {
 	Attestations             StatusCount                `json:"attestations"`
 	Proposals                StatusCount                `json:"proposals"`
 	Reward                   ClElValue[decimal.Decimal] `json:"reward" faker:"cl_el_eth"`
+	Tag                      *VDBValidatorTag           `json:"tag,omitempty"` // set if grouped by tag, the group id is only valid within the response then
 }
 type GetValidatorDashboardSummaryResponse ApiPagingResponse[VDBSummaryTableRow]
 
@@ -282,6 +283,29 @@ type VDBTotalWithdrawalsData struct {
 
 type GetValidatorDashboardTotalWithdrawalsResponse ApiDataResponse[VDBTotalWithdrawalsData]
 
+// ------------------------------------------------------------
+// Credential Migration
+type VDBPendingBlsChange struct {
+	Index       uint64 `json:"index"`
+	BroadcastId string `json:"broadcast_id"`
+	Status      string `json:"status" tstype:"'pending' | 'submitted'" faker:"oneof: pending, submitted"`
+}
+
+type VDBCompletedBlsChange struct {
+	Index                uint64  `json:"index"`
+	Slot                 uint64  `json:"slot"`
+	Timestamp            int64   `json:"timestamp"`
+	NewWithdrawalAddress Address `json:"new_withdrawal_address"`
+}
+
+type VDBBlsChangesData struct {
+	Bls       []uint64                `json:"bls"`     // validators that still use 0x00 withdrawal credentials and have no pending change
+	Pending   []VDBPendingBlsChange   `json:"pending"` // changes that were broadcast but are not included in a block yet
+	Completed []VDBCompletedBlsChange `json:"completed"`
+}
+
+type GetValidatorDashboardBlsChangesResponse ApiDataResponse[VDBBlsChangesData]
+
 // ------------------------------------------------------------
 // Rocket Pool Tab
 type VDBRocketPoolTableRow struct {
@@ -336,20 +360,114 @@ type VDBRocketPoolMinipoolsTableRow struct {
 }
 type GetValidatorDashboardRocketPoolMinipoolsResponse ApiPagingResponse[VDBRocketPoolMinipoolsTableRow]
 
+// ------------------------------------------------------------
+// Income Reports
+type VDBIncomeReport struct {
+	Id          string  `json:"id"`
+	Status      string  `json:"status" tstype:"'pending' | 'running' | 'completed' | 'failed'" faker:"oneof: pending, running, completed, failed"`
+	Format      string  `json:"format" tstype:"'csv' | 'pdf'" faker:"oneof: csv, pdf"`
+	Aggregation string  `json:"aggregation" tstype:"'validator' | 'group'" faker:"oneof: validator, group"`
+	GroupId     *int64  `json:"group_id,omitempty"`
+	StartTs     int64   `json:"start_ts"`
+	EndTs       int64   `json:"end_ts"`
+	Currency    string  `json:"currency"`
+	CreatedTs   int64   `json:"created_ts"`
+	CompletedTs *int64  `json:"completed_ts,omitempty"`
+	Error       *string `json:"error,omitempty"`
+}
+
+type PostValidatorDashboardIncomeReportsResponse ApiDataResponse[VDBIncomeReport]
+
+type GetValidatorDashboardIncomeReportResponse ApiDataResponse[VDBIncomeReport]
+
 // ------------------------------------------------------------
 // Manage Modal
 type VDBManageValidatorsTableRow struct {
-	Index                uint64          `json:"index"`
-	PublicKey            PubKey          `json:"public_key"`
-	GroupId              uint64          `json:"group_id"`
-	Balance              decimal.Decimal `json:"balance"`
-	Status               string          `json:"status" tstype:"'slashed' | 'exited' | 'deposited' | 'pending' | 'slashing_offline' | 'slashing_online' | 'exiting_offline' | 'exiting_online' | 'active_offline' | 'active_online'" faker:"oneof: slashed, exited, deposited, pending, slashing_offline, slashing_online, exiting_offline, exiting_online, active_offline, active_online"`
-	QueuePosition        *uint64         `json:"queue_position,omitempty"`
-	WithdrawalCredential Hash            `json:"withdrawal_credential"`
+	Index                uint64            `json:"index"`
+	PublicKey            PubKey            `json:"public_key"`
+	GroupId              uint64            `json:"group_id"`
+	Balance              decimal.Decimal   `json:"balance"`
+	Status               string            `json:"status" tstype:"'slashed' | 'exited' | 'deposited' | 'pending' | 'slashing_offline' | 'slashing_online' | 'exiting_offline' | 'exiting_online' | 'active_offline' | 'active_online'" faker:"oneof: slashed, exited, deposited, pending, slashing_offline, slashing_online, exiting_offline, exiting_online, active_offline, active_online"`
+	QueuePosition        *uint64           `json:"queue_position,omitempty"`
+	WithdrawalCredential Hash              `json:"withdrawal_credential"`
+	IsSafeWithdrawal     bool              `json:"is_safe_withdrawal"` // the withdrawal address is a Safe multisig
+	Tags                 map[string]string `json:"tags,omitempty"`
 }
 
 type GetValidatorDashboardValidatorsResponse ApiPagingResponse[VDBManageValidatorsTableRow]
 
+// ------------------------------------------------------------
+// Tags
+type VDBValidatorTag struct {
+	Key   string `db:"tag_key" json:"key"`
+	Value string `db:"tag_value" json:"value"`
+}
+
+type VDBTagKey struct {
+	Key            string   `json:"key"`
+	Values         []string `json:"values"`
+	ValidatorCount uint64   `json:"validator_count"`
+}
+
+type GetValidatorDashboardTagsResponse ApiDataResponse[[]VDBTagKey]
+
+// ------------------------------------------------------------
+// Import / Export
+type VDBExportValidator struct {
+	Index     uint64            `json:"index"`
+	PublicKey PubKey            `json:"public_key"` // used to match validators on import, indices are ignored as they differ between networks
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+type VDBExportTagNotificationSettings struct {
+	Tag      VDBValidatorTag                        `json:"tag"`
+	Settings NotificationSettingsValidatorDashboard `json:"settings"`
+}
+
+type VDBExportGroup struct {
+	Id                      uint64                                  `json:"id"`
+	Name                    string                                  `json:"name"`
+	Validators              []VDBExportValidator                    `json:"validators"`
+	NotificationSettings    *NotificationSettingsValidatorDashboard `json:"notification_settings,omitempty"`
+	TagNotificationSettings []VDBExportTagNotificationSettings      `json:"tag_notification_settings,omitempty"` // settings that only apply to the validators of the group with a tag
+}
+
+type VDBExportPublicId struct {
+	Name        string `db:"name" json:"name"`
+	ShareGroups bool   `db:"share_groups" json:"share_groups"`
+}
+
+type VDBExport struct {
+	Version   uint64              `json:"version"`
+	Name      string              `json:"name"`
+	Network   uint64              `json:"network"`
+	Groups    []VDBExportGroup    `json:"groups"`
+	PublicIds []VDBExportPublicId `json:"public_ids,omitempty"`
+}
+
+type GetValidatorDashboardExportResponse ApiDataResponse[VDBExport]
+
+type VDBImportGroupResult struct {
+	Id              *uint64 `json:"id,omitempty"` // not set for groups that would be created in a dry run
+	Name            string  `json:"name"`
+	IsNew           bool    `json:"is_new"`
+	ValidatorsAdded uint64  `json:"validators_added"`
+	ValidatorsMoved uint64  `json:"validators_moved"` // validators of the dashboard that are moved to this group
+}
+
+type VDBImportResult struct {
+	DryRun            bool                   `json:"dry_run"`
+	Groups            []VDBImportGroupResult `json:"groups"`
+	ValidatorsAdded   uint64                 `json:"validators_added"`
+	ValidatorsMoved   uint64                 `json:"validators_moved"`
+	UnknownValidators []string               `json:"unknown_validators"` // public keys that don't exist on the dashboard network
+	SkippedValidators []string               `json:"skipped_validators"` // exceed the validator limit of the subscription plan
+	SkippedGroups     []string               `json:"skipped_groups"`     // exceed the group limit of the subscription plan, their validators are skipped too
+	PublicIdsCreated  uint64                 `json:"public_ids_created"`
+}
+
+type PostValidatorDashboardImportResponse ApiDataResponse[VDBImportResult]
+
 // ------------------------------------------------------------
 // Misc.
 type VDBPostReturnData struct {
@@ -1,7 +1,6 @@
 package blobindexer
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gobitfly/beaconchain/pkg/commons/blobstore"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
@@ -23,11 +23,6 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/consapi/network"
 	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	lru "github.com/hashicorp/golang-lru/v2"
 	"golang.org/x/sync/errgroup"
 )
@@ -36,7 +31,7 @@ var enableCheckingBeforePutting = false
 var waitForOtherBlobIndexerDuration = time.Second * 60
 
 type BlobIndexer struct {
-	S3Client          *s3.Client
+	Store             blobstore.Store
 	running           bool
 	runningMu         *sync.Mutex
 	clEndpoint        string
@@ -48,21 +43,10 @@ type BlobIndexer struct {
 
 func NewBlobIndexer() (*BlobIndexer, error) {
 	initDB()
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
-			utils.Config.BlobIndexer.S3.AccessKeyId,
-			utils.Config.BlobIndexer.S3.AccessKeySecret,
-			"",
-		)),
-		config.WithRegion("auto"),
-	)
+	store, err := blobstore.New(context.TODO(), utils.Config)
 	if err != nil {
 		return nil, err
 	}
-	s3Client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		o.UsePathStyle = true
-		o.BaseEndpoint = aws.String(utils.Config.BlobIndexer.S3.Endpoint)
-	})
 
 	writtenBlobsCache, err := lru.New[string, bool](1000)
 	if err != nil {
@@ -71,7 +55,7 @@ func NewBlobIndexer() (*BlobIndexer, error) {
 
 	id := utils.GetUUID()
 	bi := &BlobIndexer{
-		S3Client:          s3Client,
+		Store:             store,
 		runningMu:         &sync.Mutex{},
 		clEndpoint:        "http://" + utils.Config.Indexer.Node.Host + ":" + utils.Config.Indexer.Node.Port,
 		cl:                consapi.NewClient("http://" + utils.Config.Indexer.Node.Host + ":" + utils.Config.Indexer.Node.Port),
@@ -118,7 +102,7 @@ func (bi *BlobIndexer) Start() {
 	bi.running = true
 	bi.runningMu.Unlock()
 
-	log.InfoWithFields(log.Fields{"version": version.Version, "clEndpoint": bi.clEndpoint, "s3Endpoint": utils.Config.BlobIndexer.S3.Endpoint, "id": bi.id}, "starting blobindexer")
+	log.InfoWithFields(log.Fields{"version": version.Version, "clEndpoint": bi.clEndpoint, "s3Endpoint": utils.Config.BlobIndexer.S3.Endpoint, "localPath": utils.Config.BlobIndexer.LocalPath, "id": bi.id}, "starting blobindexer")
 	for {
 		err := bi.index()
 		if err != nil {
@@ -340,8 +324,7 @@ func (bi *BlobIndexer) indexBlobsAtSlot(slot uint64) (int, error) {
 	g.SetLimit(4)
 	for _, d := range blobSidecar.Data {
 		d := d
-		versionedBlobHash := fmt.Sprintf("%#x", utils.VersionedBlobHash(d.KzgCommitment).Bytes())
-		key := fmt.Sprintf("%s/blobs/%s", bi.networkID, versionedBlobHash)
+		key := blobstore.BlobKey(bi.networkID, utils.VersionedBlobHash(d.KzgCommitment).Bytes())
 
 		if bi.writtenBlobsCache.Contains(key) {
 			continue
@@ -356,36 +339,29 @@ func (bi *BlobIndexer) indexBlobsAtSlot(slot uint64) (int, error) {
 
 			if enableCheckingBeforePutting {
 				tS3HeadObj := time.Now()
-				_, err = bi.S3Client.HeadObject(gCtx, &s3.HeadObjectInput{
-					Bucket: &utils.Config.BlobIndexer.S3.Bucket,
-					Key:    &key,
-				})
+				exists, err := bi.Store.Exists(gCtx, key)
 				metrics.TaskDuration.WithLabelValues("blobindexer_check_blob").Observe(time.Since(tS3HeadObj).Seconds())
 				if err != nil {
-					// Only put the object if it does not exist yet
-					var httpResponseErr *awshttp.ResponseError
-					if errors.As(err, &httpResponseErr) && (httpResponseErr.HTTPStatusCode() == http.StatusNotFound || httpResponseErr.HTTPStatusCode() == 403) {
-						return nil
-					}
-					return fmt.Errorf("error getting headObject: %s (%v/%v): %w", key, d.SignedBlockHeader.Message.Slot, d.Index, err)
+					return fmt.Errorf("error checking if blob exists: %s (%v/%v): %w", key, d.SignedBlockHeader.Message.Slot, d.Index, err)
+				}
+				if exists {
+					bi.writtenBlobsCache.Add(key, true)
+					return nil
 				}
 			}
 
 			tS3PutObj := time.Now()
-			_, putErr := bi.S3Client.PutObject(gCtx, &s3.PutObjectInput{
-				Bucket: &utils.Config.BlobIndexer.S3.Bucket,
-				Key:    &key,
-				Body:   bytes.NewReader(d.Blob),
-				Metadata: map[string]string{
-					"blob_index":        fmt.Sprintf("%d", d.Index),
-					"block_slot":        fmt.Sprintf("%d", d.SignedBlockHeader.Message.Slot),
-					"block_proposer":    fmt.Sprintf("%d", d.SignedBlockHeader.Message.ProposerIndex),
-					"block_state_root":  d.SignedBlockHeader.Message.StateRoot.String(),
-					"block_parent_root": d.SignedBlockHeader.Message.ParentRoot.String(),
-					"block_body_root":   d.SignedBlockHeader.Message.BodyRoot.String(),
-					"kzg_commitment":    d.KzgCommitment.String(),
-					"kzg_proof":         d.KzgProof.String(),
-				},
+			// the blob is verified against its kzg commitment and proof before writing so we never archive data the chain did not commit to
+			putErr := blobstore.PutBlobSidecar(gCtx, bi.Store, bi.networkID, &blobstore.BlobSidecar{
+				Index:         d.Index,
+				Slot:          d.SignedBlockHeader.Message.Slot,
+				ProposerIndex: d.SignedBlockHeader.Message.ProposerIndex,
+				StateRoot:     d.SignedBlockHeader.Message.StateRoot,
+				ParentRoot:    d.SignedBlockHeader.Message.ParentRoot,
+				BodyRoot:      d.SignedBlockHeader.Message.BodyRoot,
+				KzgCommitment: d.KzgCommitment,
+				KzgProof:      d.KzgProof,
+				Blob:          d.Blob,
 			})
 			metrics.TaskDuration.WithLabelValues("blobindexer_put_blob").Observe(time.Since(tS3PutObj).Seconds())
 			if putErr != nil {
@@ -411,21 +387,15 @@ func (bi *BlobIndexer) GetIndexerStatus() (*BlobIndexerStatus, error) {
 	}()
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
-	key := fmt.Sprintf("%s/blob-indexer-status.json", bi.networkID)
-	obj, err := bi.S3Client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: &utils.Config.BlobIndexer.S3.Bucket,
-		Key:    &key,
-	})
+	data, _, err := bi.Store.Get(ctx, blobstore.StatusKey(bi.networkID))
 	if err != nil {
-		// If the object that you request doesn’t exist, the error that Amazon S3 returns depends on whether you also have the s3:ListBucket permission. If you have the s3:ListBucket permission on the bucket, Amazon S3 returns an HTTP status code 404 (Not Found) error. If you don’t have the s3:ListBucket permission, Amazon S3 returns an HTTP status code 403 ("access denied") error.
-		var httpResponseErr *awshttp.ResponseError
-		if errors.As(err, &httpResponseErr) && (httpResponseErr.HTTPStatusCode() == 404 || httpResponseErr.HTTPStatusCode() == 403) {
+		if errors.Is(err, blobstore.ErrNotFound) {
 			return &BlobIndexerStatus{}, nil
 		}
 		return nil, err
 	}
 	status := &BlobIndexerStatus{}
-	err = json.NewDecoder(obj.Body).Decode(status)
+	err = json.Unmarshal(data, status)
 	return status, err
 }
 
@@ -436,24 +406,16 @@ func (bi *BlobIndexer) putIndexerStatus(status BlobIndexerStatus) error {
 	}()
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
-	key := fmt.Sprintf("%s/blob-indexer-status.json", bi.networkID)
-	contentType := "application/json"
 	body, err := json.Marshal(&status)
 	if err != nil {
 		return err
 	}
-	_, err = bi.S3Client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      &utils.Config.BlobIndexer.S3.Bucket,
-		Key:         &key,
-		Body:        bytes.NewReader(body),
-		ContentType: &contentType,
-		Metadata: map[string]string{
-			"last_indexed_finalized_slot":      fmt.Sprintf("%d", status.LastIndexedFinalizedSlot),
-			"last_indexed_finalized_blob_slot": fmt.Sprintf("%d", status.LastIndexedFinalizedBlobSlot),
-			"current_blob_indexer_id":          status.CurrentBlobIndexerId,
-			"last_update":                      status.LastUpdate.Format(time.RFC3339),
-			"blob_indexer_version":             status.BlobIndexerVersion,
-		},
+	err = bi.Store.Put(ctx, blobstore.StatusKey(bi.networkID), body, "application/json", map[string]string{
+		"last_indexed_finalized_slot":      fmt.Sprintf("%d", status.LastIndexedFinalizedSlot),
+		"last_indexed_finalized_blob_slot": fmt.Sprintf("%d", status.LastIndexedFinalizedBlobSlot),
+		"current_blob_indexer_id":          status.CurrentBlobIndexerId,
+		"last_update":                      status.LastUpdate.Format(time.RFC3339),
+		"blob_indexer_version":             status.BlobIndexerVersion,
 	})
 	if err != nil {
 		return err
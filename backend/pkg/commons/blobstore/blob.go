@@ -0,0 +1,115 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+// BlobSidecar is a blob together with the sidecar fields that are stored as object metadata
+type BlobSidecar struct {
+	VersionedHash []byte
+	Index         uint64
+	Slot          uint64
+	ProposerIndex uint64
+	StateRoot     []byte
+	ParentRoot    []byte
+	BodyRoot      []byte
+	KzgCommitment []byte
+	KzgProof      []byte
+	Blob          []byte
+}
+
+// BlobKey returns the key of the blob with the given versioned hash
+func BlobKey(networkID string, versionedHash []byte) string {
+	return fmt.Sprintf("%s/blobs/%#x", networkID, versionedHash)
+}
+
+// StatusKey returns the key of the blobindexer status
+func StatusKey(networkID string) string {
+	return fmt.Sprintf("%s/blob-indexer-status.json", networkID)
+}
+
+// VerifyBlob checks that the blob matches the kzg commitment using the kzg proof
+func VerifyBlob(blob, commitment, proof []byte) error {
+	var b kzg4844.Blob
+	var c kzg4844.Commitment
+	var p kzg4844.Proof
+	if len(blob) != len(b) {
+		return fmt.Errorf("invalid blob length %d, expected %d", len(blob), len(b))
+	}
+	if len(commitment) != len(c) {
+		return fmt.Errorf("invalid kzg commitment length %d, expected %d", len(commitment), len(c))
+	}
+	if len(proof) != len(p) {
+		return fmt.Errorf("invalid kzg proof length %d, expected %d", len(proof), len(p))
+	}
+	copy(b[:], blob)
+	copy(c[:], commitment)
+	copy(p[:], proof)
+	return kzg4844.VerifyBlobProof(b, c, p)
+}
+
+// PutBlobSidecar verifies the blob against its kzg commitment and proof and writes it to the store
+func PutBlobSidecar(ctx context.Context, store Store, networkID string, sidecar *BlobSidecar) error {
+	err := VerifyBlob(sidecar.Blob, sidecar.KzgCommitment, sidecar.KzgProof)
+	if err != nil {
+		return fmt.Errorf("error verifying blob %v/%v: %w", sidecar.Slot, sidecar.Index, err)
+	}
+	sidecar.VersionedHash = utils.VersionedBlobHash(sidecar.KzgCommitment).Bytes()
+	return store.Put(ctx, BlobKey(networkID, sidecar.VersionedHash), sidecar.Blob, "", map[string]string{
+		"blob_index":        fmt.Sprintf("%d", sidecar.Index),
+		"block_slot":        fmt.Sprintf("%d", sidecar.Slot),
+		"block_proposer":    fmt.Sprintf("%d", sidecar.ProposerIndex),
+		"block_state_root":  hexutil.Encode(sidecar.StateRoot),
+		"block_parent_root": hexutil.Encode(sidecar.ParentRoot),
+		"block_body_root":   hexutil.Encode(sidecar.BodyRoot),
+		"kzg_commitment":    hexutil.Encode(sidecar.KzgCommitment),
+		"kzg_proof":         hexutil.Encode(sidecar.KzgProof),
+	})
+}
+
+// GetBlobSidecar reads the blob with the given versioned hash from the store, returns ErrNotFound if it has not been indexed
+func GetBlobSidecar(ctx context.Context, store Store, networkID string, versionedHash []byte) (*BlobSidecar, error) {
+	data, metadata, err := store.Get(ctx, BlobKey(networkID, versionedHash))
+	if err != nil {
+		return nil, err
+	}
+	sidecar := &BlobSidecar{
+		VersionedHash: versionedHash,
+		Blob:          data,
+	}
+	for _, field := range []struct {
+		key   string
+		value *uint64
+	}{
+		{"blob_index", &sidecar.Index},
+		{"block_slot", &sidecar.Slot},
+		{"block_proposer", &sidecar.ProposerIndex},
+	} {
+		*field.value, err = strconv.ParseUint(metadata[field.key], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing metadata %s of blob %#x: %w", field.key, versionedHash, err)
+		}
+	}
+	for _, field := range []struct {
+		key   string
+		value *[]byte
+	}{
+		{"block_state_root", &sidecar.StateRoot},
+		{"block_parent_root", &sidecar.ParentRoot},
+		{"block_body_root", &sidecar.BodyRoot},
+		{"kzg_commitment", &sidecar.KzgCommitment},
+		{"kzg_proof", &sidecar.KzgProof},
+	} {
+		*field.value, err = hexutil.Decode(metadata[field.key])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing metadata %s of blob %#x: %w", field.key, versionedHash, err)
+		}
+	}
+	return sidecar, nil
+}
@@ -0,0 +1,77 @@
+package blobstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSidecar(t *testing.T) *BlobSidecar {
+	t.Helper()
+	var blob kzg4844.Blob
+	// every 32 byte field element has to be smaller than the bls modulus, keep the first byte zero
+	for i := 1; i < len(blob); i += 32 {
+		blob[i] = byte(i)
+	}
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	require.NoError(t, err)
+	return &BlobSidecar{
+		Index:         1,
+		Slot:          123,
+		ProposerIndex: 42,
+		StateRoot:     make([]byte, 32),
+		ParentRoot:    make([]byte, 32),
+		BodyRoot:      make([]byte, 32),
+		KzgCommitment: commitment[:],
+		KzgProof:      proof[:],
+		Blob:          blob[:],
+	}
+}
+
+func TestFilesystemStoreBlobRoundtrip(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFilesystemStore(t.TempDir())
+	require.NoError(t, err)
+
+	sidecar := newTestSidecar(t)
+	require.NoError(t, PutBlobSidecar(ctx, store, "1", sidecar))
+	versionedHash := utils.VersionedBlobHash(sidecar.KzgCommitment).Bytes()
+	assert.Equal(t, versionedHash, sidecar.VersionedHash)
+
+	exists, err := store.Exists(ctx, BlobKey("1", versionedHash))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	stored, err := GetBlobSidecar(ctx, store, "1", versionedHash)
+	require.NoError(t, err)
+	assert.Equal(t, sidecar, stored)
+
+	_, err = GetBlobSidecar(ctx, store, "5", versionedHash)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPutBlobSidecarRejectsInvalidProof(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFilesystemStore(t.TempDir())
+	require.NoError(t, err)
+
+	sidecar := newTestSidecar(t)
+	sidecar.Blob[1] ^= 0xff
+	require.Error(t, PutBlobSidecar(ctx, store, "1", sidecar))
+
+	exists, err := store.Exists(ctx, BlobKey("1", utils.VersionedBlobHash(sidecar.KzgCommitment).Bytes()))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestFilesystemStoreRejectsKeysOutsideRoot(t *testing.T) {
+	store, err := NewFilesystemStore(t.TempDir())
+	require.NoError(t, err)
+	assert.Error(t, store.Put(context.Background(), "../escape", []byte{1}, "", nil))
+}
@@ -0,0 +1,116 @@
+package blobstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FilesystemStore stores every object as a file below root, the metadata is kept in a json file next to it
+type FilesystemStore struct {
+	root string
+}
+
+var _ Store = (*FilesystemStore)(nil)
+
+const metadataSuffix = ".metadata.json"
+
+func NewFilesystemStore(root string) (*FilesystemStore, error) {
+	err := os.MkdirAll(root, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating blob storage directory %s: %w", root, err)
+	}
+	return &FilesystemStore{root: root}, nil
+}
+
+func (s *FilesystemStore) path(key string) (string, error) {
+	p := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(p, filepath.Clean(s.root)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid key %s", key)
+	}
+	return p, nil
+}
+
+func (s *FilesystemStore) Put(ctx context.Context, key string, data []byte, contentType string, metadata map[string]string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(p), 0o755)
+	if err != nil {
+		return err
+	}
+	metadataJson, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	// write the metadata first so that an existing object always has metadata
+	err = writeFileAtomic(p+metadataSuffix, metadataJson)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(p, data)
+}
+
+func (s *FilesystemStore) Get(ctx context.Context, key string) ([]byte, map[string]string, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	metadata := map[string]string{}
+	metadataJson, err := os.ReadFile(p + metadataSuffix)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+	if err == nil {
+		err = json.Unmarshal(metadataJson, &metadata)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error decoding metadata of %s: %w", key, err)
+		}
+	}
+	return data, metadata, nil
+}
+
+func (s *FilesystemStore) Exists(ctx context.Context, key string) (bool, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// writeFileAtomic writes to a temporary file first so readers never see partially written objects
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,90 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+type S3Store struct {
+	client *s3.Client
+	bucket string
+}
+
+var _ Store = (*S3Store)(nil)
+
+func NewS3Store(ctx context.Context, endpoint, bucket, accessKeyId, accessKeySecret string) (*S3Store, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKeyId, accessKeySecret, "")),
+		config.WithRegion("auto"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+		o.BaseEndpoint = aws.String(endpoint)
+	})
+	return &S3Store{client: client, bucket: bucket}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, data []byte, contentType string, metadata map[string]string) error {
+	input := &s3.PutObjectInput{
+		Bucket:   &s.bucket,
+		Key:      &key,
+		Body:     bytes.NewReader(data),
+		Metadata: metadata,
+	}
+	if contentType != "" {
+		input.ContentType = &contentType
+	}
+	_, err := s.client.PutObject(ctx, input)
+	return err
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, map[string]string, error) {
+	obj, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	defer obj.Body.Close()
+	data, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, obj.Metadata, nil
+}
+
+func (s *S3Store) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// If the object that you request doesn’t exist, the error that Amazon S3 returns depends on whether you also have the s3:ListBucket permission. If you have the s3:ListBucket permission on the bucket, Amazon S3 returns an HTTP status code 404 (Not Found) error. If you don’t have the s3:ListBucket permission, Amazon S3 returns an HTTP status code 403 ("access denied") error.
+func isNotFound(err error) bool {
+	var httpResponseErr *awshttp.ResponseError
+	return errors.As(err, &httpResponseErr) && (httpResponseErr.HTTPStatusCode() == http.StatusNotFound || httpResponseErr.HTTPStatusCode() == http.StatusForbidden)
+}
@@ -0,0 +1,36 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+// ErrNotFound is returned by Store.Get if no object exists for the given key
+var ErrNotFound = errors.New("object not found")
+
+// Store is a simple key-value store for blobs and the blobindexer status, objects can carry string metadata.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string, metadata map[string]string) error
+	Get(ctx context.Context, key string) ([]byte, map[string]string, error)
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// IsConfigured returns true if either a local path or an s3 endpoint is configured for the blobindexer
+func IsConfigured(config *types.Config) bool {
+	return config.BlobIndexer.LocalPath != "" || config.BlobIndexer.S3.Endpoint != ""
+}
+
+// New returns the store configured for the blobindexer, the local filesystem takes precedence over s3
+func New(ctx context.Context, config *types.Config) (Store, error) {
+	cfg := config.BlobIndexer
+	if cfg.LocalPath != "" {
+		return NewFilesystemStore(cfg.LocalPath)
+	}
+	if cfg.S3.Endpoint == "" {
+		return nil, fmt.Errorf("neither local path nor s3 endpoint configured for blob storage")
+	}
+	return NewS3Store(ctx, cfg.S3.Endpoint, cfg.S3.Bucket, cfg.S3.AccessKeyId, cfg.S3.AccessKeySecret)
+}
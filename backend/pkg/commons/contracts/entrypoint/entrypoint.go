@@ -0,0 +1,56 @@
+package entrypoint
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EntryPoints maps the ERC-4337 entry point singletons that are indexed to their version.
+// The entry points are deployed deterministically, so the addresses are the same on all networks.
+var EntryPoints = map[common.Address]string{
+	common.HexToAddress("0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789"): "v0.6",
+	common.HexToAddress("0x0000000071727de22e5e9d8baf0edac6f37da032"): "v0.7",
+}
+
+// UserOperationEventTopic is emitted by both entry point versions after a user operation was executed
+var UserOperationEventTopic = crypto.Keccak256([]byte("UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256)"))
+
+// UserOperation is a decoded UserOperationEvent
+type UserOperation struct {
+	Hash          common.Hash
+	EntryPoint    common.Address
+	Sender        common.Address  // the smart contract account
+	Paymaster     *common.Address // nil if the account paid for the gas itself
+	Nonce         *big.Int
+	Success       bool
+	ActualGasCost *big.Int // wei charged from the paymaster or the account
+	ActualGasUsed *big.Int
+}
+
+// ParseLog decodes a UserOperationEvent of an indexed entry point, nil is returned if the log is no such event
+func ParseLog(address []byte, topics [][]byte, data []byte) *UserOperation {
+	entryPoint := common.BytesToAddress(address)
+	if _, ok := EntryPoints[entryPoint]; !ok || len(address) != common.AddressLength {
+		return nil
+	}
+	if len(topics) != 4 || !bytes.Equal(topics[0], UserOperationEventTopic) || len(data) != 4*32 {
+		return nil
+	}
+
+	op := &UserOperation{
+		Hash:          common.BytesToHash(topics[1]),
+		EntryPoint:    entryPoint,
+		Sender:        common.BytesToAddress(topics[2]),
+		Nonce:         new(big.Int).SetBytes(data[:32]),
+		Success:       new(big.Int).SetBytes(data[32:64]).Sign() != 0,
+		ActualGasCost: new(big.Int).SetBytes(data[64:96]),
+		ActualGasUsed: new(big.Int).SetBytes(data[96:128]),
+	}
+	if paymaster := common.BytesToAddress(topics[3]); paymaster != (common.Address{}) {
+		op.Paymaster = &paymaster
+	}
+	return op
+}
@@ -0,0 +1,43 @@
+package entrypoint
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func word(v int64) []byte {
+	return common.BigToHash(big.NewInt(v)).Bytes()
+}
+
+func TestParseLog(t *testing.T) {
+	entryPoint := common.HexToAddress("0x0000000071727de22e5e9d8baf0edac6f37da032")
+	hash := common.HexToHash("0x01")
+	sender, paymaster := common.HexToAddress("0x02"), common.HexToAddress("0x03")
+	data := append(append(word(4), word(1)...), append(word(5), word(6)...)...)
+
+	op := ParseLog(entryPoint.Bytes(), [][]byte{UserOperationEventTopic, hash.Bytes(), common.LeftPadBytes(sender.Bytes(), 32), common.LeftPadBytes(paymaster.Bytes(), 32)}, data)
+	require.NotNil(t, op)
+	assert.Equal(t, &UserOperation{
+		Hash:          hash,
+		EntryPoint:    entryPoint,
+		Sender:        sender,
+		Paymaster:     &paymaster,
+		Nonce:         big.NewInt(4),
+		Success:       true,
+		ActualGasCost: big.NewInt(5),
+		ActualGasUsed: big.NewInt(6),
+	}, op)
+
+	// operations without paymaster are paid by the account
+	op = ParseLog(entryPoint.Bytes(), [][]byte{UserOperationEventTopic, hash.Bytes(), common.LeftPadBytes(sender.Bytes(), 32), word(0)}, data)
+	require.NotNil(t, op)
+	assert.Nil(t, op.Paymaster)
+
+	// events of other contracts are ignored
+	op = ParseLog(sender.Bytes(), [][]byte{UserOperationEventTopic, hash.Bytes(), common.LeftPadBytes(sender.Bytes(), 32), word(0)}, data)
+	assert.Nil(t, op)
+}
@@ -0,0 +1,214 @@
+package safe
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SafeABI contains the events and functions of the Safe (formerly Gnosis Safe) singleton that are indexed.
+// Safe v1.4 marks some event arguments as indexed, the event ids are the same for both variants.
+const SafeABI = `[
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"initiator","type":"address"},{"indexed":false,"internalType":"address[]","name":"owners","type":"address[]"},{"indexed":false,"internalType":"uint256","name":"threshold","type":"uint256"},{"indexed":false,"internalType":"address","name":"initializer","type":"address"},{"indexed":false,"internalType":"address","name":"fallbackHandler","type":"address"}],"name":"SafeSetup","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"owner","type":"address"}],"name":"AddedOwner","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"owner","type":"address"}],"name":"RemovedOwner","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"internalType":"uint256","name":"threshold","type":"uint256"}],"name":"ChangedThreshold","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"internalType":"bytes32","name":"txHash","type":"bytes32"},{"indexed":false,"internalType":"uint256","name":"payment","type":"uint256"}],"name":"ExecutionSuccess","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"internalType":"bytes32","name":"txHash","type":"bytes32"},{"indexed":false,"internalType":"uint256","name":"payment","type":"uint256"}],"name":"ExecutionFailure","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"approvedHash","type":"bytes32"},{"indexed":true,"internalType":"address","name":"owner","type":"address"}],"name":"ApproveHash","type":"event"},
+	{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"},{"internalType":"uint8","name":"operation","type":"uint8"},{"internalType":"uint256","name":"safeTxGas","type":"uint256"},{"internalType":"uint256","name":"baseGas","type":"uint256"},{"internalType":"uint256","name":"gasPrice","type":"uint256"},{"internalType":"address","name":"gasToken","type":"address"},{"internalType":"address payable","name":"refundReceiver","type":"address"},{"internalType":"bytes","name":"signatures","type":"bytes"}],"name":"execTransaction","outputs":[{"internalType":"bool","name":"success","type":"bool"}],"stateMutability":"payable","type":"function"}
+]`
+
+var ParsedABI, _ = abi.JSON(strings.NewReader(SafeABI))
+
+const (
+	EventSafeSetup        = "SafeSetup"
+	EventAddedOwner       = "AddedOwner"
+	EventRemovedOwner     = "RemovedOwner"
+	EventChangedThreshold = "ChangedThreshold"
+	EventExecutionSuccess = "ExecutionSuccess"
+	EventExecutionFailure = "ExecutionFailure"
+	EventApproveHash      = "ApproveHash"
+)
+
+// confirmation types, derived from the v value of a signature
+const (
+	ConfirmationEcdsa        = "ecdsa"
+	ConfirmationEthSign      = "eth_sign"
+	ConfirmationContract     = "contract"
+	ConfirmationApprovedHash = "approved_hash"
+	ConfirmationSender       = "sender" // the owner executed the transaction
+)
+
+// Event is a decoded Safe event, only the fields of the respective event are set
+type Event struct {
+	Name       string
+	Owners     []common.Address // all owners for SafeSetup, the changed owner for AddedOwner, RemovedOwner and ApproveHash
+	Threshold  *big.Int
+	SafeTxHash common.Hash // ExecutionSuccess, ExecutionFailure and ApproveHash
+	Payment    *big.Int
+}
+
+// ParseLog decodes a Safe event, nil is returned if the log is not a Safe event
+func ParseLog(topics [][]byte, data []byte) (*Event, error) {
+	if len(topics) == 0 {
+		return nil, nil
+	}
+	var name string
+	for _, event := range ParsedABI.Events {
+		if bytes.Equal(topics[0], event.ID.Bytes()) {
+			name = event.Name
+			break
+		}
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	// arguments are either indexed or part of the data depending on the safe version
+	words := make([][]byte, 0, len(topics)-1+len(data)/32)
+	words = append(words, topics[1:]...)
+	if name != EventSafeSetup {
+		for i := 0; i+32 <= len(data); i += 32 {
+			words = append(words, data[i:i+32])
+		}
+	}
+
+	event := &Event{Name: name}
+	switch name {
+	case EventSafeSetup:
+		values, err := ParsedABI.Unpack(name, data)
+		if err != nil {
+			return nil, fmt.Errorf("error unpacking %v event: %w", name, err)
+		}
+		owners, ok := values[0].([]common.Address)
+		if !ok {
+			return nil, fmt.Errorf("unexpected owners type %T of %v event", values[0], name)
+		}
+		threshold, ok := values[1].(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("unexpected threshold type %T of %v event", values[1], name)
+		}
+		event.Owners = owners
+		event.Threshold = threshold
+	case EventAddedOwner, EventRemovedOwner:
+		if len(words) != 1 {
+			return nil, nil
+		}
+		event.Owners = []common.Address{common.BytesToAddress(words[0])}
+	case EventChangedThreshold:
+		if len(words) != 1 {
+			return nil, nil
+		}
+		event.Threshold = new(big.Int).SetBytes(words[0])
+	case EventExecutionSuccess, EventExecutionFailure:
+		if len(words) != 2 {
+			return nil, nil
+		}
+		event.SafeTxHash = common.BytesToHash(words[0])
+		event.Payment = new(big.Int).SetBytes(words[1])
+	case EventApproveHash:
+		if len(words) != 2 {
+			return nil, nil
+		}
+		event.SafeTxHash = common.BytesToHash(words[0])
+		event.Owners = []common.Address{common.BytesToAddress(words[1])}
+	}
+	return event, nil
+}
+
+// ExecTransaction contains the decoded arguments of an execTransaction call
+type ExecTransaction struct {
+	To         common.Address
+	Value      *big.Int
+	Data       []byte
+	Operation  uint8 // 0 for calls, 1 for delegate calls
+	Signatures []byte
+}
+
+// DecodeExecTransaction decodes the input of an execTransaction call, nil is returned if the input is not such a call
+func DecodeExecTransaction(input []byte) (*ExecTransaction, error) {
+	method := ParsedABI.Methods["execTransaction"]
+	if len(input) < 4 || !bytes.Equal(input[:4], method.ID) {
+		return nil, nil
+	}
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, fmt.Errorf("error unpacking execTransaction input: %w", err)
+	}
+	tx := &ExecTransaction{}
+	var ok [5]bool
+	tx.To, ok[0] = values[0].(common.Address)
+	tx.Value, ok[1] = values[1].(*big.Int)
+	tx.Data, ok[2] = values[2].([]byte)
+	tx.Operation, ok[3] = values[3].(uint8)
+	tx.Signatures, ok[4] = values[9].([]byte)
+	for _, v := range ok {
+		if !v {
+			return nil, fmt.Errorf("unexpected argument types of execTransaction input")
+		}
+	}
+	return tx, nil
+}
+
+// Confirmation is an owner that confirmed a safe transaction
+type Confirmation struct {
+	Owner common.Address
+	Type  string
+}
+
+// RecoverConfirmations returns the owners that signed the safe transaction with the given hash.
+// The signatures are 65 byte {r, s, v} entries, contract signatures append their dynamic part after them.
+func RecoverConfirmations(safeTxHash common.Hash, signatures []byte, sender common.Address) []Confirmation {
+	confirmations := make([]Confirmation, 0, len(signatures)/65)
+	end := len(signatures)
+	for i := 0; (i+1)*65 <= end; i++ {
+		sig := signatures[i*65 : (i+1)*65]
+		r, s, v := sig[:32], sig[32:64], sig[64]
+		switch {
+		case v == 0:
+			// r is the address of the contract, s the offset of its dynamic signature data
+			confirmations = append(confirmations, Confirmation{Owner: common.BytesToAddress(r), Type: ConfirmationContract})
+			if offset := new(big.Int).SetBytes(s); offset.IsInt64() && offset.Int64() < int64(end) {
+				end = int(offset.Int64())
+			}
+		case v == 1:
+			// r is the address of an owner that approved the hash on chain or sent the transaction
+			confirmation := Confirmation{Owner: common.BytesToAddress(r), Type: ConfirmationApprovedHash}
+			if confirmation.Owner == sender {
+				confirmation.Type = ConfirmationSender
+			}
+			confirmations = append(confirmations, confirmation)
+		case v > 30:
+			hash := crypto.Keccak256Hash([]byte("\x19Ethereum Signed Message:\n32"), safeTxHash.Bytes())
+			if owner, ok := recoverSigner(hash, r, s, v-4); ok {
+				confirmations = append(confirmations, Confirmation{Owner: owner, Type: ConfirmationEthSign})
+			}
+		default:
+			if owner, ok := recoverSigner(safeTxHash, r, s, v); ok {
+				confirmations = append(confirmations, Confirmation{Owner: owner, Type: ConfirmationEcdsa})
+			}
+		}
+	}
+	return confirmations
+}
+
+func recoverSigner(hash common.Hash, r, s []byte, v byte) (common.Address, bool) {
+	if v < 27 {
+		return common.Address{}, false
+	}
+	sig := make([]byte, 65)
+	copy(sig, r)
+	copy(sig[32:], s)
+	sig[64] = v - 27
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, false
+	}
+	return crypto.PubkeyToAddress(*pub), true
+}
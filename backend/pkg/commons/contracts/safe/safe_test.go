@@ -0,0 +1,67 @@
+package safe
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLog(t *testing.T) {
+	safeTxHash := common.HexToHash("0x01")
+	owner := common.HexToAddress("0x02")
+	payment := common.BigToHash(big.NewInt(3))
+
+	// v1.3 emits all arguments as data
+	event, err := ParseLog([][]byte{ParsedABI.Events[EventExecutionSuccess].ID.Bytes()}, append(safeTxHash.Bytes(), payment.Bytes()...))
+	require.NoError(t, err)
+	assert.Equal(t, EventExecutionSuccess, event.Name)
+	assert.Equal(t, safeTxHash, event.SafeTxHash)
+	assert.Equal(t, int64(3), event.Payment.Int64())
+
+	// v1.4 indexes the safe tx hash
+	event, err = ParseLog([][]byte{ParsedABI.Events[EventExecutionFailure].ID.Bytes(), safeTxHash.Bytes()}, payment.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, EventExecutionFailure, event.Name)
+	assert.Equal(t, safeTxHash, event.SafeTxHash)
+	assert.Equal(t, int64(3), event.Payment.Int64())
+
+	event, err = ParseLog([][]byte{ParsedABI.Events[EventAddedOwner].ID.Bytes(), common.LeftPadBytes(owner.Bytes(), 32)}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{owner}, event.Owners)
+
+	// unrelated events are ignored
+	event, err = ParseLog([][]byte{common.HexToHash("0x04").Bytes()}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, event)
+}
+
+func TestRecoverConfirmations(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	sender := common.HexToAddress("0x05")
+	safeTxHash := crypto.Keccak256Hash([]byte("safe tx"))
+
+	ecdsa, err := crypto.Sign(safeTxHash.Bytes(), key)
+	require.NoError(t, err)
+	ecdsa[64] += 27
+
+	ethSign, err := crypto.Sign(crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), safeTxHash.Bytes()), key)
+	require.NoError(t, err)
+	ethSign[64] += 31
+
+	approved := append(common.LeftPadBytes(sender.Bytes(), 32), make([]byte, 32)...)
+	approved = append(approved, 1)
+
+	signatures := append(append(ecdsa, ethSign...), approved...)
+	confirmations := RecoverConfirmations(safeTxHash, signatures, sender)
+	assert.Equal(t, []Confirmation{
+		{Owner: signer, Type: ConfirmationEcdsa},
+		{Owner: signer, Type: ConfirmationEthSign},
+		{Owner: sender, Type: ConfirmationSender},
+	}, confirmations)
+}
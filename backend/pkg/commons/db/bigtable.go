@@ -76,6 +76,8 @@ type Bigtable struct {
 
 	v2SchemaCutOffEpoch uint64
 
+	balanceSnapshots bool
+
 	machineMetricsQueuedWritesChan chan (types.BulkMutation)
 }
 
@@ -125,6 +127,7 @@ func InitBigtable(project, instance, chainId, redisAddress string) (*Bigtable, e
 		redisCache:                     rdc,
 		LastAttestationCacheMux:        &sync.Mutex{},
 		v2SchemaCutOffEpoch:            utils.Config.Bigtable.V2SchemaCutOffEpoch,
+		balanceSnapshots:               utils.Config.Bigtable.BalanceSnapshots,
 		machineMetricsQueuedWritesChan: make(chan types.BulkMutation, MAX_BATCH_MUTATIONS),
 	}
 
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -84,6 +86,10 @@ const (
 	CONTRACT_NAME = "CONTRACTNAME"
 	CONTRACT_ABI  = "ABI"
 
+	SNAPSHOT_SUPPLY_COLUMN = "SUPPLY"
+	SNAPSHOT_TIME_COLUMN   = "t"
+	EVENT_LOG_TX_COLUMN    = "h"
+
 	ERC20_COLUMN_DECIMALS    = "DECIMALS"
 	ERC20_COLUMN_TOTALSUPPLY = "TOTALSUPPLY"
 	ERC20_COLUMN_SYMBOL      = "SYMBOL"
@@ -937,7 +943,7 @@ func (bigtable *Bigtable) TransformBlock(block *types.Eth1Block, cache *freecach
 	idx.Mev = CalculateMevFromBlock(block).Bytes() // deprecated but we still write the value to keep all blocks consistent
 
 	// Mark Coinbase for balance update
-	bigtable.markBalanceUpdate(idx.Coinbase, []byte{0x0}, bulkMetadataUpdates, cache)
+	bigtable.markBalanceUpdate(idx.Coinbase, []byte{0x0}, block, bulkMetadataUpdates, cache)
 
 	// <chainID>:b:<reverse number>
 	key := fmt.Sprintf("%s:B:%s", bigtable.chainId, reversedPaddedBlockNumber(block.GetNumber()))
@@ -1051,8 +1057,8 @@ func (bigtable *Bigtable) TransformTx(blk *types.Eth1Block, cache *freecache.Cac
 			ErrorMsg:           tx.GetErrorMsg(),
 		}
 		// Mark Sender and Recipient for balance update
-		bigtable.markBalanceUpdate(indexedTx.From, []byte{0x0}, bulkMetadataUpdates, cache)
-		bigtable.markBalanceUpdate(indexedTx.To, []byte{0x0}, bulkMetadataUpdates, cache)
+		bigtable.markBalanceUpdate(indexedTx.From, []byte{0x0}, blk, bulkMetadataUpdates, cache)
+		bigtable.markBalanceUpdate(indexedTx.To, []byte{0x0}, blk, bulkMetadataUpdates, cache)
 
 		if len(indexedTx.Hash) != 32 {
 			log.Fatal(fmt.Errorf("retrieved hash of length %v for a tx in block %v", len(indexedTx.Hash), blk.GetNumber()), "", 0)
@@ -1139,8 +1145,8 @@ func (bigtable *Bigtable) TransformBlobTx(blk *types.Eth1Block, cache *freecache
 			BlobVersionedHashes: tx.GetBlobVersionedHashes(),
 		}
 		// Mark Sender and Recipient for balance update
-		bigtable.markBalanceUpdate(indexedTx.From, []byte{0x0}, bulkMetadataUpdates, cache)
-		bigtable.markBalanceUpdate(indexedTx.To, []byte{0x0}, bulkMetadataUpdates, cache)
+		bigtable.markBalanceUpdate(indexedTx.From, []byte{0x0}, blk, bulkMetadataUpdates, cache)
+		bigtable.markBalanceUpdate(indexedTx.To, []byte{0x0}, blk, bulkMetadataUpdates, cache)
 
 		if len(indexedTx.Hash) != 32 {
 			log.Fatal(fmt.Errorf("retrieved hash of length %v for a tx in block %v", len(indexedTx.Hash), blk.GetNumber()), "", 0)
@@ -1324,8 +1330,8 @@ func (bigtable *Bigtable) TransformItx(blk *types.Eth1Block, cache *freecache.Ca
 				Value:       itx.GetValue(),
 			}
 
-			bigtable.markBalanceUpdate(indexedItx.To, []byte{0x0}, bulkMetadataUpdates, cache)
-			bigtable.markBalanceUpdate(indexedItx.From, []byte{0x0}, bulkMetadataUpdates, cache)
+			bigtable.markBalanceUpdate(indexedItx.To, []byte{0x0}, blk, bulkMetadataUpdates, cache)
+			bigtable.markBalanceUpdate(indexedItx.From, []byte{0x0}, blk, bulkMetadataUpdates, cache)
 
 			indexes := []string{
 				// fmt.Sprintf("%s:i:ITX::%s:%s:%s", bigtable.chainId, reversePaddedBigtableTimestamp(blk.GetTime()), fmt.Sprintf("%04d", i), fmt.Sprintf("%05d", j)),
@@ -1482,8 +1488,12 @@ func (bigtable *Bigtable) TransformERC20(blk *types.Eth1Block, cache *freecache.
 				To:           transfer.To.Bytes(),
 				Value:        value,
 			}
-			bigtable.markBalanceUpdate(indexedLog.From, indexedLog.TokenAddress, bulkMetadataUpdates, cache)
-			bigtable.markBalanceUpdate(indexedLog.To, indexedLog.TokenAddress, bulkMetadataUpdates, cache)
+			bigtable.markBalanceUpdate(indexedLog.From, indexedLog.TokenAddress, blk, bulkMetadataUpdates, cache)
+			bigtable.markBalanceUpdate(indexedLog.To, indexedLog.TokenAddress, blk, bulkMetadataUpdates, cache)
+			// transfers from or to the zero address mint or burn tokens
+			if bytes.Equal(indexedLog.From, ZERO_ADDRESS) || bytes.Equal(indexedLog.To, ZERO_ADDRESS) {
+				bigtable.markTotalSupplyUpdate(indexedLog.TokenAddress, blk, bulkMetadataUpdates, cache)
+			}
 
 			b, err := proto.Marshal(indexedLog)
 			if err != nil {
@@ -1527,6 +1537,52 @@ func (bigtable *Bigtable) TransformERC20(blk *types.Eth1Block, cache *freecache.
 	return bulkData, bulkMetadataUpdates, nil
 }
 
+// TransformEventLogs accepts an eth1 block and creates bigtable mutations for all event logs contained in it.
+// It indexes the logs by the address of the emitting contract:
+// Row:    <chainID>:EVENTLOG:<ADDRESS>:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: d
+// Cell:   Proto<Eth1Log>
+// Column: h
+// Cell:   transaction hash
+// Column: t
+// Cell:   block time in seconds
+// Example scan: "1:EVENTLOG:6b175474e89094c44da98b954eedeac495271d0f" returns the mainnet event logs of the contract 0x6b175474e89094c44da98b954eedeac495271d0f, newest first
+func (bigtable *Bigtable) TransformEventLogs(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
+	bulkData = &types.BulkMutations{}
+	bulkMetadataUpdates = &types.BulkMutations{}
+
+	blockTime := binary.BigEndian.AppendUint64(nil, uint64(blk.GetTime().GetSeconds()))
+	for i, tx := range blk.GetTransactions() {
+		if i >= TX_PER_BLOCK_LIMIT {
+			return nil, nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
+		}
+		iReversed := reversePaddedIndex(i, TX_PER_BLOCK_LIMIT)
+		for j, eventLog := range tx.GetLogs() {
+			if j >= ITX_PER_TX_LIMIT {
+				return nil, nil, fmt.Errorf("unexpected number of logs in block expected at most %d but got: %v tx: %x", ITX_PER_TX_LIMIT-1, j, tx.GetHash())
+			}
+			jReversed := reversePaddedIndex(j, ITX_PER_TX_LIMIT)
+
+			b, err := proto.Marshal(eventLog)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			mut := gcp_bigtable.NewMutation()
+			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+			mut.Set(DEFAULT_FAMILY, EVENT_LOG_TX_COLUMN, gcp_bigtable.Timestamp(0), tx.GetHash())
+			mut.Set(DEFAULT_FAMILY, SNAPSHOT_TIME_COLUMN, gcp_bigtable.Timestamp(0), blockTime)
+
+			key := fmt.Sprintf("%s:EVENTLOG:%x:%s:%s:%s", bigtable.chainId, eventLog.GetAddress(), reversedPaddedBlockNumber(blk.GetNumber()), iReversed, jReversed)
+			bulkData.Keys = append(bulkData.Keys, key)
+			bulkData.Muts = append(bulkData.Muts, mut)
+		}
+	}
+
+	return bulkData, bulkMetadataUpdates, nil
+}
+
 // example: https://etherscan.io/tx/0x4d3a6c56cecb40637c070601c275df9cc7b599b5dc1d5ac2473c92c7a9e62c64#eventlog
 // TransformERC721 accepts an eth1 block and creates bigtable mutations for erc721 transfer events.
 // It transforms the logs contained within a block and writes the transformed logs to bigtable
@@ -1903,7 +1959,7 @@ func (bigtable *Bigtable) TransformUncle(block *types.Eth1Block, cache *freecach
 			Reward:      r.Bytes(),
 		}
 
-		bigtable.markBalanceUpdate(uncle.Coinbase, []byte{0x0}, bulkMetadataUpdates, cache)
+		bigtable.markBalanceUpdate(uncle.Coinbase, []byte{0x0}, block, bulkMetadataUpdates, cache)
 
 		// store uncles in with the key <chainid>:U:<reversePaddedBlockNumber>:<reversePaddedUncleIndex>
 		key := fmt.Sprintf("%s:U:%s:%s", bigtable.chainId, reversedPaddedBlockNumber(block.GetNumber()), iReversed)
@@ -1972,7 +2028,7 @@ func (bigtable *Bigtable) TransformWithdrawals(block *types.Eth1Block, cache *fr
 			Time:           block.Time,
 		}
 
-		bigtable.markBalanceUpdate(withdrawal.Address, []byte{0x0}, bulkMetadataUpdates, cache)
+		bigtable.markBalanceUpdate(withdrawal.Address, []byte{0x0}, block, bulkMetadataUpdates, cache)
 
 		// store withdrawals with the key <chainid>:W:<reversePaddedBlockNumber>:<reversePaddedWithdrawalIndex>
 		key := fmt.Sprintf("%s:W:%s:%s", bigtable.chainId, reversedPaddedBlockNumber(block.GetNumber()), iReversed)
@@ -2560,6 +2616,162 @@ func (bigtable *Bigtable) GetEth1ItxsForAddress(prefix string, limit int64) ([]*
 	return data, indexes, nil
 }
 
+// GetEth1ActivityForAddress merges the TIME indices of the given index types (TX, ITX, ERC20, ERC721 and ERC1155) of an address, newest first.
+// bounds maps an index type to the position (the part of the index key following TIME:) where reading continues, going forward
+// the entries from the bound on are read and going back (reverse) the entries before it. The returned next and prev bounds
+// continue after and before the returned entries. Internal transactions without value are read but not returned.
+func (bigtable *Bigtable) GetEth1ActivityForAddress(address []byte, indexTypes []string, bounds map[string]string, reverse bool, limit int64) ([]*types.Eth1AddressActivity, map[string]string, map[string]string, bool, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"address":  fmt.Sprintf("%x", address),
+			"types":    indexTypes,
+			"limit":    limit,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	entries := make([]activityIndexEntry, 0, int64(len(indexTypes))*(limit+1))
+	for _, indexType := range indexTypes {
+		prefix := fmt.Sprintf("%s:I:%s:%x:TIME:", bigtable.chainId, indexType, address)
+		bound := bounds[indexType]
+		rowRange := gcp_bigtable.NewRange(prefix+bound, prefixSuccessor(prefix, 5))
+		opts := []gcp_bigtable.ReadOption{gcp_bigtable.LimitRows(limit + 1)}
+		if reverse {
+			if bound == "" {
+				// nothing precedes the start of the index
+				continue
+			}
+			rowRange = gcp_bigtable.NewRange(prefix, prefix+bound)
+			opts = append(opts, gcp_bigtable.ReverseScan())
+		}
+		err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+			entries = append(entries, activityIndexEntry{
+				indexType: indexType,
+				index:     row.Key(),
+				sortKey:   strings.TrimPrefix(row.Key(), prefix),
+				key:       strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, DEFAULT_FAMILY+":"),
+			})
+			return true
+		}, opts...)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+
+	entries, next, prev, moreData := mergeActivityIndexEntries(entries, bounds, reverse, limit)
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, entry.key)
+	}
+	if len(keys) == 0 {
+		return nil, next, prev, false, nil
+	}
+
+	rows := make(map[string][]byte, len(keys))
+	err := bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+		rows[row.Key()] = row[DEFAULT_FAMILY][0].Value
+		return true
+	})
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	data := make([]*types.Eth1AddressActivity, 0, len(entries))
+	for _, entry := range entries {
+		value, ok := rows[entry.key]
+		if !ok {
+			continue
+		}
+		activity, err := decodeActivity(entry, value)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+		if activity != nil {
+			data = append(data, activity)
+		}
+	}
+
+	return data, next, prev, moreData, nil
+}
+
+type activityIndexEntry struct {
+	indexType string
+	index     string
+	sortKey   string // part of the index key following TIME:, comparable across index types
+	key       string
+}
+
+// mergeActivityIndexEntries selects the limit entries closest to the bounds in the read direction and returns them newest first.
+// The bounds of index types without a selected entry stay the same as nothing of them lies between the bounds and the selection.
+func mergeActivityIndexEntries(entries []activityIndexEntry, bounds map[string]string, reverse bool, limit int64) ([]activityIndexEntry, map[string]string, map[string]string, bool) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if reverse {
+			return entries[i].sortKey > entries[j].sortKey
+		}
+		return entries[i].sortKey < entries[j].sortKey
+	})
+	moreData := int64(len(entries)) > limit
+	if moreData {
+		entries = entries[:limit]
+	}
+	if reverse {
+		slices.Reverse(entries)
+	}
+
+	next := make(map[string]string, len(bounds))
+	prev := make(map[string]string, len(bounds))
+	for indexType, bound := range bounds {
+		next[indexType] = bound
+		prev[indexType] = bound
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		prev[entries[i].indexType] = entries[i].sortKey
+	}
+	for _, entry := range entries {
+		// add \x00 such that the entry itself is skipped
+		next[entry.indexType] = entry.sortKey + "\x00"
+	}
+	return entries, next, prev, moreData
+}
+
+// decodeActivity parses the indexed data of an activity entry, nil is returned for internal transactions without value
+// as geth traces include zero-value staticalls
+func decodeActivity(entry activityIndexEntry, value []byte) (*types.Eth1AddressActivity, error) {
+	activity := &types.Eth1AddressActivity{IndexKey: entry.index}
+	var m proto.Message
+	switch entry.indexType {
+	case "TX":
+		activity.Tx = &types.Eth1TransactionIndexed{}
+		m = activity.Tx
+	case "ITX":
+		activity.Itx = &types.Eth1InternalTransactionIndexed{}
+		m = activity.Itx
+	case "ERC20":
+		activity.ERC20 = &types.Eth1ERC20Indexed{}
+		m = activity.ERC20
+	case "ERC721":
+		activity.ERC721 = &types.Eth1ERC721Indexed{}
+		m = activity.ERC721
+	case "ERC1155":
+		activity.ERC1155 = &types.ETh1ERC1155Indexed{}
+		m = activity.ERC1155
+	default:
+		return nil, fmt.Errorf("unknown index type %v", entry.indexType)
+	}
+	if err := proto.Unmarshal(value, m); err != nil {
+		return nil, fmt.Errorf("error parsing %v: %w", entry.key, err)
+	}
+	if activity.Itx != nil && new(big.Int).SetBytes(activity.Itx.Value).Sign() == 0 {
+		return nil, nil
+	}
+	return activity, nil
+}
+
 func (bigtable *Bigtable) GetEth1ERC20ForAddress(prefix string, limit int64) ([]*types.Eth1ERC20Indexed, string, error) {
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
 		log.WarnWithFields(log.Fields{
@@ -3544,6 +3756,17 @@ func (bigtable *Bigtable) SaveContractMetadata(address []byte, metadata *types.C
 	return bigtable.tableMetadata.Apply(ctx, fmt.Sprintf("%s:%x", bigtable.chainId, address), mut)
 }
 
+// SaveVerifiedContractMetadata stores a verified contract abi (e.g. from sourcify or uploaded by an admin) in the contract registry,
+// replacing any previously fetched or cached metadata of the contract
+func (bigtable *Bigtable) SaveVerifiedContractMetadata(address []byte, metadata *types.ContractMetadata) error {
+	err := bigtable.SaveContractMetadata(address, metadata)
+	if err != nil {
+		return err
+	}
+	cacheKey := bigtable.chainId + ":CONTRACT:" + fmt.Sprintf("%s:%x", bigtable.chainId, address)
+	return cache.TieredCache.Set(cacheKey, metadata, utils.Day)
+}
+
 func (bigtable *Bigtable) SaveBalances(balances []*types.Eth1AddressBalance, deleteKeys []string) error {
 	if len(balances) == 0 {
 		return nil
@@ -3591,6 +3814,272 @@ func (bigtable *Bigtable) SaveBalances(balances []*types.Eth1AddressBalance, del
 	return nil
 }
 
+// GetSnapshotUpdates returns the pending balance and total supply snapshots marked by the transformers, oldest block first
+func (bigtable *Bigtable) GetSnapshotUpdates(startToken string, limit int) ([]string, []*types.Eth1BalanceSnapshot, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"startToken": startToken,
+			"limit":      limit,
+			"func":       utils.GetCurrentFuncName(),
+			"duration":   REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Hour*2))
+	defer cancel()
+
+	prefix := bigtable.chainId + ":S:"
+	if startToken < prefix {
+		startToken = prefix
+	}
+	keys := make([]string, 0, limit)
+	snapshots := make([]*types.Eth1BalanceSnapshot, 0, limit)
+
+	err := bigtable.tableMetadataUpdates.ReadRows(ctx, gcp_bigtable.NewRange(startToken, prefixSuccessor(prefix, 3)), func(row gcp_bigtable.Row) bool {
+		if !strings.HasPrefix(row.Key(), prefix) {
+			return false
+		}
+		keys = append(keys, row.Key())
+
+		keySplit := strings.Split(row.Key(), ":")
+		blockNumber, err := strconv.ParseUint(keySplit[2], 10, 64)
+		if err != nil {
+			log.Error(err, "error parsing block number of snapshot update", 0, map[string]interface{}{"key": row.Key()})
+			return true
+		}
+		address := common.FromHex(keySplit[3])
+		for _, item := range row[DEFAULT_FAMILY] {
+			snapshot := &types.Eth1BalanceSnapshot{
+				Address:     address,
+				BlockNumber: blockNumber,
+				Time:        binary.BigEndian.Uint64(item.Value),
+			}
+			column := strings.TrimPrefix(item.Column, DEFAULT_FAMILY+":")
+			if column == SNAPSHOT_SUPPLY_COLUMN {
+				snapshot.Token = address
+				snapshot.TotalSupply = true
+			} else {
+				snapshot.Token = common.FromHex(strings.TrimPrefix(column, "B:"))
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+		return true
+	}, gcp_bigtable.LimitRows(int64(limit)))
+
+	if err == context.DeadlineExceeded && len(keys) > 0 {
+		return keys, snapshots, nil
+	}
+	return keys, snapshots, err
+}
+
+// SaveBalanceSnapshots writes the balance and total supply history and removes the processed snapshot updates, the updates
+// of snapshots without a balance are kept so they are retried. Snapshots whose call reverted are written without a balance.
+//
+// Row:    <chainID>:BALANCE:<ADDRESS>:<TOKEN_ADDRESS>:<reversePaddedBlockNumber>
+// Row:    <chainID>:SUPPLY:<TOKEN_ADDRESS>:<reversePaddedBlockNumber>
+// Family: f
+// Column: d
+// Cell:   balance or total supply, missing if the call reverted
+// Column: t
+// Cell:   block time in seconds
+func (bigtable *Bigtable) SaveBalanceSnapshots(snapshots []*types.Eth1BalanceSnapshot, deleteKeys []string) error {
+	mutsWrite := &types.BulkMutations{
+		Keys: make([]string, 0, len(snapshots)),
+		Muts: make([]*gcp_bigtable.Mutation, 0, len(snapshots)),
+	}
+
+	failedKeys := make(map[string]bool)
+	for _, snapshot := range snapshots {
+		if snapshot.Balance == nil && !snapshot.Reverted {
+			failedKeys[bigtable.snapshotUpdateKey(snapshot.BlockNumber, snapshot.Address)] = true
+			continue
+		}
+		mutWrite := gcp_bigtable.NewMutation()
+		if !snapshot.Reverted {
+			mutWrite.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), snapshot.Balance)
+		}
+		mutWrite.Set(DEFAULT_FAMILY, SNAPSHOT_TIME_COLUMN, gcp_bigtable.Timestamp(0), binary.BigEndian.AppendUint64(nil, snapshot.Time))
+
+		key := fmt.Sprintf("%s:BALANCE:%x:%x:%s", bigtable.chainId, snapshot.Address, snapshot.Token, reversedPaddedBlockNumber(snapshot.BlockNumber))
+		if snapshot.TotalSupply {
+			key = fmt.Sprintf("%s:SUPPLY:%x:%s", bigtable.chainId, snapshot.Token, reversedPaddedBlockNumber(snapshot.BlockNumber))
+		}
+		mutsWrite.Keys = append(mutsWrite.Keys, key)
+		mutsWrite.Muts = append(mutsWrite.Muts, mutWrite)
+	}
+
+	err := bigtable.WriteBulk(mutsWrite, bigtable.tableData, DEFAULT_BATCH_INSERTS)
+	if err != nil {
+		return err
+	}
+
+	mutsDelete := &types.BulkMutations{
+		Keys: make([]string, 0, len(deleteKeys)),
+		Muts: make([]*gcp_bigtable.Mutation, 0, len(deleteKeys)),
+	}
+	for _, key := range deleteKeys {
+		if failedKeys[key] {
+			continue
+		}
+		mutDelete := gcp_bigtable.NewMutation()
+		mutDelete.DeleteRow()
+		mutsDelete.Keys = append(mutsDelete.Keys, key)
+		mutsDelete.Muts = append(mutsDelete.Muts, mutDelete)
+	}
+
+	return bigtable.WriteBulk(mutsDelete, bigtable.tableMetadataUpdates, DEFAULT_BATCH_INSERTS)
+}
+
+// GetBalanceHistory returns the balance snapshots of an address for a token (0x00 for the native currency), newest first.
+// If a cursor is given only older snapshots are returned, or newer ones (oldest first) if reverse is set.
+func (bigtable *Bigtable) GetBalanceHistory(address, token []byte, cursor *types.Eth1BalanceSnapshot, reverse bool, limit int64) ([]*types.Eth1BalanceSnapshot, error) {
+	prefix := fmt.Sprintf("%s:BALANCE:%x:%x:", bigtable.chainId, address, token)
+	return bigtable.getBalanceSnapshots(prefix, address, token, false, cursor, reverse, limit)
+}
+
+// GetTotalSupplyHistory returns the total supply snapshots of a token, see GetBalanceHistory
+func (bigtable *Bigtable) GetTotalSupplyHistory(token []byte, cursor *types.Eth1BalanceSnapshot, reverse bool, limit int64) ([]*types.Eth1BalanceSnapshot, error) {
+	prefix := fmt.Sprintf("%s:SUPPLY:%x:", bigtable.chainId, token)
+	return bigtable.getBalanceSnapshots(prefix, token, token, true, cursor, reverse, limit)
+}
+
+func (bigtable *Bigtable) getBalanceSnapshots(prefix string, address, token []byte, totalSupply bool, cursor *types.Eth1BalanceSnapshot, reverse bool, limit int64) ([]*types.Eth1BalanceSnapshot, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"prefix":   prefix,
+			"limit":    limit,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	cursorKey := ""
+	if cursor != nil {
+		cursorKey = prefix + reversedPaddedBlockNumber(cursor.BlockNumber)
+	}
+
+	snapshots := make([]*types.Eth1BalanceSnapshot, 0, limit)
+	var parseErr error
+	err := bigtable.readPage(ctx, prefix, cursorKey, reverse, limit, func(row gcp_bigtable.Row) bool {
+		reversedBlockNumber, err := strconv.ParseUint(strings.TrimPrefix(row.Key(), prefix), 10, 64)
+		if err != nil {
+			parseErr = fmt.Errorf("error parsing block number of snapshot %v: %w", row.Key(), err)
+			return false
+		}
+		snapshot := &types.Eth1BalanceSnapshot{
+			Address:     address,
+			Token:       token,
+			TotalSupply: totalSupply,
+			BlockNumber: MAX_EL_BLOCK_NUMBER - reversedBlockNumber,
+			Reverted:    true,
+		}
+		for _, item := range row[DEFAULT_FAMILY] {
+			switch strings.TrimPrefix(item.Column, DEFAULT_FAMILY+":") {
+			case DATA_COLUMN:
+				snapshot.Balance = item.Value
+				snapshot.Reverted = false
+			case SNAPSHOT_TIME_COLUMN:
+				snapshot.Time = binary.BigEndian.Uint64(item.Value)
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, parseErr
+}
+
+// GetEventLogsForAddress returns the event logs emitted by a contract, newest first.
+// If a cursor is given only older logs are returned, or newer ones (oldest first) if reverse is set.
+func (bigtable *Bigtable) GetEventLogsForAddress(address []byte, cursor *types.Eth1EventLogIndexed, reverse bool, limit int64) ([]*types.Eth1EventLogIndexed, error) {
+	prefix := fmt.Sprintf("%s:EVENTLOG:%x:", bigtable.chainId, address)
+
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"prefix":   prefix,
+			"limit":    limit,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	cursorKey := ""
+	if cursor != nil {
+		cursorKey = fmt.Sprintf("%s%s:%s:%s", prefix, reversedPaddedBlockNumber(cursor.BlockNumber), reversePaddedIndex(int(cursor.TxIndex), TX_PER_BLOCK_LIMIT), reversePaddedIndex(int(cursor.LogIndex), ITX_PER_TX_LIMIT))
+	}
+
+	eventLogs := make([]*types.Eth1EventLogIndexed, 0, limit)
+	var parseErr error
+	err := bigtable.readPage(ctx, prefix, cursorKey, reverse, limit, func(row gcp_bigtable.Row) bool {
+		keySplit := strings.Split(strings.TrimPrefix(row.Key(), prefix), ":")
+		if len(keySplit) != 3 {
+			parseErr = fmt.Errorf("unexpected event log key %v", row.Key())
+			return false
+		}
+		indexes := make([]uint64, len(keySplit))
+		for i, part := range keySplit {
+			indexes[i], parseErr = strconv.ParseUint(part, 10, 64)
+			if parseErr != nil {
+				parseErr = fmt.Errorf("error parsing event log key %v: %w", row.Key(), parseErr)
+				return false
+			}
+		}
+		eventLog := &types.Eth1EventLogIndexed{
+			BlockNumber: MAX_EL_BLOCK_NUMBER - indexes[0],
+			TxIndex:     TX_PER_BLOCK_LIMIT - indexes[1],
+			LogIndex:    ITX_PER_TX_LIMIT - indexes[2],
+			Log:         &types.Eth1Log{},
+		}
+		for _, item := range row[DEFAULT_FAMILY] {
+			switch strings.TrimPrefix(item.Column, DEFAULT_FAMILY+":") {
+			case DATA_COLUMN:
+				if parseErr = proto.Unmarshal(item.Value, eventLog.Log); parseErr != nil {
+					parseErr = fmt.Errorf("error parsing event log %v: %w", row.Key(), parseErr)
+					return false
+				}
+			case EVENT_LOG_TX_COLUMN:
+				eventLog.TxHash = item.Value
+			case SNAPSHOT_TIME_COLUMN:
+				eventLog.Time = binary.BigEndian.Uint64(item.Value)
+			}
+		}
+		eventLogs = append(eventLogs, eventLog)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return eventLogs, parseErr
+}
+
+// readPage reads up to limit rows of the data table with the given prefix that follow the cursor key,
+// or that precede it in reverse order if reverse is set
+func (bigtable *Bigtable) readPage(ctx context.Context, prefix, cursorKey string, reverse bool, limit int64, f func(gcp_bigtable.Row) bool) error {
+	var rowRange gcp_bigtable.RowSet = gcp_bigtable.PrefixRange(prefix)
+	opts := []gcp_bigtable.ReadOption{gcp_bigtable.LimitRows(limit)}
+	if cursorKey != "" {
+		if reverse {
+			rowRange = gcp_bigtable.NewRange(prefix, cursorKey)
+			opts = append(opts, gcp_bigtable.ReverseScan())
+		} else {
+			// add \x00 to the row range such that we skip the cursor row
+			rowRange = gcp_bigtable.NewRange(cursorKey+"\x00", prefixSuccessor(prefix, strings.Count(prefix, ":")+1))
+		}
+	}
+	return bigtable.tableData.ReadRows(ctx, rowRange, f, opts...)
+}
+
 func (bigtable *Bigtable) SaveERC20TokenPrices(prices []*types.ERC20TokenPrice) error {
 	if len(prices) == 0 {
 		return nil
@@ -4050,6 +4539,62 @@ func (bigtable *Bigtable) GetEventLabel(id []byte) string {
 	return label
 }
 
+// DecodeTransactionInput decodes the input of a transaction with the abi of the called contract,
+// falling back to the imported 4byte signature. Returns nil if neither is known.
+func (bigtable *Bigtable) DecodeTransactionInput(to []byte, data []byte) *types.DecodedCall {
+	if len(data) < 4 {
+		return nil
+	}
+	if len(to) > 0 {
+		metadata, err := bigtable.GetContractMetadata(to)
+		if err == nil && metadata != nil && metadata.ABI != nil {
+			decoded, err := utils.DecodeMethodInput(metadata.ABI, data)
+			if err == nil {
+				return decoded
+			}
+			if !errors.Is(err, utils.ErrNoMatchingABIEntry) {
+				log.Warnf("error decoding input for contract %#x: %v", to, err)
+			}
+		}
+	}
+
+	sig, err := bigtable.GetSignature(fmt.Sprintf("0x%x", data[:4]), types.MethodSignature)
+	if err != nil || sig == nil {
+		return nil
+	}
+	decoded, err := utils.DecodeMethodInputWithSignature(*sig, data)
+	if err != nil {
+		// the signature may not match the input (collisions) or use types we can not parse, only return its name
+		return &types.DecodedCall{Name: utils.RemoveRoundBracketsIncludingContent(*sig), Signature: *sig, Source: types.DecodedSourceSignature}
+	}
+	return decoded
+}
+
+// DecodeEventLog decodes a log with the abi of the emitting contract, falling back to the imported event signature.
+// Returns nil if neither is known.
+func (bigtable *Bigtable) DecodeEventLog(eventLog *types.Eth1Log) *types.DecodedCall {
+	if len(eventLog.Topics) == 0 {
+		return nil
+	}
+	metadata, err := bigtable.GetContractMetadata(eventLog.Address)
+	if err == nil && metadata != nil && metadata.ABI != nil {
+		decoded, err := utils.DecodeEventLog(metadata.ABI, eventLog)
+		if err == nil {
+			return decoded
+		}
+		if !errors.Is(err, utils.ErrNoMatchingABIEntry) {
+			log.Warnf("error decoding log of contract %#x: %v", eventLog.Address, err)
+		}
+	}
+
+	// whether event arguments are indexed is not part of the signature, so only the name can be resolved
+	sig, err := bigtable.GetSignature(fmt.Sprintf("0x%x", eventLog.Topics[0]), types.EventSignature)
+	if err != nil || sig == nil {
+		return nil
+	}
+	return &types.DecodedCall{Name: utils.RemoveRoundBracketsIncludingContent(*sig), Signature: *sig, Source: types.DecodedSourceSignature}
+}
+
 func prefixSuccessor(prefix string, pos int) string {
 	if prefix == "" {
 		return "" // infinite range
@@ -4069,7 +4614,7 @@ func prefixSuccessor(prefix string, pos int) string {
 	return string(ans)
 }
 
-func (bigtable *Bigtable) markBalanceUpdate(address []byte, token []byte, mutations *types.BulkMutations, cache *freecache.Cache) {
+func (bigtable *Bigtable) markBalanceUpdate(address []byte, token []byte, blk *types.Eth1Block, mutations *types.BulkMutations, cache *freecache.Cache) {
 	balanceUpdateKey := fmt.Sprintf("%s:B:%x", bigtable.chainId, address)                        // format is B: for balance update as chainid:prefix:address (token id will be encoded as column name)
 	balanceUpdateCacheKey := []byte(fmt.Sprintf("%s:B:%x:%x", bigtable.chainId, address, token)) // format is B: for balance update as chainid:prefix:address (token id will be encoded as column name)
 	if _, err := cache.Get(balanceUpdateCacheKey); err != nil {
@@ -4081,6 +4626,40 @@ func (bigtable *Bigtable) markBalanceUpdate(address []byte, token []byte, mutati
 
 		_ = cache.Set(balanceUpdateCacheKey, []byte{0x1}, int((utils.Day * 2).Seconds()))
 	}
+	bigtable.markSnapshotUpdate(address, fmt.Sprintf("B:%x", token), blk, mutations, cache)
+}
+
+func (bigtable *Bigtable) markTotalSupplyUpdate(token []byte, blk *types.Eth1Block, mutations *types.BulkMutations, cache *freecache.Cache) {
+	bigtable.markSnapshotUpdate(token, SNAPSHOT_SUPPLY_COLUMN, blk, mutations, cache)
+}
+
+// markSnapshotUpdate marks that a balance or total supply changed in a block and has to be snapshotted, nothing is marked
+// if the balance snapshots are disabled as the markers would never be processed
+//
+// Row:    <chainID>:S:<paddedBlockNumber>:<ADDRESS>
+// Family: f
+// Column: B:<TOKEN_ADDRESS> for the balance of the address or SUPPLY for the total supply of the address as token
+// Cell:   block time in seconds
+func (bigtable *Bigtable) markSnapshotUpdate(address []byte, column string, blk *types.Eth1Block, mutations *types.BulkMutations, cache *freecache.Cache) {
+	if !bigtable.balanceSnapshots {
+		return
+	}
+	snapshotUpdateKey := bigtable.snapshotUpdateKey(blk.GetNumber(), address)
+	snapshotUpdateCacheKey := []byte(snapshotUpdateKey + ":" + column)
+	if _, err := cache.Get(snapshotUpdateCacheKey); err == nil {
+		return
+	}
+	mut := gcp_bigtable.NewMutation()
+	mut.Set(DEFAULT_FAMILY, column, gcp_bigtable.Timestamp(0), binary.BigEndian.AppendUint64(nil, uint64(blk.GetTime().GetSeconds())))
+
+	mutations.Keys = append(mutations.Keys, snapshotUpdateKey)
+	mutations.Muts = append(mutations.Muts, mut)
+
+	_ = cache.Set(snapshotUpdateCacheKey, []byte{0x1}, int(time.Hour.Seconds()))
+}
+
+func (bigtable *Bigtable) snapshotUpdateKey(blockNumber uint64, address []byte) string {
+	return fmt.Sprintf("%s:S:%09d:%x", bigtable.chainId, blockNumber, address)
 }
 
 var (
@@ -4088,9 +4667,10 @@ var (
 	GASNOW_FAST_COLUMN     = "FAST"
 	GASNOW_STANDARD_COLUMN = "STAN"
 	GASNOW_SLOW_COLUMN     = "SLOW"
+	GASNOW_BASEFEE_COLUMN  = "BASE"
 )
 
-func (bigtable *Bigtable) SaveGasNowHistory(slow, standard, rapid, fast *big.Int) error {
+func (bigtable *Bigtable) SaveGasNowHistory(slow, standard, rapid, fast, baseFee *big.Int) error {
 	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
 	defer done()
 
@@ -4104,6 +4684,7 @@ func (bigtable *Bigtable) SaveGasNowHistory(slow, standard, rapid, fast *big.Int
 	mut.Set(SERIES_FAMILY, GASNOW_STANDARD_COLUMN, gcpTs, standard.Bytes())
 	mut.Set(SERIES_FAMILY, GASNOW_FAST_COLUMN, gcpTs, fast.Bytes())
 	mut.Set(SERIES_FAMILY, GASNOW_RAPID_COLUMN, gcpTs, rapid.Bytes())
+	mut.Set(SERIES_FAMILY, GASNOW_BASEFEE_COLUMN, gcpTs, baseFee.Bytes())
 
 	err := bigtable.tableMetadata.Apply(ctx, row, mut)
 	if err != nil {
@@ -4140,14 +4721,27 @@ func (bigtable *Bigtable) GetGasNowHistory(ts, pastTs time.Time) ([]types.GasNow
 			log.Error(fmt.Errorf("error reading row: %+v", row), "", 0)
 			return false
 		}
-		// Columns are returned alphabetically so fast, rapid, slow, standard should be the order
-		history = append(history, types.GasNowHistory{
-			Ts:       row[SERIES_FAMILY][0].Timestamp.Time(),
-			Fast:     new(big.Int).SetBytes(row[SERIES_FAMILY][0].Value),
-			Rapid:    new(big.Int).SetBytes(row[SERIES_FAMILY][1].Value),
-			Slow:     new(big.Int).SetBytes(row[SERIES_FAMILY][2].Value),
-			Standard: new(big.Int).SetBytes(row[SERIES_FAMILY][3].Value),
-		})
+		// the base fee is not available for rows written before it was tracked
+		entry := types.GasNowHistory{
+			Ts:      row[SERIES_FAMILY][0].Timestamp.Time(),
+			BaseFee: new(big.Int),
+		}
+		for _, item := range row[SERIES_FAMILY] {
+			value := new(big.Int).SetBytes(item.Value)
+			switch strings.TrimPrefix(item.Column, SERIES_FAMILY+":") {
+			case GASNOW_FAST_COLUMN:
+				entry.Fast = value
+			case GASNOW_RAPID_COLUMN:
+				entry.Rapid = value
+			case GASNOW_SLOW_COLUMN:
+				entry.Slow = value
+			case GASNOW_STANDARD_COLUMN:
+				entry.Standard = value
+			case GASNOW_BASEFEE_COLUMN:
+				entry.BaseFee = value
+			}
+		}
+		history = append(history, entry)
 		return true
 	}
 
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func activityEntries(keys ...[2]string) []activityIndexEntry {
+	entries := make([]activityIndexEntry, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, activityIndexEntry{indexType: key[0], sortKey: key[1]})
+	}
+	return entries
+}
+
+func TestMergeActivityIndexEntries(t *testing.T) {
+	// the entries of each index type are read in key order, newest first
+	entries := activityEntries([2]string{"TX", "a"}, [2]string{"TX", "d"}, [2]string{"TX", "e"}, [2]string{"ERC20", "b"}, [2]string{"ERC20", "c"})
+	selected, next, prev, moreData := mergeActivityIndexEntries(entries, map[string]string{}, false, 3)
+	assert.True(t, moreData)
+	assert.Equal(t, activityEntries([2]string{"TX", "a"}, [2]string{"ERC20", "b"}, [2]string{"ERC20", "c"}), selected)
+	assert.Equal(t, map[string]string{"TX": "a\x00", "ERC20": "c\x00"}, next)
+	assert.Equal(t, map[string]string{"TX": "a", "ERC20": "b"}, prev)
+
+	// index types without a selected entry keep their bound
+	entries = activityEntries([2]string{"TX", "d"}, [2]string{"TX", "e"}, [2]string{"ITX", "f"})
+	selected, next, prev, moreData = mergeActivityIndexEntries(entries, next, false, 2)
+	assert.True(t, moreData)
+	assert.Equal(t, activityEntries([2]string{"TX", "d"}, [2]string{"TX", "e"}), selected)
+	assert.Equal(t, map[string]string{"TX": "e\x00", "ERC20": "c\x00"}, next)
+	assert.Equal(t, map[string]string{"TX": "d", "ERC20": "c\x00"}, prev)
+
+	// going back the entries before the bounds are read in reverse key order and returned newest first
+	entries = activityEntries([2]string{"TX", "a"}, [2]string{"ERC20", "c"}, [2]string{"ERC20", "b"})
+	selected, next, prev, moreData = mergeActivityIndexEntries(entries, prev, true, 2)
+	assert.True(t, moreData)
+	assert.Equal(t, activityEntries([2]string{"ERC20", "b"}, [2]string{"ERC20", "c"}), selected)
+	assert.Equal(t, map[string]string{"TX": "d", "ERC20": "c\x00"}, next)
+	assert.Equal(t, map[string]string{"TX": "d", "ERC20": "b"}, prev)
+}
+
+func TestDecodeActivity(t *testing.T) {
+	itx := func(value []byte) []byte {
+		data, err := proto.Marshal(&types.Eth1InternalTransactionIndexed{Value: value, Type: "call"})
+		require.NoError(t, err)
+		return data
+	}
+
+	activity, err := decodeActivity(activityIndexEntry{indexType: "ITX", index: "idx"}, itx([]byte{0x01}))
+	require.NoError(t, err)
+	require.NotNil(t, activity)
+	assert.Equal(t, "idx", activity.IndexKey)
+	assert.Equal(t, []byte{0x01}, activity.Itx.Value)
+
+	for _, value := range [][]byte{nil, {0x00}} {
+		activity, err = decodeActivity(activityIndexEntry{indexType: "ITX"}, itx(value))
+		require.NoError(t, err)
+		assert.Nil(t, activity, "internal transactions without value are skipped")
+	}
+
+	tx, err := proto.Marshal(&types.Eth1TransactionIndexed{Hash: []byte{0xaa}})
+	require.NoError(t, err)
+	activity, err = decodeActivity(activityIndexEntry{indexType: "TX"}, tx)
+	require.NoError(t, err)
+	require.NotNil(t, activity.Tx)
+	assert.Equal(t, []byte{0xaa}, activity.Tx.Hash)
+
+	_, err = decodeActivity(activityIndexEntry{indexType: "UNKNOWN"}, tx)
+	assert.Error(t, err)
+}
@@ -1386,11 +1386,13 @@ func GetEpochWithdrawals(epoch uint64) ([]*types.WithdrawalsNotification, error)
 		w.validatorindex,
 		w.address,
 		w.amount,
-		v.pubkey as pubkey
+		v.pubkey as pubkey,
+		v.withdrawalcredentials,
+		COALESCE(v.withdrawableepoch, $3) AS withdrawableepoch
 	FROM blocks_withdrawals w
 	INNER JOIN blocks b ON b.blockroot = w.block_root AND b.status = '1'
 	LEFT JOIN validators v on v.validatorindex = w.validatorindex
-	WHERE w.block_slot >= $1 AND w.block_slot < $2 ORDER BY w.withdrawalindex`, epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch, (epoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch)
+	WHERE w.block_slot >= $1 AND w.block_slot < $2 ORDER BY w.withdrawalindex`, epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch, (epoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch, MaxSqlNumber)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -2031,10 +2033,14 @@ func GetWithdrawableValidatorCount(epoch uint64) (uint64, error) {
                 DAY
         FROM
                 validator_stats
-        WHERE DAY = (SELECT COALESCE(MAX(day), 0) FROM validator_stats_status)) as stats
+        WHERE DAY = (SELECT COALESCE(MAX(day), 0) FROM validator_stats_status WHERE status)) as stats
 	ON stats.validatorindex = validators.validatorindex
 	WHERE
-		validators.withdrawalcredentials LIKE '\x01' || '%'::bytea AND ((stats.end_effective_balance = $1 AND stats.end_balance > $1) OR (validators.withdrawableepoch <= $2 AND stats.end_balance > 0));`, utils.Config.Chain.ClConfig.MaxEffectiveBalance, epoch)
+		(
+			(validators.withdrawalcredentials LIKE '\x01' || '%'::bytea AND stats.end_effective_balance = $1 AND stats.end_balance > $1) OR
+			(validators.withdrawalcredentials LIKE '\x02' || '%'::bytea AND stats.end_effective_balance = $2 AND stats.end_balance > $2) OR
+			((validators.withdrawalcredentials LIKE '\x01' || '%'::bytea OR validators.withdrawalcredentials LIKE '\x02' || '%'::bytea) AND validators.withdrawableepoch <= $3 AND stats.end_balance > 0)
+		);`, utils.Config.Chain.ClConfig.MinActivationBalance, utils.Config.Chain.ClConfig.MaxEffectiveBalanceElectra, epoch)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -5,16 +5,19 @@ import (
 	"context"
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gobitfly/beaconchain/pkg/commons/cache"
 	ensContracts "github.com/gobitfly/beaconchain/pkg/commons/contracts/ens"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 
 	gcp_bigtable "cloud.google.com/go/bigtable"
 	"github.com/coocood/freecache"
@@ -525,22 +528,31 @@ func validateEnsName(client *ethclient.Client, name string, alreadyChecked *EnsC
 		isPrimary = true
 	}
 
+	resolverAddress, textRecords, err := getEnsResolverRecords(client, name)
+	if err != nil {
+		return fmt.Errorf("error could not get resolver records for [%v]: %w", name, err)
+	}
+
 	_, err = WriterDb.Exec(`
 	INSERT INTO ens (
 		name_hash, 
 		ens_name, 
 		address,
 		is_primary_name, 
-		valid_to)
-	VALUES ($1, $2, $3, $4, $5) 
+		valid_to,
+		resolver,
+		text_records)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) 
 	ON CONFLICT 
 		(name_hash) 
 	DO UPDATE SET 
 		ens_name = excluded.ens_name,
 		address = excluded.address,
 		is_primary_name = excluded.is_primary_name,
-		valid_to = excluded.valid_to
-	`, nameHash[:], name, addr.Bytes(), isPrimary, expires)
+		valid_to = excluded.valid_to,
+		resolver = excluded.resolver,
+		text_records = excluded.text_records
+	`, nameHash[:], name, addr.Bytes(), isPrimary, expires, resolverAddress.Bytes(), textRecords)
 	if err != nil {
 		if strings.Contains(fmt.Sprintf("%v", err), "invalid byte sequence") {
 			log.Warnf("could not insert ens name [%v]: %v", name, err)
@@ -558,6 +570,40 @@ func validateEnsName(client *ethclient.Client, name string, alreadyChecked *EnsC
 	return nil
 }
 
+// EnsTextRecordKeys are the text records that are indexed for validated names
+var EnsTextRecordKeys = []string{"avatar", "description", "display", "email", "url", "com.github", "com.twitter", "org.telegram"}
+
+// getEnsResolverRecords returns the resolver of a name and its text records json encoded, records that are not set are omitted
+func getEnsResolverRecords(client *ethclient.Client, name string) (common.Address, []byte, error) {
+	startTime := time.Now()
+	defer func() {
+		metrics.TaskDuration.WithLabelValues("ens_get_resolver_records").Observe(time.Since(startTime).Seconds())
+	}()
+
+	resolver, err := go_ens.NewResolver(client, name)
+	if err != nil {
+		// the name resolved before, so this is transient and the name is validated again on the next run
+		return common.Address{}, nil, err
+	}
+	records := make(map[string]string)
+	for _, key := range EnsTextRecordKeys {
+		value, err := resolver.Text(key)
+		if err != nil {
+			// resolvers are not required to implement text records
+			log.WarnWithFields(log.Fields{"name": name, "key": key, "resolver": resolver.ContractAddr, "error": err}, "error getting ens text record")
+			break
+		}
+		if value != "" {
+			records[key] = value
+		}
+	}
+	textRecords, err := json.Marshal(records)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return resolver.ContractAddr, textRecords, nil
+}
+
 func GetEnsExpiration(client *ethclient.Client, name string) (time.Time, error) {
 	startTime := time.Now()
 	defer func() {
@@ -625,6 +671,15 @@ func GetEnsNameForAddress(address common.Address, validUntil time.Time) (name st
 	return name, err
 }
 
+// names that were updated by the validation are served from the cache until the entry expires
+const ensCacheDuration = time.Minute * 10
+
+func ensCacheKey(address string) string {
+	return fmt.Sprintf("%d:ENS:%s", utils.Config.Chain.ClConfig.DepositChainID, strings.ToLower(address))
+}
+
+// GetEnsNamesForAddresses sets the primary ens names of the given 0x prefixed lowercase addresses.
+// Lookups are cached in the tiered cache if it is initialized, including addresses without a name.
 func GetEnsNamesForAddresses(addressMap map[string]string) error {
 	if len(addressMap) == 0 {
 		return nil
@@ -636,12 +691,21 @@ func GetEnsNamesForAddresses(addressMap map[string]string) error {
 	dbAddresses := []pair{}
 	addresses := make([][]byte, 0, len(addressMap))
 	for address := range addressMap {
+		if cache.TieredCache != nil {
+			if name, err := cache.TieredCache.GetStringWithLocalTimeout(ensCacheKey(address), ensCacheDuration); err == nil {
+				addressMap[address] = name
+				continue
+			}
+		}
 		add, err := hexutil.Decode(address)
 		if err != nil {
 			return err
 		}
 		addresses = append(addresses, add)
 	}
+	if len(addresses) == 0 {
+		return nil
+	}
 
 	err := ReaderDb.Select(&dbAddresses, `
 	SELECT address, ens_name
@@ -654,12 +718,52 @@ func GetEnsNamesForAddresses(addressMap map[string]string) error {
 	if err != nil {
 		return err
 	}
+	names := make(map[string]string, len(dbAddresses))
 	for _, foundling := range dbAddresses {
+		names[hexutil.Encode(foundling.Address)] = foundling.EnsName
 		addressMap[hexutil.Encode(foundling.Address)] = foundling.EnsName
 	}
+	if cache.TieredCache != nil {
+		for _, address := range addresses {
+			key := hexutil.Encode(address)
+			if err := cache.TieredCache.SetString(ensCacheKey(key), names[key], ensCacheDuration); err != nil {
+				log.Error(err, "error caching ens name", 0, map[string]interface{}{"address": key})
+			}
+		}
+	}
 	return nil
 }
 
+// GetEnsName returns the validated ens name, nil is returned if the name is unknown or expired
+func GetEnsName(name string) (*types.EnsName, error) {
+	result := &types.EnsName{}
+	err := ReaderDb.Get(result, `
+	SELECT name_hash, ens_name, address, is_primary_name, valid_to, resolver, text_records
+	FROM ens
+	WHERE
+		ens_name = $1 AND
+		valid_to >= now()
+	`, name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return result, err
+}
+
+// GetEnsNamesOfAddress returns the validated ens names that resolve to the address, the primary name first
+func GetEnsNamesOfAddress(address common.Address) ([]*types.EnsName, error) {
+	result := []*types.EnsName{}
+	err := ReaderDb.Select(&result, `
+	SELECT name_hash, ens_name, address, is_primary_name, valid_to, resolver, text_records
+	FROM ens
+	WHERE
+		address = $1 AND
+		valid_to >= now()
+	ORDER BY is_primary_name DESC, ens_name
+	`, address.Bytes())
+	return result, err
+}
+
 func removeEnsName(name string) error {
 	_, err := WriterDb.Exec(`
 	DELETE FROM ens
@@ -0,0 +1,210 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/layer2"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/coocood/freecache"
+)
+
+// TransformLayer2 accepts an eth1 block and creates bigtable mutations for the batches and bridge transfers of the layer 2 networks
+// that settle on this chain, see layer2.Networks.
+// ==================================================
+//
+// It writes batch submissions
+// Row:    <chainID>:L2:<layer2ChainID>:B:<reversePaddedBlockNumber>:<paddedTxIndex>
+// Family: f
+// Column: d
+// Cell:   Json<Eth1Layer2Batch>
+// Example scan: "1:L2:10:B" returns the OP Mainnet batches submitted to mainnet, newest first
+//
+// It writes deposits (layer 1 to layer 2)
+// Row:    <chainID>:L2:<layer2ChainID>:D:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: d
+// Cell:   Json<Eth1Layer2Transfer>
+//
+// It writes proven and finalized withdrawals (layer 2 to layer 1)
+// Row:    <chainID>:L2:<layer2ChainID>:W:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: d
+// Cell:   Json<Eth1Layer2Transfer>
+//
+// ==================================================
+func (bigtable *Bigtable) TransformLayer2(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
+	startTime := time.Now()
+	defer func() {
+		metrics.TaskDuration.WithLabelValues("bt_transform_layer2").Observe(time.Since(startTime).Seconds())
+	}()
+
+	bulkData = &types.BulkMutations{}
+	bulkMetadataUpdates = &types.BulkMutations{}
+
+	add := func(key string, value any) error {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		mut := gcp_bigtable.NewMutation()
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+		bulkData.Keys = append(bulkData.Keys, key)
+		bulkData.Muts = append(bulkData.Muts, mut)
+		return nil
+	}
+
+	networks := layer2.Networks(utils.Config.Chain.ClConfig.DepositChainID)
+	if len(networks) == 0 {
+		return bulkData, bulkMetadataUpdates, nil
+	}
+
+	for i, tx := range blk.GetTransactions() {
+		if i >= TX_PER_BLOCK_LIMIT {
+			return nil, nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
+		}
+		if len(tx.GetLogs()) > ITX_PER_TX_LIMIT {
+			return nil, nil, fmt.Errorf("unexpected number of logs in block expected at most %d but got: %v tx: %x", ITX_PER_TX_LIMIT-1, len(tx.GetLogs()), tx.GetHash())
+		}
+		iReversed := reversePaddedIndex(i, TX_PER_BLOCK_LIMIT)
+
+		for _, network := range networks {
+			batch, transfers, err := network.Rollup.ParseTransaction(tx)
+			if err != nil {
+				log.WarnWithFields(log.Fields{"block": blk.GetNumber(), "tx": fmt.Sprintf("%#x", tx.GetHash()), "network": network.Name, "error": err}, "error parsing layer 2 transaction")
+				continue
+			}
+			prefix := fmt.Sprintf("%s:L2:%d", bigtable.chainId, network.ChainId)
+
+			if batch != nil {
+				fee := new(big.Int).Mul(new(big.Int).SetBytes(tx.GetGasPrice()), new(big.Int).SetUint64(tx.GetGasUsed()))
+				fee.Add(fee, new(big.Int).Mul(new(big.Int).SetBytes(tx.GetBlobGasPrice()), new(big.Int).SetUint64(tx.GetBlobGasUsed())))
+				l2Batch := &types.Eth1Layer2Batch{
+					Index:               batch.Index,
+					Submitter:           batch.Submitter.Bytes(),
+					DataLocation:        batch.DataLocation,
+					Size:                batch.Size,
+					BlobVersionedHashes: tx.GetBlobVersionedHashes(),
+					Fee:                 fee.Bytes(),
+					TxHash:              tx.GetHash(),
+					BlockNumber:         blk.GetNumber(),
+					Time:                uint64(blk.GetTime().GetSeconds()),
+					TxIndex:             uint64(i),
+				}
+				if err := add(fmt.Sprintf("%s:B:%s:%s", prefix, reversedPaddedBlockNumber(blk.GetNumber()), iReversed), l2Batch); err != nil {
+					return nil, nil, err
+				}
+			}
+
+			for _, transfer := range transfers {
+				l2Transfer := &types.Eth1Layer2Transfer{
+					MessageId:   transfer.MessageId,
+					Status:      transfer.Status,
+					TxHash:      tx.GetHash(),
+					BlockNumber: blk.GetNumber(),
+					Time:        uint64(blk.GetTime().GetSeconds()),
+					TxIndex:     uint64(i),
+					LogIndex:    uint64(transfer.LogIndex),
+				}
+				if transfer.From != nil {
+					l2Transfer.From = transfer.From.Bytes()
+				}
+				if transfer.To != nil {
+					l2Transfer.To = transfer.To.Bytes()
+				}
+				if transfer.Value != nil {
+					l2Transfer.Value = transfer.Value.Bytes()
+				}
+				direction := "D"
+				if transfer.Direction == layer2.TransferWithdrawal {
+					direction = "W"
+				}
+				key := fmt.Sprintf("%s:%s:%s:%s:%s", prefix, direction, reversedPaddedBlockNumber(blk.GetNumber()), iReversed, reversePaddedIndex(transfer.LogIndex, ITX_PER_TX_LIMIT))
+				if err := add(key, l2Transfer); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	return bulkData, bulkMetadataUpdates, nil
+}
+
+// GetLayer2Batches returns the batches of a layer 2 network that follow the cursor, or that precede it if reverse is set
+func (bigtable *Bigtable) GetLayer2Batches(layer2ChainId uint64, cursor *types.Eth1Layer2Batch, reverse bool, limit int64) ([]*types.Eth1Layer2Batch, error) {
+	prefix := fmt.Sprintf("%s:L2:%d:B:", bigtable.chainId, layer2ChainId)
+	cursorKey := ""
+	if cursor != nil {
+		cursorKey = fmt.Sprintf("%s%s:%s", prefix, reversedPaddedBlockNumber(cursor.BlockNumber), reversePaddedIndex(int(cursor.TxIndex), TX_PER_BLOCK_LIMIT))
+	}
+
+	batches := make([]*types.Eth1Layer2Batch, 0, limit)
+	err := bigtable.readLayer2Page(prefix, cursorKey, reverse, limit, func(value []byte) error {
+		batch := &types.Eth1Layer2Batch{}
+		if err := json.Unmarshal(value, batch); err != nil {
+			return err
+		}
+		batches = append(batches, batch)
+		return nil
+	})
+	return batches, err
+}
+
+// GetLayer2Transfers returns the deposits or withdrawals of a layer 2 network that follow the cursor, or that precede it if reverse is set
+func (bigtable *Bigtable) GetLayer2Transfers(layer2ChainId uint64, direction string, cursor *types.Eth1Layer2Transfer, reverse bool, limit int64) ([]*types.Eth1Layer2Transfer, error) {
+	prefix := fmt.Sprintf("%s:L2:%d:D:", bigtable.chainId, layer2ChainId)
+	if direction == layer2.TransferWithdrawal {
+		prefix = fmt.Sprintf("%s:L2:%d:W:", bigtable.chainId, layer2ChainId)
+	}
+	cursorKey := ""
+	if cursor != nil {
+		cursorKey = fmt.Sprintf("%s%s:%s:%s", prefix, reversedPaddedBlockNumber(cursor.BlockNumber), reversePaddedIndex(int(cursor.TxIndex), TX_PER_BLOCK_LIMIT), reversePaddedIndex(int(cursor.LogIndex), ITX_PER_TX_LIMIT))
+	}
+
+	transfers := make([]*types.Eth1Layer2Transfer, 0, limit)
+	err := bigtable.readLayer2Page(prefix, cursorKey, reverse, limit, func(value []byte) error {
+		transfer := &types.Eth1Layer2Transfer{}
+		if err := json.Unmarshal(value, transfer); err != nil {
+			return err
+		}
+		transfers = append(transfers, transfer)
+		return nil
+	})
+	return transfers, err
+}
+
+func (bigtable *Bigtable) readLayer2Page(prefix, cursorKey string, reverse bool, limit int64, parse func(value []byte) error) error {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"prefix":   prefix,
+			"limit":    limit,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	var parseErr error
+	err := bigtable.readPage(ctx, prefix, cursorKey, reverse, limit, func(row gcp_bigtable.Row) bool {
+		if parseErr = parse(row[DEFAULT_FAMILY][0].Value); parseErr != nil {
+			parseErr = fmt.Errorf("error parsing layer 2 row %v: %w", row.Key(), parseErr)
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return parseErr
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add column dedup_key to table node_jobs';
+ALTER TABLE node_jobs ADD COLUMN IF NOT EXISTS dedup_key VARCHAR(80);
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - create unique index on node_jobs (type, dedup_key) for jobs that have not failed';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_node_jobs_type_dedup_key ON node_jobs (type, dedup_key) WHERE dedup_key IS NOT NULL AND status <> 'FAILED';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop unique index on node_jobs (type, dedup_key)';
+DROP INDEX IF EXISTS idx_node_jobs_type_dedup_key;
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'down SQL query - drop column dedup_key from table node_jobs';
+ALTER TABLE node_jobs DROP COLUMN IF EXISTS dedup_key;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create table validator_stats_staging holding the checkpointed phases of not yet completed statistics days';
+CREATE TABLE IF NOT EXISTS validator_stats_staging (LIKE validator_stats INCLUDING DEFAULTS INCLUDING INDEXES);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table validator_stats_staging';
+DROP TABLE IF EXISTS validator_stats_staging;
+-- +goose StatementEnd
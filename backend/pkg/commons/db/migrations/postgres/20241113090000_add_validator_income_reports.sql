@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create table validator_income_reports';
+CREATE TABLE IF NOT EXISTS validator_income_reports (
+    id UUID NOT NULL PRIMARY KEY,
+    dashboard_id BIGINT, -- NULL for dashboards that are a plain list of validators
+    group_id BIGINT,
+    aggregation TEXT NOT NULL,
+    -- the validators and their groups are resolved when the report is requested so later dashboard changes don't alter the report
+    validators INT[] NOT NULL,
+    validator_groups BIGINT[] NOT NULL,
+    start_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    end_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    currency TEXT NOT NULL,
+    format TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    error TEXT,
+    result BYTEA,
+    created_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    started_ts TIMESTAMP WITHOUT TIME ZONE,
+    completed_ts TIMESTAMP WITHOUT TIME ZONE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - create index on validator_income_reports for unfinished reports';
+CREATE INDEX IF NOT EXISTS idx_validator_income_reports_unfinished ON validator_income_reports (created_ts) WHERE status IN ('pending', 'running');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table validator_income_reports';
+DROP TABLE IF EXISTS validator_income_reports;
+-- +goose StatementEnd
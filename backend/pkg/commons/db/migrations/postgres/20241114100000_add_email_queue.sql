@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create table email_queue';
+CREATE TABLE IF NOT EXISTS email_queue (
+    id BIGSERIAL PRIMARY KEY,
+    recipient TEXT NOT NULL, -- as given, compared case insensitively
+    subject TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    html_body TEXT,
+    status TEXT NOT NULL DEFAULT 'pending', -- pending, sent, failed, expired, undeliverable
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    provider TEXT,
+    created_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    next_attempt_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    expires_ts TIMESTAMP WITHOUT TIME ZONE,
+    sent_ts TIMESTAMP WITHOUT TIME ZONE
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - create indexes on email_queue';
+CREATE INDEX IF NOT EXISTS idx_email_queue_pending ON email_queue (next_attempt_ts) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_email_queue_recipient ON email_queue (LOWER(recipient), status, sent_ts);
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - create table email_undeliverable_addresses';
+CREATE TABLE IF NOT EXISTS email_undeliverable_addresses (
+    email TEXT NOT NULL PRIMARY KEY, -- lower case
+    reason TEXT NOT NULL, -- bounce, complaint
+    details TEXT,
+    created_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table email_undeliverable_addresses';
+DROP TABLE IF EXISTS email_undeliverable_addresses;
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table email_queue';
+DROP TABLE IF EXISTS email_queue;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create table users_val_dashboards_validator_tags';
+CREATE TABLE IF NOT EXISTS users_val_dashboards_validator_tags (
+    dashboard_id    BIGINT      NOT NULL,
+    validator_index BIGINT      NOT NULL,
+    tag_key         VARCHAR(50) NOT NULL,
+    tag_value       VARCHAR(50) NOT NULL,
+    -- tags are removed together with the validator, moving a validator to another group keeps its tags
+    foreign key (dashboard_id, validator_index) references users_val_dashboards_validators(dashboard_id, validator_index) ON DELETE CASCADE,
+    primary key (dashboard_id, validator_index, tag_key)
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - create index on users_val_dashboards_validator_tags for tag lookups';
+CREATE INDEX IF NOT EXISTS idx_users_val_dashboards_validator_tags_tag ON users_val_dashboards_validator_tags (dashboard_id, tag_key, tag_value);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table users_val_dashboards_validator_tags';
+DROP TABLE IF EXISTS users_val_dashboards_validator_tags;
+-- +goose StatementEnd
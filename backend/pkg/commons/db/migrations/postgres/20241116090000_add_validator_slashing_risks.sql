@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create table validator_slashing_risks';
+CREATE TABLE IF NOT EXISTS validator_slashing_risks (
+    id BIGSERIAL PRIMARY KEY,
+    validator_index INT NOT NULL,
+    risk_type TEXT NOT NULL, -- double_vote, surround_vote, double_proposal
+    slot INT NOT NULL,
+    epoch INT NOT NULL,
+    details TEXT NOT NULL,
+    detected_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    notified_ts TIMESTAMP WITHOUT TIME ZONE,
+    UNIQUE (validator_index, risk_type, epoch)
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - create index on validator_slashing_risks';
+CREATE INDEX IF NOT EXISTS idx_validator_slashing_risks_pending ON validator_slashing_risks (id) WHERE notified_ts IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table validator_slashing_risks';
+DROP TABLE IF EXISTS validator_slashing_risks;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create table blob_submitter_stats';
+CREATE TABLE IF NOT EXISTS blob_submitter_stats (
+    day INT NOT NULL,
+    address BYTEA NOT NULL,
+    tx_count INT NOT NULL,
+    blob_count INT NOT NULL,
+    blob_gas_used NUMERIC NOT NULL,
+    blob_fees NUMERIC NOT NULL, -- wei
+    PRIMARY KEY (day, address)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table blob_submitter_stats';
+DROP TABLE IF EXISTS blob_submitter_stats;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add columns resolver and text_records to table ens';
+ALTER TABLE ens ADD COLUMN IF NOT EXISTS resolver BYTEA;
+ALTER TABLE ens ADD COLUMN IF NOT EXISTS text_records JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop columns resolver and text_records from table ens';
+ALTER TABLE ens DROP COLUMN IF EXISTS text_records;
+ALTER TABLE ens DROP COLUMN IF EXISTS resolver;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create table paymaster_stats';
+CREATE TABLE IF NOT EXISTS paymaster_stats (
+    day INT NOT NULL,
+    address BYTEA NOT NULL,
+    op_count INT NOT NULL, -- sponsored user operations
+    gas_used NUMERIC NOT NULL,
+    gas_cost NUMERIC NOT NULL, -- wei
+    PRIMARY KEY (day, address)
+);
+CREATE INDEX IF NOT EXISTS idx_paymaster_stats_address ON paymaster_stats (address);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop table paymaster_stats';
+DROP TABLE IF EXISTS paymaster_stats;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+
+SELECT 'up SQL query - create idx_blocks_blob_sidecars_blob_versioned_hash';
+-- +goose StatementBegin
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_blocks_blob_sidecars_blob_versioned_hash ON public.blocks_blob_sidecars USING btree (blob_versioned_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+SELECT 'down SQL query - drop idx_blocks_blob_sidecars_blob_versioned_hash';
+-- +goose StatementBegin
+DROP INDEX CONCURRENTLY IF EXISTS idx_blocks_blob_sidecars_blob_versioned_hash;
+-- +goose StatementEnd
@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create materialized view network_slashings';
+-- a validator can be part of several slashings (e.g. attester slashings that contain already slashed validators),
+-- only the first included one actually slashes it
+CREATE MATERIALIZED VIEW IF NOT EXISTS network_slashings AS
+SELECT
+    f.*,
+    COUNT(*) OVER (PARTITION BY f.slasher) AS slasher_total
+FROM (
+    SELECT DISTINCT ON (validator_index)
+        slot,
+        epoch,
+        type,
+        block_index,
+        validator_index,
+        slasher
+    FROM (
+        SELECT
+            b.slot,
+            b.epoch,
+            'attester' AS type,
+            s.block_index,
+            UNNEST(ARRAY(
+                SELECT UNNEST(s.attestation1_indices)
+                    INTERSECT
+                SELECT UNNEST(s.attestation2_indices)
+            )) AS validator_index,
+            b.proposer AS slasher
+        FROM blocks_attesterslashings s
+        INNER JOIN blocks b ON b.slot = s.block_slot
+        WHERE b.status = '1'
+        UNION ALL
+        SELECT
+            b.slot,
+            b.epoch,
+            'proposer' AS type,
+            s.block_index,
+            s.proposerindex AS validator_index,
+            b.proposer AS slasher
+        FROM blocks_proposerslashings s
+        INNER JOIN blocks b ON b.slot = s.block_slot
+        WHERE b.status = '1'
+    ) a
+    ORDER BY validator_index, slot
+) f
+WITH DATA;
+-- +goose StatementEnd
+-- +goose StatementBegin
+SELECT 'up SQL query - create indexes on network_slashings';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_network_slashings_validator_index ON network_slashings (validator_index);
+CREATE INDEX IF NOT EXISTS idx_network_slashings_slot_type_block_index ON network_slashings (slot, type, block_index, validator_index);
+CREATE INDEX IF NOT EXISTS idx_network_slashings_slasher ON network_slashings (slasher);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop materialized view network_slashings';
+DROP MATERIALIZED VIEW IF EXISTS network_slashings;
+-- +goose StatementEnd
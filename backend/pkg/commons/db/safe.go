@@ -0,0 +1,374 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/contracts/safe"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/coocood/freecache"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TransformSafe accepts an eth1 block and creates bigtable mutations for the events of Safe (formerly Gnosis Safe) multisigs.
+// Safes are detected by their events, proxies that were set up before the indexed range are detected on their first execution.
+// Only the setup and execution events mark a safe, the owner, threshold and approval events are emitted by other contracts as well.
+// Owner and threshold changes are therefore only written for safes that are marked in the same block or already known.
+// ==================================================
+//
+// It marks safes
+// Row:    <chainID>:SAFE:<safeAddress>
+// Family: f
+// Column: d
+// Cell:   nil
+//
+// It writes owner and threshold changes
+// Row:    <chainID>:SAFE:<safeAddress>:C:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: d
+// Cell:   Json<Eth1SafeEvent>
+//
+// It writes executed transactions
+// Row:    <chainID>:SAFE:<safeAddress>:X:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: d
+// Cell:   Json<Eth1SafeExecution>
+// Example scan: "1:SAFE:849d52316331967b6ff1198e5e32a0eb168d039d:X" returns the mainnet transactions of the safe 0x849d52316331967b6ff1198e5e32a0eb168d039d, newest first
+//
+// It indexes executed transactions by their safe transaction hash
+// Row:    <chainID>:SAFE_TX:<safeTxHash>:X
+// Family: f
+// Column: d
+// Cell:   key of the execution row
+//
+// It writes on-chain approvals of safe transactions
+// Row:    <chainID>:SAFE_TX:<safeTxHash>:A:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: d
+// Cell:   Json<Eth1SafeConfirmation>
+//
+// ==================================================
+func (bigtable *Bigtable) TransformSafe(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
+	startTime := time.Now()
+	defer func() {
+		metrics.TaskDuration.WithLabelValues("bt_transform_safe").Observe(time.Since(startTime).Seconds())
+	}()
+
+	bulkData = &types.BulkMutations{}
+	bulkMetadataUpdates = &types.BulkMutations{}
+
+	add := func(key string, value []byte) {
+		mut := gcp_bigtable.NewMutation()
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), value)
+		bulkData.Keys = append(bulkData.Keys, key)
+		bulkData.Muts = append(bulkData.Muts, mut)
+	}
+	markedSafes := make(map[string]bool)
+	// owner and threshold changes by address, they are written once it is known whether the address is a safe
+	type change struct {
+		key   string
+		value []byte
+	}
+	changes := make(map[string][]change)
+
+	for i, tx := range blk.GetTransactions() {
+		if i >= TX_PER_BLOCK_LIMIT {
+			return nil, nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
+		}
+		iReversed := reversePaddedIndex(i, TX_PER_BLOCK_LIMIT)
+		for j, txLog := range tx.GetLogs() {
+			if j >= ITX_PER_TX_LIMIT {
+				return nil, nil, fmt.Errorf("unexpected number of logs in block expected at most %d but got: %v tx: %x", ITX_PER_TX_LIMIT-1, j, tx.GetHash())
+			}
+			event, err := safe.ParseLog(txLog.GetTopics(), txLog.GetData())
+			if err != nil {
+				// contracts that are no safes may emit events with the same signature
+				log.WarnWithFields(log.Fields{"block": blk.GetNumber(), "tx": fmt.Sprintf("%#x", tx.GetHash()), "logIndex": j, "error": err}, "error parsing safe event")
+				continue
+			}
+			if event == nil {
+				continue
+			}
+			jReversed := reversePaddedIndex(j, ITX_PER_TX_LIMIT)
+			position := fmt.Sprintf("%s:%s:%s", reversedPaddedBlockNumber(blk.GetNumber()), iReversed, jReversed)
+			safeAddress := txLog.GetAddress()
+
+			switch event.Name {
+			case safe.EventSafeSetup, safe.EventExecutionSuccess, safe.EventExecutionFailure:
+				if !markedSafes[string(safeAddress)] {
+					add(fmt.Sprintf("%s:SAFE:%x", bigtable.chainId, safeAddress), nil)
+					markedSafes[string(safeAddress)] = true
+				}
+			}
+
+			switch event.Name {
+			case safe.EventSafeSetup, safe.EventAddedOwner, safe.EventRemovedOwner, safe.EventChangedThreshold:
+				safeEvent := &types.Eth1SafeEvent{
+					Event:       event.Name,
+					TxHash:      tx.GetHash(),
+					BlockNumber: blk.GetNumber(),
+					Time:        uint64(blk.GetTime().GetSeconds()),
+					TxIndex:     uint64(i),
+					LogIndex:    uint64(j),
+				}
+				for _, owner := range event.Owners {
+					safeEvent.Owners = append(safeEvent.Owners, owner.Bytes())
+				}
+				if event.Threshold != nil {
+					safeEvent.Threshold = event.Threshold.Uint64()
+				}
+				b, err := json.Marshal(safeEvent)
+				if err != nil {
+					return nil, nil, err
+				}
+				changes[string(safeAddress)] = append(changes[string(safeAddress)], change{key: fmt.Sprintf("%s:SAFE:%x:C:%s", bigtable.chainId, safeAddress, position), value: b})
+			case safe.EventExecutionSuccess, safe.EventExecutionFailure:
+				execution := &types.Eth1SafeExecution{
+					Safe:        safeAddress,
+					SafeTxHash:  event.SafeTxHash.Bytes(),
+					Success:     event.Name == safe.EventExecutionSuccess,
+					Payment:     event.Payment.Bytes(),
+					TxHash:      tx.GetHash(),
+					BlockNumber: blk.GetNumber(),
+					Time:        uint64(blk.GetTime().GetSeconds()),
+					TxIndex:     uint64(i),
+					LogIndex:    uint64(j),
+				}
+				// the call details are only available if the safe was called directly, not via another contract
+				if bytes.Equal(tx.GetTo(), safeAddress) {
+					call, err := safe.DecodeExecTransaction(tx.GetData())
+					if err != nil {
+						log.WarnWithFields(log.Fields{"block": blk.GetNumber(), "tx": fmt.Sprintf("%#x", tx.GetHash()), "error": err}, "error decoding safe transaction")
+					} else if call != nil {
+						execution.To = call.To.Bytes()
+						execution.Value = call.Value.Bytes()
+						execution.Data = call.Data
+						execution.Operation = call.Operation
+						for _, confirmation := range safe.RecoverConfirmations(event.SafeTxHash, call.Signatures, common.BytesToAddress(tx.GetFrom())) {
+							execution.Confirmations = append(execution.Confirmations, &types.Eth1SafeConfirmation{
+								Owner: confirmation.Owner.Bytes(),
+								Type:  confirmation.Type,
+							})
+						}
+					}
+				}
+				b, err := json.Marshal(execution)
+				if err != nil {
+					return nil, nil, err
+				}
+				key := fmt.Sprintf("%s:SAFE:%x:X:%s", bigtable.chainId, safeAddress, position)
+				add(key, b)
+				add(fmt.Sprintf("%s:SAFE_TX:%x:X", bigtable.chainId, event.SafeTxHash.Bytes()), []byte(key))
+			case safe.EventApproveHash:
+				b, err := json.Marshal(&types.Eth1SafeConfirmation{
+					Owner:       event.Owners[0].Bytes(),
+					Type:        safe.ConfirmationApprovedHash,
+					TxHash:      tx.GetHash(),
+					BlockNumber: blk.GetNumber(),
+					Time:        uint64(blk.GetTime().GetSeconds()),
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				add(fmt.Sprintf("%s:SAFE_TX:%x:A:%s", bigtable.chainId, event.SafeTxHash.Bytes(), position), b)
+			}
+		}
+	}
+
+	unmarked := make([][]byte, 0, len(changes))
+	for address := range changes {
+		if !markedSafes[address] {
+			unmarked = append(unmarked, []byte(address))
+		}
+	}
+	knownSafes, err := bigtable.GetSafes(unmarked)
+	if err != nil {
+		return nil, nil, err
+	}
+	for address, safeChanges := range changes {
+		if !markedSafes[address] && !knownSafes[fmt.Sprintf("%x", address)] {
+			continue
+		}
+		for _, c := range safeChanges {
+			add(c.key, c.value)
+		}
+	}
+
+	return bulkData, bulkMetadataUpdates, nil
+}
+
+// GetSafes returns the subset of the given addresses that are known safes
+func (bigtable *Bigtable) GetSafes(addresses [][]byte) (map[string]bool, error) {
+	result := make(map[string]bool)
+	if len(addresses) == 0 {
+		return result, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	keys := make(gcp_bigtable.RowList, 0, len(addresses))
+	for _, address := range addresses {
+		keys = append(keys, fmt.Sprintf("%s:SAFE:%x", bigtable.chainId, address))
+	}
+	prefix := fmt.Sprintf("%s:SAFE:", bigtable.chainId)
+	err := bigtable.tableData.ReadRows(ctx, keys, func(row gcp_bigtable.Row) bool {
+		result[strings.TrimPrefix(row.Key(), prefix)] = true
+		return true
+	}, gcp_bigtable.RowFilter(gcp_bigtable.StripValueFilter()))
+	if err != nil {
+		return nil, fmt.Errorf("error reading safe markers: %w", err)
+	}
+	return result, nil
+}
+
+// GetSafe replays the indexed owner and threshold changes of a safe, nil is returned if the address is not a known safe
+func (bigtable *Bigtable) GetSafe(address []byte) (*types.Eth1Safe, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	safes, err := bigtable.GetSafes([][]byte{address})
+	if err != nil {
+		return nil, err
+	}
+	if !safes[fmt.Sprintf("%x", address)] {
+		return nil, nil
+	}
+
+	var events []*types.Eth1SafeEvent
+	var parseErr error
+	prefix := fmt.Sprintf("%s:SAFE:%x:C:", bigtable.chainId, address)
+	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.PrefixRange(prefix), func(row gcp_bigtable.Row) bool {
+		event := &types.Eth1SafeEvent{}
+		if parseErr = json.Unmarshal(row[DEFAULT_FAMILY][0].Value, event); parseErr != nil {
+			parseErr = fmt.Errorf("error parsing safe event %v: %w", row.Key(), parseErr)
+			return false
+		}
+		events = append(events, event)
+		return true
+	}, gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter(DATA_COLUMN)))
+	if err != nil {
+		return nil, fmt.Errorf("error reading safe events of %#x: %w", address, err)
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	// rows are sorted newest first
+	result := &types.Eth1Safe{Address: address}
+	for _, event := range slices.Backward(events) {
+		switch event.Event {
+		case safe.EventSafeSetup:
+			result.Owners = event.Owners
+			result.Threshold = event.Threshold
+			result.SetupTxHash = event.TxHash
+			result.SetupBlock = event.BlockNumber
+			result.SetupTime = event.Time
+		case safe.EventAddedOwner:
+			// owners are added at the front of the owner list
+			result.Owners = append(event.Owners[:1:1], result.Owners...)
+		case safe.EventRemovedOwner:
+			result.Owners = slices.DeleteFunc(result.Owners, func(owner []byte) bool { return bytes.Equal(owner, event.Owners[0]) })
+		case safe.EventChangedThreshold:
+			result.Threshold = event.Threshold
+		}
+	}
+	return result, nil
+}
+
+// GetSafeExecutions returns the executed transactions of a safe that follow the cursor, or that precede it if reverse is set
+func (bigtable *Bigtable) GetSafeExecutions(address []byte, cursor *types.Eth1SafeExecution, reverse bool, limit int64) ([]*types.Eth1SafeExecution, error) {
+	prefix := fmt.Sprintf("%s:SAFE:%x:X:", bigtable.chainId, address)
+
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"prefix":   prefix,
+			"limit":    limit,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	cursorKey := ""
+	if cursor != nil {
+		cursorKey = fmt.Sprintf("%s%s:%s:%s", prefix, reversedPaddedBlockNumber(cursor.BlockNumber), reversePaddedIndex(int(cursor.TxIndex), TX_PER_BLOCK_LIMIT), reversePaddedIndex(int(cursor.LogIndex), ITX_PER_TX_LIMIT))
+	}
+
+	executions := make([]*types.Eth1SafeExecution, 0, limit)
+	var parseErr error
+	err := bigtable.readPage(ctx, prefix, cursorKey, reverse, limit, func(row gcp_bigtable.Row) bool {
+		execution := &types.Eth1SafeExecution{}
+		if parseErr = json.Unmarshal(row[DEFAULT_FAMILY][0].Value, execution); parseErr != nil {
+			parseErr = fmt.Errorf("error parsing safe execution %v: %w", row.Key(), parseErr)
+			return false
+		}
+		executions = append(executions, execution)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return executions, parseErr
+}
+
+// GetSafeTransactionConfirmations returns the execution of the safe transaction with the given hash and its on-chain approvals.
+// The execution is nil if the transaction has not been executed yet.
+func (bigtable *Bigtable) GetSafeTransactionConfirmations(safeTxHash []byte) (*types.Eth1SafeExecution, []*types.Eth1SafeConfirmation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	prefix := fmt.Sprintf("%s:SAFE_TX:%x:", bigtable.chainId, safeTxHash)
+	var executionKey string
+	var approvals []*types.Eth1SafeConfirmation
+	var parseErr error
+	err := bigtable.tableData.ReadRows(ctx, gcp_bigtable.PrefixRange(prefix), func(row gcp_bigtable.Row) bool {
+		value := row[DEFAULT_FAMILY][0].Value
+		if strings.HasPrefix(row.Key(), prefix+"X") {
+			executionKey = string(value)
+			return true
+		}
+		approval := &types.Eth1SafeConfirmation{}
+		if parseErr = json.Unmarshal(value, approval); parseErr != nil {
+			parseErr = fmt.Errorf("error parsing safe approval %v: %w", row.Key(), parseErr)
+			return false
+		}
+		approvals = append(approvals, approval)
+		return true
+	}, gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter(DATA_COLUMN)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading safe transaction %#x: %w", safeTxHash, err)
+	}
+	if parseErr != nil {
+		return nil, nil, parseErr
+	}
+	if executionKey == "" {
+		return nil, approvals, nil
+	}
+
+	row, err := bigtable.tableData.ReadRow(ctx, executionKey, gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter(DATA_COLUMN)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading safe execution %v: %w", executionKey, err)
+	}
+	if len(row[DEFAULT_FAMILY]) == 0 {
+		return nil, nil, fmt.Errorf("safe execution %v of safe transaction %#x not found", executionKey, safeTxHash)
+	}
+	execution := &types.Eth1SafeExecution{}
+	if err := json.Unmarshal(row[DEFAULT_FAMILY][0].Value, execution); err != nil {
+		return nil, nil, fmt.Errorf("error parsing safe execution %v: %w", executionKey, err)
+	}
+	return execution, approvals, nil
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
+	"github.com/gobitfly/beaconchain/pkg/commons/contracts/entrypoint"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
 	"github.com/gobitfly/beaconchain/pkg/commons/price"
@@ -43,133 +44,47 @@ func WriteValidatorStatisticsForDay(day uint64, client rpc.Client) error {
 
 	log.Infof("getting exported state for day %v", day)
 
-	type Exported struct {
-		Status bool `db:"status"`
-	}
-	exported := Exported{}
-	err := WriterDb.Get(&exported, `
-		SELECT
-			status
-		FROM validator_stats_status
-		WHERE day = $1;
-		`, day)
-
-	if err != nil && err != sql.ErrNoRows {
-		return fmt.Errorf("error retrieving exported state: %w", err)
+	checkpoints, err := getValidatorStatsCheckpoints(int64(day))
+	if err != nil {
+		return err
 	}
 
-	if exported.Status {
+	if checkpoints.Status {
 		log.Infof("Skipping day %v as it is already exported", day)
 		return nil
 	}
 
-	previousDayExported := Exported{}
-	err = WriterDb.Get(&previousDayExported, `
-		SELECT
-			status
-		FROM validator_stats_status
-		WHERE day = $1;
-		`, int64(day)-1)
-
-	if err != nil && err != sql.ErrNoRows {
-		return fmt.Errorf("error retrieving previous day exported state: %w", err)
+	previousDayCheckpoints, err := getValidatorStatsCheckpoints(int64(day) - 1)
+	if err != nil {
+		return err
 	}
 
-	if day > 0 && !previousDayExported.Status {
+	if day > 0 && !previousDayCheckpoints.Status {
 		return fmt.Errorf("cannot export day %v as day %v has not yet been exported yet", day, int64(day)-1)
 	}
 
-	maxValidatorIndex, err := BigtableClient.GetMaxValidatorindexForEpoch(lastEpoch)
+	validators, validatorData, err := newValidatorStatsData(day)
 	if err != nil {
 		return err
 	}
-	validators := make([]uint64, 0, maxValidatorIndex)
-	validatorData := make([]*types.ValidatorStatsTableDbRow, 0, maxValidatorIndex)
 	validatorDataMux := &sync.Mutex{}
 
-	log.Infof("processing statistics for validators 0-%d", maxValidatorIndex)
-	for i := uint64(0); i <= maxValidatorIndex; i++ {
-		validators = append(validators, i)
-		validatorData = append(validatorData, &types.ValidatorStatsTableDbRow{
-			ValidatorIndex: i,
-			Day:            int64(day),
-		})
-	}
-
+	// phases that have been checkpointed by a previous, interrupted run are loaded instead of being gathered again
+	pendingPhases := make([]*validatorStatsPhase, 0, len(validatorStatsGatherPhases))
 	g := &errgroup.Group{}
-
-	g.Go(func() error {
-		if err := gatherValidatorMissedAttestationsStatisticsForDay(validators, day, validatorData, validatorDataMux); err != nil {
-			return fmt.Errorf("error in GatherValidatorFailedAttestationsStatisticsForDay: %w", err)
-		}
-		return nil
-	})
-	g.Go(func() error {
-		if err := GatherValidatorSyncDutiesForDay(validators, day, validatorData, validatorDataMux); err != nil {
-			return fmt.Errorf("error in GatherValidatorSyncDutiesForDay: %w", err)
-		}
-		return nil
-	})
-	g.Go(func() error {
-		if err := gatherValidatorDepositWithdrawals(day, validatorData, validatorDataMux); err != nil {
-			return fmt.Errorf("error in GatherValidatorDepositWithdrawals: %w", err)
-		}
-		return nil
-	})
-	g.Go(func() error {
-		if err := gatherValidatorBlockStats(day, validatorData, validatorDataMux); err != nil {
-			return fmt.Errorf("error in GatherValidatorBlockStats: %w", err)
-		}
-		return nil
-	})
-	g.Go(func() error {
-		if err := gatherValidatorBalances(client, day, validatorData, validatorDataMux); err != nil {
-			return fmt.Errorf("error in GatherValidatorBalances: %w", err)
-		}
-		return nil
-	})
-	g.Go(func() error {
-		if err := gatherValidatorElIcome(day, validatorData, validatorDataMux); err != nil {
-			return fmt.Errorf("error in GatherValidatorElIcome: %w", err)
-		}
-		return nil
-	})
-
-	var statisticsData1d []*types.ValidatorStatsTableDbRow
-	g.Go(func() error {
-		var err error
-		statisticsData1d, err = GatherStatisticsForDay(int64(day) - 1) // convert to int64 to avoid underflows
-		if err != nil {
-			return fmt.Errorf("error in GatherPreviousDayStatisticsData: %w", err)
-		}
-		return nil
-	})
-	var statisticsData7d []*types.ValidatorStatsTableDbRow
-	g.Go(func() error {
-		var err error
-		statisticsData7d, err = GatherStatisticsForDay(int64(day) - 7) // convert to int64 to avoid underflows
-		if err != nil {
-			return fmt.Errorf("error in GatherPreviousDayStatisticsData: %w", err)
-		}
-		return nil
-	})
-	var statisticsData31d []*types.ValidatorStatsTableDbRow
-	g.Go(func() error {
-		var err error
-		statisticsData31d, err = GatherStatisticsForDay(int64(day) - 31) // convert to int64 to avoid underflows
-		if err != nil {
-			return fmt.Errorf("error in GatherPreviousDayStatisticsData: %w", err)
+	for _, phase := range validatorStatsGatherPhases {
+		if !checkpoints.exported(phase.name) {
+			pendingPhases = append(pendingPhases, phase)
+			continue
 		}
-		return nil
-	})
-	var statisticsData365d []*types.ValidatorStatsTableDbRow
+		g.Go(func() error {
+			return loadValidatorStatsPhase(day, phase, validatorData, validatorDataMux)
+		})
+	}
 	g.Go(func() error {
-		var err error
-		statisticsData365d, err = GatherStatisticsForDay(int64(day) - 365) // convert to int64 to avoid underflows
-		if err != nil {
-			return fmt.Errorf("error in GatherPreviousDayStatisticsData: %w", err)
-		}
-		return nil
+		return gatherValidatorStatsPhases(client, validators, day, pendingPhases, validatorData, validatorDataMux, func(phase *validatorStatsPhase) error {
+			return writeValidatorStatsPhase(day, phase, validatorData, validatorDataMux, validatorStatsStagingTable)
+		})
 	})
 
 	err = g.Wait()
@@ -179,82 +94,8 @@ func WriteValidatorStatisticsForDay(day uint64, client rpc.Client) error {
 
 	log.Infof("statistics data collection for day %v completed", day)
 
-	// calculate cl income data & update totals
-	for index, data := range validatorData {
-		previousDayData := &types.ValidatorStatsTableDbRow{
-			ValidatorIndex: data.ValidatorIndex,
-		}
-
-		if index < len(statisticsData1d) && day > 0 {
-			previousDayData = statisticsData1d[index]
-		}
-
-		if data.ValidatorIndex != previousDayData.ValidatorIndex {
-			return fmt.Errorf("logic error when retrieving previous day data for validator %v (%v wanted, %v retrieved)", index, data.ValidatorIndex, previousDayData.ValidatorIndex)
-		}
-
-		// update attestation totals
-		data.MissedAttestationsTotal = previousDayData.MissedAttestationsTotal + data.MissedAttestations
-
-		// update sync total
-		data.ParticipatedSyncTotal = previousDayData.ParticipatedSyncTotal + data.ParticipatedSync
-		data.MissedSyncTotal = previousDayData.MissedSyncTotal + data.MissedSync
-		data.OrphanedSyncTotal = previousDayData.OrphanedSyncTotal + data.OrphanedSync
-
-		// calculate cl reward & update totals
-		data.ClRewardsGWei = data.EndBalance - previousDayData.EndBalance + data.WithdrawalsAmount - data.DepositsAmount
-		data.ClRewardsGWeiTotal = previousDayData.ClRewardsGWeiTotal + data.ClRewardsGWei
-
-		// update el reward total
-		data.ElRewardsWeiTotal = previousDayData.ElRewardsWeiTotal.Add(data.ElRewardsWei)
-
-		// update mev reward total
-		data.MEVRewardsWeiTotal = previousDayData.MEVRewardsWeiTotal.Add(data.MEVRewardsWei)
-
-		// update withdrawal total
-		data.WithdrawalsTotal = previousDayData.WithdrawalsTotal + data.Withdrawals
-		data.WithdrawalsAmountTotal = previousDayData.WithdrawalsAmountTotal + data.WithdrawalsAmount
-
-		// update deposits total
-		data.DepositsTotal = previousDayData.DepositsTotal + data.Deposits
-		data.DepositsAmountTotal = previousDayData.DepositsAmountTotal + data.DepositsAmount
-
-		if statisticsData1d != nil && len(statisticsData1d) > index {
-			data.ClPerformance1d = data.ClRewardsGWeiTotal - statisticsData1d[index].ClRewardsGWeiTotal
-			data.ElPerformance1d = data.ElRewardsWeiTotal.Sub(statisticsData1d[index].ElRewardsWeiTotal)
-			data.MEVPerformance1d = data.MEVRewardsWeiTotal.Sub(statisticsData1d[index].MEVRewardsWeiTotal)
-		} else {
-			data.ClPerformance1d = data.ClRewardsGWeiTotal
-			data.ElPerformance1d = data.ElRewardsWeiTotal
-			data.MEVPerformance1d = data.MEVRewardsWeiTotal
-		}
-		if statisticsData7d != nil && len(statisticsData7d) > index {
-			data.ClPerformance7d = data.ClRewardsGWeiTotal - statisticsData7d[index].ClRewardsGWeiTotal
-			data.ElPerformance7d = data.ElRewardsWeiTotal.Sub(statisticsData7d[index].ElRewardsWeiTotal)
-			data.MEVPerformance7d = data.MEVRewardsWeiTotal.Sub(statisticsData7d[index].MEVRewardsWeiTotal)
-		} else {
-			data.ClPerformance7d = data.ClRewardsGWeiTotal
-			data.ElPerformance7d = data.ElRewardsWeiTotal
-			data.MEVPerformance7d = data.MEVRewardsWeiTotal
-		}
-		if statisticsData31d != nil && len(statisticsData31d) > index {
-			data.ClPerformance31d = data.ClRewardsGWeiTotal - statisticsData31d[index].ClRewardsGWeiTotal
-			data.ElPerformance31d = data.ElRewardsWeiTotal.Sub(statisticsData31d[index].ElRewardsWeiTotal)
-			data.MEVPerformance31d = data.MEVRewardsWeiTotal.Sub(statisticsData31d[index].MEVRewardsWeiTotal)
-		} else {
-			data.ClPerformance31d = data.ClRewardsGWeiTotal
-			data.ElPerformance31d = data.ElRewardsWeiTotal
-			data.MEVPerformance31d = data.MEVRewardsWeiTotal
-		}
-		if statisticsData365d != nil && len(statisticsData365d) > index {
-			data.ClPerformance365d = data.ClRewardsGWeiTotal - statisticsData365d[index].ClRewardsGWeiTotal
-			data.ElPerformance365d = data.ElRewardsWeiTotal.Sub(statisticsData365d[index].ElRewardsWeiTotal)
-			data.MEVPerformance365d = data.MEVRewardsWeiTotal.Sub(statisticsData365d[index].MEVRewardsWeiTotal)
-		} else {
-			data.ClPerformance365d = data.ClRewardsGWeiTotal
-			data.ElPerformance365d = data.ElRewardsWeiTotal
-			data.MEVPerformance365d = data.MEVRewardsWeiTotal
-		}
+	if err := accumulateValidatorStats(day, validatorData); err != nil {
+		return err
 	}
 
 	conn, err := WriterDb.Conn(context.Background())
@@ -460,6 +301,11 @@ func WriteValidatorStatisticsForDay(day uint64, client rpc.Client) error {
 			log.Infof("skipping total performance export as last exported day (%v) is greater than the exported day (%v)", lastExportedStatsDay, day)
 		}
 
+		_, err = tx.Exec(context.Background(), "DELETE FROM validator_stats_staging WHERE day = $1", day)
+		if err != nil {
+			return fmt.Errorf("error deleting checkpointed statistics for day %v: %w", day, err)
+		}
+
 		log.Infof("marking day %v as exported", day)
 		if err := WriteValidatorStatsExported(day, tx); err != nil {
 			return fmt.Errorf("error in WriteValidatorStatsExported: %w", err)
@@ -1517,6 +1363,16 @@ func WriteExecutionChartSeriesForDay(day int64) error {
 	totalBlobGasUsed := decimal.NewFromInt(0)
 	totalBlobCount := decimal.NewFromInt(0)
 
+	// blob fee market, only tracked for blocks after the deneb fork
+	blobBlockCount := int64(0)
+	totalBlockBlobGasUsed := decimal.NewFromInt(0)
+	totalExcessBlobGas := decimal.NewFromInt(0)
+	totalBlobBaseFee := decimal.NewFromInt(0)
+	blobSubmitters := make(map[string]*blobSubmitterStats)
+
+	// gas sponsored by the paymasters of ERC-4337 user operations
+	paymasters := make(map[string]*paymasterStats)
+
 	legacyTxCount := int64(0)
 	accessListTxCount := int64(0)
 	eip1559TxCount := int64(0)
@@ -1555,6 +1411,13 @@ func WriteExecutionChartSeriesForDay(day int64) error {
 
 		totalBaseBlockReward = totalBaseBlockReward.Add(decimal.NewFromBigInt(utils.Eth1BlockReward(blk.Number, blk.Difficulty), 0))
 
+		if blkEpoch := uint64(utils.TimeToEpoch(blk.Time.AsTime())); blkEpoch >= utils.Config.Chain.ClConfig.DenebForkEpoch {
+			blobBlockCount += 1
+			totalBlockBlobGasUsed = totalBlockBlobGasUsed.Add(decimal.NewFromBigInt(new(big.Int).SetUint64(blk.BlobGasUsed), 0))
+			totalExcessBlobGas = totalExcessBlobGas.Add(decimal.NewFromBigInt(new(big.Int).SetUint64(blk.ExcessBlobGas), 0))
+			totalBlobBaseFee = totalBlobBaseFee.Add(decimal.NewFromBigInt(utils.CalcBlobBaseFee(blk.ExcessBlobGas, blkEpoch), 0))
+		}
+
 		for _, tx := range blk.Transactions {
 			// for _, itx := range tx.Itx {
 			// }
@@ -1567,6 +1430,7 @@ func WriteExecutionChartSeriesForDay(day int64) error {
 
 			var tipFee decimal.Decimal
 			var txFees decimal.Decimal
+			txBurnedBlob := decimal.Zero
 			switch tx.Type {
 			case 0:
 				legacyTxCount += 1
@@ -1597,10 +1461,22 @@ func WriteExecutionChartSeriesForDay(day int64) error {
 				totalTxSavings = totalTxSavings.Add(maxFee.Mul(gasUsed).Sub(baseFee.Mul(gasUsed).Add(tipFee.Mul(gasUsed))))
 
 				blobGasUsed := decimal.NewFromBigInt(new(big.Int).SetUint64(tx.BlobGasUsed), 0)
+				blobFee := blobGasUsed.Mul(decimal.NewFromBigInt(new(big.Int).SetBytes(tx.BlobGasPrice), 0))
 				totalBlobGasUsed = totalBlobGasUsed.Add(blobGasUsed)
-				totalBurnedBlob = blobGasUsed.Mul(decimal.NewFromBigInt(new(big.Int).SetBytes(tx.BlobGasPrice), 0))
+				txBurnedBlob = blobFee
+				totalBurnedBlob = totalBurnedBlob.Add(blobFee)
 				totalBlobCount = totalBlobCount.Add(decimal.NewFromInt(int64(len(tx.BlobVersionedHashes))))
 
+				submitter, ok := blobSubmitters[string(tx.From)]
+				if !ok {
+					submitter = &blobSubmitterStats{Address: tx.From, BlobGasUsed: decimal.Zero, BlobFees: decimal.Zero}
+					blobSubmitters[string(tx.From)] = submitter
+				}
+				submitter.TransactionCount += 1
+				submitter.BlobCount += int64(len(tx.BlobVersionedHashes))
+				submitter.BlobGasUsed = submitter.BlobGasUsed.Add(blobGasUsed)
+				submitter.BlobFees = submitter.BlobFees.Add(blobFee)
+
 			default:
 				log.Fatal(fmt.Errorf("error unknown tx type %v hash: %x", tx.Status, tx.Hash), "", 0)
 			}
@@ -1616,8 +1492,22 @@ func WriteExecutionChartSeriesForDay(day int64) error {
 			default:
 				log.Fatal(fmt.Errorf("error unknown status code %v hash: %x", tx.Status, tx.Hash), "", 0)
 			}
+			for _, txLog := range tx.Logs {
+				op := entrypoint.ParseLog(txLog.Address, txLog.Topics, txLog.Data)
+				if op == nil || op.Paymaster == nil {
+					continue
+				}
+				paymaster, ok := paymasters[string(op.Paymaster.Bytes())]
+				if !ok {
+					paymaster = &paymasterStats{Address: op.Paymaster.Bytes(), GasUsed: decimal.Zero, GasCost: decimal.Zero}
+					paymasters[string(op.Paymaster.Bytes())] = paymaster
+				}
+				paymaster.OperationCount += 1
+				paymaster.GasUsed = paymaster.GasUsed.Add(decimal.NewFromBigInt(op.ActualGasUsed, 0))
+				paymaster.GasCost = paymaster.GasCost.Add(decimal.NewFromBigInt(op.ActualGasCost, 0))
+			}
 			totalGasUsed = totalGasUsed.Add(gasUsed)
-			totalBurned = totalBurned.Add(baseFee.Mul(gasUsed)).Add(totalBurnedBlob)
+			totalBurned = totalBurned.Add(baseFee.Mul(gasUsed)).Add(txBurnedBlob)
 			if blk.Number < 12244000 {
 				totalTips = totalTips.Add(gasUsed.Mul(gasPrice))
 			} else {
@@ -1664,12 +1554,31 @@ func WriteExecutionChartSeriesForDay(day int64) error {
 		return fmt.Errorf("error calculating BLOCK_COUNT chart_series: %w", err)
 	}
 
-	log.Infof("Exporting BLOB_COUNT %v", blockCount)
+	log.Infof("Exporting BLOB_COUNT %v", totalBlobCount)
 	err = SaveChartSeriesPoint(dateTrunc, "BLOB_COUNT", totalBlobCount)
 	if err != nil {
 		return fmt.Errorf("error calculating BLOB_COUNT chart_series: %w", err)
 	}
 
+	if blobBlockCount > 0 {
+		err = writeBlobChartSeries(dateTrunc, blobBlockCount, totalBlockBlobGasUsed, totalExcessBlobGas, totalBlobBaseFee, totalBlobCount)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Exporting %v blob submitters", len(blobSubmitters))
+		err = saveBlobSubmitterStats(day, blobSubmitters)
+		if err != nil {
+			return fmt.Errorf("error saving blob submitter stats: %w", err)
+		}
+	}
+
+	log.Infof("Exporting %v paymasters", len(paymasters))
+	err = savePaymasterStats(day, paymasters)
+	if err != nil {
+		return fmt.Errorf("error saving paymaster stats: %w", err)
+	}
+
 	// convert microseconds to seconds
 	log.Infof("Exporting BLOCK_TIME_AVG %v", avgBlockTime.Div(decimal.NewFromInt(1e6)).Abs().String())
 	err = SaveChartSeriesPoint(dateTrunc, "BLOCK_TIME_AVG", avgBlockTime.Div(decimal.NewFromInt(1e6)).String())
@@ -1774,6 +1683,93 @@ func WriteExecutionChartSeriesForDay(day int64) error {
 	return nil
 }
 
+type blobSubmitterStats struct {
+	Address          []byte
+	TransactionCount int64
+	BlobCount        int64
+	BlobGasUsed      decimal.Decimal
+	BlobFees         decimal.Decimal
+}
+
+// writeBlobChartSeries writes the daily averages of the blob fee market, blockCount is the number of post-deneb blocks of the day
+func writeBlobChartSeries(dateTrunc time.Time, blockCount int64, totalBlobGasUsed, totalExcessBlobGas, totalBlobBaseFee, totalBlobCount decimal.Decimal) error {
+	blocks := decimal.NewFromInt(blockCount)
+	series := []struct {
+		indicator string
+		value     decimal.Decimal
+	}{
+		{"AVG_BLOB_GASUSED", totalBlobGasUsed.Div(blocks)},
+		{"AVG_EXCESS_BLOB_GAS", totalExcessBlobGas.Div(blocks)},
+		{"AVG_BLOB_BASE_FEE", totalBlobBaseFee.Div(blocks)},
+		{"AVG_BLOBS_PER_BLOCK", totalBlobCount.Div(blocks)},
+	}
+	for _, s := range series {
+		log.Infof("Exporting %v %v", s.indicator, s.value.String())
+		err := SaveChartSeriesPoint(dateTrunc, s.indicator, s.value.String())
+		if err != nil {
+			return fmt.Errorf("error calculating %v chart_series: %w", s.indicator, err)
+		}
+	}
+	return nil
+}
+
+func saveBlobSubmitterStats(day int64, submitters map[string]*blobSubmitterStats) error {
+	tx, err := WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db tx: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.Exec(`DELETE FROM blob_submitter_stats WHERE day = $1`, day)
+	if err != nil {
+		return fmt.Errorf("error deleting existing blob submitter stats for day %v: %w", day, err)
+	}
+
+	for _, s := range submitters {
+		_, err = tx.Exec(`
+			INSERT INTO blob_submitter_stats (day, address, tx_count, blob_count, blob_gas_used, blob_fees)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			day, s.Address, s.TransactionCount, s.BlobCount, s.BlobGasUsed.String(), s.BlobFees.String())
+		if err != nil {
+			return fmt.Errorf("error inserting blob submitter stats for %#x: %w", s.Address, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+type paymasterStats struct {
+	Address        []byte
+	OperationCount int64
+	GasUsed        decimal.Decimal
+	GasCost        decimal.Decimal
+}
+
+func savePaymasterStats(day int64, paymasters map[string]*paymasterStats) error {
+	tx, err := WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db tx: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.Exec(`DELETE FROM paymaster_stats WHERE day = $1`, day)
+	if err != nil {
+		return fmt.Errorf("error deleting existing paymaster stats for day %v: %w", day, err)
+	}
+
+	for _, s := range paymasters {
+		_, err = tx.Exec(`
+			INSERT INTO paymaster_stats (day, address, op_count, gas_used, gas_cost)
+			VALUES ($1, $2, $3, $4, $5)`,
+			day, s.Address, s.OperationCount, s.GasUsed.String(), s.GasCost.String())
+		if err != nil {
+			return fmt.Errorf("error inserting paymaster stats for %#x: %w", s.Address, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 func WriteGraffitiStatisticsForDay(day int64) error {
 	if day < 0 {
 		log.Warnf("no graffiti-stats for days before beaconchain")
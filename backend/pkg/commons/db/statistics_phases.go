@@ -0,0 +1,728 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/rpc"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"golang.org/x/sync/errgroup"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+)
+
+// ValidatorStatsPhase is a single step of the validator statistics export of a day.
+// Every phase is checkpointed in the <phase>_exported column of the validator_stats_status table,
+// so an interrupted export only has to redo the phases that have not been completed yet.
+type ValidatorStatsPhase string
+
+const (
+	ValidatorStatsPhaseFailedAttestations  ValidatorStatsPhase = "failed_attestations"
+	ValidatorStatsPhaseSyncDuties          ValidatorStatsPhase = "sync_duties"
+	ValidatorStatsPhaseWithdrawalsDeposits ValidatorStatsPhase = "withdrawals_deposits"
+	ValidatorStatsPhaseBlockStats          ValidatorStatsPhase = "block_stats"
+	ValidatorStatsPhaseBalance             ValidatorStatsPhase = "balance"
+	ValidatorStatsPhaseElRewards           ValidatorStatsPhase = "el_rewards"
+	ValidatorStatsPhaseTotalAccumulation   ValidatorStatsPhase = "total_accumulation"
+)
+
+// phases of days that have not been completed yet are stored in this table until the whole day is written to validator_stats
+const validatorStatsStagingTable = "validator_stats_staging"
+
+type validatorStatsPhase struct {
+	name    ValidatorStatsPhase
+	columns []string
+	values  func(row *types.ValidatorStatsTableDbRow) []interface{}
+	copy    func(dst, src *types.ValidatorStatsTableDbRow)
+	gather  func(client rpc.Client, validators []uint64, day uint64, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex) error
+}
+
+var validatorStatsGatherPhases = []*validatorStatsPhase{
+	{
+		name:    ValidatorStatsPhaseFailedAttestations,
+		columns: []string{"missed_attestations"},
+		values: func(row *types.ValidatorStatsTableDbRow) []interface{} {
+			return []interface{}{row.MissedAttestations}
+		},
+		copy: func(dst, src *types.ValidatorStatsTableDbRow) {
+			dst.MissedAttestations = src.MissedAttestations
+		},
+		gather: func(client rpc.Client, validators []uint64, day uint64, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex) error {
+			return gatherValidatorMissedAttestationsStatisticsForDay(validators, day, data, mux)
+		},
+	},
+	{
+		name:    ValidatorStatsPhaseSyncDuties,
+		columns: []string{"participated_sync", "missed_sync"},
+		values: func(row *types.ValidatorStatsTableDbRow) []interface{} {
+			return []interface{}{row.ParticipatedSync, row.MissedSync}
+		},
+		copy: func(dst, src *types.ValidatorStatsTableDbRow) {
+			dst.ParticipatedSync = src.ParticipatedSync
+			dst.MissedSync = src.MissedSync
+		},
+		gather: func(client rpc.Client, validators []uint64, day uint64, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex) error {
+			return GatherValidatorSyncDutiesForDay(validators, day, data, mux)
+		},
+	},
+	{
+		name:    ValidatorStatsPhaseWithdrawalsDeposits,
+		columns: []string{"deposits", "deposits_amount", "withdrawals", "withdrawals_amount"},
+		values: func(row *types.ValidatorStatsTableDbRow) []interface{} {
+			return []interface{}{row.Deposits, row.DepositsAmount, row.Withdrawals, row.WithdrawalsAmount}
+		},
+		copy: func(dst, src *types.ValidatorStatsTableDbRow) {
+			dst.Deposits = src.Deposits
+			dst.DepositsAmount = src.DepositsAmount
+			dst.Withdrawals = src.Withdrawals
+			dst.WithdrawalsAmount = src.WithdrawalsAmount
+		},
+		gather: func(client rpc.Client, validators []uint64, day uint64, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex) error {
+			return gatherValidatorDepositWithdrawals(day, data, mux)
+		},
+	},
+	{
+		name:    ValidatorStatsPhaseBlockStats,
+		columns: []string{"proposed_blocks", "missed_blocks", "orphaned_blocks", "attester_slashings", "proposer_slashings"},
+		values: func(row *types.ValidatorStatsTableDbRow) []interface{} {
+			return []interface{}{row.ProposedBlocks, row.MissedBlocks, row.OrphanedBlocks, row.AttesterSlashings, row.ProposerSlashing}
+		},
+		copy: func(dst, src *types.ValidatorStatsTableDbRow) {
+			dst.ProposedBlocks = src.ProposedBlocks
+			dst.MissedBlocks = src.MissedBlocks
+			dst.OrphanedBlocks = src.OrphanedBlocks
+			dst.AttesterSlashings = src.AttesterSlashings
+			dst.ProposerSlashing = src.ProposerSlashing
+		},
+		gather: func(client rpc.Client, validators []uint64, day uint64, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex) error {
+			return gatherValidatorBlockStats(day, data, mux)
+		},
+	},
+	{
+		name:    ValidatorStatsPhaseBalance,
+		columns: []string{"start_balance", "end_balance", "start_effective_balance", "end_effective_balance"},
+		values: func(row *types.ValidatorStatsTableDbRow) []interface{} {
+			return []interface{}{row.StartBalance, row.EndBalance, row.StartEffectiveBalance, row.EndEffectiveBalance}
+		},
+		copy: func(dst, src *types.ValidatorStatsTableDbRow) {
+			dst.StartBalance = src.StartBalance
+			dst.EndBalance = src.EndBalance
+			dst.StartEffectiveBalance = src.StartEffectiveBalance
+			dst.EndEffectiveBalance = src.EndEffectiveBalance
+		},
+		gather: func(client rpc.Client, validators []uint64, day uint64, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex) error {
+			return gatherValidatorBalances(client, day, data, mux)
+		},
+	},
+	{
+		name:    ValidatorStatsPhaseElRewards,
+		columns: []string{"el_rewards_wei", "mev_rewards_wei"},
+		values: func(row *types.ValidatorStatsTableDbRow) []interface{} {
+			return []interface{}{row.ElRewardsWei, row.MEVRewardsWei}
+		},
+		copy: func(dst, src *types.ValidatorStatsTableDbRow) {
+			dst.ElRewardsWei = src.ElRewardsWei
+			dst.MEVRewardsWei = src.MEVRewardsWei
+		},
+		gather: func(client rpc.Client, validators []uint64, day uint64, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex) error {
+			return gatherValidatorElIcome(day, data, mux)
+		},
+	},
+}
+
+// the accumulation phase does not gather any data, it derives the cl rewards and all totals from the gathered phases and the previous day
+var validatorStatsAccumulationPhase = &validatorStatsPhase{
+	name: ValidatorStatsPhaseTotalAccumulation,
+	columns: []string{
+		"missed_attestations_total",
+		"participated_sync_total",
+		"missed_sync_total",
+		"orphaned_sync_total",
+		"deposits_total",
+		"deposits_amount_total",
+		"withdrawals_total",
+		"withdrawals_amount_total",
+		"cl_rewards_gwei",
+		"cl_rewards_gwei_total",
+		"el_rewards_wei_total",
+		"mev_rewards_wei_total",
+	},
+	values: func(row *types.ValidatorStatsTableDbRow) []interface{} {
+		return []interface{}{
+			row.MissedAttestationsTotal,
+			row.ParticipatedSyncTotal,
+			row.MissedSyncTotal,
+			row.OrphanedSyncTotal,
+			row.DepositsTotal,
+			row.DepositsAmountTotal,
+			row.WithdrawalsTotal,
+			row.WithdrawalsAmountTotal,
+			row.ClRewardsGWei,
+			row.ClRewardsGWeiTotal,
+			row.ElRewardsWeiTotal,
+			row.MEVRewardsWeiTotal,
+		}
+	},
+}
+
+func getValidatorStatsPhase(name ValidatorStatsPhase) (*validatorStatsPhase, error) {
+	if name == ValidatorStatsPhaseTotalAccumulation {
+		return validatorStatsAccumulationPhase, nil
+	}
+	for _, phase := range validatorStatsGatherPhases {
+		if phase.name == name {
+			return phase, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown validator statistics phase %q", name)
+}
+
+// ValidatorStatsPhases returns the names of all phases of the validator statistics export in the order they are applied
+func ValidatorStatsPhases() []ValidatorStatsPhase {
+	phases := make([]ValidatorStatsPhase, 0, len(validatorStatsGatherPhases)+1)
+	for _, phase := range validatorStatsGatherPhases {
+		phases = append(phases, phase.name)
+	}
+	return append(phases, ValidatorStatsPhaseTotalAccumulation)
+}
+
+type validatorStatsCheckpoints struct {
+	Status                      bool `db:"status"`
+	FailedAttestationsExported  bool `db:"failed_attestations_exported"`
+	SyncDutiesExported          bool `db:"sync_duties_exported"`
+	WithdrawalsDepositsExported bool `db:"withdrawals_deposits_exported"`
+	BlockStatsExported          bool `db:"block_stats_exported"`
+	BalanceExported             bool `db:"balance_exported"`
+	ElRewardsExported           bool `db:"el_rewards_exported"`
+	TotalAccumulationExported   bool `db:"total_accumulation_exported"`
+}
+
+func (c *validatorStatsCheckpoints) exported(phase ValidatorStatsPhase) bool {
+	switch phase {
+	case ValidatorStatsPhaseFailedAttestations:
+		return c.FailedAttestationsExported
+	case ValidatorStatsPhaseSyncDuties:
+		return c.SyncDutiesExported
+	case ValidatorStatsPhaseWithdrawalsDeposits:
+		return c.WithdrawalsDepositsExported
+	case ValidatorStatsPhaseBlockStats:
+		return c.BlockStatsExported
+	case ValidatorStatsPhaseBalance:
+		return c.BalanceExported
+	case ValidatorStatsPhaseElRewards:
+		return c.ElRewardsExported
+	case ValidatorStatsPhaseTotalAccumulation:
+		return c.TotalAccumulationExported
+	}
+	return false
+}
+
+func getValidatorStatsCheckpoints(day int64) (*validatorStatsCheckpoints, error) {
+	checkpoints := &validatorStatsCheckpoints{}
+	if day < 0 {
+		return checkpoints, nil
+	}
+
+	err := WriterDb.Get(checkpoints, `
+		SELECT
+			status,
+			failed_attestations_exported,
+			sync_duties_exported,
+			withdrawals_deposits_exported,
+			block_stats_exported,
+			balance_exported,
+			el_rewards_exported,
+			total_accumulation_exported
+		FROM validator_stats_status
+		WHERE day = $1;
+		`, day)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("error retrieving exported state of day %v: %w", day, err)
+	}
+	return checkpoints, nil
+}
+
+// newValidatorStatsData returns an empty statistics row for every validator known at the end of the day
+func newValidatorStatsData(day uint64) ([]uint64, []*types.ValidatorStatsTableDbRow, error) {
+	_, lastEpoch := utils.GetFirstAndLastEpochForDay(day)
+
+	maxValidatorIndex, err := BigtableClient.GetMaxValidatorindexForEpoch(lastEpoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	validators := make([]uint64, 0, maxValidatorIndex)
+	validatorData := make([]*types.ValidatorStatsTableDbRow, 0, maxValidatorIndex)
+
+	log.Infof("processing statistics for validators 0-%d", maxValidatorIndex)
+	for i := uint64(0); i <= maxValidatorIndex; i++ {
+		validators = append(validators, i)
+		validatorData = append(validatorData, &types.ValidatorStatsTableDbRow{
+			ValidatorIndex: i,
+			Day:            int64(day),
+		})
+	}
+	return validators, validatorData, nil
+}
+
+// gatherValidatorStatsPhases runs the gather functions of the given phases in parallel, onGathered is called after each completed phase
+func gatherValidatorStatsPhases(client rpc.Client, validators []uint64, day uint64, phases []*validatorStatsPhase, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex, onGathered func(phase *validatorStatsPhase) error) error {
+	g := &errgroup.Group{}
+	for _, phase := range phases {
+		g.Go(func() error {
+			if err := phase.gather(client, validators, day, data, mux); err != nil {
+				return fmt.Errorf("error gathering %v statistics for day %v: %w", phase.name, day, err)
+			}
+			if onGathered == nil {
+				return nil
+			}
+			return onGathered(phase)
+		})
+	}
+	return g.Wait()
+}
+
+// loadValidatorStatsPhase fills the columns of a checkpointed phase from the staging table
+func loadValidatorStatsPhase(day uint64, phase *validatorStatsPhase, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex) error {
+	start := time.Now()
+
+	columns := make([]string, 0, len(phase.columns))
+	for _, column := range phase.columns {
+		columns = append(columns, fmt.Sprintf("COALESCE(%[1]s, 0) AS %[1]s", column))
+	}
+
+	rows := make([]*types.ValidatorStatsTableDbRow, 0, len(data))
+	err := WriterDb.Select(&rows, fmt.Sprintf(`SELECT validatorindex, day, %s FROM %s WHERE day = $1`, strings.Join(columns, ", "), validatorStatsStagingTable), day)
+	if err != nil {
+		return fmt.Errorf("error retrieving checkpointed %v statistics for day %v: %w", phase.name, day, err)
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	for _, row := range rows {
+		if row.ValidatorIndex >= uint64(len(data)) {
+			return fmt.Errorf("checkpointed %v statistics of day %v contain unknown validator %v", phase.name, day, row.ValidatorIndex)
+		}
+		phase.copy(data[row.ValidatorIndex], row)
+	}
+
+	log.Infof("loaded checkpointed %v statistics for day %v, took %v", phase.name, day, time.Since(start))
+	return nil
+}
+
+// writeValidatorStatsPhase upserts the columns of a phase into the given table and marks the phase as exported
+func writeValidatorStatsPhase(day uint64, phase *validatorStatsPhase, data []*types.ValidatorStatsTableDbRow, mux *sync.Mutex, table string) error {
+	start := time.Now()
+
+	columns := append([]string{"validatorindex", "day"}, phase.columns...)
+	mux.Lock()
+	values := make([][]interface{}, 0, len(data))
+	for _, row := range data {
+		values = append(values, append([]interface{}{row.ValidatorIndex, row.Day}, phase.values(row)...))
+	}
+	mux.Unlock()
+
+	updates := make([]string, 0, len(phase.columns))
+	for _, column := range phase.columns {
+		updates = append(updates, fmt.Sprintf("%[1]s = excluded.%[1]s", column))
+	}
+
+	statusColumns := []string{string(phase.name) + "_exported"}
+	if phase.name == ValidatorStatsPhaseTotalAccumulation {
+		statusColumns = append(statusColumns, "cl_rewards_exported")
+	}
+	statusUpdates := make([]string, 0, len(statusColumns))
+	for _, column := range statusColumns {
+		statusUpdates = append(statusUpdates, column+" = true")
+	}
+
+	err := withRawStatisticsTx(func(tx pgx.Tx) error {
+		tmpTable := "tmp_validator_stats_" + string(phase.name)
+		_, err := tx.Exec(context.Background(), fmt.Sprintf("CREATE TEMP TABLE %s (LIKE validator_stats INCLUDING DEFAULTS) ON COMMIT DROP", tmpTable))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CopyFrom(context.Background(), pgx.Identifier{tmpTable}, columns, pgx.CopyFromRows(values))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(context.Background(), fmt.Sprintf(`
+			INSERT INTO %[1]s (%[2]s)
+			SELECT %[2]s FROM %[3]s ORDER BY validatorindex
+			ON CONFLICT (validatorindex, day) DO UPDATE SET %[4]s`,
+			table, strings.Join(columns, ", "), tmpTable, strings.Join(updates, ", ")))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(context.Background(), fmt.Sprintf(`
+			INSERT INTO validator_stats_status (day, status, %s) VALUES ($1, false, %s)
+			ON CONFLICT (day) DO UPDATE SET %s`,
+			strings.Join(statusColumns, ", "), strings.TrimSuffix(strings.Repeat("true, ", len(statusColumns)), ", "), strings.Join(statusUpdates, ", ")), day)
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit(context.Background())
+	})
+	if err != nil {
+		return fmt.Errorf("error writing %v statistics of day %v to %v: %w", phase.name, day, table, err)
+	}
+
+	log.Infof("checkpointed %v statistics for day %v, took %v", phase.name, day, time.Since(start))
+	return nil
+}
+
+// accumulateValidatorStats calculates the cl rewards, the totals and the performance of the day based on the previously exported days
+func accumulateValidatorStats(day uint64, validatorData []*types.ValidatorStatsTableDbRow) error {
+	g := &errgroup.Group{}
+
+	var statisticsData1d []*types.ValidatorStatsTableDbRow
+	g.Go(func() error {
+		var err error
+		statisticsData1d, err = GatherStatisticsForDay(int64(day) - 1) // convert to int64 to avoid underflows
+		if err != nil {
+			return fmt.Errorf("error in GatherPreviousDayStatisticsData: %w", err)
+		}
+		return nil
+	})
+	var statisticsData7d []*types.ValidatorStatsTableDbRow
+	g.Go(func() error {
+		var err error
+		statisticsData7d, err = GatherStatisticsForDay(int64(day) - 7) // convert to int64 to avoid underflows
+		if err != nil {
+			return fmt.Errorf("error in GatherPreviousDayStatisticsData: %w", err)
+		}
+		return nil
+	})
+	var statisticsData31d []*types.ValidatorStatsTableDbRow
+	g.Go(func() error {
+		var err error
+		statisticsData31d, err = GatherStatisticsForDay(int64(day) - 31) // convert to int64 to avoid underflows
+		if err != nil {
+			return fmt.Errorf("error in GatherPreviousDayStatisticsData: %w", err)
+		}
+		return nil
+	})
+	var statisticsData365d []*types.ValidatorStatsTableDbRow
+	g.Go(func() error {
+		var err error
+		statisticsData365d, err = GatherStatisticsForDay(int64(day) - 365) // convert to int64 to avoid underflows
+		if err != nil {
+			return fmt.Errorf("error in GatherPreviousDayStatisticsData: %w", err)
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// calculate cl income data & update totals
+	for index, data := range validatorData {
+		previousDayData := &types.ValidatorStatsTableDbRow{
+			ValidatorIndex: data.ValidatorIndex,
+		}
+
+		if index < len(statisticsData1d) && day > 0 {
+			previousDayData = statisticsData1d[index]
+		}
+
+		if data.ValidatorIndex != previousDayData.ValidatorIndex {
+			return fmt.Errorf("logic error when retrieving previous day data for validator %v (%v wanted, %v retrieved)", index, data.ValidatorIndex, previousDayData.ValidatorIndex)
+		}
+
+		// update attestation totals
+		data.MissedAttestationsTotal = previousDayData.MissedAttestationsTotal + data.MissedAttestations
+
+		// update sync total
+		data.ParticipatedSyncTotal = previousDayData.ParticipatedSyncTotal + data.ParticipatedSync
+		data.MissedSyncTotal = previousDayData.MissedSyncTotal + data.MissedSync
+		data.OrphanedSyncTotal = previousDayData.OrphanedSyncTotal + data.OrphanedSync
+
+		// calculate cl reward & update totals
+		data.ClRewardsGWei = data.EndBalance - previousDayData.EndBalance + data.WithdrawalsAmount - data.DepositsAmount
+		data.ClRewardsGWeiTotal = previousDayData.ClRewardsGWeiTotal + data.ClRewardsGWei
+
+		// update el reward total
+		data.ElRewardsWeiTotal = previousDayData.ElRewardsWeiTotal.Add(data.ElRewardsWei)
+
+		// update mev reward total
+		data.MEVRewardsWeiTotal = previousDayData.MEVRewardsWeiTotal.Add(data.MEVRewardsWei)
+
+		// update withdrawal total
+		data.WithdrawalsTotal = previousDayData.WithdrawalsTotal + data.Withdrawals
+		data.WithdrawalsAmountTotal = previousDayData.WithdrawalsAmountTotal + data.WithdrawalsAmount
+
+		// update deposits total
+		data.DepositsTotal = previousDayData.DepositsTotal + data.Deposits
+		data.DepositsAmountTotal = previousDayData.DepositsAmountTotal + data.DepositsAmount
+
+		if statisticsData1d != nil && len(statisticsData1d) > index {
+			data.ClPerformance1d = data.ClRewardsGWeiTotal - statisticsData1d[index].ClRewardsGWeiTotal
+			data.ElPerformance1d = data.ElRewardsWeiTotal.Sub(statisticsData1d[index].ElRewardsWeiTotal)
+			data.MEVPerformance1d = data.MEVRewardsWeiTotal.Sub(statisticsData1d[index].MEVRewardsWeiTotal)
+		} else {
+			data.ClPerformance1d = data.ClRewardsGWeiTotal
+			data.ElPerformance1d = data.ElRewardsWeiTotal
+			data.MEVPerformance1d = data.MEVRewardsWeiTotal
+		}
+		if statisticsData7d != nil && len(statisticsData7d) > index {
+			data.ClPerformance7d = data.ClRewardsGWeiTotal - statisticsData7d[index].ClRewardsGWeiTotal
+			data.ElPerformance7d = data.ElRewardsWeiTotal.Sub(statisticsData7d[index].ElRewardsWeiTotal)
+			data.MEVPerformance7d = data.MEVRewardsWeiTotal.Sub(statisticsData7d[index].MEVRewardsWeiTotal)
+		} else {
+			data.ClPerformance7d = data.ClRewardsGWeiTotal
+			data.ElPerformance7d = data.ElRewardsWeiTotal
+			data.MEVPerformance7d = data.MEVRewardsWeiTotal
+		}
+		if statisticsData31d != nil && len(statisticsData31d) > index {
+			data.ClPerformance31d = data.ClRewardsGWeiTotal - statisticsData31d[index].ClRewardsGWeiTotal
+			data.ElPerformance31d = data.ElRewardsWeiTotal.Sub(statisticsData31d[index].ElRewardsWeiTotal)
+			data.MEVPerformance31d = data.MEVRewardsWeiTotal.Sub(statisticsData31d[index].MEVRewardsWeiTotal)
+		} else {
+			data.ClPerformance31d = data.ClRewardsGWeiTotal
+			data.ElPerformance31d = data.ElRewardsWeiTotal
+			data.MEVPerformance31d = data.MEVRewardsWeiTotal
+		}
+		if statisticsData365d != nil && len(statisticsData365d) > index {
+			data.ClPerformance365d = data.ClRewardsGWeiTotal - statisticsData365d[index].ClRewardsGWeiTotal
+			data.ElPerformance365d = data.ElRewardsWeiTotal.Sub(statisticsData365d[index].ElRewardsWeiTotal)
+			data.MEVPerformance365d = data.MEVRewardsWeiTotal.Sub(statisticsData365d[index].MEVRewardsWeiTotal)
+		} else {
+			data.ClPerformance365d = data.ClRewardsGWeiTotal
+			data.ElPerformance365d = data.ElRewardsWeiTotal
+			data.MEVPerformance365d = data.MEVRewardsWeiTotal
+		}
+	}
+	return nil
+}
+
+// withRawStatisticsTx runs f in a pgx transaction with decimal support, f is responsible for committing the transaction
+func withRawStatisticsTx(f func(tx pgx.Tx) error) error {
+	conn, err := WriterDb.Conn(context.Background())
+	if err != nil {
+		return fmt.Errorf("error retrieving raw sql connection: %w", err)
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		conn := driverConn.(*stdlib.Conn).Conn()
+
+		pgxdecimal.Register(conn.TypeMap())
+		tx, err := conn.Begin(context.Background())
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			err := tx.Rollback(context.Background())
+			if err != nil && err != pgx.ErrTxClosed {
+				log.Error(err, "error rolling back transaction", 0)
+			}
+		}()
+
+		return f(tx)
+	})
+}
+
+// WriteValidatorStatisticsPhaseForDay re-exports a single phase of a day.
+// If the day has already been fully exported the phase is written to validator_stats directly, otherwise it is checkpointed
+// and picked up by the next WriteValidatorStatisticsForDay run. Since totals are cumulative, re-exporting a gather phase of
+// an exported day must be followed by re-exporting the total_accumulation phase of that day and all following days.
+func WriteValidatorStatisticsPhaseForDay(day uint64, phaseName ValidatorStatsPhase, client rpc.Client) error {
+	exportStart := time.Now()
+
+	phase, err := getValidatorStatsPhase(phaseName)
+	if err != nil {
+		return err
+	}
+
+	if err := CheckIfDayIsFinalized(day); err != nil {
+		return err
+	}
+
+	checkpoints, err := getValidatorStatsCheckpoints(int64(day))
+	if err != nil {
+		return err
+	}
+
+	mux := &sync.Mutex{}
+
+	if phase == validatorStatsAccumulationPhase {
+		if !checkpoints.Status {
+			return fmt.Errorf("cannot export %v statistics for day %v as the day has not been fully exported yet", phase.name, day)
+		}
+		previousDayCheckpoints, err := getValidatorStatsCheckpoints(int64(day) - 1)
+		if err != nil {
+			return err
+		}
+		if day > 0 && !previousDayCheckpoints.Status {
+			return fmt.Errorf("cannot export %v statistics for day %v as day %v has not been exported yet", phase.name, day, int64(day)-1)
+		}
+
+		validatorData, err := GatherStatisticsForDay(int64(day))
+		if err != nil {
+			return err
+		}
+		if err := accumulateValidatorStats(day, validatorData); err != nil {
+			return err
+		}
+		if err := writeValidatorStatsPhase(day, phase, validatorData, mux, "validator_stats"); err != nil {
+			return err
+		}
+
+		lastExportedStatsDay, err := GetLastExportedStatisticDay()
+		if err != nil {
+			return fmt.Errorf("error retrieving last exported statistics day: %w", err)
+		}
+		if day == lastExportedStatsDay {
+			err = withRawStatisticsTx(func(tx pgx.Tx) error {
+				if err := WriteValidatorTotalPerformance(day, tx); err != nil {
+					return err
+				}
+				return tx.Commit(context.Background())
+			})
+			if err != nil {
+				return fmt.Errorf("error updating validator_performance for day %v: %w", day, err)
+			}
+		}
+
+		log.Infof("export of %v statistics for day %v completed, took %v", phase.name, day, time.Since(exportStart))
+		return nil
+	}
+
+	validators, validatorData, err := newValidatorStatsData(day)
+	if err != nil {
+		return err
+	}
+
+	table := validatorStatsStagingTable
+	if checkpoints.Status {
+		table = "validator_stats"
+	}
+
+	err = gatherValidatorStatsPhases(client, validators, day, []*validatorStatsPhase{phase}, validatorData, mux, func(phase *validatorStatsPhase) error {
+		return writeValidatorStatsPhase(day, phase, validatorData, mux, table)
+	})
+	if err != nil {
+		return err
+	}
+
+	if checkpoints.Status {
+		log.Warnf("%v statistics of the already exported day %v have been replaced, the %v phase has to be exported for day %v onwards to update the totals", phase.name, day, ValidatorStatsPhaseTotalAccumulation, day)
+	}
+
+	log.Infof("export of %v statistics for day %v completed, took %v", phase.name, day, time.Since(exportStart))
+	return nil
+}
+
+type ValidatorStatsDiff struct {
+	ValidatorIndex uint64
+	Column         string
+	Existing       string
+	Computed       string
+}
+
+// DiffValidatorStatisticsForDay recomputes the statistics of an exported day without writing anything and returns all values that differ
+// from the rows stored in validator_stats. If phaseName is empty all phases are compared, including the totals.
+func DiffValidatorStatisticsForDay(day uint64, phaseName ValidatorStatsPhase, client rpc.Client) ([]*ValidatorStatsDiff, error) {
+	phases := append(append([]*validatorStatsPhase{}, validatorStatsGatherPhases...), validatorStatsAccumulationPhase)
+	if phaseName != "" {
+		phase, err := getValidatorStatsPhase(phaseName)
+		if err != nil {
+			return nil, err
+		}
+		phases = []*validatorStatsPhase{phase}
+	}
+
+	if err := CheckIfDayIsFinalized(day); err != nil {
+		return nil, err
+	}
+
+	existing, err := GatherStatisticsForDay(int64(day))
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		return nil, fmt.Errorf("no statistics have been exported for day %v", day)
+	}
+
+	var computed []*types.ValidatorStatsTableDbRow
+	if phaseName == ValidatorStatsPhaseTotalAccumulation {
+		// recompute the totals on top of the stored daily values
+		computed, err = GatherStatisticsForDay(int64(day))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var validators []uint64
+		validators, computed, err = newValidatorStatsData(day)
+		if err != nil {
+			return nil, err
+		}
+
+		gatherPhases := phases
+		if phaseName == "" {
+			gatherPhases = validatorStatsGatherPhases
+		}
+		err = gatherValidatorStatsPhases(client, validators, day, gatherPhases, computed, &sync.Mutex{}, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if phaseName == "" || phaseName == ValidatorStatsPhaseTotalAccumulation {
+		if err := accumulateValidatorStats(day, computed); err != nil {
+			return nil, err
+		}
+	}
+
+	diffs := diffValidatorStats(existing, computed, phases)
+	log.Infof("dry run of day %v found %v differing values", day, len(diffs))
+	return diffs, nil
+}
+
+// diffValidatorStats compares the columns of the given phases, rows are indexed by validator and missing rows are compared as zero values
+func diffValidatorStats(existing, computed []*types.ValidatorStatsTableDbRow, phases []*validatorStatsPhase) []*ValidatorStatsDiff {
+	diffs := make([]*ValidatorStatsDiff, 0)
+	rowCount := max(len(existing), len(computed))
+	for i := 0; i < rowCount; i++ {
+		existingRow := &types.ValidatorStatsTableDbRow{ValidatorIndex: uint64(i)}
+		if i < len(existing) {
+			existingRow = existing[i]
+		}
+		computedRow := &types.ValidatorStatsTableDbRow{ValidatorIndex: uint64(i)}
+		if i < len(computed) {
+			computedRow = computed[i]
+		}
+
+		for _, phase := range phases {
+			existingValues := phase.values(existingRow)
+			computedValues := phase.values(computedRow)
+			for j, column := range phase.columns {
+				existingValue := fmt.Sprint(existingValues[j])
+				computedValue := fmt.Sprint(computedValues[j])
+				if existingValue != computedValue {
+					diffs = append(diffs, &ValidatorStatsDiff{
+						ValidatorIndex: computedRow.ValidatorIndex,
+						Column:         column,
+						Existing:       existingValue,
+						Computed:       computedValue,
+					})
+				}
+			}
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validatorStatsRowWithColumn returns a row where only the field stored in the given column is set
+func validatorStatsRowWithColumn(t *testing.T, column string, value int64) *types.ValidatorStatsTableDbRow {
+	row := &types.ValidatorStatsTableDbRow{}
+	v := reflect.ValueOf(row).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("db") != column {
+			continue
+		}
+		switch field := v.Field(i).Addr().Interface().(type) {
+		case *int64:
+			*field = value
+		case *decimal.Decimal:
+			*field = decimal.NewFromInt(value)
+		default:
+			t.Fatalf("unexpected type %T of column %v", field, column)
+		}
+		return row
+	}
+	t.Fatalf("no field stored in column %v", column)
+	return nil
+}
+
+func TestGetValidatorStatsPhase(t *testing.T) {
+	tests := []struct {
+		name    ValidatorStatsPhase
+		wantErr bool
+	}{
+		{name: ValidatorStatsPhaseFailedAttestations},
+		{name: ValidatorStatsPhaseSyncDuties},
+		{name: ValidatorStatsPhaseWithdrawalsDeposits},
+		{name: ValidatorStatsPhaseBlockStats},
+		{name: ValidatorStatsPhaseBalance},
+		{name: ValidatorStatsPhaseElRewards},
+		{name: ValidatorStatsPhaseTotalAccumulation},
+		{name: "", wantErr: true},
+		{name: "balances", wantErr: true},
+		{name: "BALANCE", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			phase, err := getValidatorStatsPhase(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, phase.name)
+		})
+	}
+}
+
+func TestValidatorStatsPhases(t *testing.T) {
+	// the totals are derived from all other phases, so they have to be applied last
+	phases := ValidatorStatsPhases()
+	require.Len(t, phases, len(validatorStatsGatherPhases)+1)
+	assert.Equal(t, ValidatorStatsPhaseTotalAccumulation, phases[len(phases)-1])
+
+	for _, name := range phases {
+		checkpoints := &validatorStatsCheckpoints{}
+		assert.False(t, checkpoints.exported(name), name)
+		// every phase needs its own checkpoint column
+		v := reflect.ValueOf(checkpoints).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Tag.Get("db") == string(name)+"_exported" {
+				v.Field(i).SetBool(true)
+			}
+		}
+		assert.True(t, checkpoints.exported(name), name)
+	}
+}
+
+func TestValidatorStatsPhaseColumns(t *testing.T) {
+	phases := append(append([]*validatorStatsPhase{}, validatorStatsGatherPhases...), validatorStatsAccumulationPhase)
+	seen := make(map[string]ValidatorStatsPhase)
+	for _, phase := range phases {
+		t.Run(string(phase.name), func(t *testing.T) {
+			require.Len(t, phase.values(&types.ValidatorStatsTableDbRow{}), len(phase.columns))
+			for i, column := range phase.columns {
+				if other, ok := seen[column]; ok {
+					t.Errorf("column %v is written by phase %v and %v", column, other, phase.name)
+				}
+				seen[column] = phase.name
+
+				// the values have to be in the order of the columns
+				row := validatorStatsRowWithColumn(t, column, 42)
+				for j, value := range phase.values(row) {
+					expected := "0"
+					if j == i {
+						expected = "42"
+					}
+					assert.Equal(t, expected, fmt.Sprint(value), "value %d of column %v", j, column)
+				}
+
+				// staged phases are restored by copying exactly their columns
+				if phase.copy != nil {
+					dst := &types.ValidatorStatsTableDbRow{}
+					phase.copy(dst, row)
+					assert.Equal(t, row, dst, column)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffValidatorStats(t *testing.T) {
+	balance, err := getValidatorStatsPhase(ValidatorStatsPhaseBalance)
+	require.NoError(t, err)
+	elRewards, err := getValidatorStatsPhase(ValidatorStatsPhaseElRewards)
+	require.NoError(t, err)
+
+	row := func(index uint64, endBalance int64, elRewards int64) *types.ValidatorStatsTableDbRow {
+		return &types.ValidatorStatsTableDbRow{ValidatorIndex: index, EndBalance: endBalance, ElRewardsWei: decimal.NewFromInt(elRewards)}
+	}
+
+	tests := []struct {
+		name     string
+		existing []*types.ValidatorStatsTableDbRow
+		computed []*types.ValidatorStatsTableDbRow
+		phases   []*validatorStatsPhase
+		expected []*ValidatorStatsDiff
+	}{
+		{
+			name:     "equal",
+			existing: []*types.ValidatorStatsTableDbRow{row(0, 32, 1), row(1, 31, 0)},
+			computed: []*types.ValidatorStatsTableDbRow{row(0, 32, 1), row(1, 31, 0)},
+			phases:   []*validatorStatsPhase{balance, elRewards},
+			expected: []*ValidatorStatsDiff{},
+		},
+		{
+			name:     "changed value",
+			existing: []*types.ValidatorStatsTableDbRow{row(0, 32, 1), row(1, 31, 0)},
+			computed: []*types.ValidatorStatsTableDbRow{row(0, 32, 1), row(1, 30, 0)},
+			phases:   []*validatorStatsPhase{balance, elRewards},
+			expected: []*ValidatorStatsDiff{{ValidatorIndex: 1, Column: "end_balance", Existing: "31", Computed: "30"}},
+		},
+		{
+			name:     "only compares the given phases",
+			existing: []*types.ValidatorStatsTableDbRow{row(0, 32, 1)},
+			computed: []*types.ValidatorStatsTableDbRow{row(0, 30, 2)},
+			phases:   []*validatorStatsPhase{elRewards},
+			expected: []*ValidatorStatsDiff{{ValidatorIndex: 0, Column: "el_rewards_wei", Existing: "1", Computed: "2"}},
+		},
+		{
+			name:     "new validator",
+			existing: []*types.ValidatorStatsTableDbRow{row(0, 32, 0)},
+			computed: []*types.ValidatorStatsTableDbRow{row(0, 32, 0), row(1, 32, 0)},
+			phases:   []*validatorStatsPhase{balance},
+			expected: []*ValidatorStatsDiff{{ValidatorIndex: 1, Column: "end_balance", Existing: "0", Computed: "32"}},
+		},
+		{
+			name:     "missing validator",
+			existing: []*types.ValidatorStatsTableDbRow{row(0, 32, 0), row(1, 32, 0)},
+			computed: []*types.ValidatorStatsTableDbRow{row(0, 32, 0)},
+			phases:   []*validatorStatsPhase{balance},
+			expected: []*ValidatorStatsDiff{{ValidatorIndex: 1, Column: "end_balance", Existing: "32", Computed: "0"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diffValidatorStats(tt.existing, tt.computed, tt.phases))
+		})
+	}
+}
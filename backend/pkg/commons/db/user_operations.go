@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/contracts/entrypoint"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/coocood/freecache"
+)
+
+// roles of an address in a user operation
+const (
+	UserOperationRoleSender    = "sender"
+	UserOperationRolePaymaster = "paymaster"
+	UserOperationRoleBundler   = "bundler"
+)
+
+var userOperationRoleKeys = map[string]string{
+	UserOperationRoleSender:    "S",
+	UserOperationRolePaymaster: "P",
+	UserOperationRoleBundler:   "B",
+}
+
+// TransformUserOperations accepts an eth1 block and creates bigtable mutations for the ERC-4337 user operations executed by the
+// entry points, see entrypoint.EntryPoints. Every operation is written once for its sender, its paymaster and its bundler.
+// ==================================================
+//
+// It writes the operations of an account
+// Row:    <chainID>:UO:<senderAddress>:S:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: d
+// Cell:   Json<Eth1UserOperation>
+// Example scan: "1:UO:6b175474e89094c44da98b954eedeac495271d0f:S" returns the mainnet user operations of the account, newest first
+//
+// It writes the operations sponsored by a paymaster
+// Row:    <chainID>:UO:<paymasterAddress>:P:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: d
+// Cell:   Json<Eth1UserOperation>
+//
+// It writes the operations submitted by a bundler
+// Row:    <chainID>:UO:<bundlerAddress>:B:<reversePaddedBlockNumber>:<paddedTxIndex>:<PaddedLogIndex>
+// Family: f
+// Column: d
+// Cell:   Json<Eth1UserOperation>
+//
+// ==================================================
+func (bigtable *Bigtable) TransformUserOperations(blk *types.Eth1Block, cache *freecache.Cache) (bulkData *types.BulkMutations, bulkMetadataUpdates *types.BulkMutations, err error) {
+	startTime := time.Now()
+	defer func() {
+		metrics.TaskDuration.WithLabelValues("bt_transform_user_operations").Observe(time.Since(startTime).Seconds())
+	}()
+
+	bulkData = &types.BulkMutations{}
+	bulkMetadataUpdates = &types.BulkMutations{}
+
+	add := func(key string, value []byte) {
+		mut := gcp_bigtable.NewMutation()
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), value)
+		bulkData.Keys = append(bulkData.Keys, key)
+		bulkData.Muts = append(bulkData.Muts, mut)
+	}
+
+	for i, tx := range blk.GetTransactions() {
+		if i >= TX_PER_BLOCK_LIMIT {
+			return nil, nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
+		}
+		iReversed := reversePaddedIndex(i, TX_PER_BLOCK_LIMIT)
+		for j, txLog := range tx.GetLogs() {
+			if j >= ITX_PER_TX_LIMIT {
+				return nil, nil, fmt.Errorf("unexpected number of logs in block expected at most %d but got: %v tx: %x", ITX_PER_TX_LIMIT-1, j, tx.GetHash())
+			}
+			op := entrypoint.ParseLog(txLog.GetAddress(), txLog.GetTopics(), txLog.GetData())
+			if op == nil {
+				continue
+			}
+
+			userOperation := &types.Eth1UserOperation{
+				Hash:          op.Hash.Bytes(),
+				EntryPoint:    op.EntryPoint.Bytes(),
+				Sender:        op.Sender.Bytes(),
+				Bundler:       tx.GetFrom(),
+				Nonce:         op.Nonce.Bytes(),
+				Success:       op.Success,
+				ActualGasCost: op.ActualGasCost.Bytes(),
+				ActualGasUsed: op.ActualGasUsed.Uint64(),
+				TxHash:        tx.GetHash(),
+				BlockNumber:   blk.GetNumber(),
+				Time:          uint64(blk.GetTime().GetSeconds()),
+				TxIndex:       uint64(i),
+				LogIndex:      uint64(j),
+			}
+			if op.Paymaster != nil {
+				userOperation.Paymaster = op.Paymaster.Bytes()
+			}
+			b, err := json.Marshal(userOperation)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			position := fmt.Sprintf("%s:%s:%s", reversedPaddedBlockNumber(blk.GetNumber()), iReversed, reversePaddedIndex(j, ITX_PER_TX_LIMIT))
+			add(fmt.Sprintf("%s:UO:%x:S:%s", bigtable.chainId, userOperation.Sender, position), b)
+			if userOperation.Paymaster != nil {
+				add(fmt.Sprintf("%s:UO:%x:P:%s", bigtable.chainId, userOperation.Paymaster, position), b)
+			}
+			add(fmt.Sprintf("%s:UO:%x:B:%s", bigtable.chainId, userOperation.Bundler, position), b)
+		}
+	}
+
+	return bulkData, bulkMetadataUpdates, nil
+}
+
+// GetUserOperations returns the user operations in which the address has the given role that follow the cursor, or that precede it if reverse is set
+func (bigtable *Bigtable) GetUserOperations(address []byte, role string, cursor *types.Eth1UserOperation, reverse bool, limit int64) ([]*types.Eth1UserOperation, error) {
+	roleKey, ok := userOperationRoleKeys[role]
+	if !ok {
+		return nil, fmt.Errorf("unknown user operation role %v", role)
+	}
+	prefix := fmt.Sprintf("%s:UO:%x:%s:", bigtable.chainId, address, roleKey)
+
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"prefix":   prefix,
+			"limit":    limit,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	cursorKey := ""
+	if cursor != nil {
+		cursorKey = fmt.Sprintf("%s%s:%s:%s", prefix, reversedPaddedBlockNumber(cursor.BlockNumber), reversePaddedIndex(int(cursor.TxIndex), TX_PER_BLOCK_LIMIT), reversePaddedIndex(int(cursor.LogIndex), ITX_PER_TX_LIMIT))
+	}
+
+	userOperations := make([]*types.Eth1UserOperation, 0, limit)
+	var parseErr error
+	err := bigtable.readPage(ctx, prefix, cursorKey, reverse, limit, func(row gcp_bigtable.Row) bool {
+		userOperation := &types.Eth1UserOperation{}
+		if parseErr = json.Unmarshal(row[DEFAULT_FAMILY][0].Value, userOperation); parseErr != nil {
+			parseErr = fmt.Errorf("error parsing user operation %v: %w", row.Key(), parseErr)
+			return false
+		}
+		userOperations = append(userOperations, userOperation)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return userOperations, parseErr
+}
@@ -0,0 +1,53 @@
+package gasoracle
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// BaseFeePrediction is the range the base fee can be in after a number of blocks
+type BaseFeePrediction struct {
+	BlocksAhead uint64
+	Min         *big.Int
+	Max         *big.Int
+}
+
+// NextBaseFee calculates the base fee of the block following a block with the given base fee, gas used and gas limit as specified by EIP-1559
+func NextBaseFee(baseFee *big.Int, gasUsed, gasLimit uint64) *big.Int {
+	target := gasLimit / params.DefaultElasticityMultiplier
+	if target == 0 || gasUsed == target {
+		return new(big.Int).Set(baseFee)
+	}
+
+	denominator := new(big.Int).SetUint64(target * params.DefaultBaseFeeChangeDenominator)
+	if gasUsed > target {
+		delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gasUsed-target))
+		delta.Div(delta, denominator)
+		if delta.Sign() == 0 {
+			delta.SetUint64(1)
+		}
+		return delta.Add(baseFee, delta)
+	}
+	delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(target-gasUsed))
+	delta.Div(delta, denominator)
+	return delta.Sub(baseFee, delta)
+}
+
+// PredictBaseFees returns the range of the base fee for the given number of blocks after the block with the given base fee.
+// The lower bound assumes empty blocks, the upper bound full blocks.
+func PredictBaseFees(baseFee *big.Int, blocks uint64) []BaseFeePrediction {
+	predictions := make([]BaseFeePrediction, 0, blocks)
+	low, high := new(big.Int).Set(baseFee), new(big.Int).Set(baseFee)
+	for i := uint64(1); i <= blocks; i++ {
+		// a gas limit of twice the target allows full blocks to use the maximum increase
+		low = NextBaseFee(low, 0, params.DefaultElasticityMultiplier)
+		high = NextBaseFee(high, params.DefaultElasticityMultiplier, params.DefaultElasticityMultiplier)
+		predictions = append(predictions, BaseFeePrediction{
+			BlocksAhead: i,
+			Min:         low,
+			Max:         high,
+		})
+	}
+	return predictions
+}
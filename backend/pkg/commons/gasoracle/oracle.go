@@ -0,0 +1,172 @@
+package gasoracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/rpc"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// number of recent blocks the priority fees are derived from
+const historyBlocks = 20
+
+// speeds from slow to rapid, each speed is derived from a reward percentile of the recent blocks and from
+// the priority fee required to get into the given number of (target sized) blocks with the current mempool
+var (
+	speedPercentiles  = []float64{10, 35, 60, 90}
+	speedMempoolDepth = []uint64{8, 4, 2, 1}
+)
+
+// Oracle derives gas price recommendations from recent blocks and the pending mempool of an execution client
+type Oracle struct {
+	client *rpc.ErigonClient
+}
+
+func NewOracle(client *rpc.ErigonClient) *Oracle {
+	return &Oracle{client: client}
+}
+
+// Estimate returns the current gas price recommendations, the base fee is the one of the next block
+func (o *Oracle) Estimate(ctx context.Context) (*types.GasNowHistory, error) {
+	header, err := o.client.GetNativeClient().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving latest header: %w", err)
+	}
+	if header.BaseFee == nil {
+		return nil, fmt.Errorf("latest block %v has no base fee", header.Number)
+	}
+	baseFee := NextBaseFee(header.BaseFee, header.GasUsed, header.GasLimit)
+
+	history, err := o.client.GetNativeClient().FeeHistory(ctx, historyBlocks, header.Number, speedPercentiles)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving fee history: %w", err)
+	}
+	tips := historyTips(history.Reward, history.GasUsedRatio)
+
+	// not every client exposes the txpool namespace, the estimate then relies on the fee history only
+	var mempool types.RawMempoolResponse
+	err = o.client.GetRPCClient().CallContext(ctx, &mempool, "txpool_content")
+	if err != nil {
+		log.Warnf("error retrieving mempool content, estimating gas prices from fee history only: %v", err)
+	} else {
+		mempoolTips := pendingTips(mempool, baseFee, header.GasLimit/params.DefaultElasticityMultiplier)
+		for i := range tips {
+			if mempoolTips[i].Cmp(tips[i]) > 0 {
+				tips[i] = mempoolTips[i]
+			}
+		}
+	}
+
+	// faster speeds never recommend a lower tip than slower ones
+	for i := 1; i < len(tips); i++ {
+		if tips[i].Cmp(tips[i-1]) < 0 {
+			tips[i] = tips[i-1]
+		}
+	}
+
+	return &types.GasNowHistory{
+		Ts:       time.Now(),
+		BaseFee:  baseFee,
+		Slow:     new(big.Int).Add(baseFee, tips[0]),
+		Standard: new(big.Int).Add(baseFee, tips[1]),
+		Fast:     new(big.Int).Add(baseFee, tips[2]),
+		Rapid:    new(big.Int).Add(baseFee, tips[3]),
+	}, nil
+}
+
+// historyTips returns the median reward per speed percentile, empty blocks are ignored as they report zero rewards
+func historyTips(rewards [][]*big.Int, gasUsedRatios []float64) []*big.Int {
+	tips := make([]*big.Int, len(speedPercentiles))
+	for i := range speedPercentiles {
+		values := make([]*big.Int, 0, len(rewards))
+		for j, reward := range rewards {
+			if j < len(gasUsedRatios) && gasUsedRatios[j] == 0 || i >= len(reward) {
+				continue
+			}
+			values = append(values, reward[i])
+		}
+		if len(values) == 0 {
+			tips[i] = new(big.Int)
+			continue
+		}
+		slices.SortFunc(values, func(a, b *big.Int) int { return a.Cmp(b) })
+		tips[i] = new(big.Int).Set(values[len(values)/2])
+	}
+	return tips
+}
+
+// pendingTips returns per speed the priority fee required to be included within the speed's mempool depth,
+// assuming the pending transactions are included by descending priority fee. Zero is returned if the pending
+// transactions don't fill the depth.
+func pendingTips(mempool types.RawMempoolResponse, baseFee *big.Int, targetGas uint64) []*big.Int {
+	type pendingTx struct {
+		tip *big.Int
+		gas uint64
+	}
+	var pending []pendingTx
+	for _, txs := range mempool.Pending {
+		for _, tx := range txs {
+			if tx.Gas == nil {
+				continue
+			}
+			var tip *big.Int
+			switch {
+			case tx.GasFeeCap != nil && tx.GasTipCap != nil:
+				tip = new(big.Int).Sub(tx.GasFeeCap.ToInt(), baseFee)
+				if tx.GasTipCap.ToInt().Cmp(tip) < 0 {
+					tip = new(big.Int).Set(tx.GasTipCap.ToInt())
+				}
+			case tx.GasPrice != nil:
+				tip = new(big.Int).Sub(tx.GasPrice.ToInt(), baseFee)
+			default:
+				continue
+			}
+			// transactions that can't pay the base fee of the next block are not competing for inclusion
+			if tip.Sign() < 0 {
+				continue
+			}
+			pending = append(pending, pendingTx{tip: tip, gas: tx.Gas.ToInt().Uint64()})
+		}
+	}
+	slices.SortFunc(pending, func(a, b pendingTx) int { return b.tip.Cmp(a.tip) })
+
+	tips := make([]*big.Int, len(speedMempoolDepth))
+	for i, depth := range speedMempoolDepth {
+		tips[i] = new(big.Int)
+		limit := depth * targetGas
+		cumulative := uint64(0)
+		for _, tx := range pending {
+			cumulative += tx.gas
+			if cumulative >= limit {
+				tips[i].Set(tx.tip)
+				break
+			}
+		}
+	}
+	return tips
+}
+
+// RecentAverage returns the average of the rapid gas price recommendations stored during the given duration, nil is returned if there are none
+func RecentAverage(duration time.Duration) (*big.Int, error) {
+	ts := time.Now()
+	history, err := db.BigtableClient.GetGasNowHistory(ts, ts.Add(-duration))
+	if err != nil {
+		return nil, fmt.Errorf("error getting gas price history: %w", err)
+	}
+	if len(history) == 0 {
+		return nil, nil
+	}
+	sum := new(big.Int)
+	for _, entry := range history {
+		sum.Add(sum, entry.Rapid)
+	}
+	return sum.Div(sum, big.NewInt(int64(len(history)))), nil
+}
@@ -0,0 +1,62 @@
+package gasoracle
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	baseFee := big.NewInt(1_000_000_000)
+	// target is 15M for a 30M gas limit
+	assert.Equal(t, int64(1_000_000_000), NextBaseFee(baseFee, 15_000_000, 30_000_000).Int64())
+	assert.Equal(t, int64(1_125_000_000), NextBaseFee(baseFee, 30_000_000, 30_000_000).Int64())
+	assert.Equal(t, int64(875_000_000), NextBaseFee(baseFee, 0, 30_000_000).Int64())
+	// increases are at least 1 wei
+	assert.Equal(t, int64(8), NextBaseFee(big.NewInt(7), 15_000_001, 30_000_000).Int64())
+}
+
+func TestPredictBaseFees(t *testing.T) {
+	predictions := PredictBaseFees(big.NewInt(1_000_000_000), 2)
+	assert.Len(t, predictions, 2)
+	assert.Equal(t, uint64(2), predictions[1].BlocksAhead)
+	assert.Equal(t, int64(765_625_000), predictions[1].Min.Int64())
+	assert.Equal(t, int64(1_265_625_000), predictions[1].Max.Int64())
+}
+
+func TestHistoryTips(t *testing.T) {
+	reward := func(values ...int64) []*big.Int {
+		result := make([]*big.Int, len(values))
+		for i, v := range values {
+			result[i] = big.NewInt(v)
+		}
+		return result
+	}
+	rewards := [][]*big.Int{reward(1, 2, 3, 4), reward(0, 0, 0, 0), reward(3, 4, 5, 6), reward(2, 3, 4, 5)}
+	tips := historyTips(rewards, []float64{0.5, 0, 0.5, 0.5})
+	assert.Equal(t, []*big.Int{big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}, tips)
+}
+
+func TestPendingTips(t *testing.T) {
+	tx := func(tip int64, gas uint64) *types.RawMempoolTransaction {
+		return &types.RawMempoolTransaction{
+			GasFeeCap: (*hexutil.Big)(big.NewInt(100 + tip)),
+			GasTipCap: (*hexutil.Big)(big.NewInt(tip)),
+			Gas:       (*hexutil.Big)(new(big.Int).SetUint64(gas)),
+		}
+	}
+	mempool := types.RawMempoolResponse{
+		Pending: map[string]map[string]*types.RawMempoolTransaction{
+			"a": {"0": tx(10, 10), "1": tx(5, 10)},
+			"b": {"0": tx(1, 20)},
+			// can't pay the base fee
+			"c": {"0": {GasPrice: (*hexutil.Big)(big.NewInt(50)), Gas: (*hexutil.Big)(big.NewInt(1000))}},
+		},
+	}
+	tips := pendingTips(mempool, big.NewInt(100), 10)
+	// depths of 80, 40, 20 and 10 gas from slow to rapid
+	assert.Equal(t, []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(5), big.NewInt(10)}, tips)
+}
@@ -0,0 +1,99 @@
+package layer2
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+const StackArbitrum = "arbitrum"
+
+var (
+	arbSequencerBatchDeliveredTopic   = crypto.Keccak256([]byte("SequencerBatchDelivered(uint256,bytes32,bytes32,bytes32,uint256,(uint64,uint64,uint64,uint64),uint8)"))
+	arbMessageDeliveredTopic          = crypto.Keccak256([]byte("MessageDelivered(uint256,bytes32,address,uint8,address,bytes32,uint256,uint64)"))
+	arbInboxMessageDeliveredTopic     = crypto.Keccak256([]byte("InboxMessageDelivered(uint256,bytes)"))
+	arbOutBoxTransactionExecutedTopic = crypto.Keccak256([]byte("OutBoxTransactionExecuted(address,address,uint256,uint256)"))
+)
+
+// message kinds of the arbitrum bridge that transfer value to layer 2
+const (
+	arbMessageKindRetryable  = 9
+	arbMessageKindEthDeposit = 12
+)
+
+// Arbitrum is a rollup built on arbitrum nitro.
+// Batches are posted to the sequencer inbox, deposits are delivered by the bridge and withdrawals are executed by the outbox.
+type Arbitrum struct {
+	SequencerInbox common.Address
+	Bridge         common.Address
+	Inbox          common.Address
+	Outbox         common.Address
+}
+
+func (a *Arbitrum) ParseTransaction(tx *types.Eth1Transaction) (*Batch, []*Transfer, error) {
+	var batch *Batch
+	var transfers []*Transfer
+	// the message data is emitted by the inbox after the bridge delivered the message
+	inboxMessages := make(map[string][]byte)
+	for _, txLog := range tx.GetLogs() {
+		topics := txLog.GetTopics()
+		if bytes.Equal(txLog.GetAddress(), a.Inbox.Bytes()) && len(topics) == 2 && bytes.Equal(topics[0], arbInboxMessageDeliveredTopic) {
+			inboxMessages[string(topics[1])] = unpackBytes(txLog.GetData())
+		}
+	}
+
+	for i, txLog := range tx.GetLogs() {
+		topics := txLog.GetTopics()
+		if len(topics) == 0 {
+			continue
+		}
+		data := txLog.GetData()
+		switch {
+		case bytes.Equal(txLog.GetAddress(), a.SequencerInbox.Bytes()) && bytes.Equal(topics[0], arbSequencerBatchDeliveredTopic) && len(topics) == 4:
+			index := new(big.Int).SetBytes(topics[1]).Uint64()
+			batch = &Batch{Index: &index, Submitter: common.BytesToAddress(tx.GetFrom())}
+			batch.DataLocation, batch.Size = submissionSize(tx)
+		case bytes.Equal(txLog.GetAddress(), a.Bridge.Bytes()) && bytes.Equal(topics[0], arbMessageDeliveredTopic) && len(topics) == 3 && len(data) == 6*32:
+			kind := new(big.Int).SetBytes(data[32:64]).Uint64()
+			if kind != arbMessageKindRetryable && kind != arbMessageKindEthDeposit {
+				continue
+			}
+			from := common.BytesToAddress(data[64:96])
+			transfer := &Transfer{Direction: TransferDeposit, LogIndex: i, From: &from, MessageId: topics[1]}
+			message := inboxMessages[string(topics[1])]
+			switch {
+			case kind == arbMessageKindEthDeposit && len(message) == 20+32:
+				to := common.BytesToAddress(message[:20])
+				transfer.To = &to
+				transfer.Value = new(big.Int).SetBytes(message[20:])
+			case kind == arbMessageKindRetryable && len(message) >= 2*32:
+				to := common.BytesToAddress(message[:32])
+				transfer.To = &to
+				transfer.Value = new(big.Int).SetBytes(message[32:64])
+			}
+			transfers = append(transfers, transfer)
+		case bytes.Equal(txLog.GetAddress(), a.Outbox.Bytes()) && bytes.Equal(topics[0], arbOutBoxTransactionExecutedTopic) && len(topics) == 4 && len(data) == 32:
+			from, to := common.BytesToAddress(topics[2]), common.BytesToAddress(topics[1])
+			transfer := &Transfer{
+				Direction: TransferWithdrawal,
+				LogIndex:  i,
+				From:      &from,
+				To:        &to,
+				MessageId: data,
+				Status:    WithdrawalFinalized,
+			}
+			// the bridge executes the call of the withdrawal, including its value
+			for _, itx := range tx.GetItx() {
+				if bytes.Equal(itx.GetFrom(), a.Bridge.Bytes()) && bytes.Equal(itx.GetTo(), to.Bytes()) {
+					transfer.Value = new(big.Int).SetBytes(itx.GetValue())
+					break
+				}
+			}
+			transfers = append(transfers, transfer)
+		}
+	}
+	return batch, transfers, nil
+}
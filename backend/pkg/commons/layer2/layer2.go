@@ -0,0 +1,140 @@
+package layer2
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+// BlobSize is the size of the data of a single blob in bytes
+const BlobSize = 131072
+
+const (
+	DataLocationCalldata = "calldata"
+	DataLocationBlob     = "blob"
+)
+
+const (
+	TransferDeposit    = "deposit"    // layer 1 to layer 2
+	TransferWithdrawal = "withdrawal" // layer 2 to layer 1
+)
+
+// withdrawal states, deposits are final once they are included on layer 1
+const (
+	WithdrawalProven    = "proven"
+	WithdrawalFinalized = "finalized"
+	WithdrawalFailed    = "failed"
+)
+
+// Batch is a submission of layer 2 transaction data to layer 1
+type Batch struct {
+	Index        *uint64 // nil if the rollup does not number its batches on layer 1
+	Submitter    common.Address
+	DataLocation string
+	Size         uint64 // bytes of calldata or blob data
+}
+
+// Transfer is a bridge message between layer 1 and layer 2 that was emitted on layer 1
+type Transfer struct {
+	Direction string
+	LogIndex  int
+	From      *common.Address // nil if not part of the emitted events
+	To        *common.Address // nil if not part of the emitted events
+	Value     *big.Int        // nil if not part of the emitted events
+	MessageId []byte          // withdrawal hash for op-stack rollups, message or transaction index for arbitrum rollups
+	Status    string          // only set for withdrawals
+}
+
+// Rollup detects the batches and bridge transfers of a layer 2 network in layer 1 transactions
+type Rollup interface {
+	// ParseTransaction returns the batch submitted by the transaction and the bridge transfers it initiated or settled.
+	// The batch is nil if the transaction is no batch submission of the rollup.
+	ParseTransaction(tx *types.Eth1Transaction) (*Batch, []*Transfer, error)
+}
+
+// Network is a layer 2 network that settles on a layer 1 network
+type Network struct {
+	ChainId uint64
+	Name    string
+	Stack   string
+	Rollup  Rollup
+}
+
+// networks maps layer 1 chain ids to the layer 2 networks that are indexed on them
+var networks = map[uint64][]Network{
+	1: {
+		{
+			ChainId: 10,
+			Name:    "optimism",
+			Stack:   StackOp,
+			Rollup: &OpStack{
+				BatchInbox:     common.HexToAddress("0xff00000000000000000000000000000000000010"),
+				BatchSender:    common.HexToAddress("0x6887246668a3b87f54deb3b94ba47a6f63f32985"),
+				OptimismPortal: common.HexToAddress("0xbeb5fc579115071764c7423a4f12edde41f106ed"),
+			},
+		},
+		{
+			ChainId: 8453,
+			Name:    "base",
+			Stack:   StackOp,
+			Rollup: &OpStack{
+				BatchInbox:     common.HexToAddress("0xff00000000000000000000000000000000008453"),
+				BatchSender:    common.HexToAddress("0x5050f69a9786f081509234f1a7f4684b5e5b76c9"),
+				OptimismPortal: common.HexToAddress("0x49048044d57e1c92a77f79988d21fa8faf74e97e"),
+			},
+		},
+		{
+			ChainId: 42161,
+			Name:    "arbitrum",
+			Stack:   StackArbitrum,
+			Rollup: &Arbitrum{
+				SequencerInbox: common.HexToAddress("0x1c479675ad559dc151f6ec7ed3fbf8cee79582b6"),
+				Bridge:         common.HexToAddress("0x8315177ab297ba92a06054ce80a67ed4dbd7ed3a"),
+				Inbox:          common.HexToAddress("0x4dbd4fc535ac27206064b68ffcf827b0a60bab3f"),
+				Outbox:         common.HexToAddress("0x0b9857ae2d4a3dbe74ffe1d7df045bb7f96e4840"),
+			},
+		},
+	},
+}
+
+// Networks returns the layer 2 networks that are indexed on the given layer 1 network
+func Networks(l1ChainId uint64) []Network {
+	return networks[l1ChainId]
+}
+
+// FindNetwork returns the layer 2 network of the given layer 1 network with the given name or chain id
+func FindNetwork(l1ChainId uint64, nameOrChainId string) (Network, bool) {
+	chainId, err := strconv.ParseUint(nameOrChainId, 10, 64)
+	for _, network := range networks[l1ChainId] {
+		if network.Name == nameOrChainId || (err == nil && network.ChainId == chainId) {
+			return network, true
+		}
+	}
+	return Network{}, false
+}
+
+func submissionSize(tx *types.Eth1Transaction) (string, uint64) {
+	if len(tx.GetBlobVersionedHashes()) > 0 {
+		return DataLocationBlob, uint64(len(tx.GetBlobVersionedHashes())) * BlobSize
+	}
+	return DataLocationCalldata, uint64(len(tx.GetData()))
+}
+
+// unpackBytes decodes abi encoded data that consists of a single bytes argument
+func unpackBytes(data []byte) []byte {
+	if len(data) < 64 {
+		return nil
+	}
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data)-32) {
+		return nil
+	}
+	length := new(big.Int).SetBytes(data[offset.Uint64() : offset.Uint64()+32])
+	start := offset.Uint64() + 32
+	if !length.IsUint64() || length.Uint64() > uint64(len(data))-start {
+		return nil
+	}
+	return data[start : start+length.Uint64()]
+}
@@ -0,0 +1,105 @@
+package layer2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func word(v int64) []byte {
+	return common.BigToHash(big.NewInt(v)).Bytes()
+}
+
+func packBytes(data []byte) []byte {
+	result := append(word(32), word(int64(len(data)))...)
+	result = append(result, data...)
+	return append(result, make([]byte, (32-len(data)%32)%32)...)
+}
+
+func TestOpStack(t *testing.T) {
+	network, ok := FindNetwork(1, "10")
+	require.True(t, ok)
+	rollup := network.Rollup.(*OpStack)
+	from, to := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	batch, transfers, err := rollup.ParseTransaction(&types.Eth1Transaction{
+		From:                rollup.BatchSender.Bytes(),
+		To:                  rollup.BatchInbox.Bytes(),
+		BlobVersionedHashes: [][]byte{word(1), word(2)},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+	assert.Equal(t, &Batch{Submitter: rollup.BatchSender, DataLocation: DataLocationBlob, Size: 2 * BlobSize}, batch)
+
+	// opaque data of a deposit of 5 wei
+	opaqueData := append(append(word(5), word(5)...), make([]byte, 9)...)
+	batch, transfers, err = rollup.ParseTransaction(&types.Eth1Transaction{
+		Logs: []*types.Eth1Log{
+			{Address: from.Bytes(), Topics: [][]byte{opTransactionDepositedTopic}},
+			{
+				Address: rollup.OptimismPortal.Bytes(),
+				Topics:  [][]byte{opTransactionDepositedTopic, common.LeftPadBytes(from.Bytes(), 32), common.LeftPadBytes(to.Bytes(), 32), word(0)},
+				Data:    packBytes(opaqueData),
+			},
+			{
+				Address: rollup.OptimismPortal.Bytes(),
+				Topics:  [][]byte{opWithdrawalFinalizedTopic, word(7)},
+				Data:    word(0),
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, batch)
+	require.Len(t, transfers, 2)
+	assert.Equal(t, &Transfer{Direction: TransferDeposit, LogIndex: 1, From: &from, To: &to, Value: big.NewInt(5)}, transfers[0])
+	assert.Equal(t, &Transfer{Direction: TransferWithdrawal, LogIndex: 2, MessageId: word(7), Status: WithdrawalFailed}, transfers[1])
+}
+
+func TestArbitrum(t *testing.T) {
+	network, ok := FindNetwork(1, "arbitrum")
+	require.True(t, ok)
+	rollup := network.Rollup.(*Arbitrum)
+	sender, from, to := common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")
+
+	messageDelivered := append(common.LeftPadBytes(rollup.Inbox.Bytes(), 32), word(arbMessageKindEthDeposit)...)
+	messageDelivered = append(messageDelivered, common.LeftPadBytes(from.Bytes(), 32)...)
+	messageDelivered = append(messageDelivered, make([]byte, 3*32)...)
+	batch, transfers, err := rollup.ParseTransaction(&types.Eth1Transaction{
+		From: sender.Bytes(),
+		Data: make([]byte, 100),
+		Logs: []*types.Eth1Log{
+			{
+				Address: rollup.SequencerInbox.Bytes(),
+				Topics:  [][]byte{arbSequencerBatchDeliveredTopic, word(42), word(0), word(0)},
+			},
+			{
+				Address: rollup.Bridge.Bytes(),
+				Topics:  [][]byte{arbMessageDeliveredTopic, word(3), word(0)},
+				Data:    messageDelivered,
+			},
+			{
+				Address: rollup.Inbox.Bytes(),
+				Topics:  [][]byte{arbInboxMessageDeliveredTopic, word(3)},
+				Data:    packBytes(append(to.Bytes(), word(9)...)),
+			},
+		},
+	})
+	require.NoError(t, err)
+	index := uint64(42)
+	assert.Equal(t, &Batch{Index: &index, Submitter: sender, DataLocation: DataLocationCalldata, Size: 100}, batch)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, &Transfer{Direction: TransferDeposit, LogIndex: 1, From: &from, To: &to, Value: big.NewInt(9), MessageId: word(3)}, transfers[0])
+}
+
+func TestFindNetwork(t *testing.T) {
+	_, ok := FindNetwork(1, "base")
+	assert.True(t, ok)
+	_, ok = FindNetwork(17000, "base")
+	assert.False(t, ok)
+	_, ok = FindNetwork(1, "1")
+	assert.False(t, ok)
+}
@@ -0,0 +1,74 @@
+package layer2
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+const StackOp = "op-stack"
+
+var (
+	opTransactionDepositedTopic = crypto.Keccak256([]byte("TransactionDeposited(address,address,uint256,bytes)"))
+	opWithdrawalProvenTopic     = crypto.Keccak256([]byte("WithdrawalProven(bytes32,address,address)"))
+	opWithdrawalFinalizedTopic  = crypto.Keccak256([]byte("WithdrawalFinalized(bytes32,bool)"))
+)
+
+// OpStack is a rollup built on the OP stack, like OP Mainnet or Base.
+// Batches are sent by the batcher to the batch inbox, an address without code, and bridge messages pass through the portal.
+type OpStack struct {
+	BatchInbox     common.Address
+	BatchSender    common.Address
+	OptimismPortal common.Address
+}
+
+func (o *OpStack) ParseTransaction(tx *types.Eth1Transaction) (*Batch, []*Transfer, error) {
+	var batch *Batch
+	if bytes.Equal(tx.GetTo(), o.BatchInbox.Bytes()) && bytes.Equal(tx.GetFrom(), o.BatchSender.Bytes()) {
+		batch = &Batch{Submitter: o.BatchSender}
+		batch.DataLocation, batch.Size = submissionSize(tx)
+	}
+
+	var transfers []*Transfer
+	for i, txLog := range tx.GetLogs() {
+		topics := txLog.GetTopics()
+		if !bytes.Equal(txLog.GetAddress(), o.OptimismPortal.Bytes()) || len(topics) == 0 {
+			continue
+		}
+		switch {
+		case bytes.Equal(topics[0], opTransactionDepositedTopic) && len(topics) == 4:
+			from, to := common.BytesToAddress(topics[1]), common.BytesToAddress(topics[2])
+			transfer := &Transfer{Direction: TransferDeposit, LogIndex: i, From: &from, To: &to}
+			// version 0 of the opaque data starts with the minted and the transferred value, the minted value is the bridged eth
+			if opaqueData := unpackBytes(txLog.GetData()); new(big.Int).SetBytes(topics[3]).Sign() == 0 && len(opaqueData) >= 64 {
+				transfer.Value = new(big.Int).SetBytes(opaqueData[:32])
+			}
+			transfers = append(transfers, transfer)
+		case bytes.Equal(topics[0], opWithdrawalProvenTopic) && len(topics) == 4:
+			from, to := common.BytesToAddress(topics[2]), common.BytesToAddress(topics[3])
+			transfers = append(transfers, &Transfer{
+				Direction: TransferWithdrawal,
+				LogIndex:  i,
+				From:      &from,
+				To:        &to,
+				MessageId: topics[1],
+				Status:    WithdrawalProven,
+			})
+		case bytes.Equal(topics[0], opWithdrawalFinalizedTopic) && len(topics) == 2 && len(txLog.GetData()) == 32:
+			transfer := &Transfer{
+				Direction: TransferWithdrawal,
+				LogIndex:  i,
+				MessageId: topics[1],
+				Status:    WithdrawalFinalized,
+			}
+			if new(big.Int).SetBytes(txLog.GetData()).Sign() == 0 {
+				transfer.Status = WithdrawalFailed
+			}
+			transfers = append(transfers, transfer)
+		}
+	}
+	return batch, transfers, nil
+}
@@ -3,7 +3,10 @@ package mail
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"errors"
 	"html/template"
+	"net"
 
 	"fmt"
 	"net/smtp"
@@ -40,11 +43,11 @@ func SendHTMLMail(to, subject string, msg types.Email, attachment []types.EmailA
 		}
 
 		log.Infof("Email Attachments will not work with SMTP server")
-		err = SendMailSMTP(to, body.Bytes())
+		err = SendMailSMTP(context.Background(), to, body.Bytes())
 	} else if utils.Config.Frontend.Mail.Mailgun.PrivateKey != "" {
 		_ = renderer.ExecuteTemplate(&body, "layout", MailTemplate{Mail: msg, Domain: utils.Config.Frontend.SiteDomain})
 		content := body.String()
-		err = SendMailMailgun(to, subject, content, createTextMessage(msg), attachment)
+		err = SendMailMailgun(context.Background(), to, subject, content, createTextMessage(msg), attachment)
 	} else {
 		log.Error(nil, "error sending reset-email: invalid config for mail-service", 0)
 		err = nil
@@ -112,15 +115,14 @@ func SendMailRateLimited(content types.TransitEmailContent, maxEmailsPerDay int6
 	return nil
 }
 
-// SendMailSMTP sends an email to the given address with the given message, using smtp.
-func SendMailSMTP(to string, msg []byte) error {
+// SendMailSMTP sends an email to the given address with the given message, using smtp. The send is aborted once the context is done.
+func SendMailSMTP(ctx context.Context, to string, msg []byte) error {
 	server := utils.Config.Frontend.Mail.SMTP.Server // eg. smtp.gmail.com:587
 	host := utils.Config.Frontend.Mail.SMTP.Host     // eg. smtp.gmail.com
 	from := utils.Config.Frontend.Mail.SMTP.User     // eg. userxyz123@gmail.com
 	password := utils.Config.Frontend.Mail.SMTP.Password
-	auth := smtp.PlainAuth("", from, password, host)
 
-	err := smtp.SendMail(server, auth, from, []string{to}, msg)
+	err := sendMailSMTP(ctx, server, &tls.Config{ServerName: host}, smtp.PlainAuth("", from, password, host), from, to, msg)
 	if err != nil {
 		return fmt.Errorf("error sending mail via smtp: %w", err)
 	}
@@ -128,20 +130,89 @@ func SendMailSMTP(to string, msg []byte) error {
 	return nil
 }
 
-// SendMailMailgun sends an email to the given address with the given message, using mailgun.
-func SendMailMailgun(to, subject, msgHtml, msgText string, attachment []types.EmailAttachment) error {
+// sendMailSMTP works like smtp.SendMail but aborts once the context is done, tlsConfig is used if the server supports STARTTLS
+func sendMailSMTP(ctx context.Context, server string, tlsConfig *tls.Config, auth smtp.Auth, from, to string, msg []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", server)
+	if err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	host, _, err := net.SplitHostPort(server)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// SendMailMailgun sends an email to the given address with the given message, using mailgun. msgHtml is optional.
+func SendMailMailgun(ctx context.Context, to, subject, msgHtml, msgText string, attachment []types.EmailAttachment) error {
 	mg := mailgun.NewMailgun(
 		utils.Config.Frontend.Mail.Mailgun.Domain,
 		utils.Config.Frontend.Mail.Mailgun.PrivateKey,
 	)
+	if utils.Config.Frontend.Mail.Mailgun.ApiBase != "" {
+		mg.SetAPIBase(utils.Config.Frontend.Mail.Mailgun.ApiBase)
+	}
 
-	// if the text part still contains html tags / entities, remove / convert them
-	msgText = html2text.HTML2Text(msgText)
+	if msgHtml != "" {
+		// if the text part still contains html tags / entities, remove / convert them
+		msgText = html2text.HTML2Text(msgText)
+	}
 
 	message := mg.NewMessage(utils.Config.Frontend.Mail.Mailgun.Sender, subject, msgText, to)
-	message.SetHtml(msgHtml)
+	if msgHtml != "" {
+		message.SetHtml(msgHtml)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 	if len(attachment) > 0 {
 		for _, att := range attachment {
@@ -161,43 +232,11 @@ func SendMailMailgun(to, subject, msgHtml, msgText string, attachment []types.Em
 
 // SendMailSMTP sends an email to the given address with the given message, using smtp.
 func SendTextMailSMTP(to, subject, body string) error {
-	server := utils.Config.Frontend.Mail.SMTP.Server // eg. smtp.gmail.com:587
-	host := utils.Config.Frontend.Mail.SMTP.Host     // eg. smtp.gmail.com
-	from := utils.Config.Frontend.Mail.SMTP.User     // eg. userxyz123@gmail.com
-	password := utils.Config.Frontend.Mail.SMTP.Password
-	auth := smtp.PlainAuth("", from, password, host)
 	msg := []byte(fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s\r\n", to, subject, body))
-
-	err := smtp.SendMail(server, auth, from, []string{to}, msg)
-	if err != nil {
-		return fmt.Errorf("error sending mail via smtp: %w", err)
-	}
-
-	return nil
+	return SendMailSMTP(context.Background(), to, msg)
 }
 
 // SendMailMailgun sends an email to the given address with the given message, using mailgun.
 func SendTextMailMailgun(to, subject, msg string, attachment []types.EmailAttachment) error {
-	mg := mailgun.NewMailgun(
-		utils.Config.Frontend.Mail.Mailgun.Domain,
-		utils.Config.Frontend.Mail.Mailgun.PrivateKey,
-	)
-	message := mg.NewMessage(utils.Config.Frontend.Mail.Mailgun.Sender, subject, msg, to)
-
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-	defer cancel()
-	if len(attachment) > 0 {
-		for _, att := range attachment {
-			message.AddBufferAttachment(att.Name, att.Attachment)
-		}
-	}
-
-	// Send the message with a 10 second timeout
-	resp, id, err := mg.Send(ctx, message)
-	if err != nil {
-		log.InfoWithFields(log.Fields{"resp": resp, "id": id}, "error sending mail via mailgun")
-		return fmt.Errorf("error sending mail via mailgun: %w", err)
-	}
-
-	return nil
+	return SendMailMailgun(context.Background(), to, subject, "", msg, attachment)
 }
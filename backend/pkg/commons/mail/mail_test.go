@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendMailSMTP(t *testing.T) {
+	// the test certificate of httptest is valid for example.com
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+	clientTLSConfig := &tls.Config{
+		RootCAs:    tlsServer.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+		ServerName: "example.com",
+	}
+	auth := smtp.PlainAuth("", "sender@example.com", "password", "127.0.0.1")
+	msg := []byte("To: user@example.com\r\nSubject: Hello\r\n\r\ntext\r\n")
+
+	t.Run("starttls and auth", func(t *testing.T) {
+		addr, data := serveSMTP(t, tlsServer.TLS, "password")
+		err := sendMailSMTP(context.Background(), addr, clientTLSConfig, auth, "sender@example.com", "user@example.com", msg)
+		require.NoError(t, err)
+		assert.Contains(t, <-data, "Subject: Hello")
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		addr, _ := serveSMTP(t, tlsServer.TLS, "password")
+		err := sendMailSMTP(context.Background(), addr, &tls.Config{ServerName: "example.com"}, auth, "sender@example.com", "user@example.com", msg)
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		addr, _ := serveSMTP(t, tlsServer.TLS, "other")
+		err := sendMailSMTP(context.Background(), addr, clientTLSConfig, auth, "sender@example.com", "user@example.com", msg)
+		assert.ErrorContains(t, err, "535")
+	})
+
+	t.Run("auth not supported", func(t *testing.T) {
+		addr, _ := serveSMTP(t, nil, "")
+		err := sendMailSMTP(context.Background(), addr, clientTLSConfig, auth, "sender@example.com", "user@example.com", msg)
+		assert.ErrorContains(t, err, "doesn't support AUTH")
+	})
+
+	t.Run("rejected recipient", func(t *testing.T) {
+		addr, _ := serveSMTP(t, nil, "password")
+		err := sendMailSMTP(context.Background(), addr, clientTLSConfig, auth, "sender@example.com", "rejected@example.com", msg)
+		assert.ErrorContains(t, err, "550")
+	})
+}
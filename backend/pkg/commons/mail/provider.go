@@ -0,0 +1,151 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+const (
+	ProviderSMTP    = "smtp"
+	ProviderMailgun = "mailgun"
+	ProviderMaildir = "maildir"
+)
+
+// Message is a single outbound email, Html is optional.
+type Message struct {
+	To          string
+	Subject     string
+	Text        string
+	Html        string
+	Attachments []types.EmailAttachment
+}
+
+// Provider delivers emails to a single recipient.
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewProviderFromConfig returns the provider configured in utils.Config.Frontend.Mail.Provider.
+// If no provider is configured it will use smtp if configured otherwise it will use mailgun if configured.
+func NewProviderFromConfig() (Provider, error) {
+	cfg := utils.Config.Frontend.Mail
+	provider := cfg.Provider
+	if provider == "" {
+		if cfg.SMTP.User != "" {
+			provider = ProviderSMTP
+		} else if cfg.Mailgun.PrivateKey != "" {
+			provider = ProviderMailgun
+		}
+	}
+	switch provider {
+	case ProviderSMTP:
+		return &SMTPProvider{}, nil
+	case ProviderMailgun:
+		return &MailgunProvider{}, nil
+	case ProviderMaildir:
+		return NewMaildirProvider(cfg.Maildir)
+	}
+	return nil, fmt.Errorf("invalid config for mail-service")
+}
+
+// SMTPProvider sends emails with SendMailSMTP using the smtp server of utils.Config.Frontend.Mail.SMTP, attachments are not supported.
+type SMTPProvider struct{}
+
+func (p *SMTPProvider) Name() string {
+	return ProviderSMTP
+}
+
+func (p *SMTPProvider) Send(ctx context.Context, msg Message) error {
+	if len(msg.Attachments) > 0 {
+		log.Infof("Email Attachments will not work with SMTP server")
+	}
+	body := fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s\r\n", msg.To, msg.Subject, msg.Text)
+	if msg.Html != "" {
+		headers := "MIME-version: 1.0;\nContent-Type: text/html;"
+		body = fmt.Sprintf("To: %s\r\nSubject: %s\r\n%s\r\n%s", msg.To, msg.Subject, headers, msg.Html)
+	}
+	return SendMailSMTP(ctx, msg.To, []byte(body))
+}
+
+// MailgunProvider sends emails with SendMailMailgun using the account of utils.Config.Frontend.Mail.Mailgun.
+type MailgunProvider struct{}
+
+func (p *MailgunProvider) Name() string {
+	return ProviderMailgun
+}
+
+func (p *MailgunProvider) Send(ctx context.Context, msg Message) error {
+	return SendMailMailgun(ctx, msg.To, msg.Subject, msg.Html, msg.Text, msg.Attachments)
+}
+
+// MaildirProvider writes emails to a local maildir instead of sending them, meant for development and tests.
+type MaildirProvider struct {
+	dir     string
+	counter atomic.Uint64
+}
+
+func NewMaildirProvider(dir string) (*MaildirProvider, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("no maildir configured")
+	}
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("error creating maildir %s: %w", dir, err)
+		}
+	}
+	return &MaildirProvider{dir: dir}, nil
+}
+
+func (p *MaildirProvider) Name() string {
+	return ProviderMaildir
+}
+
+// Send delivers the message like a mail delivery agent would: it is written to tmp first and then moved to new, so readers never see partial messages.
+func (p *MaildirProvider) Send(ctx context.Context, msg Message) error {
+	if _, err := mail.ParseAddress(msg.To); err != nil {
+		return fmt.Errorf("invalid recipient %s: %w", msg.To, err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	if msg.Html != "" {
+		buf.WriteString("Content-Type: text/html; charset=utf-8\r\n\r\n")
+		buf.WriteString(msg.Html)
+	} else {
+		buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+		buf.WriteString(strings.ReplaceAll(msg.Text, "\n", "\r\n"))
+	}
+	for _, att := range msg.Attachments {
+		log.Infof("not writing attachment %s of email to %s to maildir", att.Name, msg.To)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+	name := fmt.Sprintf("%d.%d_%d.%s", time.Now().UnixNano(), os.Getpid(), p.counter.Add(1), strings.ReplaceAll(hostname, "/", "_"))
+	tmpPath := filepath.Join(p.dir, "tmp", name)
+	if err := os.WriteFile(tmpPath, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("error writing email to maildir: %w", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(p.dir, "new", name)); err != nil {
+		return fmt.Errorf("error moving email to maildir: %w", err)
+	}
+	return nil
+}
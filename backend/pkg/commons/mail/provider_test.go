@@ -0,0 +1,199 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaildirProvider(t *testing.T) {
+	dir := t.TempDir()
+	p, err := NewMaildirProvider(dir)
+	require.NoError(t, err)
+
+	err = p.Send(context.Background(), Message{To: "user@example.com", Subject: "Hello", Text: "line1\nline2"})
+	require.NoError(t, err)
+	err = p.Send(context.Background(), Message{To: "not an address", Subject: "Hello", Text: "text"})
+	assert.Error(t, err)
+
+	tmp, err := os.ReadDir(filepath.Join(dir, "tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, tmp)
+	delivered, err := os.ReadDir(filepath.Join(dir, "new"))
+	require.NoError(t, err)
+	require.Len(t, delivered, 1)
+
+	content, err := os.ReadFile(filepath.Join(dir, "new", delivered[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "To: user@example.com\r\n")
+	assert.Contains(t, string(content), "Subject: Hello\r\n")
+	assert.Contains(t, string(content), "Content-Type: text/plain; charset=utf-8\r\n\r\nline1\r\nline2")
+}
+
+// serveSMTP accepts a single smtp session and sends the received message data to the returned channel.
+// STARTTLS is offered if tlsConfig is set, AUTH PLAIN is offered and required if password is set.
+func serveSMTP(t *testing.T, tlsConfig *tls.Config, password string) (addr string, data <-chan string) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := textproto.NewConn(conn)
+		_ = r.PrintfLine("220 localhost ESMTP")
+		secure, authenticated := false, false
+		for {
+			line, err := r.ReadLine()
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+			case "EHLO", "HELO":
+				extensions := []string{"localhost"}
+				if tlsConfig != nil && !secure {
+					extensions = append(extensions, "STARTTLS")
+				}
+				if password != "" {
+					extensions = append(extensions, "AUTH PLAIN")
+				}
+				for i, extension := range extensions {
+					separator := "-"
+					if i == len(extensions)-1 {
+						separator = " "
+					}
+					_ = r.PrintfLine("250%s%s", separator, extension)
+				}
+			case "STARTTLS":
+				_ = r.PrintfLine("220 ready")
+				tlsConn := tls.Server(conn, tlsConfig)
+				defer tlsConn.Close()
+				r = textproto.NewConn(tlsConn)
+				secure = true
+			case "AUTH":
+				// AUTH PLAIN <base64 of "identity\x00user\x00password">
+				fields := strings.Fields(line)
+				credentials, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+				if !strings.HasSuffix(string(credentials), "\x00"+password) {
+					_ = r.PrintfLine("535 authentication failed")
+					continue
+				}
+				authenticated = true
+				_ = r.PrintfLine("235 authenticated")
+			case "MAIL":
+				if password != "" && !authenticated {
+					_ = r.PrintfLine("530 authentication required")
+					continue
+				}
+				_ = r.PrintfLine("250 ok")
+			case "RCPT":
+				if strings.Contains(line, "rejected@") {
+					_ = r.PrintfLine("550 mailbox unavailable")
+					continue
+				}
+				_ = r.PrintfLine("250 ok")
+			case "DATA":
+				_ = r.PrintfLine("354 go ahead")
+				lines, err := r.ReadDotLines()
+				if err != nil {
+					return
+				}
+				received <- strings.Join(lines, "\n")
+				_ = r.PrintfLine("250 ok")
+			case "QUIT":
+				_ = r.PrintfLine("221 bye")
+				return
+			default:
+				_ = r.PrintfLine("250 ok")
+			}
+		}
+	}()
+	return l.Addr().String(), received
+}
+
+// setMailConfig replaces the global config for the duration of the test
+func setMailConfig(t *testing.T) *types.Config {
+	t.Helper()
+	previous := utils.Config
+	utils.Config = &types.Config{}
+	t.Cleanup(func() { utils.Config = previous })
+	return utils.Config
+}
+
+func TestSMTPProvider(t *testing.T) {
+	addr, data := serveSMTP(t, nil, "password")
+	cfg := setMailConfig(t)
+	cfg.Frontend.Mail.SMTP.Server = addr
+	cfg.Frontend.Mail.SMTP.Host = "127.0.0.1"
+	cfg.Frontend.Mail.SMTP.User = "sender@example.com"
+	cfg.Frontend.Mail.SMTP.Password = "password"
+	p := &SMTPProvider{}
+
+	err := p.Send(context.Background(), Message{To: "user@example.com", Subject: "Hello", Text: "text", Html: "<b>html</b>"})
+	require.NoError(t, err)
+	msg := <-data
+	assert.Contains(t, msg, "To: user@example.com")
+	assert.Contains(t, msg, "Subject: Hello")
+	assert.Contains(t, msg, "<b>html</b>")
+
+	// the send is aborted once the context is done
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	cfg.Frontend.Mail.SMTP.Server = l.Addr().String()
+	err = p.Send(ctx, Message{To: "user@example.com", Subject: "Hello", Text: "text"})
+	assert.Error(t, err)
+}
+
+func TestMailgunProvider(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/example.com/messages", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		form = r.MultipartForm.Value
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"Queued. Thank you.","id":"<1@example.com>"}`))
+	}))
+	defer server.Close()
+
+	cfg := setMailConfig(t)
+	cfg.Frontend.Mail.Mailgun.Domain = "example.com"
+	cfg.Frontend.Mail.Mailgun.PrivateKey = "key"
+	cfg.Frontend.Mail.Mailgun.Sender = "sender@example.com"
+	cfg.Frontend.Mail.Mailgun.ApiBase = server.URL + "/v3"
+	p := &MailgunProvider{}
+	err := p.Send(context.Background(), Message{To: "user@example.com", Subject: "Hello", Text: "<p>text</p>", Html: "<p>html</p>"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user@example.com"}, form["to"])
+	assert.Equal(t, []string{"Hello"}, form["subject"])
+	assert.Equal(t, []string{"text"}, form["text"])
+	assert.Equal(t, []string{"<p>html</p>"}, form["html"])
+
+	// the send is aborted once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = p.Send(ctx, Message{To: "user@example.com", Subject: "Hello", Text: "text"})
+	assert.Error(t, err)
+}
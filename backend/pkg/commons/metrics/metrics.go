@@ -128,6 +128,11 @@ func (r *responseWriterDelegator) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap allows http.ResponseController to access the underlying writer, e.g. to flush streamed responses
+func (r *responseWriterDelegator) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // Serve serves prometheus metrics on the given address under /metrics
 func Serve(addr string, servePprof bool, enableExtraPprof bool) error {
 	router := http.NewServeMux()
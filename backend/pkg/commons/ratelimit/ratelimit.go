@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"strconv"
@@ -25,9 +26,9 @@ import (
 type TimeWindow string
 
 const (
-	SecondTimeWindow = "second"
-	HourTimeWindow   = "hour"
-	MonthTimeWindow  = "month"
+	SecondTimeWindow TimeWindow = "second"
+	HourTimeWindow   TimeWindow = "hour"
+	MonthTimeWindow  TimeWindow = "month"
 
 	HeaderRateLimitLimit       = "ratelimit-limit"       // the rate limit ceiling that is applicable for the current request
 	HeaderRateLimitRemaining   = "ratelimit-remaining"   // the number of requests left for the current rate-limit window
@@ -53,40 +54,17 @@ const (
 	DefaultRateLimitHour   = 500 // RateLimit per second if no ratelimits are set in database
 	DefaultRateLimitMonth  = 0   // RateLimit per second if no ratelimits are set in database
 
-	FallbackRateLimitSecond = 20 // RateLimit per second for when redis is offline
-	FallbackRateLimitBurst  = 20 // RateLimit burst for when redis is offline
+	FallbackRateLimitSecond = 20 // RateLimit per second for when the store is offline
+	FallbackRateLimitBurst  = 20 // RateLimit burst for when the store is offline
 
 	defaultWeight = 1         // if no weight is set for a route, use this one
 	defaultBucket = "default" // if no bucket is set for a route, use this one
 
 	statsTruncateDuration = time.Hour * 1 // ratelimit-stats are truncated to this duration
-)
-
-var updateInterval = time.Second * 60 // how often to update ratelimits, weights and stats
-
-var apiProducts = map[string]*ApiProduct{} // key: <bucket>:<product_name>
-var apiProductsMu = &sync.RWMutex{}
-
-var redisClient *redis.Client
-var redisIsHealthy atomic.Bool
-var redisTimeout = time.Second * 1
-
-var lastRateLimitUpdateKeys = time.Unix(0, 0)       // guarded by lastRateLimitUpdateMu
-var lastRateLimitUpdateRateLimits = time.Unix(0, 0) // guarded by lastRateLimitUpdateMu
-var lastRateLimitUpdateMu = &sync.Mutex{}
 
-var fallbackRateLimiter = NewFallbackRateLimiter() // if redis is offline, use this rate limiter
-
-var initializedWg = &sync.WaitGroup{} // wait for everything to be initialized before serving requests
-
-var rateLimitsMu = &sync.RWMutex{}
-var rateLimits = map[string]*RateLimit{}         // guarded by rateLimitsMu
-var rateLimitsByUserId = map[string]*RateLimit{} // guarded by rateLimitsMu, key: <bucket>:<userId>
-var userIdByApiKey = map[string]int64{}          // guarded by rateLimitsMu
-
-var weightsMu = &sync.RWMutex{}
-var weights = map[string]int64{}  // guarded by weightsMu
-var buckets = map[string]string{} // guarded by weightsMu
+	StoreRedis  = "redis"
+	StoreMemory = "memory"
+)
 
 type DbEntry struct {
 	Date     time.Time
@@ -104,17 +82,17 @@ type RateLimit struct {
 }
 
 type RateLimitResult struct {
-	BlockRequest  bool
-	Time          time.Time
-	Weight        int64
-	Route         string
-	IP            string
-	Key           string
-	IsValidKey    bool
-	UserId        int64
-	RedisKeys     []RedisKey
-	RedisStatsKey string
-	RateLimit     *RateLimit
+	BlockRequest bool
+	Time         time.Time
+	Weight       int64
+	Route        string
+	IP           string
+	Key          string
+	IsValidKey   bool
+	UserId       int64
+	Limits       []Limit
+	StatsKey     StatsKey
+	RateLimit    *RateLimit
 
 	Limit       int64
 	LimitSecond int64
@@ -135,11 +113,6 @@ type RateLimitResult struct {
 	Window TimeWindow
 }
 
-type RedisKey struct {
-	Key      string
-	ExpireAt time.Time
-}
-
 type ApiProduct struct {
 	Name          string    `db:"name"`
 	Bucket        string    `db:"bucket"`
@@ -176,6 +149,11 @@ func (r *responseWriterDelegator) Status() int {
 	return r.status
 }
 
+// Unwrap returns the underlying http.ResponseWriter
+func (r *responseWriterDelegator) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 var DefaultRequestFilter = func(req *http.Request) bool {
 	if req.Method == http.MethodOptions {
 		return false
@@ -194,116 +172,210 @@ var DefaultRequestFilter = func(req *http.Request) bool {
 	return true
 }
 
-var requestFilter = DefaultRequestFilter
-var requestFilterMu = &sync.RWMutex{}
+// RateLimiter limits requests by the weights, buckets and ratelimits stored in postgres, the counters are kept in a Store.
+type RateLimiter struct {
+	store          Store
+	storeTimeout   time.Duration
+	storeIsHealthy atomic.Bool
+	updateInterval time.Duration // how often to update ratelimits, weights and stats
+
+	fallbackRateLimiter *FallbackRateLimiter // if the store is offline, use this rate limiter
+	initializedWg       *sync.WaitGroup      // wait for everything to be initialized before serving requests
+
+	apiProducts   map[string]*ApiProduct // key: <bucket>:<product_name>
+	apiProductsMu *sync.RWMutex
 
-func SetRequestFilter(filter func(req *http.Request) bool) {
-	requestFilterMu.Lock()
-	defer requestFilterMu.Unlock()
-	requestFilter = filter
+	lastRateLimitUpdateKeys       time.Time // guarded by lastRateLimitUpdateMu
+	lastRateLimitUpdateRateLimits time.Time // guarded by lastRateLimitUpdateMu
+	lastRateLimitUpdateMu         *sync.Mutex
+
+	rateLimitsMu       *sync.RWMutex
+	rateLimits         map[string]*RateLimit // guarded by rateLimitsMu
+	rateLimitsByUserId map[string]*RateLimit // guarded by rateLimitsMu, key: <bucket>/<userId>
+	userIdByApiKey     map[string]int64      // guarded by rateLimitsMu
+
+	weightsMu *sync.RWMutex
+	weights   map[string]int64  // guarded by weightsMu
+	buckets   map[string]string // guarded by weightsMu
+
+	requestFilter       func(req *http.Request) bool // guarded by requestFilterMu
+	requestFilterMu     *sync.RWMutex
+	maxBadRequestWeight atomic.Int64
 }
 
-func GetRequestFilter() func(req *http.Request) bool {
-	requestFilterMu.RLock()
-	defer requestFilterMu.RUnlock()
-	return requestFilter
+// NewRateLimiter creates a RateLimiter that keeps its counters in the given store. Limits, weights and buckets are empty until Init is called, which makes it possible to use the RateLimiter without a database.
+func NewRateLimiter(store Store) *RateLimiter {
+	rl := &RateLimiter{
+		store:                         store,
+		storeTimeout:                  time.Second * 1,
+		updateInterval:                time.Second * 60,
+		fallbackRateLimiter:           NewFallbackRateLimiter(),
+		initializedWg:                 &sync.WaitGroup{},
+		apiProducts:                   map[string]*ApiProduct{},
+		apiProductsMu:                 &sync.RWMutex{},
+		lastRateLimitUpdateKeys:       time.Unix(0, 0),
+		lastRateLimitUpdateRateLimits: time.Unix(0, 0),
+		lastRateLimitUpdateMu:         &sync.Mutex{},
+		rateLimitsMu:                  &sync.RWMutex{},
+		rateLimits:                    map[string]*RateLimit{},
+		rateLimitsByUserId:            map[string]*RateLimit{},
+		userIdByApiKey:                map[string]int64{},
+		weightsMu:                     &sync.RWMutex{},
+		weights:                       map[string]int64{},
+		buckets:                       map[string]string{},
+		requestFilter:                 DefaultRequestFilter,
+		requestFilterMu:               &sync.RWMutex{},
+	}
+	rl.storeIsHealthy.Store(true)
+	rl.maxBadRequestWeight.Store(1)
+	return rl
 }
 
-var maxBadRequestWeight int64 = 1
+// NewRateLimiterFromConfig creates a RateLimiter with the store that is configured in utils.Config.Frontend.RatelimitStore, defaults to redis.
+func NewRateLimiterFromConfig() (*RateLimiter, error) {
+	var store Store
+	switch utils.Config.Frontend.RatelimitStore {
+	case StoreRedis, "":
+		store = NewRedisStore(redis.NewClient(&redis.Options{
+			Addr:        utils.Config.RedisSessionStoreEndpoint,
+			ReadTimeout: time.Second * 3,
+		}))
+	case StoreMemory:
+		store = NewMemoryStore()
+	default:
+		return nil, fmt.Errorf("unknown ratelimit store %s", utils.Config.Frontend.RatelimitStore)
+	}
+	return NewRateLimiter(store), nil
+}
 
-func SetMaxBadRequestWeight(weight int64) {
-	atomic.StoreInt64(&maxBadRequestWeight, weight)
+func (rl *RateLimiter) SetRequestFilter(filter func(req *http.Request) bool) {
+	rl.requestFilterMu.Lock()
+	defer rl.requestFilterMu.Unlock()
+	rl.requestFilter = filter
 }
 
-func GetMaxBadRequestWeight() int64 {
-	return atomic.LoadInt64(&maxBadRequestWeight)
+func (rl *RateLimiter) GetRequestFilter() func(req *http.Request) bool {
+	rl.requestFilterMu.RLock()
+	defer rl.requestFilterMu.RUnlock()
+	return rl.requestFilter
 }
 
-// Init initializes the RateLimiting middleware, the rateLimiting middleware will not work without calling Init first. The second parameter is a function the will get called on every request, it will only apply ratelimiting to requests when this func returns true.
-func Init() {
-	redisClient = redis.NewClient(&redis.Options{
-		Addr:        utils.Config.RedisSessionStoreEndpoint,
-		ReadTimeout: time.Second * 3,
-	})
+func (rl *RateLimiter) SetMaxBadRequestWeight(weight int64) {
+	rl.maxBadRequestWeight.Store(weight)
+}
+
+func (rl *RateLimiter) GetMaxBadRequestWeight() int64 {
+	return rl.maxBadRequestWeight.Load()
+}
 
-	updateInterval = utils.Config.Frontend.RatelimitUpdateInterval
-	if updateInterval < time.Second {
+// SetWeight sets the weight and bucket of a route, they are overwritten by the next update from the database.
+func (rl *RateLimiter) SetWeight(route string, weight int64, bucket string) {
+	rl.weightsMu.Lock()
+	defer rl.weightsMu.Unlock()
+	rl.weights[route] = weight
+	rl.buckets[route] = bucket
+}
+
+// SetApiKey assigns an api key and its ratelimit in the given bucket to a user, they are overwritten by the next update from the database.
+func (rl *RateLimiter) SetApiKey(apiKey string, userId int64, bucket string, limit *RateLimit) {
+	rl.rateLimitsMu.Lock()
+	defer rl.rateLimitsMu.Unlock()
+	rl.userIdByApiKey[apiKey] = userId
+	rl.rateLimitsByUserId[fmt.Sprintf("%s/%d", bucket, userId)] = limit
+}
+
+// Init loads weights and ratelimits from postgres and keeps them up to date, the RateLimiter will not enforce configured limits without calling Init first.
+func (rl *RateLimiter) Init() {
+	rl.updateInterval = utils.Config.Frontend.RatelimitUpdateInterval
+	if rl.updateInterval < time.Second {
 		log.Warnf("updateInterval is below 1s, setting to 60s")
-		updateInterval = time.Second * 60
+		rl.updateInterval = time.Second * 60
 	}
 
-	redisTimeout = utils.Config.Frontend.RatelimitRedisTimeout
-	if redisTimeout < time.Millisecond*100 {
+	rl.storeTimeout = utils.Config.Frontend.RatelimitRedisTimeout
+	if rl.storeTimeout < time.Millisecond*100 {
 		log.Warnf("redisTimeout is below 100ms, setting to 1s")
-		redisTimeout = time.Millisecond * 1000
+		rl.storeTimeout = time.Millisecond * 1000
 	}
 
-	initializedWg.Add(3)
+	rl.initializedWg.Add(3)
+
+	if collector, ok := rl.store.(StatsCollector); ok {
+		go func() {
+			for {
+				time.Sleep(rl.updateInterval)
+				err := rl.updateCollectedStats(collector)
+				if err != nil {
+					log.Error(err, "error updating stats", 0)
+				}
+			}
+		}()
+	}
 
 	go func() {
 		firstRun := true
 		for {
-			err := updateWeights(firstRun)
+			err := rl.updateWeights(firstRun)
 			if err != nil {
 				log.Error(err, "error updating weights", 0)
 				time.Sleep(time.Second * 2)
 				continue
 			}
 			if firstRun {
-				initializedWg.Done()
+				rl.initializedWg.Done()
 				firstRun = false
 			}
-			time.Sleep(updateInterval)
+			time.Sleep(rl.updateInterval)
 		}
 	}()
 	go func() {
 		firstRun := true
 		for {
-			err := updateRateLimits()
+			err := rl.updateRateLimits()
 			if err != nil {
 				log.Error(err, "error updating ratelimits", 0)
 				time.Sleep(time.Second * 2)
 				continue
 			}
 			if firstRun {
-				initializedWg.Done()
+				rl.initializedWg.Done()
 				firstRun = false
 			}
-			time.Sleep(updateInterval)
+			time.Sleep(rl.updateInterval)
 		}
 	}()
 	go func() {
 		firstRun := true
 		for {
-			updateRedisStatus()
+			rl.updateStoreStatus()
 			if firstRun {
-				initializedWg.Done()
+				rl.initializedWg.Done()
 				firstRun = false
 			}
 			time.Sleep(time.Second * 1)
 		}
 	}()
 
-	initializedWg.Wait()
+	rl.initializedWg.Wait()
 }
 
-// HttpMiddleware returns an http.Handler that can be used as middleware to RateLimit requests. If redis is offline, it will use a fallback rate limiter.
-func HttpMiddleware(next http.Handler) http.Handler {
-	initializedWg.Wait()
+// HttpMiddleware returns an http.Handler that can be used as middleware to RateLimit requests. If the store is offline, it will use a fallback rate limiter.
+func (rl *RateLimiter) HttpMiddleware(next http.Handler) http.Handler {
+	rl.initializedWg.Wait()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		f := GetRequestFilter()
+		f := rl.GetRequestFilter()
 		if !f(r) {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		if !redisIsHealthy.Load() {
+		if !rl.storeIsHealthy.Load() {
 			metrics.Counter.WithLabelValues("ratelimit_fallback").Inc()
-			fallbackRateLimiter.Handle(w, r, next.ServeHTTP)
+			rl.fallbackRateLimiter.Handle(w, r, next.ServeHTTP)
 			return
 		}
 
-		rl, err := rateLimitRequest(r)
+		res, err := rl.rateLimitRequest(r)
 		if err != nil {
 			// just serve the request if there is a problem with getting the rate limit
 			log.Error(err, "error getting rate limit", 0)
@@ -312,34 +384,34 @@ func HttpMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// log.WithFields(log.Fields{"route": rl.Route, "key": rl.Key, "limit": rl.Limit, "remaining": rl.Remaining, "reset": rl.Reset, "window": rl.Window, "validKey": rl.IsValidKey}, "rateLimiting")
+		// log.WithFields(log.Fields{"route": res.Route, "key": res.Key, "limit": res.Limit, "remaining": res.Remaining, "reset": res.Reset, "window": res.Window, "validKey": res.IsValidKey}, "rateLimiting")
 
-		w.Header().Set(HeaderRateLimitLimit, strconv.FormatInt(rl.Limit, 10))
-		w.Header().Set(HeaderRateLimitRemaining, strconv.FormatInt(rl.Remaining, 10))
-		w.Header().Set(HeaderRateLimitReset, strconv.FormatInt(rl.Reset, 10))
+		w.Header().Set(HeaderRateLimitLimit, strconv.FormatInt(res.Limit, 10))
+		w.Header().Set(HeaderRateLimitRemaining, strconv.FormatInt(res.Remaining, 10))
+		w.Header().Set(HeaderRateLimitReset, strconv.FormatInt(res.Reset, 10))
 
-		w.Header().Set(HeaderRateLimitWindow, string(rl.Window))
+		w.Header().Set(HeaderRateLimitWindow, string(res.Window))
 
-		w.Header().Set(HeaderRateLimitLimitMonth, strconv.FormatInt(rl.LimitMonth, 10))
-		w.Header().Set(HeaderRateLimitLimitDay, strconv.FormatInt(rl.LimitDay, 10))
-		w.Header().Set(HeaderRateLimitLimitHour, strconv.FormatInt(rl.LimitHour, 10))
-		w.Header().Set(HeaderRateLimitLimitMinute, strconv.FormatInt(rl.LimitMinute, 10))
-		w.Header().Set(HeaderRateLimitLimitSecond, strconv.FormatInt(rl.LimitSecond, 10))
+		w.Header().Set(HeaderRateLimitLimitMonth, strconv.FormatInt(res.LimitMonth, 10))
+		w.Header().Set(HeaderRateLimitLimitDay, strconv.FormatInt(res.LimitDay, 10))
+		w.Header().Set(HeaderRateLimitLimitHour, strconv.FormatInt(res.LimitHour, 10))
+		w.Header().Set(HeaderRateLimitLimitMinute, strconv.FormatInt(res.LimitMinute, 10))
+		w.Header().Set(HeaderRateLimitLimitSecond, strconv.FormatInt(res.LimitSecond, 10))
 
-		w.Header().Set(HeaderRateLimitRemainingMonth, strconv.FormatInt(rl.RemainingMonth, 10))
-		w.Header().Set(HeaderRateLimitRemainingDay, strconv.FormatInt(rl.RemainingDay, 10))
-		w.Header().Set(HeaderRateLimitRemainingHour, strconv.FormatInt(rl.RemainingHour, 10))
-		w.Header().Set(HeaderRateLimitRemainingMinute, strconv.FormatInt(rl.RemainingMinute, 10))
-		w.Header().Set(HeaderRateLimitRemainingSecond, strconv.FormatInt(rl.RemainingSecond, 10))
+		w.Header().Set(HeaderRateLimitRemainingMonth, strconv.FormatInt(res.RemainingMonth, 10))
+		w.Header().Set(HeaderRateLimitRemainingDay, strconv.FormatInt(res.RemainingDay, 10))
+		w.Header().Set(HeaderRateLimitRemainingHour, strconv.FormatInt(res.RemainingHour, 10))
+		w.Header().Set(HeaderRateLimitRemainingMinute, strconv.FormatInt(res.RemainingMinute, 10))
+		w.Header().Set(HeaderRateLimitRemainingSecond, strconv.FormatInt(res.RemainingSecond, 10))
 
-		w.Header().Set(HeaderRateLimitBucket, rl.Bucket)
-		w.Header().Set(HeaderRateLimitValidApiKey, strconv.FormatBool(rl.IsValidKey))
+		w.Header().Set(HeaderRateLimitBucket, res.Bucket)
+		w.Header().Set(HeaderRateLimitValidApiKey, strconv.FormatBool(res.IsValidKey))
 
-		if rl.BlockRequest {
+		if res.BlockRequest {
 			metrics.Counter.WithLabelValues("ratelimit_block").Inc()
-			w.Header().Set(HeaderRetryAfter, strconv.FormatInt(rl.Reset, 10))
+			w.Header().Set(HeaderRetryAfter, strconv.FormatInt(res.Reset, 10))
 			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
-			err = postRateLimit(rl, http.StatusTooManyRequests)
+			err = rl.postRateLimit(res, http.StatusTooManyRequests)
 			if err != nil {
 				log.Error(err, "error calling postRateLimit", 0)
 			}
@@ -348,7 +420,7 @@ func HttpMiddleware(next http.Handler) http.Handler {
 
 		d := &responseWriterDelegator{ResponseWriter: w}
 		next.ServeHTTP(d, r)
-		err = postRateLimit(rl, d.Status())
+		err = rl.postRateLimit(res, d.Status())
 		if err != nil {
 			log.Error(err, "error calling postRateLimit", 0)
 		}
@@ -356,7 +428,7 @@ func HttpMiddleware(next http.Handler) http.Handler {
 }
 
 // updateWeights gets the weights and buckets from postgres and updates the weights and buckets maps.
-func updateWeights(firstRun bool) error {
+func (rl *RateLimiter) updateWeights(firstRun bool) error {
 	start := time.Now()
 	defer func() {
 		log.InfoWithFields(log.Fields{"duration": time.Since(start)}, "updateWeights")
@@ -373,42 +445,43 @@ func updateWeights(firstRun bool) error {
 	if err != nil {
 		return err
 	}
-	weightsMu.Lock()
-	defer weightsMu.Unlock()
-	oldWeights := weights
-	oldBuckets := buckets
-	weights = make(map[string]int64, len(dbWeights))
+	rl.weightsMu.Lock()
+	defer rl.weightsMu.Unlock()
+	oldWeights := rl.weights
+	oldBuckets := rl.buckets
+	rl.weights = make(map[string]int64, len(dbWeights))
+	rl.buckets = make(map[string]string, len(dbWeights))
 	for _, w := range dbWeights {
-		weights[w.Endpoint] = w.Weight
-		if !firstRun && oldWeights[w.Endpoint] != weights[w.Endpoint] {
+		rl.weights[w.Endpoint] = w.Weight
+		if !firstRun && oldWeights[w.Endpoint] != rl.weights[w.Endpoint] {
 			log.InfoWithFields(log.Fields{"endpoint": w.Endpoint, "weight": w.Weight, "oldWeight": oldWeights[w.Endpoint]}, "weight changed")
 		}
-		buckets[w.Endpoint] = strings.ReplaceAll(w.Bucket, ":", "_")
-		if buckets[w.Endpoint] == "" {
-			buckets[w.Endpoint] = defaultBucket
+		rl.buckets[w.Endpoint] = strings.ReplaceAll(w.Bucket, ":", "_")
+		if rl.buckets[w.Endpoint] == "" {
+			rl.buckets[w.Endpoint] = defaultBucket
 		}
-		if !firstRun && oldBuckets[w.Endpoint] != buckets[w.Endpoint] {
+		if !firstRun && oldBuckets[w.Endpoint] != rl.buckets[w.Endpoint] {
 			log.InfoWithFields(log.Fields{"endpoint": w.Endpoint, "bucket": w.Weight, "oldBucket": oldBuckets[w.Endpoint]}, "bucket changed")
 		}
 	}
 	return nil
 }
 
-// updateRedisStatus checks if redis is healthy and updates redisIsHealthy accordingly.
-func updateRedisStatus() {
-	oldStatus := redisIsHealthy.Load()
+// updateStoreStatus checks if the store is healthy and updates storeIsHealthy accordingly.
+func (rl *RateLimiter) updateStoreStatus() {
+	oldStatus := rl.storeIsHealthy.Load()
 	newStatus := true
-	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(redisTimeout))
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(rl.storeTimeout))
 	defer cancel()
-	err := redisClient.Ping(ctx).Err()
+	err := rl.store.Ping(ctx)
 	if err != nil {
-		log.Error(err, "error pinging redis", 0)
+		log.Error(err, "error pinging ratelimit store", 0)
 		newStatus = false
 	}
 	if oldStatus != newStatus {
-		log.InfoWithFields(log.Fields{"oldStatus": oldStatus, "newStatus": newStatus}, "redis status changed")
+		log.InfoWithFields(log.Fields{"oldStatus": oldStatus, "newStatus": newStatus}, "ratelimit store status changed")
 	}
-	redisIsHealthy.Store(newStatus)
+	rl.storeIsHealthy.Store(newStatus)
 }
 
 // updateStats scans redis for ratelimit:stats:* keys and inserts them into postgres, if the key's truncated date is older than specified stats-truncation it will also delete the key in redis.
@@ -538,6 +611,25 @@ func updateStats(redisClient *redis.Client) error {
 	return nil
 }
 
+// updateCollectedStats inserts the stats of a store that collects them itself into postgres, the stats of ended periods are removed from the store once they are written.
+func (rl *RateLimiter) updateCollectedStats(collector StatsCollector) error {
+	start := time.Now()
+	defer func() {
+		metrics.TaskDuration.WithLabelValues("ratelimit_updateStats").Observe(time.Since(start).Seconds())
+	}()
+
+	entries := collector.CollectStats()
+	if len(entries) == 0 {
+		return nil
+	}
+	err := updateStatsEntries(entries)
+	if err != nil {
+		return fmt.Errorf("error updating stats entries: %w", err)
+	}
+	collector.RemoveStats(start.Truncate(statsTruncateDuration))
+	return nil
+}
+
 func updateStatsEntries(entries []DbEntry) error {
 	tx, err := db.UserWriter.Beginx()
 	if err != nil {
@@ -589,17 +681,17 @@ func updateStatsEntries(entries []DbEntry) error {
 }
 
 // updateRateLimits updates the maps rateLimits, rateLimitsByUserId and userIdByApiKey with data from postgres-tables api_keys and api_ratelimits.
-func updateRateLimits() error {
+func (rl *RateLimiter) updateRateLimits() error {
 	start := time.Now()
 	defer func() {
 		log.InfoWithFields(log.Fields{"duration": time.Since(start)}, "updateRateLimits")
 		metrics.TaskDuration.WithLabelValues("ratelimit_updateRateLimits").Observe(time.Since(start).Seconds())
 	}()
 
-	lastRateLimitUpdateMu.Lock()
-	lastTKeys := lastRateLimitUpdateKeys
-	lastTRateLimits := lastRateLimitUpdateRateLimits
-	lastRateLimitUpdateMu.Unlock()
+	rl.lastRateLimitUpdateMu.Lock()
+	lastTKeys := rl.lastRateLimitUpdateKeys
+	lastTRateLimits := rl.lastRateLimitUpdateRateLimits
+	rl.lastRateLimitUpdateMu.Unlock()
 
 	tx, err := db.UserWriter.Beginx()
 	if err != nil {
@@ -643,23 +735,23 @@ func updateRateLimits() error {
 	if err != nil {
 		return err
 	}
-	apiProductsMu.Lock()
+	rl.apiProductsMu.Lock()
 	for _, dbApiProduct := range dbApiProducts {
-		apiProducts[fmt.Sprintf("%s:%s", dbApiProduct.Bucket, dbApiProduct.Name)] = dbApiProduct
+		rl.apiProducts[fmt.Sprintf("%s:%s", dbApiProduct.Bucket, dbApiProduct.Name)] = dbApiProduct
 	}
-	apiProductsMu.Unlock()
+	rl.apiProductsMu.Unlock()
 
-	rateLimitsMu.Lock()
+	rl.rateLimitsMu.Lock()
 	now := time.Now()
 	for _, dbKey := range dbApiKeys {
 		if dbKey.ChangedAt.After(lastTKeys) {
 			lastTKeys = dbKey.ChangedAt
 		}
 		if dbKey.ValidUntil.Before(now) {
-			delete(userIdByApiKey, dbKey.ApiKey)
+			delete(rl.userIdByApiKey, dbKey.ApiKey)
 			continue
 		}
-		userIdByApiKey[dbKey.ApiKey] = dbKey.UserID
+		rl.userIdByApiKey[dbKey.ApiKey] = dbKey.UserID
 	}
 
 	for _, dbRl := range dbRateLimits {
@@ -668,68 +760,61 @@ func updateRateLimits() error {
 			lastTRateLimits = dbRl.ChangedAt
 		}
 		if dbRl.ValidUntil.Before(now) {
-			delete(rateLimitsByUserId, k)
+			delete(rl.rateLimitsByUserId, k)
 			continue
 		}
 		rlStr := fmt.Sprintf("%d/%d/%d", dbRl.Second, dbRl.Hour, dbRl.Month)
-		rl, exists := rateLimits[rlStr]
+		limit, exists := rl.rateLimits[rlStr]
 		if !exists {
-			rl = &RateLimit{
+			limit = &RateLimit{
 				Second: dbRl.Second,
 				Hour:   dbRl.Hour,
 				Month:  dbRl.Month,
 			}
-			rateLimits[rlStr] = rl
+			rl.rateLimits[rlStr] = limit
 		}
-		rateLimitsByUserId[k] = rl
+		rl.rateLimitsByUserId[k] = limit
 	}
-	rateLimitsMu.Unlock()
+	rl.rateLimitsMu.Unlock()
 	metrics.TaskDuration.WithLabelValues("ratelimit_updateRateLimits_lock").Observe(time.Since(now).Seconds())
 
-	lastRateLimitUpdateMu.Lock()
-	lastRateLimitUpdateKeys = lastTKeys
-	lastRateLimitUpdateRateLimits = lastTRateLimits
-	lastRateLimitUpdateMu.Unlock()
+	rl.lastRateLimitUpdateMu.Lock()
+	rl.lastRateLimitUpdateKeys = lastTKeys
+	rl.lastRateLimitUpdateRateLimits = lastTRateLimits
+	rl.lastRateLimitUpdateMu.Unlock()
 
 	return nil
 }
 
-// postRateLimit decrements the rate limit keys in redis if the status is not 200.
-func postRateLimit(rl *RateLimitResult, status int) error {
+// postRateLimit refunds the consumed weight in the store if the status is 5xx or 429, blocked requests did not consume weight so only their stats are refunded.
+func (rl *RateLimiter) postRateLimit(res *RateLimitResult, status int) error {
 	if !(status >= 500 && status <= 599) && status != 429 {
 		// any statuscode but 5xx or 429 will count towards the ratelimit
 		return nil
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), rl.storeTimeout)
 	defer cancel()
-	pipe := redisClient.Pipeline()
 
-	decrByWeight := rl.Weight
-	mbrw := GetMaxBadRequestWeight()
+	decrByWeight := res.Weight
+	mbrw := rl.GetMaxBadRequestWeight()
 	if decrByWeight > mbrw {
 		decrByWeight = mbrw
 	}
-
-	for _, k := range rl.RedisKeys {
-		pipe.DecrBy(ctx, k.Key, decrByWeight)
-		pipe.ExpireAt(ctx, k.Key, k.ExpireAt) // make sure all keys have a TTL
+	if res.BlockRequest {
+		decrByWeight = 0
 	}
-	pipe.DecrBy(ctx, rl.RedisStatsKey, 1)
-	_, err := pipe.Exec(ctx)
-	if err != nil {
-		return err
-	}
-	return nil
+
+	return rl.store.Refund(ctx, res.Limits, decrByWeight, res.StatsKey)
 }
 
-// rateLimitRequest is the main function for rate limiting, it will check the rate limits for the request and update the rate limits in redis.
-func rateLimitRequest(r *http.Request) (*RateLimitResult, error) {
+// rateLimitRequest is the main function for rate limiting, it will check the rate limits for the request and update the counters in the store.
+func (rl *RateLimiter) rateLimitRequest(r *http.Request) (*RateLimitResult, error) {
 	start := time.Now()
 	defer func() {
 		metrics.TaskDuration.WithLabelValues("ratelimit_rateLimitRequest").Observe(time.Since(start).Seconds())
 	}()
 
-	ctx, cancel := context.WithTimeout(r.Context(), redisTimeout)
+	ctx, cancel := context.WithTimeout(r.Context(), rl.storeTimeout)
 	defer cancel()
 
 	res := &RateLimitResult{}
@@ -739,15 +824,15 @@ func rateLimitRequest(r *http.Request) (*RateLimitResult, error) {
 	res.Key = key
 	res.IP = ip
 
-	weight, route, bucket := getWeight(r)
+	weight, route, bucket := rl.getWeight(r)
 	res.Weight = weight
 	res.Route = route
 	res.Bucket = bucket
 
-	nokeyRatelimit, freeRatelimit := getDefaultRatelimit(bucket)
+	nokeyRatelimit, freeRatelimit := rl.getDefaultRatelimit(bucket)
 
-	rateLimitsMu.RLock()
-	userId, ok := userIdByApiKey[key]
+	rl.rateLimitsMu.RLock()
+	userId, ok := rl.userIdByApiKey[key]
 	if !ok {
 		res.UserId = -1
 		res.IsValidKey = false
@@ -755,103 +840,85 @@ func rateLimitRequest(r *http.Request) (*RateLimitResult, error) {
 	} else {
 		res.UserId = userId
 		res.IsValidKey = true
-		limit, ok := rateLimitsByUserId[fmt.Sprintf("%s/%d", bucket, userId)]
+		limit, ok := rl.rateLimitsByUserId[fmt.Sprintf("%s/%d", bucket, userId)]
 		if ok {
 			res.RateLimit = limit
 		} else {
 			res.RateLimit = freeRatelimit
 		}
 	}
-	rateLimitsMu.RUnlock()
+	rl.rateLimitsMu.RUnlock()
 
 	startUtc := start.UTC()
 	res.Time = startUtc
 
-	nextHourUtc := time.Now().Truncate(time.Hour).Add(time.Hour)
-	nextMonthUtc := time.Date(startUtc.Year(), startUtc.Month()+1, 1, 0, 0, 0, 0, time.UTC)
-
-	timeUntilNextHourUtc := nextHourUtc.Sub(startUtc)
-	timeUntilNextMonthUtc := nextMonthUtc.Sub(startUtc)
-
-	rateLimitSecondKey := fmt.Sprintf("rl:c:s:%s:%d", res.Bucket, res.UserId)
-	rateLimitHourKey := fmt.Sprintf("rl:c:h:%04d-%02d-%02d-%02d:%s:%d", startUtc.Year(), startUtc.Month(), startUtc.Day(), startUtc.Hour(), res.Bucket, res.UserId)
-	rateLimitMonthKey := fmt.Sprintf("rl:c:m:%04d-%02d:%s:%d", startUtc.Year(), startUtc.Month(), res.Bucket, res.UserId)
-	statsKey := fmt.Sprintf("rl:s:%04d-%02d-%02d-%02d:%d:%s:%s:%s", startUtc.Year(), startUtc.Month(), startUtc.Day(), startUtc.Hour(), res.UserId, res.Key, res.Route, res.Bucket)
+	subject := fmt.Sprintf("%s:%d", res.Bucket, res.UserId)
+	res.StatsKey = StatsKey{Time: startUtc.Truncate(statsTruncateDuration), UserId: res.UserId, ApiKey: res.Key, Route: res.Route, Bucket: res.Bucket}
 	if !res.IsValidKey {
-		normedIP := "ip_" + strings.ReplaceAll(ip, ":", "_")
-		rateLimitSecondKey = fmt.Sprintf("rl:c:s:%s:%s", res.Bucket, normedIP)
-		rateLimitHourKey = fmt.Sprintf("rl:c:h:%04d-%02d-%02d-%02d:%s:%s", startUtc.Year(), startUtc.Month(), startUtc.Day(), startUtc.Hour(), res.Bucket, normedIP)
-		rateLimitMonthKey = fmt.Sprintf("rl:c:m:%04d-%02d:%s:%s", startUtc.Year(), startUtc.Month(), res.Bucket, normedIP)
-		statsKey = fmt.Sprintf("rl:s:%04d-%02d-%02d-%02d:%d:%s:%s:%s", startUtc.Year(), startUtc.Month(), startUtc.Day(), startUtc.Hour(), res.UserId, "nokey", res.Route, res.Bucket)
-	}
-	res.RedisStatsKey = statsKey
-
-	pipe := redisClient.Pipeline()
-
-	var rateLimitSecond, rateLimitHour, rateLimitMonth *redis.IntCmd
-
-	if res.RateLimit.Second > 0 {
-		rateLimitSecond = pipe.IncrBy(ctx, rateLimitSecondKey, weight)
-		pipe.ExpireNX(ctx, rateLimitSecondKey, time.Second)
-	}
-
-	if res.RateLimit.Hour > 0 {
-		rateLimitHour = pipe.IncrBy(ctx, rateLimitHourKey, weight)
-		pipe.ExpireAt(ctx, rateLimitHourKey, nextHourUtc.Add(time.Second*60)) // expire 1 minute after the window to make sure we do not miss any requests due to time-sync
-		res.RedisKeys = append(res.RedisKeys, RedisKey{rateLimitHourKey, nextHourUtc.Add(time.Second * 60)})
+		subject = fmt.Sprintf("%s:ip_%s", res.Bucket, strings.ReplaceAll(ip, ":", "_"))
+		res.StatsKey.ApiKey = "nokey"
 	}
 
-	if res.RateLimit.Month > 0 {
-		rateLimitMonth = pipe.IncrBy(ctx, rateLimitMonthKey, weight)
-		pipe.ExpireAt(ctx, rateLimitMonthKey, nextMonthUtc.Add(time.Second*60)) // expire 1 minute after the window to make sure we do not miss any requests due to time-sync
-		res.RedisKeys = append(res.RedisKeys, RedisKey{rateLimitMonthKey, nextMonthUtc.Add(time.Second * 60)})
+	for _, l := range []Limit{
+		{Window: SecondTimeWindow, Max: res.RateLimit.Second},
+		{Window: HourTimeWindow, Max: res.RateLimit.Hour},
+		{Window: MonthTimeWindow, Max: res.RateLimit.Month},
+	} {
+		if l.Max > 0 {
+			l.Subject = subject
+			l.Time = startUtc
+			res.Limits = append(res.Limits, l)
+		}
 	}
 
-	pipe.Incr(ctx, statsKey)
-	_, err := pipe.Exec(ctx)
+	usages, err := rl.store.Take(ctx, res.Limits, weight, res.StatsKey)
 	if err != nil {
 		return nil, err
 	}
+	usage := make(map[TimeWindow]LimitUsage, len(usages))
+	for i, u := range usages {
+		usage[res.Limits[i].Window] = u
+	}
 
-	if res.RateLimit.Month > 0 && rateLimitMonth.Val() > res.RateLimit.Month {
+	if res.RateLimit.Month > 0 && usage[MonthTimeWindow].Used > res.RateLimit.Month {
 		res.Limit = res.RateLimit.Month
 		res.Remaining = 0
-		res.Reset = int64(timeUntilNextMonthUtc.Seconds())
+		res.Reset = resetSeconds(usage[MonthTimeWindow].Reset)
 		res.Window = MonthTimeWindow
 		res.BlockRequest = true
-	} else if res.RateLimit.Hour > 0 && rateLimitHour.Val() > res.RateLimit.Hour {
+	} else if res.RateLimit.Hour > 0 && usage[HourTimeWindow].Used > res.RateLimit.Hour {
 		res.Limit = res.RateLimit.Hour
 		res.Remaining = 0
-		res.Reset = int64(timeUntilNextHourUtc.Seconds())
+		res.Reset = resetSeconds(usage[HourTimeWindow].Reset)
 		res.Window = HourTimeWindow
 		res.BlockRequest = true
-	} else if res.RateLimit.Second > 0 && rateLimitSecond.Val() > res.RateLimit.Second {
+	} else if res.RateLimit.Second > 0 && usage[SecondTimeWindow].Used > res.RateLimit.Second {
 		res.Limit = res.RateLimit.Second
 		res.Remaining = 0
-		res.Reset = int64(1)
+		res.Reset = resetSeconds(usage[SecondTimeWindow].Reset)
 		res.Window = SecondTimeWindow
 		res.BlockRequest = true
 	} else {
 		res.Limit = res.RateLimit.Second
-		res.Remaining = res.RateLimit.Second - rateLimitSecond.Val()
+		res.Remaining = res.RateLimit.Second - usage[SecondTimeWindow].Used
 		res.Reset = int64(1)
 		res.Window = SecondTimeWindow
 	}
 
 	if res.RateLimit.Second > 0 {
-		res.RemainingSecond = res.RateLimit.Second - rateLimitSecond.Val()
+		res.RemainingSecond = res.RateLimit.Second - usage[SecondTimeWindow].Used
 		if res.RemainingSecond < 0 {
 			res.RemainingSecond = 0
 		}
 	}
 	if res.RateLimit.Hour > 0 {
-		res.RemainingHour = res.RateLimit.Hour - rateLimitHour.Val()
+		res.RemainingHour = res.RateLimit.Hour - usage[HourTimeWindow].Used
 		if res.RemainingHour < 0 {
 			res.RemainingHour = 0
 		}
 	}
 	if res.RateLimit.Month > 0 {
-		res.RemainingMonth = res.RateLimit.Month - rateLimitMonth.Val()
+		res.RemainingMonth = res.RateLimit.Month - usage[MonthTimeWindow].Used
 		if res.RemainingMonth < 0 {
 			res.RemainingMonth = 0
 		}
@@ -885,7 +952,12 @@ func rateLimitRequest(r *http.Request) (*RateLimitResult, error) {
 	return res, nil
 }
 
-func getDefaultRatelimit(bucket string) (freeRatelimit, nokeyRatelimit *RateLimit) {
+// resetSeconds rounds the time until a limit resets up to full seconds, at least 1
+func resetSeconds(d time.Duration) int64 {
+	return max(int64(math.Ceil(d.Seconds())), 1)
+}
+
+func (rl *RateLimiter) getDefaultRatelimit(bucket string) (freeRatelimit, nokeyRatelimit *RateLimit) {
 	nokeyRatelimit = &RateLimit{
 		Second: DefaultRateLimitSecond,
 		Hour:   DefaultRateLimitHour,
@@ -898,20 +970,20 @@ func getDefaultRatelimit(bucket string) (freeRatelimit, nokeyRatelimit *RateLimi
 		Month:  DefaultRateLimitMonth,
 	}
 
-	apiProductsMu.RLock()
-	apiProduct, ok := apiProducts[fmt.Sprintf("%s:%s", bucket, "nokey")]
+	rl.apiProductsMu.RLock()
+	apiProduct, ok := rl.apiProducts[fmt.Sprintf("%s:%s", bucket, "nokey")]
 	if ok {
 		nokeyRatelimit.Second = apiProduct.Second
 		nokeyRatelimit.Hour = apiProduct.Hour
 		nokeyRatelimit.Month = apiProduct.Month
 	}
-	apiProduct, ok = apiProducts[fmt.Sprintf("%s:%s", bucket, "free")]
+	apiProduct, ok = rl.apiProducts[fmt.Sprintf("%s:%s", bucket, "free")]
 	if ok {
 		freeRatelimit.Second = apiProduct.Second
 		freeRatelimit.Hour = apiProduct.Hour
 		freeRatelimit.Month = apiProduct.Month
 	}
-	apiProductsMu.RUnlock()
+	rl.apiProductsMu.RUnlock()
 
 	return freeRatelimit, nokeyRatelimit
 }
@@ -945,12 +1017,12 @@ func getKey(r *http.Request) (key, ip string) {
 }
 
 // getWeight returns the weight of an endpoint. if the weight of the endpoint is not defined, it returns 1.
-func getWeight(r *http.Request) (cost int64, identifier, bucket string) {
+func (rl *RateLimiter) getWeight(r *http.Request) (cost int64, identifier, bucket string) {
 	route := getRoute(r)
-	weightsMu.RLock()
-	weight, weightOk := weights[route]
-	bucket, bucketOk := buckets[route]
-	weightsMu.RUnlock()
+	rl.weightsMu.RLock()
+	weight, weightOk := rl.weights[route]
+	bucket, bucketOk := rl.buckets[route]
+	rl.weightsMu.RUnlock()
 	if !weightOk {
 		weight = defaultWeight
 	}
@@ -962,6 +1034,9 @@ func getWeight(r *http.Request) (cost int64, identifier, bucket string) {
 
 func getRoute(r *http.Request) string {
 	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "UNDEFINED"
+	}
 	pathTpl, err := route.GetPathTemplate()
 	if err != nil {
 		return "UNDEFINED"
@@ -1049,17 +1124,17 @@ func (rl *FallbackRateLimiter) Handle(w http.ResponseWriter, r *http.Request, ne
 	next(w, r)
 }
 
-func DBGetUserApiRateLimit(userId int64) (*RateLimit, error) {
-	rl := &RateLimit{}
-	err := db.UserWriter.Get(rl, `
+func (rl *RateLimiter) DBGetUserApiRateLimit(userId int64) (*RateLimit, error) {
+	limit := &RateLimit{}
+	err := db.UserWriter.Get(limit, `
         select second, hour, month
         from api_ratelimits
         where user_id = $1 and bucket = 'default'`, userId)
 	if err != nil && err == sql.ErrNoRows {
-		_, freeRatelimit := getDefaultRatelimit("default")
+		_, freeRatelimit := rl.getDefaultRatelimit("default")
 		return freeRatelimit, nil
 	}
-	return rl, err
+	return limit, err
 }
 
 func DBGetCurrentApiProducts() ([]*ApiProduct, error) {
@@ -1079,7 +1154,7 @@ func DBUpdater() {
 		iv = time.Second * 60
 	}
 	log.InfoWithFields(log.Fields{"redis": utils.Config.RedisSessionStoreEndpoint}, "starting ratelimit-db-updater")
-	redisClient = redis.NewClient(&redis.Options{
+	redisClient := redis.NewClient(&redis.Options{
 		Addr:        utils.Config.RedisSessionStoreEndpoint,
 		ReadTimeout: time.Second * 3,
 	})
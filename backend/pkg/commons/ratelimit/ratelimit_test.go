@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	limits := []Limit{{Window: SecondTimeWindow, Subject: "default:1", Max: 5, Time: now}}
+	stats := StatsKey{Time: now.Truncate(statsTruncateDuration), UserId: 1, ApiKey: "key", Route: "/api/v2/test", Bucket: "default"}
+
+	for i := int64(1); i <= 5; i++ {
+		usage, err := store.Take(context.Background(), limits, 1, stats)
+		require.NoError(t, err)
+		assert.Equal(t, i, usage[0].Used, "burst of max requests should be allowed")
+	}
+	usage, err := store.Take(context.Background(), limits, 1, stats)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), usage[0].Used)
+	assert.Equal(t, 200*time.Millisecond, usage[0].Reset, "limit should allow requests again after one emission interval")
+
+	// denied requests do not consume weight
+	usage, err = store.Take(context.Background(), limits, 1, stats)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), usage[0].Used)
+
+	// the limit is a sliding window, after one interval one more request is allowed
+	now = now.Add(200 * time.Millisecond)
+	usage, err = store.Take(context.Background(), limits, 1, stats)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), usage[0].Used)
+
+	// refunded weight can be used again
+	require.NoError(t, store.Refund(context.Background(), limits, 1, stats))
+	usage, err = store.Take(context.Background(), limits, 1, stats)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), usage[0].Used)
+
+	// a weight counts as multiple requests
+	now = now.Add(400 * time.Millisecond)
+	usage, err = store.Take(context.Background(), limits, 2, stats)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), usage[0].Used)
+
+	// the limit is fully replenished after one period
+	now = now.Add(2 * time.Second)
+	usage, err = store.Take(context.Background(), limits, 1, stats)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage[0].Used)
+
+	entries := store.CollectStats()
+	require.Len(t, entries, 1)
+	assert.Equal(t, DbEntry{Date: stats.Time, UserId: 1, ApiKey: "key", Endpoint: "/api/v2/test", Count: 10, Bucket: "default"}, entries[0])
+	store.RemoveStats(stats.Time.Add(statsTruncateDuration))
+	assert.Empty(t, store.CollectStats())
+}
+
+func TestMemoryStoreMonth(t *testing.T) {
+	now := time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	stats := StatsKey{Time: now.Truncate(statsTruncateDuration), UserId: 1, ApiKey: "key", Route: "/api/v2/test", Bucket: "default"}
+	take := func() LimitUsage {
+		usage, err := store.Take(context.Background(), []Limit{{Window: MonthTimeWindow, Subject: "default:1", Max: 2, Time: now}}, 1, stats)
+		require.NoError(t, err)
+		return usage[0]
+	}
+
+	assert.Equal(t, int64(1), take().Used)
+	assert.Equal(t, int64(2), take().Used)
+	usage := take()
+	assert.Equal(t, int64(3), usage.Used)
+	assert.Equal(t, time.Hour, usage.Reset, "month limits reset at the end of the calendar month")
+
+	// like in redis the month limit is counted per calendar month
+	now = now.Add(time.Hour)
+	assert.Equal(t, int64(1), take().Used)
+}
+
+func TestRateLimiterHttpMiddleware(t *testing.T) {
+	rl := NewRateLimiter(NewMemoryStore())
+	rl.SetApiKey("test-key", 1, defaultBucket, &RateLimit{Second: 2})
+
+	status := http.StatusOK
+	handler := rl.HttpMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	request := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2/test?apikey="+apiKey, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("test-key")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get(HeaderRateLimitLimitSecond))
+	assert.Equal(t, "1", w.Header().Get(HeaderRateLimitRemainingSecond))
+	assert.Equal(t, "true", w.Header().Get(HeaderRateLimitValidApiKey))
+
+	// server errors do not count towards the limit
+	status = http.StatusInternalServerError
+	w = request("test-key")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	status = http.StatusOK
+
+	w = request("test-key")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get(HeaderRateLimitRemainingSecond))
+
+	w = request("test-key")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, string(SecondTimeWindow), w.Header().Get(HeaderRateLimitWindow))
+	assert.NotEmpty(t, w.Header().Get(HeaderRetryAfter))
+
+	// requests without a valid key are limited by ip with the default limits
+	w = request("invalid-key")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "false", w.Header().Get(HeaderRateLimitValidApiKey))
+	assert.Equal(t, "500", w.Header().Get(HeaderRateLimitLimitHour))
+}
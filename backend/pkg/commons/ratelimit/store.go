@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Store keeps the counters of a RateLimiter, implementations must be safe for concurrent use.
+type Store interface {
+	// Take consumes weight from all given limits, counts the request in the stats and returns the usage of each limit after the request.
+	// If the request exceeds one of the limits no weight is consumed, only the stats are counted.
+	Take(ctx context.Context, limits []Limit, weight int64, stats StatsKey) ([]LimitUsage, error)
+	// Refund gives back weight that has previously been consumed by Take and removes the request from the stats.
+	Refund(ctx context.Context, limits []Limit, weight int64, stats StatsKey) error
+	// Ping returns an error if the store is not able to serve requests.
+	Ping(ctx context.Context) error
+}
+
+// StatsCollector is implemented by stores that keep the request statistics themselves, the RateLimiter writes them to postgres.
+// The statistics of the RedisStore are written by DBUpdater instead.
+type StatsCollector interface {
+	// CollectStats returns the request statistics of all periods that have not been removed yet.
+	CollectStats() []DbEntry
+	// RemoveStats removes the request statistics of periods that started before the given time.
+	RemoveStats(before time.Time)
+}
+
+// Limit is a single limit that applies to a request.
+type Limit struct {
+	Window  TimeWindow
+	Subject string    // <bucket>:<userId> or <bucket>:ip_<ip>
+	Max     int64     // must be greater than 0
+	Time    time.Time // time of the request, fixed windows are derived from it
+}
+
+// LimitUsage is the state of a Limit after a request has been taken into account.
+type LimitUsage struct {
+	Used  int64
+	Reset time.Duration // time until the limit allows requests again (or resets)
+}
+
+// StatsKey identifies the counter of the request statistics a request is added to.
+type StatsKey struct {
+	Time   time.Time
+	UserId int64
+	ApiKey string
+	Route  string
+	Bucket string
+}
+
+// String returns the key in the format used by redis: rl:s:<year>-<month>-<day>-<hour>:<userId>:<apikey>:<route>:<bucket>
+func (k StatsKey) String() string {
+	t := k.Time.UTC()
+	return fmt.Sprintf("rl:s:%04d-%02d-%02d-%02d:%d:%s:%s:%s", t.Year(), t.Month(), t.Day(), t.Hour(), k.UserId, k.ApiKey, k.Route, k.Bucket)
+}
+
+// windowPeriod returns the length of a second or hour window, used by stores that do not use fixed windows.
+func windowPeriod(window TimeWindow) time.Duration {
+	switch window {
+	case HourTimeWindow:
+		return time.Hour
+	default:
+		return time.Second
+	}
+}
+
+// windowEnd returns the end of the fixed window the given time belongs to.
+func windowEnd(window TimeWindow, t time.Time) time.Time {
+	t = t.UTC()
+	switch window {
+	case HourTimeWindow:
+		return t.Truncate(time.Hour).Add(time.Hour)
+	case MonthTimeWindow:
+		return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return t.Add(time.Second)
+	}
+}
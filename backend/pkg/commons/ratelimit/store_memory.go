@@ -0,0 +1,175 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the limits of a single instance in memory. The second and hour limits use the generic cell rate algorithm (GCRA),
+// which behaves like a sliding window: a limit of n requests per period allows bursts of n requests and then one request every period/n.
+// The month limit is counted per calendar month like in the RedisStore.
+type MemoryStore struct {
+	mu          sync.Mutex
+	tats        map[string]time.Time // theoretical arrival time per limit, key: <window>:<subject>
+	months      map[string]int64     // usage of month limits, key: <year>-<month>:<subject>
+	stats       map[StatsKey]int64
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tats:   make(map[string]time.Time),
+		months: make(map[string]int64),
+		stats:  make(map[StatsKey]int64),
+		now:    time.Now,
+	}
+}
+
+func monthKey(l Limit) string {
+	return l.Time.UTC().Format("2006-01") + ":" + l.Subject
+}
+
+func (s *MemoryStore) Take(ctx context.Context, limits []Limit, weight int64, stats StatsKey) ([]LimitUsage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.cleanup(now)
+
+	usage := make([]LimitUsage, len(limits))
+	tats := make(map[string]time.Time, len(limits))
+	exceeded := false
+	for i, l := range limits {
+		if l.Window == MonthTimeWindow {
+			used := s.months[monthKey(l)] + weight
+			usage[i] = LimitUsage{Used: used, Reset: windowEnd(l.Window, l.Time).Sub(l.Time)}
+			exceeded = exceeded || used > l.Max
+			continue
+		}
+
+		period := windowPeriod(l.Window)
+		interval := period / time.Duration(l.Max)
+		key := string(l.Window) + ":" + l.Subject
+
+		tat := s.tats[key]
+		if tat.Before(now) {
+			tat = now
+		}
+		tat = tat.Add(interval * time.Duration(weight))
+		tats[key] = tat
+
+		// the weight that has not been emitted yet is the usage of the limit
+		pending := tat.Sub(now)
+		used := int64((pending + interval - 1) / interval)
+		reset := interval
+		if used > l.Max {
+			reset = pending - period
+			exceeded = true
+		}
+		usage[i] = LimitUsage{Used: used, Reset: reset}
+	}
+	s.stats[stats]++
+
+	// denied requests do not consume weight, otherwise clients that keep retrying would never be allowed again
+	if exceeded {
+		return usage, nil
+	}
+	for key, tat := range tats {
+		s.tats[key] = tat
+	}
+	for _, l := range limits {
+		if l.Window == MonthTimeWindow {
+			s.months[monthKey(l)] += weight
+		}
+	}
+	return usage, nil
+}
+
+func (s *MemoryStore) Refund(ctx context.Context, limits []Limit, weight int64, stats StatsKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for _, l := range limits {
+		if l.Window == MonthTimeWindow {
+			key := monthKey(l)
+			if s.months[key] <= weight {
+				delete(s.months, key)
+				continue
+			}
+			s.months[key] -= weight
+			continue
+		}
+
+		interval := windowPeriod(l.Window) / time.Duration(l.Max)
+		key := string(l.Window) + ":" + l.Subject
+		tat, ok := s.tats[key]
+		if !ok {
+			continue
+		}
+		tat = tat.Add(-interval * time.Duration(weight))
+		if tat.Before(now) {
+			delete(s.tats, key)
+			continue
+		}
+		s.tats[key] = tat
+	}
+	if s.stats[stats] > 0 {
+		s.stats[stats]--
+	}
+	return nil
+}
+
+func (s *MemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+// CollectStats returns the request statistics of all periods that have not been removed yet.
+func (s *MemoryStore) CollectStats() []DbEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]DbEntry, 0, len(s.stats))
+	for k, v := range s.stats {
+		entries = append(entries, DbEntry{
+			Date:     k.Time.Truncate(statsTruncateDuration),
+			UserId:   k.UserId,
+			ApiKey:   k.ApiKey,
+			Endpoint: k.Route,
+			Count:    v,
+			Bucket:   k.Bucket,
+		})
+	}
+	return entries
+}
+
+// RemoveStats removes the request statistics of periods that started before the given time.
+func (s *MemoryStore) RemoveStats(before time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.stats {
+		if k.Time.Before(before) {
+			delete(s.stats, k)
+		}
+	}
+}
+
+// cleanup removes limits that are fully replenished or belong to a past month, must be called with s.mu held
+func (s *MemoryStore) cleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < time.Minute {
+		return
+	}
+	s.lastCleanup = now
+	for k, tat := range s.tats {
+		if tat.Before(now) {
+			delete(s.tats, k)
+		}
+	}
+	currentMonth := now.UTC().Format("2006-01")
+	for k := range s.months {
+		if k[:len(currentMonth)] < currentMonth {
+			delete(s.months, k)
+		}
+	}
+}
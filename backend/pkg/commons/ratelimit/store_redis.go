@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisStore keeps fixed-window counters in redis so that limits are shared between all instances of the api.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// key returns the redis key of the counter and when it may expire, keys expire 1 minute after the window to make sure we do not miss any requests due to time-sync
+func (s *RedisStore) key(l Limit) (key string, expireAt time.Time) {
+	t := l.Time.UTC()
+	switch l.Window {
+	case HourTimeWindow:
+		return fmt.Sprintf("rl:c:h:%04d-%02d-%02d-%02d:%s", t.Year(), t.Month(), t.Day(), t.Hour(), l.Subject), windowEnd(l.Window, t).Add(time.Second * 60)
+	case MonthTimeWindow:
+		return fmt.Sprintf("rl:c:m:%04d-%02d:%s", t.Year(), t.Month(), l.Subject), windowEnd(l.Window, t).Add(time.Second * 60)
+	default:
+		return fmt.Sprintf("rl:c:s:%s", l.Subject), time.Time{}
+	}
+}
+
+func (s *RedisStore) expire(ctx context.Context, pipe redis.Pipeliner, l Limit, key string, expireAt time.Time) {
+	if l.Window == SecondTimeWindow {
+		pipe.ExpireNX(ctx, key, time.Second)
+		return
+	}
+	pipe.ExpireAt(ctx, key, expireAt)
+}
+
+func (s *RedisStore) Take(ctx context.Context, limits []Limit, weight int64, stats StatsKey) ([]LimitUsage, error) {
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.IntCmd, len(limits))
+	for i, l := range limits {
+		key, expireAt := s.key(l)
+		cmds[i] = pipe.IncrBy(ctx, key, weight)
+		s.expire(ctx, pipe, l, key, expireAt)
+	}
+	pipe.Incr(ctx, stats.String())
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]LimitUsage, len(limits))
+	exceeded := false
+	for i, l := range limits {
+		usage[i] = LimitUsage{
+			Used:  cmds[i].Val(),
+			Reset: windowEnd(l.Window, l.Time).Sub(l.Time),
+		}
+		exceeded = exceeded || usage[i].Used > l.Max
+	}
+
+	// denied requests do not consume weight
+	if exceeded {
+		pipe := s.client.Pipeline()
+		for _, l := range limits {
+			key, _ := s.key(l)
+			pipe.DecrBy(ctx, key, weight)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+func (s *RedisStore) Refund(ctx context.Context, limits []Limit, weight int64, stats StatsKey) error {
+	pipe := s.client.Pipeline()
+	for _, l := range limits {
+		key, expireAt := s.key(l)
+		pipe.DecrBy(ctx, key, weight)
+		s.expire(ctx, pipe, l, key, expireAt) // make sure all keys have a TTL
+	}
+	pipe.DecrBy(ctx, stats.String(), 1)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (s *RedisStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
@@ -593,6 +593,64 @@ func (client *ErigonClient) GetBalances(pairs []*types.Eth1AddressBalance, addre
 	return ret, nil
 }
 
+// GetBalanceSnapshots retrieves the balances (or total supplies) of the snapshots at the end of their respective block.
+// The balance of snapshots whose request failed stays nil so the caller can retry them, snapshots whose call reverted are marked as such as they would fail on every retry.
+func (client *ErigonClient) GetBalanceSnapshots(snapshots []*types.Eth1BalanceSnapshot) error {
+	batchElements := make([]gethrpc.BatchElem, 0, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		result := ""
+		blockNumber := hexutil.EncodeUint64(snapshot.BlockNumber)
+
+		if len(snapshot.Token) < 20 {
+			batchElements = append(batchElements, gethrpc.BatchElem{
+				Method: "eth_getBalance",
+				Args:   []interface{}{common.BytesToAddress(snapshot.Address), blockNumber},
+				Result: &result,
+			})
+			continue
+		}
+
+		to := common.BytesToAddress(snapshot.Token)
+		msg := ethereum.CallMsg{
+			To:   &to,
+			Gas:  1000000,
+			Data: common.Hex2Bytes(fmt.Sprintf("70a08231000000000000000000000000%x", snapshot.Address)),
+		}
+		if snapshot.TotalSupply {
+			msg.Data = common.Hex2Bytes("18160ddd")
+		}
+		batchElements = append(batchElements, gethrpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{toCallArg(msg), blockNumber},
+			Result: &result,
+		})
+	}
+
+	err := client.rpcClient.BatchCall(batchElements)
+	if err != nil {
+		return fmt.Errorf("error during batch request: %w", err)
+	}
+
+	for i, el := range batchElements {
+		if el.Error != nil && el.Method == "eth_call" && strings.HasPrefix(el.Error.Error(), "execution reverted") {
+			snapshots[i].Balance = nil
+			snapshots[i].Reverted = true
+			continue
+		}
+		if el.Error != nil {
+			log.Warnf("error in batch call: %v", el.Error)
+			snapshots[i].Balance = nil
+			continue
+		}
+
+		res := strings.TrimPrefix(*el.Result.(*string), "0x")
+		snapshots[i].Balance = new(big.Int).SetBytes(common.FromHex(res)).Bytes()
+	}
+
+	return nil
+}
+
 func (client *ErigonClient) GetBalancesForAddresse(address string, tokenStr []string) ([]*types.Eth1AddressBalance, error) {
 	opts := &bind.CallOpts{
 		BlockNumber: nil,
@@ -55,6 +55,7 @@ type GasNowHistory struct {
 	Standard *big.Int
 	Fast     *big.Int
 	Rapid    *big.Int
+	BaseFee  *big.Int
 }
 
 type BulkMutations struct {
@@ -28,6 +28,8 @@ type ClChainConfig struct {
 	CappellaForkEpoch    uint64 `yaml:"CAPELLA_FORK_EPOCH"`
 	DenebForkVersion     string `yaml:"DENEB_FORK_VERSION"`
 	DenebForkEpoch       uint64 `yaml:"DENEB_FORK_EPOCH"`
+	ElectraForkVersion   string `yaml:"ELECTRA_FORK_VERSION"`
+	ElectraForkEpoch     uint64 `yaml:"ELECTRA_FORK_EPOCH"`
 	Eip6110ForkVersion   string `yaml:"EIP6110_FORK_VERSION"`
 	Eip6110ForkEpoch     uint64 `yaml:"EIP6110_FORK_EPOCH"`
 	Eip7002ForkVersion   string `yaml:"EIP7002_FORK_VERSION"`
@@ -85,6 +87,8 @@ type ClChainConfig struct {
 	SafeSlotsToUpdateJustified     uint64 `yaml:"SAFE_SLOTS_TO_UPDATE_JUSTIFIED"`
 	MinDepositAmount               uint64 `yaml:"MIN_DEPOSIT_AMOUNT"`
 	MaxEffectiveBalance            uint64 `yaml:"MAX_EFFECTIVE_BALANCE"`
+	MinActivationBalance           uint64 `yaml:"MIN_ACTIVATION_BALANCE"`
+	MaxEffectiveBalanceElectra     uint64 `yaml:"MAX_EFFECTIVE_BALANCE_ELECTRA"`
 	EffectiveBalanceIncrement      uint64 `yaml:"EFFECTIVE_BALANCE_INCREMENT"`
 	MinAttestationInclusionDelay   uint64 `yaml:"MIN_ATTESTATION_INCLUSION_DELAY"`
 	SlotsPerEpoch                  uint64 `yaml:"SLOTS_PER_EPOCH"`
@@ -139,4 +143,8 @@ type ClChainConfig struct {
 	FieldElementsPerBlob       uint64 `yaml:"FIELD_ELEMENTS_PER_BLOB"`
 	MaxBlobCommitmentsPerBlock uint64 `yaml:"MAX_BLOB_COMMITMENTS_PER_BLOCK"`
 	MaxBlobsPerBlock           uint64 `yaml:"MAX_BLOBS_PER_BLOCK"`
+
+	// electra
+	// https://github.com/ethereum/consensus-specs/blob/dev/presets/mainnet/electra.yaml
+	WhistleblowerRewardQuotientElectra uint64 `yaml:"WHISTLEBLOWER_REWARD_QUOTIENT_ELECTRA"`
 }
@@ -58,6 +58,7 @@ type Config struct {
 		EmulatorPort        int    `yaml:"emulatorPort" envconfig:"BIGTABLE_EMULATOR_PORT"`
 		EmulatorHost        string `yaml:"emulatorHost" envconfig:"BIGTABLE_EMULATOR_HOST"`
 		V2SchemaCutOffEpoch uint64 `yaml:"v2SchemaCutOffEpoch" envconfig:"BIGTABLE_V2_SCHEMA_CUTT_OFF_EPOCH"`
+		BalanceSnapshots    bool   `yaml:"balanceSnapshots" envconfig:"BIGTABLE_BALANCE_SNAPSHOTS"` // mark balance and token supply changes for the balance history, only needed if the snapshots are processed
 	} `yaml:"bigtable"`
 	BlobIndexer struct {
 		S3 struct {
@@ -66,6 +67,7 @@ type Config struct {
 			AccessKeyId     string `yaml:"accessKeyId" envconfig:"BLOB_INDEXER_S3_ACCESS_KEY_ID"`         // s3 access key id
 			AccessKeySecret string `yaml:"accessKeySecret" envconfig:"BLOB_INDEXER_S3_ACCESS_KEY_SECRET"` // s3 access key secret
 		} `yaml:"s3"`
+		LocalPath            string `yaml:"localPath" envconfig:"BLOB_INDEXER_LOCAL_PATH"`                        // store blobs in this directory instead of s3
 		PruneMarginEpochs    uint64 `yaml:"pruneMarginEpochs" envconfig:"BLOB_INDEXER_PRUNE_MARGIN_EPOCHS"`       // PruneMarginEpochs helps blobindexer to decide if connected node has pruned too far to have no holes in the data, set it to same value as lighthouse flag --blob-prune-margin-epochs
 		DisableStatusReports bool   `yaml:"disableStatusReports" envconfig:"BLOB_INDEXER_DISABLE_STATUS_REPORTS"` // disable status reports (no connection to db needed)
 	} `yaml:"blobIndexer"`
@@ -125,6 +127,7 @@ type Config struct {
 		EnsTransformer struct {
 			ValidRegistrarContracts []string `yaml:"validRegistrarContracts" envconfig:"ENS_VALID_REGISTRAR_CONTRACTS"`
 		} `yaml:"ensTransformer"`
+		GasNowFrequency time.Duration `yaml:"gasNowFrequency" envconfig:"INDEXER_GAS_NOW_FREQUENCY"` // interval of the gas oracle, the api derives the staleness of recommendations from it
 	} `yaml:"indexer"`
 	Frontend struct {
 		Debug                          bool   `yaml:"debug" envconfig:"FRONTEND_DEBUG"`
@@ -217,6 +220,7 @@ type Config struct {
 		RatelimitUpdateInterval time.Duration `yaml:"ratelimitUpdateInterval" envconfig:"FRONTEND_RATELIMIT_UPDATE_INTERVAL"`
 		RatelimitEnabled        bool          `yaml:"ratelimitEnabled" envconfig:"FRONTEND_RATELIMIT_ENABLED"`
 		RatelimitRedisTimeout   time.Duration `yaml:"ratelimitRedisTimeout" envconfig:"FRONTEND_RATELIMIT_REDIS_TIMEOUT"`
+		RatelimitStore          string        `yaml:"ratelimitStore" envconfig:"FRONTEND_RATELIMIT_STORE"` // redis (default) or memory
 		SessionSecret           string        `yaml:"sessionSecret" envconfig:"FRONTEND_SESSION_SECRET"`
 		SessionSameSiteNone     bool          `yaml:"sessionSameSiteNone" envconfig:"FRONTEND_SESSION_SAMESITE_NONE"`
 		SessionCookieDomain     string        `yaml:"sessionCookieDomain" envconfig:"FRONTEND_SESSION_COOKIE_DOMAIN"`
@@ -225,16 +229,20 @@ type Config struct {
 		JwtValidityInMinutes    int           `yaml:"jwtValidityInMinutes" envconfig:"FRONTEND_JWT_VALIDITY_INMINUTES"`
 		MaxMailsPerEmailPerDay  int           `yaml:"maxMailsPerEmailPerDay" envconfig:"FRONTEND_MAX_MAIL_PER_EMAIL_PER_DAY"`
 		Mail                    struct {
-			SMTP struct {
+			Provider string `yaml:"provider" envconfig:"FRONTEND_MAIL_PROVIDER"` // smtp, mailgun or maildir, defaults to smtp or mailgun depending on which one is configured
+			Maildir  string `yaml:"maildir" envconfig:"FRONTEND_MAIL_MAILDIR"`   // directory emails are written to by the maildir provider
+			SMTP     struct {
 				Server   string `yaml:"server" envconfig:"FRONTEND_MAIL_SMTP_SERVER"`
 				Host     string `yaml:"host" envconfig:"FRONTEND_MAIL_SMTP_HOST"`
 				User     string `yaml:"user" envconfig:"FRONTEND_MAIL_SMTP_USER"`
 				Password string `yaml:"password" envconfig:"FRONTEND_MAIL_SMTP_PASSWORD"`
 			} `yaml:"smtp"`
 			Mailgun struct {
-				Domain     string `yaml:"domain" envconfig:"FRONTEND_MAIL_MAILGUN_DOMAIN"`
-				PrivateKey string `yaml:"privateKey" envconfig:"FRONTEND_MAIL_MAILGUN_PRIVATE_KEY"`
-				Sender     string `yaml:"sender" envconfig:"FRONTEND_MAIL_MAILGUN_SENDER"`
+				Domain            string `yaml:"domain" envconfig:"FRONTEND_MAIL_MAILGUN_DOMAIN"`
+				PrivateKey        string `yaml:"privateKey" envconfig:"FRONTEND_MAIL_MAILGUN_PRIVATE_KEY"`
+				Sender            string `yaml:"sender" envconfig:"FRONTEND_MAIL_MAILGUN_SENDER"`
+				ApiBase           string `yaml:"apiBase" envconfig:"FRONTEND_MAIL_MAILGUN_API_BASE"` // optional, e.g. the api of the eu region, defaults to the us region
+				WebhookSigningKey string `yaml:"webhookSigningKey" envconfig:"FRONTEND_MAIL_MAILGUN_WEBHOOK_SIGNING_KEY"`
 			} `yaml:"mailgun"`
 			Contact struct {
 				SupportEmail string `yaml:"supportEmail" envconfig:"FRONTEND_MAIL_CONTACT_SUPPORT_EMAIL"`
@@ -299,6 +307,9 @@ type Config struct {
 	MevBoostRelayExporter struct {
 		Enabled bool `yaml:"enabled" envconfig:"MEVBOOSTRELAY_EXPORTER_ENABLED"`
 	} `yaml:"mevBoostRelayExporter"`
+	SlashingRiskDetector struct {
+		Enabled bool `yaml:"enabled" envconfig:"SLASHING_RISK_DETECTOR_ENABLED"`
+	} `yaml:"slashingRiskDetector"`
 	Pprof struct {
 		Enabled bool   `yaml:"enabled" envconfig:"PPROF_ENABLED"`
 		Port    string `yaml:"port" envconfig:"PPROF_PORT"`
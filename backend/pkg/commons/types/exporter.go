@@ -205,12 +205,14 @@ type WithdrawalsByEpoch struct {
 }
 
 type WithdrawalsNotification struct {
-	Slot           uint64 `json:"slot,omitempty"`
-	Index          uint64 `json:"index"`
-	ValidatorIndex uint64 `json:"validatorindex"`
-	Address        []byte `json:"address"`
-	Amount         uint64 `json:"amount"`
-	Pubkey         []byte `json:"pubkey"`
+	Slot                  uint64 `json:"slot,omitempty"`
+	Index                 uint64 `json:"index"`
+	ValidatorIndex        uint64 `json:"validatorindex"`
+	Address               []byte `json:"address"`
+	Amount                uint64 `json:"amount"`
+	Pubkey                []byte `json:"pubkey"`
+	WithdrawalCredentials []byte `json:"withdrawalcredentials"`
+	WithdrawableEpoch     uint64 `json:"withdrawableepoch"`
 }
 
 // Eth1Data is a struct to hold the ETH1 data
@@ -88,7 +88,9 @@ const (
 	SyncCommitteeSoonEventName              EventName = "validator_synccommittee_soon"
 	ValidatorReceivedWithdrawalEventName    EventName = "validator_withdrawal"
 	ValidatorGotSlashedEventName            EventName = "validator_got_slashed"
+	ValidatorSlashingRiskEventName          EventName = "validator_slashing_risk"
 	ValidatorGroupEfficiencyEventName       EventName = "validator_group_efficiency"
+	ValidatorPerformanceAnomalyEventName    EventName = "validator_performance_anomaly"
 	RocketpoolCollateralMinReachedEventName EventName = "rocketpool_colleteral_min" //nolint:misspell
 	RocketpoolCollateralMaxReachedEventName EventName = "rocketpool_colleteral_max" //nolint:misspell
 
@@ -116,6 +118,7 @@ const (
 )
 
 var EventSortOrder = []EventName{
+	ValidatorSlashingRiskEventName,
 	ValidatorUpcomingProposalEventName,
 	ValidatorGotSlashedEventName,
 	ValidatorDidSlashEventName,
@@ -129,6 +132,7 @@ var EventSortOrder = []EventName{
 	ValidatorIsOfflineEventName,
 	ValidatorIsOnlineEventName,
 	ValidatorGroupEfficiencyEventName,
+	ValidatorPerformanceAnomalyEventName,
 	ValidatorReceivedWithdrawalEventName,
 	NetworkLivenessIncreasedEventName,
 	EthClientUpdateEventName,
@@ -175,10 +179,12 @@ var MachineEventsMap = map[EventName]struct{}{
 var LegacyEventLabel map[EventName]string = map[EventName]string{
 	ValidatorUpcomingProposalEventName:       "Your validator(s) will soon propose a block",
 	ValidatorGroupEfficiencyEventName:        "Your validator group efficiency is low",
+	ValidatorPerformanceAnomalyEventName:     "Your validator group performance degraded",
 	ValidatorMissedProposalEventName:         "Your validator(s) missed a proposal",
 	ValidatorExecutedProposalEventName:       "Your validator(s) submitted a proposal",
 	ValidatorMissedAttestationEventName:      "Your validator(s) missed an attestation",
 	ValidatorGotSlashedEventName:             "Your validator(s) got slashed",
+	ValidatorSlashingRiskEventName:           "Your validator(s) are at risk of being slashed",
 	ValidatorDidSlashEventName:               "Your validator(s) slashed another validator",
 	ValidatorIsOfflineEventName:              "Your validator(s) went offline",
 	ValidatorIsOnlineEventName:               "Your validator(s) came back online",
@@ -202,10 +208,12 @@ var LegacyEventLabel map[EventName]string = map[EventName]string{
 var EventLabel map[EventName]string = map[EventName]string{
 	ValidatorUpcomingProposalEventName:       "Upcoming block proposal",
 	ValidatorGroupEfficiencyEventName:        "Low validator group efficiency",
+	ValidatorPerformanceAnomalyEventName:     "Validator group performance anomaly",
 	ValidatorMissedProposalEventName:         "Block proposal missed",
 	ValidatorExecutedProposalEventName:       "Block proposal submitted",
 	ValidatorMissedAttestationEventName:      "Attestation missed",
 	ValidatorGotSlashedEventName:             "Validator slashed",
+	ValidatorSlashingRiskEventName:           "Slashing risk detected",
 	ValidatorDidSlashEventName:               "Validator has slashed",
 	ValidatorIsOfflineEventName:              "Validator offline",
 	ValidatorIsOnlineEventName:               "Validator back online",
@@ -239,9 +247,11 @@ func IsMachineNotification(event EventName) bool {
 var EventNames = []EventName{
 	ValidatorExecutedProposalEventName,
 	ValidatorGroupEfficiencyEventName,
+	ValidatorPerformanceAnomalyEventName,
 	ValidatorMissedProposalEventName,
 	ValidatorMissedAttestationEventName,
 	ValidatorGotSlashedEventName,
+	ValidatorSlashingRiskEventName,
 	ValidatorDidSlashEventName,
 	ValidatorIsOfflineEventName,
 	ValidatorIsOnlineEventName,
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"sort"
@@ -9,6 +11,9 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 type NodeJobStatus string
@@ -22,11 +27,55 @@ type NodeJobType string
 
 const BLSToExecutionChangesNodeJobType NodeJobType = "BLS_TO_EXECUTION_CHANGES"
 const VoluntaryExitsNodeJobType NodeJobType = "VOLUNTARY_EXITS"
+const ExecutionTransactionsNodeJobType NodeJobType = "EXECUTION_TRANSACTIONS"
+const WithdrawalRequestsNodeJobType NodeJobType = "WITHDRAWAL_REQUESTS"
+const ConsolidationRequestsNodeJobType NodeJobType = "CONSOLIDATION_REQUESTS"
 const UnknownNodeJobType NodeJobType = "UNKNOWN"
 
 var NodeJobTypes = []NodeJobType{
 	BLSToExecutionChangesNodeJobType,
 	VoluntaryExitsNodeJobType,
+	ExecutionTransactionsNodeJobType,
+	WithdrawalRequestsNodeJobType,
+	ConsolidationRequestsNodeJobType,
+}
+
+// system contracts that process execution layer triggered requests, see https://eips.ethereum.org/EIPS/eip-7002 and https://eips.ethereum.org/EIPS/eip-7251
+var WithdrawalRequestPredeployAddress = common.HexToAddress("0x00000961Ef480Eb55e80D19ad83579A64c007002")
+var ConsolidationRequestPredeployAddress = common.HexToAddress("0x0000BBdDc7CE488642fb579F8B00f3a590007251")
+
+// WithdrawalRequest is an execution layer triggered exit (Amount = 0) or partial withdrawal (EIP-7002), Amount is in Gwei
+type WithdrawalRequest struct {
+	SourceAddress   common.Address   `json:"source_address"`
+	ValidatorPubkey phase0.BLSPubKey `json:"validator_pubkey"`
+	Amount          uint64           `json:"amount,string"`
+}
+
+// CallData returns the input for a transaction from SourceAddress to the WithdrawalRequestPredeployAddress
+func (r *WithdrawalRequest) CallData() []byte {
+	d := make([]byte, 0, 56)
+	d = append(d, r.ValidatorPubkey[:]...)
+	return binary.BigEndian.AppendUint64(d, r.Amount)
+}
+
+// ConsolidationRequest moves the balance of the source validator to the target validator (EIP-7251).
+// If source and target are the same validator the request switches its withdrawal credentials to compounding (0x02).
+type ConsolidationRequest struct {
+	SourceAddress common.Address   `json:"source_address"`
+	SourcePubkey  phase0.BLSPubKey `json:"source_pubkey"`
+	TargetPubkey  phase0.BLSPubKey `json:"target_pubkey"`
+}
+
+// CallData returns the input for a transaction from SourceAddress to the ConsolidationRequestPredeployAddress
+func (r *ConsolidationRequest) CallData() []byte {
+	d := make([]byte, 0, 96)
+	d = append(d, r.SourcePubkey[:]...)
+	return append(d, r.TargetPubkey[:]...)
+}
+
+// IsSwitchToCompounding returns true if the request only switches the withdrawal credentials of the source validator
+func (r *ConsolidationRequest) IsSwitchToCompounding() bool {
+	return r.SourcePubkey == r.TargetPubkey
 }
 
 func NewNodeJob(data []byte) (*NodeJob, error) {
@@ -99,9 +148,62 @@ func (nj *NodeJob) ParseData() error {
 			return nj.SanitizeRawData()
 		}
 	}
+	{
+		d := []*WithdrawalRequest{}
+		err := unmarshalStrict(nj.RawData, &d)
+		if err == nil && len(d) > 0 {
+			if nj.Type != "" && nj.Type != UnknownNodeJobType && nj.Type != WithdrawalRequestsNodeJobType {
+				return fmt.Errorf("nodejob.RawData mismatches nodejob.Type (%v)", nj.Type)
+			}
+			sort.Slice(d, func(i, j int) bool {
+				return bytes.Compare(d[i].ValidatorPubkey[:], d[j].ValidatorPubkey[:]) < 0
+			})
+			nj.Type = WithdrawalRequestsNodeJobType
+			nj.Data = d
+			return nj.SanitizeRawData()
+		}
+	}
+	{
+		d := []*ConsolidationRequest{}
+		err := unmarshalStrict(nj.RawData, &d)
+		if err == nil && len(d) > 0 {
+			if nj.Type != "" && nj.Type != UnknownNodeJobType && nj.Type != ConsolidationRequestsNodeJobType {
+				return fmt.Errorf("nodejob.RawData mismatches nodejob.Type (%v)", nj.Type)
+			}
+			sort.Slice(d, func(i, j int) bool {
+				return bytes.Compare(d[i].SourcePubkey[:], d[j].SourcePubkey[:]) < 0
+			})
+			nj.Type = ConsolidationRequestsNodeJobType
+			nj.Data = d
+			return nj.SanitizeRawData()
+		}
+	}
+	{
+		// a raw signed execution layer transaction, hex encoded
+		var d hexutil.Bytes
+		err := json.Unmarshal(nj.RawData, &d)
+		if err == nil && len(d) > 0 {
+			if err := new(gethtypes.Transaction).UnmarshalBinary(d); err != nil {
+				return CreateNodeJobUserError{Message: fmt.Sprintf("can not decode transaction: %v", err)}
+			}
+			if nj.Type != "" && nj.Type != UnknownNodeJobType && nj.Type != ExecutionTransactionsNodeJobType {
+				return fmt.Errorf("nodejob.RawData mismatches nodejob.Type (%v)", nj.Type)
+			}
+			nj.Type = ExecutionTransactionsNodeJobType
+			nj.Data = d
+			return nj.SanitizeRawData()
+		}
+	}
 	return CreateNodeJobUserError{Message: "can not unmarshal data: invalid json"}
 }
 
+// unmarshalStrict is used for job-data that can not be told apart from other job-data by its go-type alone
+func unmarshalStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
 func (nj *NodeJob) SanitizeRawData() error {
 	d, err := json.Marshal(nj.Data)
 	if err != nil {
@@ -120,3 +222,25 @@ func (nj NodeJob) GetVoluntaryExitsNodeJobData() (*phase0.SignedVoluntaryExit, b
 	d, ok := nj.Data.(*phase0.SignedVoluntaryExit)
 	return d, ok
 }
+
+func (nj NodeJob) GetExecutionTransactionsNodeJobData() (*gethtypes.Transaction, bool) {
+	d, ok := nj.Data.(hexutil.Bytes)
+	if !ok {
+		return nil, false
+	}
+	tx := new(gethtypes.Transaction)
+	if err := tx.UnmarshalBinary(d); err != nil {
+		return nil, false
+	}
+	return tx, true
+}
+
+func (nj NodeJob) GetWithdrawalRequestsNodeJobData() ([]*WithdrawalRequest, bool) {
+	d, ok := nj.Data.([]*WithdrawalRequest)
+	return d, ok
+}
+
+func (nj NodeJob) GetConsolidationRequestsNodeJobData() ([]*ConsolidationRequest, bool) {
+	d, ok := nj.Data.([]*ConsolidationRequest)
+	return d, ok
+}
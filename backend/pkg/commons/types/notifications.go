@@ -30,8 +30,12 @@ type Subscription struct {
 	DashboardName      string `db:"-"`
 	DashboardGroupId   *int64 `db:"-"`
 	DashboardGroupName string `db:"-"`
+	DashboardTag       string `db:"-"` // {tag_key}={tag_value} if the subscription only applies to the validators of the group with the tag
 }
 
+// PerformanceAnomalyThresholdDefault is the z-score threshold of performance anomaly subscriptions that do not specify one
+const PerformanceAnomalyThresholdDefault = 3.0
+
 type ValidatorDashboard struct {
 	Name   string `db:"name"`
 	Groups map[DashboardGroupId]*ValidatorDashboardGroup
@@ -1588,6 +1588,144 @@ type Eth1AddressBalance struct {
 	Metadata *ERC20Metadata
 }
 
+// Eth1BalanceSnapshot is the balance of an address (or the total supply of a token) at the end of a block
+type Eth1BalanceSnapshot struct {
+	Address     []byte
+	Token       []byte // 0x00 for the native currency
+	TotalSupply bool   // the snapshot holds the total supply of the token instead of the balance of the address
+	BlockNumber uint64
+	Time        uint64
+	Balance     []byte // nil if the balance could not be retrieved from the node
+	Reverted    bool   // the balance call reverted, e.g. because the token does not implement it, so the snapshot has no balance
+}
+
+type Eth1EventLogIndexed struct {
+	TxHash      []byte
+	BlockNumber uint64
+	Time        uint64
+	TxIndex     uint64
+	LogIndex    uint64 // index of the log within its transaction
+	Log         *Eth1Log
+}
+
+// Eth1AddressActivity is a transaction, internal transaction or token transfer of an address, exactly one of the data fields is set
+type Eth1AddressActivity struct {
+	IndexKey string
+	Tx       *Eth1TransactionIndexed
+	Itx      *Eth1InternalTransactionIndexed
+	ERC20    *Eth1ERC20Indexed
+	ERC721   *Eth1ERC721Indexed
+	ERC1155  *ETh1ERC1155Indexed
+}
+
+// Eth1SafeEvent is an owner or threshold change of a Safe multisig, it is stored json encoded
+type Eth1SafeEvent struct {
+	Event       string   `json:"event"`
+	Owners      [][]byte `json:"owners,omitempty"`
+	Threshold   uint64   `json:"threshold,omitempty"`
+	TxHash      []byte   `json:"tx_hash"`
+	BlockNumber uint64   `json:"block_number"`
+	Time        uint64   `json:"time"`
+	TxIndex     uint64   `json:"tx_index"`
+	LogIndex    uint64   `json:"log_index"`
+}
+
+// Eth1SafeConfirmation is an owner that confirmed a safe transaction, either by signature or on-chain approval
+type Eth1SafeConfirmation struct {
+	Owner []byte `json:"owner"`
+	Type  string `json:"type"`
+	// set for on-chain approvals only
+	TxHash      []byte `json:"tx_hash,omitempty"`
+	BlockNumber uint64 `json:"block_number,omitempty"`
+	Time        uint64 `json:"time,omitempty"`
+}
+
+// Eth1SafeExecution is an executed safe transaction, it is stored json encoded.
+// The call details and signatures are only known if the safe was called directly.
+type Eth1SafeExecution struct {
+	Safe          []byte                  `json:"safe"`
+	SafeTxHash    []byte                  `json:"safe_tx_hash"`
+	Success       bool                    `json:"success"`
+	Payment       []byte                  `json:"payment,omitempty"`
+	To            []byte                  `json:"to,omitempty"`
+	Value         []byte                  `json:"value,omitempty"`
+	Data          []byte                  `json:"data,omitempty"`
+	Operation     uint8                   `json:"operation,omitempty"`
+	Confirmations []*Eth1SafeConfirmation `json:"confirmations,omitempty"`
+	TxHash        []byte                  `json:"tx_hash"`
+	BlockNumber   uint64                  `json:"block_number"`
+	Time          uint64                  `json:"time"`
+	TxIndex       uint64                  `json:"tx_index"`
+	LogIndex      uint64                  `json:"log_index"`
+}
+
+// Eth1Safe is the current configuration of a Safe multisig, replayed from its indexed events
+type Eth1Safe struct {
+	Address     []byte
+	Owners      [][]byte
+	Threshold   uint64
+	SetupTxHash []byte // nil if the setup happened before the indexed range
+	SetupBlock  uint64
+	SetupTime   uint64
+}
+
+// EnsName is a validated ens name as stored in the ens table
+type EnsName struct {
+	NameHash    []byte    `db:"name_hash"`
+	Name        string    `db:"ens_name"`
+	Address     []byte    `db:"address"`
+	IsPrimary   bool      `db:"is_primary_name"` // the address reverse resolves to the name
+	ValidTo     time.Time `db:"valid_to"`
+	Resolver    []byte    `db:"resolver"`
+	TextRecords []byte    `db:"text_records"` // json object of the indexed text records
+}
+
+// Eth1Layer2Batch is a batch of a layer 2 network that was submitted to layer 1, it is stored json encoded
+type Eth1Layer2Batch struct {
+	Index               *uint64  `json:"index,omitempty"`
+	Submitter           []byte   `json:"submitter"`
+	DataLocation        string   `json:"data_location"`
+	Size                uint64   `json:"size"`
+	BlobVersionedHashes [][]byte `json:"blob_versioned_hashes,omitempty"`
+	Fee                 []byte   `json:"fee"` // execution and blob fees of the submission
+	TxHash              []byte   `json:"tx_hash"`
+	BlockNumber         uint64   `json:"block_number"`
+	Time                uint64   `json:"time"`
+	TxIndex             uint64   `json:"tx_index"`
+}
+
+// Eth1Layer2Transfer is a bridge message of a layer 2 network that was emitted on layer 1, it is stored json encoded
+type Eth1Layer2Transfer struct {
+	From        []byte `json:"from,omitempty"`
+	To          []byte `json:"to,omitempty"`
+	Value       []byte `json:"value"` // nil if unknown
+	MessageId   []byte `json:"message_id,omitempty"`
+	Status      string `json:"status,omitempty"`
+	TxHash      []byte `json:"tx_hash"`
+	BlockNumber uint64 `json:"block_number"`
+	Time        uint64 `json:"time"`
+	TxIndex     uint64 `json:"tx_index"`
+	LogIndex    uint64 `json:"log_index"`
+}
+
+// Eth1UserOperation is an ERC-4337 user operation that was executed by an entry point, it is stored json encoded
+type Eth1UserOperation struct {
+	Hash          []byte `json:"hash"`
+	EntryPoint    []byte `json:"entry_point"`
+	Sender        []byte `json:"sender"`
+	Paymaster     []byte `json:"paymaster,omitempty"` // empty if the account paid for the gas itself
+	Bundler       []byte `json:"bundler"`             // sender of the transaction
+	Nonce         []byte `json:"nonce"`
+	Success       bool   `json:"success"`
+	ActualGasCost []byte `json:"actual_gas_cost"`
+	ActualGasUsed uint64 `json:"actual_gas_used"`
+	TxHash        []byte `json:"tx_hash"`
+	BlockNumber   uint64 `json:"block_number"`
+	Time          uint64 `json:"time"`
+	TxIndex       uint64 `json:"tx_index"`
+	LogIndex      uint64 `json:"log_index"`
+}
+
 type ERC20TokenPrice struct {
 	Token       []byte
 	Price       []byte
@@ -1620,6 +1758,26 @@ type ContractMetadata struct {
 	ABIJson []byte
 }
 
+type DecodedArgument struct {
+	Name    string
+	Type    string
+	Value   string
+	Indexed bool
+}
+
+const (
+	DecodedSourceABI       = "abi"       // decoded with the abi of the contract
+	DecodedSourceSignature = "signature" // decoded with the text signature only, arguments are unnamed
+)
+
+// DecodedCall is a decoded transaction input or event log
+type DecodedCall struct {
+	Name      string
+	Signature string
+	Arguments []DecodedArgument
+	Source    string
+}
+
 type Eth1TokenPageData struct {
 	Token            string `json:"token"`
 	Address          string `json:"address"`
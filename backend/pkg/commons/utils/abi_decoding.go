@@ -0,0 +1,241 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+var ErrNoMatchingABIEntry = errors.New("no matching abi entry")
+
+// ParseSourcifyMetadata parses the metadata.json of a verified contract as published by sourcify
+func ParseSourcifyMetadata(data []byte) (*types.ContractMetadata, error) {
+	metadata := struct {
+		Output struct {
+			Abi json.RawMessage `json:"abi"`
+		} `json:"output"`
+		Settings struct {
+			CompilationTarget map[string]string `json:"compilationTarget"`
+		} `json:"settings"`
+	}{}
+	err := json.Unmarshal(data, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding sourcify metadata: %w", err)
+	}
+	if len(metadata.Output.Abi) == 0 {
+		return nil, fmt.Errorf("sourcify metadata does not contain an abi")
+	}
+	ret, err := NewContractMetadata("", metadata.Output.Abi)
+	if err != nil {
+		return nil, err
+	}
+	// the compilation target maps the source file to the name of the deployed contract
+	for _, name := range metadata.Settings.CompilationTarget {
+		ret.Name = name
+	}
+	return ret, nil
+}
+
+// NewContractMetadata validates the given abi and returns the contract metadata for it
+func NewContractMetadata(name string, abiJson []byte) (*types.ContractMetadata, error) {
+	contractAbi, err := abi.JSON(strings.NewReader(string(abiJson)))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing abi: %w", err)
+	}
+	return &types.ContractMetadata{
+		Name:    name,
+		ABI:     &contractAbi,
+		ABIJson: abiJson,
+	}, nil
+}
+
+// DecodeMethodInput decodes the input of a transaction using the abi of the called contract
+func DecodeMethodInput(contractAbi *abi.ABI, data []byte) (*types.DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, ErrNoMatchingABIEntry
+	}
+	method, err := contractAbi.MethodById(data[:4])
+	if err != nil {
+		return nil, ErrNoMatchingABIEntry
+	}
+	return decodeMethod(method, data[4:], types.DecodedSourceABI)
+}
+
+// DecodeMethodInputWithSignature decodes the input of a transaction using a text signature like `transfer(address,uint256)`.
+// Argument names are not known in this case.
+func DecodeMethodInputWithSignature(signature string, data []byte) (*types.DecodedCall, error) {
+	name, argTypes, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	args := make(abi.Arguments, len(argTypes))
+	for i, argType := range argTypes {
+		t, err := abi.NewType(argType, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing type %v of signature %v: %w", argType, signature, err)
+		}
+		args[i] = abi.Argument{Type: t}
+	}
+	method := abi.NewMethod(name, name, abi.Function, "", false, false, args, nil)
+	if len(data) < 4 {
+		return nil, ErrNoMatchingABIEntry
+	}
+	return decodeMethod(&method, data[4:], types.DecodedSourceSignature)
+}
+
+func decodeMethod(method *abi.Method, input []byte, source string) (*types.DecodedCall, error) {
+	values, err := method.Inputs.Unpack(input)
+	if err != nil {
+		return nil, fmt.Errorf("error unpacking input of %v: %w", method.Sig, err)
+	}
+	ret := &types.DecodedCall{
+		Name:      method.RawName,
+		Signature: method.Sig,
+		Arguments: make([]types.DecodedArgument, len(values)),
+		Source:    source,
+	}
+	for i, value := range values {
+		ret.Arguments[i] = types.DecodedArgument{
+			Name:  method.Inputs[i].Name,
+			Type:  method.Inputs[i].Type.String(),
+			Value: FormatABIValue(value),
+		}
+	}
+	return ret, nil
+}
+
+// DecodeEventLog decodes the topics and data of a log using the abi of the emitting contract
+func DecodeEventLog(contractAbi *abi.ABI, log *types.Eth1Log) (*types.DecodedCall, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrNoMatchingABIEntry
+	}
+	event, err := contractAbi.EventByID(common.BytesToHash(log.Topics[0]))
+	if err != nil {
+		return nil, ErrNoMatchingABIEntry
+	}
+
+	values := make(map[string]interface{}, len(event.Inputs))
+	if len(log.Data) > 0 {
+		err = event.Inputs.UnpackIntoMap(values, log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error unpacking data of event %v: %w", event.Sig, err)
+		}
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(log.Topics)-1 {
+		return nil, fmt.Errorf("event %v expects %v indexed arguments, log has %v topics", event.Sig, len(indexed), len(log.Topics))
+	}
+	topics := make([]common.Hash, len(log.Topics)-1)
+	for i, topic := range log.Topics[1:] {
+		topics[i] = common.BytesToHash(topic)
+	}
+	err = abi.ParseTopicsIntoMap(values, indexed, topics)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing topics of event %v: %w", event.Sig, err)
+	}
+
+	ret := &types.DecodedCall{
+		Name:      event.RawName,
+		Signature: event.Sig,
+		Arguments: make([]types.DecodedArgument, len(event.Inputs)),
+		Source:    types.DecodedSourceABI,
+	}
+	for i, input := range event.Inputs {
+		ret.Arguments[i] = types.DecodedArgument{
+			Name:    input.Name,
+			Type:    input.Type.String(),
+			Value:   FormatABIValue(values[input.Name]),
+			Indexed: input.Indexed,
+		}
+	}
+	return ret, nil
+}
+
+// parseSignature splits a text signature like `transfer(address,uint256)` into its name and argument types
+func parseSignature(signature string) (string, []string, error) {
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("invalid signature %v", signature)
+	}
+	name := signature[:open]
+	params := signature[open+1 : len(signature)-1]
+	if params == "" {
+		return name, nil, nil
+	}
+
+	// only split on top level commas, tuples may contain commas themselves
+	var argTypes []string
+	depth, start := 0, 0
+	for i, c := range params {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				argTypes = append(argTypes, params[start:i])
+				start = i + 1
+			}
+		}
+	}
+	argTypes = append(argTypes, params[start:])
+	return name, argTypes, nil
+}
+
+// FormatABIValue returns a human readable representation of an unpacked abi value
+func FormatABIValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	case string:
+		return v
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array:
+		// fixed size byte arrays (bytes1 - bytes32)
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = FormatABIValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Struct:
+		items := make([]string, rv.NumField())
+		for i := range items {
+			items[i] = FormatABIValue(rv.Field(i).Interface())
+		}
+		return "(" + strings.Join(items, ", ") + ")"
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testErc20Abi = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}],"anonymous":false}
+]`
+
+var (
+	testRecipient = common.HexToAddress("0x00000000219ab540356cbb839cbe05303d7705fa")
+	// transfer(0x00000000219ab540356cbb839cbe05303d7705fa, 1000)
+	testTransferInput = hexutil.MustDecode("0xa9059cbb00000000000000000000000000000000219ab540356cbb839cbe05303d7705fa00000000000000000000000000000000000000000000000000000000000003e8")
+)
+
+func TestDecodeMethodInput(t *testing.T) {
+	metadata, err := NewContractMetadata("Token", []byte(testErc20Abi))
+	require.NoError(t, err)
+
+	decoded, err := DecodeMethodInput(metadata.ABI, testTransferInput)
+	require.NoError(t, err)
+	assert.Equal(t, types.DecodedSourceABI, decoded.Source)
+	assert.Equal(t, "transfer", decoded.Name)
+	assert.Equal(t, "transfer(address,uint256)", decoded.Signature)
+	assert.Equal(t, []types.DecodedArgument{
+		{Name: "to", Type: "address", Value: testRecipient.Hex()},
+		{Name: "amount", Type: "uint256", Value: "1000"},
+	}, decoded.Arguments)
+
+	_, err = DecodeMethodInput(metadata.ABI, []byte{0x12, 0x34, 0x56, 0x78})
+	assert.ErrorIs(t, err, ErrNoMatchingABIEntry)
+}
+
+func TestDecodeMethodInputWithSignature(t *testing.T) {
+	decoded, err := DecodeMethodInputWithSignature("transfer(address,uint256)", testTransferInput)
+	require.NoError(t, err)
+	assert.Equal(t, "transfer", decoded.Name)
+	assert.Equal(t, types.DecodedSourceSignature, decoded.Source)
+	require.Len(t, decoded.Arguments, 2)
+	assert.Equal(t, "", decoded.Arguments[0].Name)
+	assert.Equal(t, testRecipient.Hex(), decoded.Arguments[0].Value)
+	assert.Equal(t, "1000", decoded.Arguments[1].Value)
+
+	name, argTypes, err := parseSignature("swap((address,uint256),bytes)")
+	require.NoError(t, err)
+	assert.Equal(t, "swap", name)
+	assert.Equal(t, []string{"(address,uint256)", "bytes"}, argTypes)
+}
+
+func TestDecodeEventLog(t *testing.T) {
+	metadata, err := NewContractMetadata("Token", []byte(testErc20Abi))
+	require.NoError(t, err)
+
+	sender := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	log := &types.Eth1Log{
+		Topics: [][]byte{
+			metadata.ABI.Events["Transfer"].ID.Bytes(),
+			common.BytesToHash(sender.Bytes()).Bytes(),
+			common.BytesToHash(testRecipient.Bytes()).Bytes(),
+		},
+		Data: common.LeftPadBytes([]byte{0x03, 0xe8}, 32),
+	}
+	decoded, err := DecodeEventLog(metadata.ABI, log)
+	require.NoError(t, err)
+	assert.Equal(t, "Transfer(address,address,uint256)", decoded.Signature)
+	assert.Equal(t, []types.DecodedArgument{
+		{Name: "from", Type: "address", Value: sender.Hex(), Indexed: true},
+		{Name: "to", Type: "address", Value: testRecipient.Hex(), Indexed: true},
+		{Name: "value", Type: "uint256", Value: "1000"},
+	}, decoded.Arguments)
+}
+
+func TestParseSourcifyMetadata(t *testing.T) {
+	metadata, err := ParseSourcifyMetadata([]byte(`{"compiler":{"version":"0.8.19"},"output":{"abi":` + testErc20Abi + `},"settings":{"compilationTarget":{"src/Token.sol":"Token"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "Token", metadata.Name)
+	assert.Contains(t, metadata.ABI.Methods, "transfer")
+
+	_, err = ParseSourcifyMetadata([]byte(`{"settings":{}}`))
+	assert.Error(t, err)
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/gobitfly/beaconchain/pkg/commons/config"
@@ -196,6 +197,10 @@ func ReadConfig(cfg *types.Config, path string) error {
 		cfg.Frontend.Keywords = "open source ethereum block explorer, ethereum block explorer, beacon chain explorer, ethereum blockchain explorer"
 	}
 
+	if cfg.Indexer.GasNowFrequency == 0 {
+		cfg.Indexer.GasNowFrequency = time.Second * 30
+	}
+
 	if cfg.Frontend.Ratelimits.FreeDay == 0 {
 		cfg.Frontend.Ratelimits.FreeDay = 30000
 	}
@@ -374,6 +379,10 @@ func setCLConfig(cfg *types.Config) error {
 			log.Warnf("DenebForkEpoch not set, defaulting to maxForkEpoch")
 			jr.Data.DenebForkEpoch = &maxForkEpoch
 		}
+		if jr.Data.ElectraForkEpoch == nil {
+			log.Warnf("ElectraForkEpoch not set, defaulting to maxForkEpoch")
+			jr.Data.ElectraForkEpoch = &maxForkEpoch
+		}
 
 		chainCfg := types.ClChainConfig{
 			PresetBase:                              jr.Data.PresetBase,
@@ -393,6 +402,8 @@ func setCLConfig(cfg *types.Config) error {
 			CappellaForkEpoch:                       *jr.Data.CapellaForkEpoch,
 			DenebForkVersion:                        jr.Data.DenebForkVersion,
 			DenebForkEpoch:                          *jr.Data.DenebForkEpoch,
+			ElectraForkVersion:                      jr.Data.ElectraForkVersion,
+			ElectraForkEpoch:                        *jr.Data.ElectraForkEpoch,
 			SecondsPerSlot:                          uint64(jr.Data.SecondsPerSlot),
 			SecondsPerEth1Block:                     uint64(jr.Data.SecondsPerEth1Block),
 			MinValidatorWithdrawabilityDelay:        uint64(jr.Data.MinValidatorWithdrawabilityDelay),
@@ -417,6 +428,8 @@ func setCLConfig(cfg *types.Config) error {
 			SafeSlotsToUpdateJustified:              uint64(jr.Data.SafeSlotsToUpdateJustified),
 			MinDepositAmount:                        uint64(jr.Data.MinDepositAmount),
 			MaxEffectiveBalance:                     uint64(jr.Data.MaxEffectiveBalance),
+			MinActivationBalance:                    uint64(jr.Data.MinActivationBalance),
+			MaxEffectiveBalanceElectra:              uint64(jr.Data.MaxEffectiveBalanceElectra),
 			EffectiveBalanceIncrement:               uint64(jr.Data.EffectiveBalanceIncrement),
 			MinAttestationInclusionDelay:            uint64(jr.Data.MinAttestationInclusionDelay),
 			SlotsPerEpoch:                           uint64(jr.Data.SlotsPerEpoch),
@@ -431,6 +444,7 @@ func setCLConfig(cfg *types.Config) error {
 			ValidatorRegistryLimit:                  uint64(jr.Data.ValidatorRegistryLimit),
 			BaseRewardFactor:                        uint64(jr.Data.BaseRewardFactor),
 			WhistleblowerRewardQuotient:             uint64(jr.Data.WhistleblowerRewardQuotient),
+			WhistleblowerRewardQuotientElectra:      uint64(jr.Data.WhistleblowerRewardQuotientElectra),
 			ProposerRewardQuotient:                  uint64(jr.Data.ProposerRewardQuotient),
 			InactivityPenaltyQuotient:               uint64(jr.Data.InactivityPenaltyQuotient),
 			MinSlashingPenaltyQuotient:              uint64(jr.Data.MinSlashingPenaltyQuotient),
@@ -483,6 +497,22 @@ func setCLConfig(cfg *types.Config) error {
 		cfg.Chain.ClConfig = *chainConfig
 	}
 
+	if cfg.Chain.ClConfig.ElectraForkVersion == "" {
+		// chain configs that predate electra do not specify the fork
+		cfg.Chain.ClConfig.ElectraForkEpoch = uint64(18446744073709551615)
+	}
+	// EIP-7251 splits MAX_EFFECTIVE_BALANCE into the balance required for activation and the maximum for compounding validators
+	if cfg.Chain.ClConfig.MinActivationBalance == 0 {
+		cfg.Chain.ClConfig.MinActivationBalance = cfg.Chain.ClConfig.MaxEffectiveBalance
+	}
+	if cfg.Chain.ClConfig.MaxEffectiveBalanceElectra == 0 {
+		cfg.Chain.ClConfig.MaxEffectiveBalanceElectra = cfg.Chain.ClConfig.MinActivationBalance * 64
+	}
+	// EIP-7251 also lowers the whistleblower reward so that slashing compounding validators does not pay out more
+	if cfg.Chain.ClConfig.WhistleblowerRewardQuotientElectra == 0 {
+		cfg.Chain.ClConfig.WhistleblowerRewardQuotientElectra = 4096
+	}
+
 	// rewrite to match to allow trace as well
 	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
 	case "trace":
@@ -4,11 +4,13 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/prysmaticlabs/go-ssz"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
@@ -238,3 +240,49 @@ func FirstEpochOfSyncPeriod(syncPeriod uint64) uint64 {
 func SlotsPerSyncCommittee() uint64 {
 	return Config.Chain.ClConfig.EpochsPerSyncCommitteePeriod * Config.Chain.ClConfig.SlotsPerEpoch
 }
+
+// HasBLSWithdrawalCredential returns true if the withdrawal credentials still have the BLS (0x00) prefix and thus require a BLS to execution change before withdrawals are possible
+func HasBLSWithdrawalCredential(withdrawalCredentials []byte) bool {
+	return len(withdrawalCredentials) == 32 && withdrawalCredentials[0] == 0x00
+}
+
+// HasCompoundingWithdrawalCredential returns true if the withdrawal credentials have the compounding (0x02) prefix introduced by EIP-7251
+func HasCompoundingWithdrawalCredential(withdrawalCredentials []byte) bool {
+	return len(withdrawalCredentials) == 32 && withdrawalCredentials[0] == 0x02
+}
+
+// GetMaxEffectiveBalance returns the maximum effective balance of a validator with the given withdrawal credentials,
+// which is also the balance above which the validator is swept by partial withdrawals
+func GetMaxEffectiveBalance(withdrawalCredentials []byte) uint64 {
+	if HasCompoundingWithdrawalCredential(withdrawalCredentials) {
+		return Config.Chain.ClConfig.MaxEffectiveBalanceElectra
+	}
+	return Config.Chain.ClConfig.MinActivationBalance
+}
+
+func IsElectraEpoch(epoch uint64) bool {
+	return epoch >= Config.Chain.ClConfig.ElectraForkEpoch
+}
+
+// blob base fee update fraction of EIP-7691, which raised the blob target with prague
+const blobBaseFeeUpdateFractionPrague = 5007716
+
+// CalcBlobBaseFee returns the blob base fee for the excess blob gas of a block in the given epoch, prague (activated
+// together with electra) changed the update fraction so the cancun formula of geth is only used before electra
+func CalcBlobBaseFee(excessBlobGas uint64, epoch uint64) *big.Int {
+	if !IsElectraEpoch(epoch) {
+		return eip4844.CalcBlobFee(excessBlobGas)
+	}
+	// fake exponential of EIP-4844 with a minimum blob base fee of 1 wei
+	numerator := new(big.Int).SetUint64(excessBlobGas)
+	denominator := big.NewInt(blobBaseFeeUpdateFractionPrague)
+	output := new(big.Int)
+	accum := new(big.Int).Set(denominator)
+	for i := 1; accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(int64(i)))
+	}
+	return output.Div(output, denominator)
+}
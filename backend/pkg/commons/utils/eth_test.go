@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcBlobBaseFee(t *testing.T) {
+	previous := Config
+	Config = &types.Config{}
+	Config.Chain.ClConfig.ElectraForkEpoch = 100
+	t.Cleanup(func() { Config = previous })
+
+	excessBlobGas := uint64(10_000_000)
+	assert.Equal(t, eip4844.CalcBlobFee(excessBlobGas), CalcBlobBaseFee(excessBlobGas, 99), "the cancun fraction applies before electra")
+	// e^(10000000/5007716) = 7.36
+	assert.Equal(t, big.NewInt(7), CalcBlobBaseFee(excessBlobGas, 100))
+	assert.Equal(t, big.NewInt(1), CalcBlobBaseFee(0, 100))
+}
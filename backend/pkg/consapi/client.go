@@ -60,6 +60,15 @@ type ClientInt interface {
 	// /eth/v1/beacon/genesis
 	GetGenesis() (*types.StandardGenesisResponse, error)
 
+	// /eth/v1/beacon/states/{state_id}/pending_deposits
+	GetPendingDeposits(stateID any) (*types.StandardPendingDepositsResponse, error)
+
+	// /eth/v1/beacon/states/{state_id}/pending_partial_withdrawals
+	GetPendingPartialWithdrawals(stateID any) (*types.StandardPendingPartialWithdrawalsResponse, error)
+
+	// /eth/v1/beacon/states/{state_id}/pending_consolidations
+	GetPendingConsolidations(stateID any) (*types.StandardPendingConsolidationsResponse, error)
+
 	// /eth/v1/events
 	GetEvents(topics []types.EventTopic) chan *types.EventResponse
 }
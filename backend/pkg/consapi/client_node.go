@@ -144,6 +144,21 @@ func (r *NodeClient) GetGenesis() (*types.StandardGenesisResponse, error) {
 	return network.Get[types.StandardGenesisResponse](r.httpClient, requestURL)
 }
 
+func (r *NodeClient) GetPendingDeposits(stateID any) (*types.StandardPendingDepositsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/pending_deposits", r.Endpoint, stateID)
+	return network.Get[types.StandardPendingDepositsResponse](r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetPendingPartialWithdrawals(stateID any) (*types.StandardPendingPartialWithdrawalsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/pending_partial_withdrawals", r.Endpoint, stateID)
+	return network.Get[types.StandardPendingPartialWithdrawalsResponse](r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetPendingConsolidations(stateID any) (*types.StandardPendingConsolidationsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/pending_consolidations", r.Endpoint, stateID)
+	return network.Get[types.StandardPendingConsolidationsResponse](r.httpClient, requestURL)
+}
+
 func (r *NodeClient) GetEvents(topics []types.EventTopic) chan *types.EventResponse {
 	joinedTopics := strings.Join(utils.ConvertToStringSlice(topics), ",")
 	requestURL := fmt.Sprintf("%s/eth/v1/events?topics=%v", r.Endpoint, joinedTopics)
@@ -8,9 +8,9 @@ import (
 type EventTopic string
 
 const (
-	EventHead  EventTopic = "head"
-	EventBlock EventTopic = "block"
-	// EventAttestation                 EventTopic = "attestation"
+	EventHead        EventTopic = "head"
+	EventBlock       EventTopic = "block"
+	EventAttestation EventTopic = "attestation"
 	// EventVoluntaryExit               EventTopic = "voluntary_exit"
 	// EventBlsToExecutionChange        EventTopic = "bls_to_execution_change"
 	EventFinalizedCheckpoint EventTopic = "finalized_checkpoint"
@@ -43,6 +43,14 @@ func (e EventResponse) Block() (*StandardEventBlockResponse, error) {
 	return utils.UnmarshalOld[StandardEventBlockResponse](e.Data, e.Error)
 }
 
+// Helper to get Attestation response type, returns nil if it is not an attestation event
+func (e EventResponse) Attestation() (*Attestation, error) {
+	if e.Event != EventAttestation {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[Attestation](e.Data, e.Error)
+}
+
 // Helper to get ChainReorg response type, returns nil if it is not a chain reorg event
 func (e EventResponse) ChainReorg() (*StandardEventChainReorg, error) {
 	if e.Event != EventChainReorg {
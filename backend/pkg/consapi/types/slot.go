@@ -119,21 +119,23 @@ func (a *AttesterSlashing) GetSlashedIndices() []uint64 {
 }
 
 type Attestation struct {
-	AggregationBits hexutil.Bytes `json:"aggregation_bits"`
-	Signature       hexutil.Bytes `json:"signature"`
-	Data            struct {
-		Slot            uint64        `json:"slot,string"`
-		Index           uint16        `json:"index,string"`
-		BeaconBlockRoot hexutil.Bytes `json:"beacon_block_root"`
-		Source          struct {
-			Epoch uint64        `json:"epoch,string"`
-			Root  hexutil.Bytes `json:"root"`
-		} `json:"source"`
-		Target struct {
-			Epoch uint64        `json:"epoch,string"`
-			Root  hexutil.Bytes `json:"root"`
-		} `json:"target"`
-	} `json:"data"`
+	AggregationBits hexutil.Bytes   `json:"aggregation_bits"`
+	Signature       hexutil.Bytes   `json:"signature"`
+	Data            AttestationData `json:"data"`
+	CommitteeBits   hexutil.Bytes   `json:"committee_bits,omitempty"` // electra
+}
+
+type AttestationData struct {
+	Slot            uint64        `json:"slot,string"`
+	Index           uint16        `json:"index,string"`
+	BeaconBlockRoot hexutil.Bytes `json:"beacon_block_root"`
+	Source          Checkpoint    `json:"source"`
+	Target          Checkpoint    `json:"target"`
+}
+
+type Checkpoint struct {
+	Epoch uint64        `json:"epoch,string"`
+	Root  hexutil.Bytes `json:"root"`
 }
 
 type Deposit struct {
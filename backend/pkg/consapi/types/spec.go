@@ -23,6 +23,8 @@ type StandardSpec struct {
 	CapellaForkEpoch                        *uint64  `json:"CAPELLA_FORK_EPOCH,string"`
 	DenebForkVersion                        string   `json:"DENEB_FORK_VERSION"`
 	DenebForkEpoch                          *uint64  `json:"DENEB_FORK_EPOCH,string"`
+	ElectraForkVersion                      string   `json:"ELECTRA_FORK_VERSION"`
+	ElectraForkEpoch                        *uint64  `json:"ELECTRA_FORK_EPOCH,string"`
 	SecondsPerSlot                          int64    `json:"SECONDS_PER_SLOT,string"`
 	SecondsPerEth1Block                     int64    `json:"SECONDS_PER_ETH1_BLOCK,string"`
 	MinValidatorWithdrawabilityDelay        int64    `json:"MIN_VALIDATOR_WITHDRAWABILITY_DELAY,string"`
@@ -57,6 +59,8 @@ type StandardSpec struct {
 	SafeSlotsToUpdateJustified              int64    `json:"SAFE_SLOTS_TO_UPDATE_JUSTIFIED,string"`
 	MinDepositAmount                        int64    `json:"MIN_DEPOSIT_AMOUNT,string"`
 	MaxEffectiveBalance                     int64    `json:"MAX_EFFECTIVE_BALANCE,string"`
+	MinActivationBalance                    int64    `json:"MIN_ACTIVATION_BALANCE,string"`
+	MaxEffectiveBalanceElectra              int64    `json:"MAX_EFFECTIVE_BALANCE_ELECTRA,string"`
 	EffectiveBalanceIncrement               int64    `json:"EFFECTIVE_BALANCE_INCREMENT,string"`
 	MinAttestationInclusionDelay            int64    `json:"MIN_ATTESTATION_INCLUSION_DELAY,string"`
 	SlotsPerEpoch                           int64    `json:"SLOTS_PER_EPOCH,string"`
@@ -71,6 +75,7 @@ type StandardSpec struct {
 	ValidatorRegistryLimit                  int64    `json:"VALIDATOR_REGISTRY_LIMIT,string"`
 	BaseRewardFactor                        int64    `json:"BASE_REWARD_FACTOR,string"`
 	WhistleblowerRewardQuotient             int64    `json:"WHISTLEBLOWER_REWARD_QUOTIENT,string"`
+	WhistleblowerRewardQuotientElectra      int64    `json:"WHISTLEBLOWER_REWARD_QUOTIENT_ELECTRA,string"`
 	ProposerRewardQuotient                  int64    `json:"PROPOSER_REWARD_QUOTIENT,string"`
 	InactivityPenaltyQuotient               int64    `json:"INACTIVITY_PENALTY_QUOTIENT,string"`
 	MinSlashingPenaltyQuotient              int64    `json:"MIN_SLASHING_PENALTY_QUOTIENT,string"`
@@ -79,3 +79,43 @@ type StandardValidatorBalancesResponse struct {
 		Balance uint64 `json:"balance,string"`
 	} `json:"data"`
 }
+
+// /eth/v1/beacon/states/{state_id}/pending_deposits
+type StandardPendingDepositsResponse struct {
+	ExecutionOptimistic bool             `json:"execution_optimistic"`
+	Finalized           bool             `json:"finalized"`
+	Data                []PendingDeposit `json:"data"`
+}
+
+type PendingDeposit struct {
+	Pubkey                hexutil.Bytes `json:"pubkey"`
+	WithdrawalCredentials hexutil.Bytes `json:"withdrawal_credentials"`
+	Amount                uint64        `json:"amount,string"`
+	Signature             hexutil.Bytes `json:"signature"`
+	Slot                  uint64        `json:"slot,string"`
+}
+
+// /eth/v1/beacon/states/{state_id}/pending_partial_withdrawals
+type StandardPendingPartialWithdrawalsResponse struct {
+	ExecutionOptimistic bool                       `json:"execution_optimistic"`
+	Finalized           bool                       `json:"finalized"`
+	Data                []PendingPartialWithdrawal `json:"data"`
+}
+
+type PendingPartialWithdrawal struct {
+	ValidatorIndex    uint64 `json:"validator_index,string"`
+	Amount            uint64 `json:"amount,string"`
+	WithdrawableEpoch uint64 `json:"withdrawable_epoch,string"`
+}
+
+// /eth/v1/beacon/states/{state_id}/pending_consolidations
+type StandardPendingConsolidationsResponse struct {
+	ExecutionOptimistic bool                   `json:"execution_optimistic"`
+	Finalized           bool                   `json:"finalized"`
+	Data                []PendingConsolidation `json:"data"`
+}
+
+type PendingConsolidation struct {
+	SourceIndex uint64 `json:"source_index,string"`
+	TargetIndex uint64 `json:"target_index,string"`
+}
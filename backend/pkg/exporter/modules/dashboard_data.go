@@ -1,6 +1,7 @@
 package modules
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
 	"math"
@@ -387,6 +388,8 @@ func (d *dashboardData) epochDataFetcher(epochs []uint64, epochFetchParallelism
 			// provide data from the previous epochs
 			for i := 1; i < len(datas); i++ {
 				datas[i].lastEpochStateEnd = datas[i-1].currentEpochStateEnd
+				datas[i].lastEpochPendingDeposits = datas[i-1].currentEpochPendingDeposits
+				datas[i].lastEpochPendingConsolidations = datas[i-1].currentEpochPendingConsolidations
 
 				for slot := range datas[i-1].missedslots {
 					datas[i].missedslots[slot] = true
@@ -1075,9 +1078,65 @@ type Data struct {
 
 	// Contains the validator state of the epoch where the current sync committee election took place
 	syncCommitteeElectedState *constypes.StandardValidatorsResponse
+
+	// Since electra deposits and consolidations are queued in the state and applied during epoch processing.
+	// Only set for epochs after the fork, the queues of the previous state are nil for the fork epoch itself (see electraUpgradePendingDeposits).
+	lastEpochPendingDeposits          *constypes.StandardPendingDepositsResponse
+	currentEpochPendingDeposits       *constypes.StandardPendingDepositsResponse
+	lastEpochPendingConsolidations    *constypes.StandardPendingConsolidationsResponse
+	currentEpochPendingConsolidations *constypes.StandardPendingConsolidationsResponse
+}
+
+func pendingDepositKey(deposit *constypes.PendingDeposit) string {
+	return fmt.Sprintf("%x:%x:%d:%d", deposit.Pubkey, deposit.Signature, deposit.Amount, deposit.Slot)
+}
+
+// g2PointAtInfinity is the signature of pending deposits that move the balance of an existing validator instead of depositing new funds
+var g2PointAtInfinity = append([]byte{0xc0}, make([]byte, 95)...)
+
+// isBalanceMovementDeposit reports whether the deposit was queued by upgrade_to_electra or by a switch to compounding withdrawal credentials.
+// The balance of such deposits left the validator when they were queued, so they are no new deposits.
+func isBalanceMovementDeposit(deposit *constypes.PendingDeposit) bool {
+	return deposit.Slot == 0 && bytes.Equal(deposit.Signature, g2PointAtInfinity)
 }
 
-const MAX_EFFECTIVE_BALANCE = 32e9
+// electraUpgradePendingDeposits returns the pending deposits that upgrade_to_electra queues at the start of the fork epoch, given the state before the fork:
+// the whole balance of validators that are not activated yet and the balance above MIN_ACTIVATION_BALANCE of compounding validators
+func electraUpgradePendingDeposits(state *constypes.StandardValidatorsResponse) *constypes.StandardPendingDepositsResponse {
+	preActivation := make([]*constypes.StandardValidator, 0)
+	for i := range state.Data {
+		if state.Data[i].Validator.ActivationEpoch == db.FarFutureEpoch {
+			preActivation = append(preActivation, &state.Data[i])
+		}
+	}
+	sort.Slice(preActivation, func(i, j int) bool {
+		if preActivation[i].Validator.ActivationEligibilityEpoch != preActivation[j].Validator.ActivationEligibilityEpoch {
+			return preActivation[i].Validator.ActivationEligibilityEpoch < preActivation[j].Validator.ActivationEligibilityEpoch
+		}
+		return preActivation[i].Index < preActivation[j].Index
+	})
+
+	result := &constypes.StandardPendingDepositsResponse{}
+	for _, v := range preActivation {
+		result.Data = append(result.Data, constypes.PendingDeposit{
+			Pubkey:                v.Validator.Pubkey,
+			WithdrawalCredentials: v.Validator.WithdrawalCredentials,
+			Amount:                v.Balance,
+			Signature:             g2PointAtInfinity,
+		})
+	}
+	for _, v := range state.Data {
+		if len(v.Validator.WithdrawalCredentials) > 0 && v.Validator.WithdrawalCredentials[0] == 2 && v.Balance > utils.Config.Chain.ClConfig.MinActivationBalance {
+			result.Data = append(result.Data, constypes.PendingDeposit{
+				Pubkey:                v.Validator.Pubkey,
+				WithdrawalCredentials: v.Validator.WithdrawalCredentials,
+				Amount:                v.Balance - utils.Config.Chain.ClConfig.MinActivationBalance,
+				Signature:             g2PointAtInfinity,
+			})
+		}
+	}
+	return result
+}
 
 // Data for a single validator
 // use skipSerialCalls = false if you are not sure what you are doing. This flag is mainly
@@ -1185,6 +1244,27 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 		return nil
 	})
 
+	postElectra := utils.IsElectraEpoch(epoch)
+	if postElectra {
+		errGroup.Go(func() error {
+			// retrieve the pending queues at the end of the epoch
+			start := time.Now()
+			var err error
+			result.currentEpochPendingDeposits, err = cl.GetPendingDeposits(lastSlotOfEpoch)
+			if err != nil {
+				d.log.Error(err, "can not get pending deposits", 0, map[string]interface{}{"lastSlotOfEpoch": lastSlotOfEpoch})
+				return err
+			}
+			result.currentEpochPendingConsolidations, err = cl.GetPendingConsolidations(lastSlotOfEpoch)
+			if err != nil {
+				d.log.Error(err, "can not get pending consolidations", 0, map[string]interface{}{"lastSlotOfEpoch": lastSlotOfEpoch})
+				return err
+			}
+			d.log.Debugf("retrieved end pending queues using state at slot %d in %v", lastSlotOfEpoch, time.Since(start))
+			return nil
+		})
+	}
+
 	// if this flag is used the caller must provide startBalance from the previous epoch themselves
 	// as well as providing the missedslots data from the previous epoch
 	if !skipSerialCalls {
@@ -1206,6 +1286,26 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 			return nil
 		})
 
+		if postElectra && epoch > 0 && utils.IsElectraEpoch(epoch-1) {
+			errGroup.Go(func() error {
+				// retrieve the pending queues at the start of the epoch
+				start := time.Now()
+				var err error
+				result.lastEpochPendingDeposits, err = cl.GetPendingDeposits(lastSlotOfPreviousEpoch)
+				if err != nil {
+					d.log.Error(err, "can not get pending deposits", 0, map[string]interface{}{"lastSlotOfPreviousEpoch": lastSlotOfPreviousEpoch})
+					return err
+				}
+				result.lastEpochPendingConsolidations, err = cl.GetPendingConsolidations(lastSlotOfPreviousEpoch)
+				if err != nil {
+					d.log.Error(err, "can not get pending consolidations", 0, map[string]interface{}{"lastSlotOfPreviousEpoch": lastSlotOfPreviousEpoch})
+					return err
+				}
+				d.log.Debugf("retrieved start pending queues using state at slot %d in %v", lastSlotOfPreviousEpoch, time.Since(start))
+				return nil
+			})
+		}
+
 		errGroup.Go(func() error {
 			start := time.Now()
 
@@ -1311,6 +1411,7 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 	currentSyncPeriod := utils.SyncPeriodOfEpoch(data.epoch)
 
 	postAltair := data.epoch >= utils.Config.Chain.ClConfig.AltairForkEpoch
+	postElectra := utils.IsElectraEpoch(data.epoch)
 
 	// write start & end balances and slashed status
 	for i := 0; i < len(validatorsData); i++ {
@@ -1443,6 +1544,126 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 		}
 	}
 
+	if postElectra {
+		getDepositValidatorIndex := func(deposit *constypes.PendingDeposit) (int64, bool, error) {
+			validator_index, ok := pubkeyToIndexMapNewlyActivatedValidators[string(deposit.Pubkey)]
+			if !ok {
+				validator_index, ok = pubkeyToIndexMapOldValidators[string(deposit.Pubkey)]
+			}
+			if ok && validator_index >= sizeInt {
+				return 0, false, errors.New("deposit index out of range")
+			}
+			return validator_index, ok, nil
+		}
+
+		// the queue at the start of the epoch, the state before the fork epoch has no queue yet
+		startPendingDeposits := data.lastEpochPendingDeposits
+		if data.epoch == utils.Config.Chain.ClConfig.ElectraForkEpoch {
+			startPendingDeposits = electraUpgradePendingDeposits(data.lastEpochStateEnd)
+			// the queued balances left the validators during the upgrade, like the balance of consolidations it is accounted like a withdrawal (without counting it)
+			for _, deposit := range startPendingDeposits.Data {
+				validator_index, ok, err := getDepositValidatorIndex(&deposit)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					continue
+				}
+				validatorsData[validator_index].WithdrawalsAmount.Int64 += int64(deposit.Amount)
+				validatorsData[validator_index].WithdrawalsAmount.Valid = true
+			}
+		}
+
+		// deposits that left the pending deposits queue during the epoch have been applied to the validator balances
+		remainingDeposits := make(map[string]int)
+		if data.currentEpochPendingDeposits != nil {
+			for _, deposit := range data.currentEpochPendingDeposits.Data {
+				remainingDeposits[pendingDepositKey(&deposit)]++
+			}
+		}
+		queuedDeposits := make(map[string]int)
+		if startPendingDeposits != nil {
+			for _, deposit := range startPendingDeposits.Data {
+				key := pendingDepositKey(&deposit)
+				queuedDeposits[key]++
+				if remainingDeposits[key] > 0 {
+					remainingDeposits[key]--
+					continue
+				}
+				validator_index, ok, err := getDepositValidatorIndex(&deposit)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					// deposits of new validators with an invalid signature are dropped
+					continue
+				}
+
+				validatorsData[validator_index].DepositsAmount.Int64 += int64(deposit.Amount)
+				validatorsData[validator_index].DepositsAmount.Valid = true
+
+				if !isBalanceMovementDeposit(&deposit) {
+					validatorsData[validator_index].DepositsCount.Int16++
+					validatorsData[validator_index].DepositsCount.Valid = true
+				}
+			}
+		}
+		// balance that is still queued after a switch to compounding withdrawal credentials during the epoch has left the validator
+		if data.currentEpochPendingDeposits != nil {
+			for _, deposit := range data.currentEpochPendingDeposits.Data {
+				key := pendingDepositKey(&deposit)
+				if queuedDeposits[key] > 0 {
+					queuedDeposits[key]--
+					continue
+				}
+				if !isBalanceMovementDeposit(&deposit) {
+					continue
+				}
+				validator_index, ok, err := getDepositValidatorIndex(&deposit)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					continue
+				}
+				validatorsData[validator_index].WithdrawalsAmount.Int64 += int64(deposit.Amount)
+				validatorsData[validator_index].WithdrawalsAmount.Valid = true
+			}
+		}
+
+		// consolidations move balance from the source to the target validator, which is neither income nor loss.
+		// The moved balance is accounted like a withdrawal of the source and a deposit to the target (without counting them)
+		remainingConsolidations := make(map[constypes.PendingConsolidation]int)
+		if data.currentEpochPendingConsolidations != nil {
+			for _, consolidation := range data.currentEpochPendingConsolidations.Data {
+				remainingConsolidations[consolidation]++
+			}
+		}
+		if data.lastEpochPendingConsolidations != nil {
+			for _, consolidation := range data.lastEpochPendingConsolidations.Data {
+				if remainingConsolidations[consolidation] > 0 {
+					remainingConsolidations[consolidation]--
+					continue
+				}
+				if consolidation.SourceIndex >= uint64(len(data.lastEpochStateEnd.Data)) || consolidation.TargetIndex >= size {
+					return nil, errors.New("consolidation index out of range")
+				}
+				source := data.lastEpochStateEnd.Data[consolidation.SourceIndex]
+				if source.Validator.Slashed {
+					// consolidations of slashed validators are dropped
+					continue
+				}
+				amount := int64(min(source.Balance, source.Validator.EffectiveBalance))
+
+				validatorsData[consolidation.SourceIndex].WithdrawalsAmount.Int64 += amount
+				validatorsData[consolidation.SourceIndex].WithdrawalsAmount.Valid = true
+
+				validatorsData[consolidation.TargetIndex].DepositsAmount.Int64 += amount
+				validatorsData[consolidation.TargetIndex].DepositsAmount.Valid = true
+			}
+		}
+	}
+
 	// write block specific data
 	for _, block := range data.beaconBlockData {
 		if block.Data.Message.Slot != 0 { // Special case to exclude genesis block as Validator 0 did not propose it
@@ -1451,7 +1672,12 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 			validatorsData[block.Data.Message.ProposerIndex].LastSubmittedDutyEpoch = utils.NullInt32(int32(block.Data.Message.Slot / utils.Config.Chain.ClConfig.SlotsPerEpoch))
 		}
 
-		for depositIndex, depositData := range block.Data.Message.Body.Deposits {
+		deposits := block.Data.Message.Body.Deposits
+		if postElectra {
+			// deposits are only added to the pending deposits queue, they are accounted for once they are applied (see below)
+			deposits = nil
+		}
+		for depositIndex, depositData := range deposits {
 			// Properly verify that deposit is valid:
 			// if signature is valid I count the deposit towards the balance
 			// if signature is invalid and the validator was in the state at the beginning of the epoch I count the deposit towards the balance
@@ -1748,6 +1974,9 @@ func (r *ResponseCache) GetSyncCommitteeCacheKey(period uint64) string {
 	return fmt.Sprintf("%s%d", RawSyncCommitteeCacheKey, period)
 }
 
+// refreshMaterializedSlashedByCounts keeps the number of validators slashed per slasher up to date.
+// It also refreshes the first slashing of every validator that the network slashings endpoints of the api page through,
+// the view itself is created by a migration so the api can read it before the exporter ran.
 func refreshMaterializedSlashedByCounts() error {
 	tx, err := db.AlloyWriter.Beginx()
 	if err != nil {
@@ -1796,6 +2025,7 @@ func refreshMaterializedSlashedByCounts() error {
 		REFRESH MATERIALIZED VIEW validator_dashboard_data_rolling_weekly_slashedby_count;
 		REFRESH MATERIALIZED VIEW validator_dashboard_data_rolling_monthly_slashedby_count;
 		REFRESH MATERIALIZED VIEW validator_dashboard_data_epoch_slashedby_count;
+		REFRESH MATERIALIZED VIEW network_slashings;
 	`)
 
 	if err != nil {
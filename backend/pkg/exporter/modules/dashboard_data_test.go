@@ -0,0 +1,52 @@
+package modules
+
+import (
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElectraUpgradePendingDeposits(t *testing.T) {
+	previous := utils.Config
+	utils.Config = &types.Config{}
+	utils.Config.Chain.ClConfig.MinActivationBalance = 32e9
+	t.Cleanup(func() { utils.Config = previous })
+
+	validator := func(index, balance, eligibilityEpoch, activationEpoch uint64, credentialsPrefix byte) constypes.StandardValidator {
+		v := constypes.StandardValidator{Index: index, Balance: balance}
+		v.Validator.Pubkey = []byte{byte(index)}
+		v.Validator.WithdrawalCredentials = []byte{credentialsPrefix, 1}
+		v.Validator.ActivationEligibilityEpoch = eligibilityEpoch
+		v.Validator.ActivationEpoch = activationEpoch
+		return v
+	}
+	state := &constypes.StandardValidatorsResponse{Data: []constypes.StandardValidator{
+		validator(0, 33e9, 0, 0, 1),                                 // active, no compounding credentials
+		validator(1, 40e9, 0, 0, 2),                                 // active compounding validator with excess balance
+		validator(2, 32e9, 7, db.FarFutureEpoch, 1),                 // pending activation
+		validator(3, 32e9, 5, db.FarFutureEpoch, 1),                 // pending activation, eligible earlier
+		validator(4, 32e9, db.FarFutureEpoch, db.FarFutureEpoch, 2), // not eligible yet
+	}}
+
+	deposits := electraUpgradePendingDeposits(state)
+	require.Len(t, deposits.Data, 4)
+
+	// pre-activation validators first, ordered by eligibility epoch, then the excess balance of compounding validators
+	expected := []struct {
+		pubkey byte
+		amount uint64
+	}{{3, 32e9}, {2, 32e9}, {4, 32e9}, {1, 8e9}}
+	for i, e := range expected {
+		assert.Equal(t, []byte{e.pubkey}, []byte(deposits.Data[i].Pubkey))
+		assert.Equal(t, e.amount, deposits.Data[i].Amount)
+		assert.True(t, isBalanceMovementDeposit(&deposits.Data[i]))
+	}
+
+	// regular deposits are signed and included at a slot
+	assert.False(t, isBalanceMovementDeposit(&constypes.PendingDeposit{Signature: make([]byte, 96), Slot: 10}))
+}
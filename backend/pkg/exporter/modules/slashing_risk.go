@@ -0,0 +1,379 @@
+package modules
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+// number of epochs the votes and proposals of the validators are kept in memory to compare new messages against
+const slashingRiskWindowEpochs = 4
+
+type SlashingRiskType string
+
+const (
+	SlashingRiskDoubleVote     SlashingRiskType = "double_vote"
+	SlashingRiskSurroundVote   SlashingRiskType = "surround_vote"
+	SlashingRiskDoubleProposal SlashingRiskType = "double_proposal"
+)
+
+type slashingRisk struct {
+	ValidatorIndex uint64
+	Type           SlashingRiskType
+	Slot           uint64
+	Epoch          uint64
+	Details        string
+}
+
+type attestationVote struct {
+	Slot        uint64
+	SourceEpoch uint64
+	TargetEpoch uint64
+	Data        constypes.AttestationData
+}
+
+// slashingRiskDetector keeps track of the messages signed by each validator and detects conflicting messages that would get
+// the validator slashed once they are included on chain. It is safe for concurrent use.
+type slashingRiskDetector struct {
+	mu        sync.Mutex
+	votes     map[uint64][]attestationVote           // validator index -> votes
+	proposals map[uint64]map[uint64][]byte           // slot -> proposer index -> block root
+	reported  map[uint64]map[SlashingRiskType]uint64 // validator index -> risk type -> last reported epoch
+}
+
+func newSlashingRiskDetector() *slashingRiskDetector {
+	return &slashingRiskDetector{
+		votes:     make(map[uint64][]attestationVote),
+		proposals: make(map[uint64]map[uint64][]byte),
+		reported:  make(map[uint64]map[SlashingRiskType]uint64),
+	}
+}
+
+func attestationDataEqual(a, b *constypes.AttestationData) bool {
+	return a.Slot == b.Slot && a.Index == b.Index && bytes.Equal(a.BeaconBlockRoot, b.BeaconBlockRoot) &&
+		a.Source.Epoch == b.Source.Epoch && bytes.Equal(a.Source.Root, b.Source.Root) &&
+		a.Target.Epoch == b.Target.Epoch && bytes.Equal(a.Target.Root, b.Target.Root)
+}
+
+// addAttestation records the vote of a validator and returns the risk if it conflicts with a previously seen vote
+func (d *slashingRiskDetector) addAttestation(validatorIndex uint64, data constypes.AttestationData) *slashingRisk {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	vote := attestationVote{
+		Slot:        data.Slot,
+		SourceEpoch: data.Source.Epoch,
+		TargetEpoch: data.Target.Epoch,
+		Data:        data,
+	}
+	var risk *slashingRisk
+	for _, previous := range d.votes[validatorIndex] {
+		if attestationDataEqual(&previous.Data, &data) {
+			// the same attestation is seen multiple times, e.g. once unaggregated and once as part of an aggregate
+			return nil
+		}
+		if risk != nil {
+			continue
+		}
+		switch {
+		case previous.TargetEpoch == vote.TargetEpoch:
+			risk = &slashingRisk{
+				ValidatorIndex: validatorIndex,
+				Type:           SlashingRiskDoubleVote,
+				Slot:           vote.Slot,
+				Epoch:          vote.TargetEpoch,
+				Details:        fmt.Sprintf("conflicting votes for target epoch %d in slots %d and %d (head 0x%x vs 0x%x)", vote.TargetEpoch, previous.Slot, vote.Slot, previous.Data.BeaconBlockRoot, data.BeaconBlockRoot),
+			}
+		case previous.SourceEpoch < vote.SourceEpoch && vote.TargetEpoch < previous.TargetEpoch,
+			vote.SourceEpoch < previous.SourceEpoch && previous.TargetEpoch < vote.TargetEpoch:
+			risk = &slashingRisk{
+				ValidatorIndex: validatorIndex,
+				Type:           SlashingRiskSurroundVote,
+				Slot:           vote.Slot,
+				Epoch:          vote.TargetEpoch,
+				Details:        fmt.Sprintf("vote with source %d and target %d surrounds or is surrounded by vote with source %d and target %d", vote.SourceEpoch, vote.TargetEpoch, previous.SourceEpoch, previous.TargetEpoch),
+			}
+		}
+	}
+	d.votes[validatorIndex] = append(d.votes[validatorIndex], vote)
+	return d.report(risk)
+}
+
+// addProposal records the block of a proposer and returns the risk if the proposer already proposed a different block in the same slot
+func (d *slashingRiskDetector) addProposal(slot, proposerIndex uint64, blockRoot []byte) *slashingRisk {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.proposals[slot] == nil {
+		d.proposals[slot] = make(map[uint64][]byte)
+	}
+	previous, ok := d.proposals[slot][proposerIndex]
+	if !ok {
+		d.proposals[slot][proposerIndex] = blockRoot
+		return nil
+	}
+	if bytes.Equal(previous, blockRoot) {
+		return nil
+	}
+	return d.report(&slashingRisk{
+		ValidatorIndex: proposerIndex,
+		Type:           SlashingRiskDoubleProposal,
+		Slot:           slot,
+		Epoch:          utils.EpochOfSlot(slot),
+		Details:        fmt.Sprintf("two different blocks proposed in slot %d (0x%x and 0x%x)", slot, previous, blockRoot),
+	})
+}
+
+// report returns the risk unless a risk of the same type was already reported for the validator in the same epoch
+func (d *slashingRiskDetector) report(risk *slashingRisk) *slashingRisk {
+	if risk == nil {
+		return nil
+	}
+	if d.reported[risk.ValidatorIndex] == nil {
+		d.reported[risk.ValidatorIndex] = make(map[SlashingRiskType]uint64)
+	}
+	if epoch, ok := d.reported[risk.ValidatorIndex][risk.Type]; ok && epoch == risk.Epoch {
+		return nil
+	}
+	d.reported[risk.ValidatorIndex][risk.Type] = risk.Epoch
+	return risk
+}
+
+// prune removes all votes and proposals before the given epoch
+func (d *slashingRiskDetector) prune(epoch uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for validatorIndex, votes := range d.votes {
+		kept := votes[:0]
+		for _, vote := range votes {
+			if vote.TargetEpoch >= epoch {
+				kept = append(kept, vote)
+			}
+		}
+		if len(kept) == 0 {
+			delete(d.votes, validatorIndex)
+		} else {
+			d.votes[validatorIndex] = kept
+		}
+	}
+	firstSlot := epoch * utils.Config.Chain.ClConfig.SlotsPerEpoch
+	for slot := range d.proposals {
+		if slot < firstSlot {
+			delete(d.proposals, slot)
+		}
+	}
+	for validatorIndex, reported := range d.reported {
+		for riskType, reportedEpoch := range reported {
+			if reportedEpoch < epoch {
+				delete(reported, riskType)
+			}
+		}
+		if len(reported) == 0 {
+			delete(d.reported, validatorIndex)
+		}
+	}
+}
+
+// attestingIndices returns the validators of the committee that signed the attestation according to its aggregation bits
+func attestingIndices(aggregationBits []byte, committee []uint64) []uint64 {
+	var indices []uint64
+	for i, validatorIndex := range committee {
+		if i/8 >= len(aggregationBits) {
+			break
+		}
+		if utils.BitAtVector(aggregationBits, i) {
+			indices = append(indices, validatorIndex)
+		}
+	}
+	return indices
+}
+
+// slashingRiskDetectorModule watches the attestations and blocks seen by the node and stores validators that signed conflicting
+// messages, which usually means that the same key is running on multiple machines. This happens before a slashing is included
+// on chain, the notification service picks the risks up and warns the users.
+type slashingRiskDetectorModule struct {
+	ModuleContext
+	Detector *slashingRiskDetector
+	log      ModuleLog
+
+	committeesMu sync.Mutex
+	committees   map[uint64]map[uint64]map[uint64][]uint64 // epoch -> slot -> committee index -> validators
+}
+
+func NewSlashingRiskDetector(moduleContext ModuleContext) ModuleInterface {
+	m := &slashingRiskDetectorModule{
+		ModuleContext: moduleContext,
+		Detector:      newSlashingRiskDetector(),
+		committees:    make(map[uint64]map[uint64]map[uint64][]uint64),
+	}
+	m.log = ModuleLog{module: m}
+	return m
+}
+
+func (m *slashingRiskDetectorModule) Init() error {
+	go m.watch()
+	return nil
+}
+
+func (m *slashingRiskDetectorModule) GetName() string {
+	return "SlashingRisk-Detector"
+}
+
+func (m *slashingRiskDetectorModule) OnHead(event *constypes.StandardEventHeadResponse) error {
+	return nil // nop
+}
+
+func (m *slashingRiskDetectorModule) OnChainReorg(event *constypes.StandardEventChainReorg) error {
+	return nil // nop
+}
+
+func (m *slashingRiskDetectorModule) OnFinalizedCheckpoint(event *constypes.StandardFinalizedCheckpointResponse) error {
+	if event.Epoch < slashingRiskWindowEpochs {
+		return nil
+	}
+	epoch := event.Epoch - slashingRiskWindowEpochs
+	m.Detector.prune(epoch)
+
+	m.committeesMu.Lock()
+	defer m.committeesMu.Unlock()
+	for committeeEpoch := range m.committees {
+		if committeeEpoch < epoch {
+			delete(m.committees, committeeEpoch)
+		}
+	}
+	return nil
+}
+
+// watch subscribes to the attestations and blocks of the node, the module event loop only forwards head, finality and reorg events
+func (m *slashingRiskDetectorModule) watch() {
+	events := m.CL.GetEvents([]constypes.EventTopic{
+		constypes.EventAttestation,
+		constypes.EventBlock,
+	})
+	for event := range events {
+		if event.Error != nil {
+			m.log.Error(event.Error, "error getting event", 0)
+			continue
+		}
+		var err error
+		switch event.Event {
+		case constypes.EventAttestation:
+			var attestation *constypes.Attestation
+			attestation, err = event.Attestation()
+			if err == nil {
+				err = m.processAttestation(attestation)
+			}
+		case constypes.EventBlock:
+			var block *constypes.StandardEventBlockResponse
+			block, err = event.Block()
+			if err == nil {
+				err = m.processBlock(block)
+			}
+		}
+		if err != nil {
+			m.log.Error(err, fmt.Sprintf("error processing %s event", event.Event), 0)
+		}
+	}
+}
+
+func (m *slashingRiskDetectorModule) processAttestation(attestation *constypes.Attestation) error {
+	committee, err := m.attestationCommittee(attestation)
+	if err != nil {
+		return err
+	}
+	for _, validatorIndex := range attestingIndices(attestation.AggregationBits, committee) {
+		if risk := m.Detector.addAttestation(validatorIndex, attestation.Data); risk != nil {
+			err = m.storeRisk(risk)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *slashingRiskDetectorModule) processBlock(block *constypes.StandardEventBlockResponse) error {
+	root := "0x" + hex.EncodeToString(block.Block)
+	header, err := m.CL.GetBlockHeader(root)
+	if err != nil {
+		return fmt.Errorf("error getting header of block %s: %w", root, err)
+	}
+	if risk := m.Detector.addProposal(block.Slot, header.Data.Header.Message.ProposerIndex, block.Block); risk != nil {
+		return m.storeRisk(risk)
+	}
+	return nil
+}
+
+// attestationCommittee returns the validators the aggregation bits of an attestation refer to. Since electra the committee
+// index of the attestation data is 0 and the committee bits select the committees, the aggregation bits span all selected
+// committees in ascending order of their index
+func (m *slashingRiskDetectorModule) attestationCommittee(attestation *constypes.Attestation) ([]uint64, error) {
+	if len(attestation.CommitteeBits) == 0 {
+		return m.getCommittee(attestation.Data.Slot, uint64(attestation.Data.Index))
+	}
+	var committee []uint64
+	for index := 0; index < len(attestation.CommitteeBits)*8; index++ {
+		if !utils.BitAtVector(attestation.CommitteeBits, index) {
+			continue
+		}
+		validators, err := m.getCommittee(attestation.Data.Slot, uint64(index))
+		if err != nil {
+			return nil, err
+		}
+		committee = append(committee, validators...)
+	}
+	return committee, nil
+}
+
+// getCommittee returns the validators of a committee, the committees are fetched once per epoch
+func (m *slashingRiskDetectorModule) getCommittee(slot, index uint64) ([]uint64, error) {
+	epoch := utils.EpochOfSlot(slot)
+	m.committeesMu.Lock()
+	defer m.committeesMu.Unlock()
+
+	if _, ok := m.committees[epoch]; !ok {
+		res, err := m.CL.GetCommittees("head", &epoch, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting committees of epoch %d: %w", epoch, err)
+		}
+		committees := make(map[uint64]map[uint64][]uint64)
+		for _, committee := range res.Data {
+			if committees[committee.Slot] == nil {
+				committees[committee.Slot] = make(map[uint64][]uint64)
+			}
+			validators := make([]uint64, len(committee.Validators))
+			for i, validator := range committee.Validators {
+				validators[i] = uint64(validator)
+			}
+			committees[committee.Slot][committee.Index] = validators
+		}
+		m.committees[epoch] = committees
+	}
+	committee, ok := m.committees[epoch][slot][index]
+	if !ok {
+		return nil, fmt.Errorf("committee %d of slot %d not found", index, slot)
+	}
+	return committee, nil
+}
+
+func (m *slashingRiskDetectorModule) storeRisk(risk *slashingRisk) error {
+	m.log.InfoWithFields(log.Fields{"validator": risk.ValidatorIndex, "type": risk.Type, "slot": risk.Slot}, "detected slashing risk")
+	metrics.Tasks.WithLabelValues(fmt.Sprintf("slashing_risk_%s", risk.Type)).Inc()
+	_, err := db.WriterDb.Exec(`
+		INSERT INTO validator_slashing_risks (validator_index, risk_type, slot, epoch, details)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (validator_index, risk_type, epoch) DO NOTHING`,
+		risk.ValidatorIndex, risk.Type, risk.Slot, risk.Epoch, risk.Details)
+	if err != nil {
+		return fmt.Errorf("error storing slashing risk of validator %d: %w", risk.ValidatorIndex, err)
+	}
+	return nil
+}
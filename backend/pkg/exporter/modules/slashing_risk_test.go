@@ -0,0 +1,155 @@
+package modules
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/consapi"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSlashingRiskTest(t *testing.T) {
+	t.Helper()
+	previous := utils.Config
+	utils.Config = &types.Config{}
+	utils.Config.Chain.ClConfig.SlotsPerEpoch = 32
+	t.Cleanup(func() { utils.Config = previous })
+}
+
+// slashingRiskTestClient serves the headers and committees the module requests and records the requested block ids
+type slashingRiskTestClient struct {
+	consapi.ClientInt
+	committees   []constypes.StandardCommitteeEntry
+	blockHeaders []any
+}
+
+func (c *slashingRiskTestClient) GetBlockHeader(blockID any) (*constypes.StandardBeaconHeaderResponse, error) {
+	c.blockHeaders = append(c.blockHeaders, blockID)
+	res := &constypes.StandardBeaconHeaderResponse{}
+	res.Data.Header.Message.ProposerIndex = 5
+	return res, nil
+}
+
+func (c *slashingRiskTestClient) GetCommittees(stateID any, epoch, index, slot *uint64) (*constypes.StandardCommitteesResponse, error) {
+	return &constypes.StandardCommitteesResponse{Data: c.committees}, nil
+}
+
+// loadAttestationFixtures reads synthetic attestation events as they are sent by the node on the attestation topic
+func loadAttestationFixtures(t *testing.T) []*constypes.Attestation {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/slashing_risk_attestations.json")
+	require.NoError(t, err)
+	var fixtures []struct {
+		Event constypes.EventTopic `json:"event"`
+		Data  json.RawMessage      `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &fixtures))
+
+	attestations := make([]*constypes.Attestation, 0, len(fixtures))
+	for _, fixture := range fixtures {
+		attestation, err := constypes.EventResponse{Event: fixture.Event, Data: fixture.Data}.Attestation()
+		require.NoError(t, err)
+		require.NotNil(t, attestation)
+		attestations = append(attestations, attestation)
+	}
+	return attestations
+}
+
+func TestSlashingRiskDetectorAttestations(t *testing.T) {
+	setupSlashingRiskTest(t)
+	committees := map[uint64][]uint64{
+		64: {100, 101, 102},
+		96: {101, 103},
+	}
+
+	detector := newSlashingRiskDetector()
+	var risks []*slashingRisk
+	for _, attestation := range loadAttestationFixtures(t) {
+		for _, validatorIndex := range attestingIndices(attestation.AggregationBits, committees[attestation.Data.Slot]) {
+			if risk := detector.addAttestation(validatorIndex, attestation.Data); risk != nil {
+				risks = append(risks, risk)
+			}
+		}
+	}
+
+	require.Len(t, risks, 2, "duplicate attestations and already reported conflicts must not be reported")
+	assert.Equal(t, uint64(100), risks[0].ValidatorIndex)
+	assert.Equal(t, SlashingRiskDoubleVote, risks[0].Type)
+	assert.Equal(t, uint64(2), risks[0].Epoch)
+	assert.Equal(t, uint64(101), risks[1].ValidatorIndex)
+	assert.Equal(t, SlashingRiskSurroundVote, risks[1].Type)
+	assert.Equal(t, uint64(3), risks[1].Epoch)
+
+	// once the votes are pruned there is nothing left to conflict with
+	detector.prune(4)
+	assert.Empty(t, detector.votes)
+	assert.Empty(t, detector.reported)
+}
+
+func TestSlashingRiskDetectorProposals(t *testing.T) {
+	setupSlashingRiskTest(t)
+	detector := newSlashingRiskDetector()
+	rootA := common.HexToHash("0xaa").Bytes()
+	rootB := common.HexToHash("0xbb").Bytes()
+
+	assert.Nil(t, detector.addProposal(70, 5, rootA))
+	assert.Nil(t, detector.addProposal(70, 5, rootA), "the same block seen twice is not a double proposal")
+	assert.Nil(t, detector.addProposal(71, 5, rootB))
+
+	risk := detector.addProposal(70, 5, rootB)
+	require.NotNil(t, risk)
+	assert.Equal(t, SlashingRiskDoubleProposal, risk.Type)
+	assert.Equal(t, uint64(5), risk.ValidatorIndex)
+	assert.Equal(t, uint64(70), risk.Slot)
+	assert.Equal(t, uint64(2), risk.Epoch)
+
+	detector.prune(3)
+	assert.Empty(t, detector.proposals)
+}
+
+func TestAttestingIndices(t *testing.T) {
+	committee := []uint64{10, 11, 12, 13, 14, 15, 16, 17, 18}
+	// bitlist with 9 bits for the committee and the length bit at position 9
+	assert.Equal(t, []uint64{10, 17, 18}, attestingIndices([]byte{0x81, 0x03}, committee))
+	assert.Empty(t, attestingIndices([]byte{0x00, 0x02}, committee))
+	// malformed bitlists that are too short must not panic
+	assert.Equal(t, []uint64{10}, attestingIndices([]byte{0x01}, committee))
+}
+
+func TestSlashingRiskModuleBlockHeader(t *testing.T) {
+	setupSlashingRiskTest(t)
+	client := &slashingRiskTestClient{}
+	m := NewSlashingRiskDetector(ModuleContext{CL: consapi.Client{ClientInt: client}}).(*slashingRiskDetectorModule)
+
+	root := common.HexToHash("0xaa")
+	require.NoError(t, m.processBlock(&constypes.StandardEventBlockResponse{Slot: 70, Block: root.Bytes()}))
+	assert.Equal(t, []any{root.Hex()}, client.blockHeaders, "the block root must be requested 0x prefixed")
+	assert.Equal(t, root.Bytes(), m.Detector.proposals[70][5])
+}
+
+func TestSlashingRiskModuleAttestationCommittee(t *testing.T) {
+	setupSlashingRiskTest(t)
+	client := &slashingRiskTestClient{committees: []constypes.StandardCommitteeEntry{
+		{Slot: 70, Index: 0, Validators: []constypes.Uint64Str{10, 11}},
+		{Slot: 70, Index: 1, Validators: []constypes.Uint64Str{20, 21, 22}},
+		{Slot: 70, Index: 2, Validators: []constypes.Uint64Str{30, 31}},
+	}}
+	m := NewSlashingRiskDetector(ModuleContext{CL: consapi.Client{ClientInt: client}}).(*slashingRiskDetectorModule)
+
+	committee, err := m.attestationCommittee(&constypes.Attestation{Data: constypes.AttestationData{Slot: 70, Index: 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{20, 21, 22}, committee, "pre electra attestations use the index of the attestation data")
+
+	committee, err = m.attestationCommittee(&constypes.Attestation{CommitteeBits: []byte{0x05}, Data: constypes.AttestationData{Slot: 70}})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10, 11, 30, 31}, committee, "electra attestations concatenate the committees of the committee bits")
+
+	_, err = m.attestationCommittee(&constypes.Attestation{CommitteeBits: []byte{0x08}, Data: constypes.AttestationData{Slot: 70}})
+	assert.Error(t, err)
+}
@@ -281,6 +281,12 @@ func (d *slotExporterData) OnHead(event *constypes.StandardEventHeadResponse) (e
 	latestEpoch = utils.EpochOfSlot(head.HeadSlot)
 	latestSlot = head.HeadSlot
 
+	// inform the api about the new head so it can push live updates without waiting for its next refresh
+	err = db.PersistentRedisDbClient.Publish(context.Background(), fmt.Sprintf("%d:slotViz", utils.Config.Chain.ClConfig.DepositChainID), fmt.Sprintf("%s:%d", "head", head.HeadSlot)).Err()
+	if err != nil {
+		log.Error(err, "error publishing head event", 0)
+	}
+
 	services.ReportStatus("slotExporter", "Running", nil)
 
 	return nil
@@ -413,8 +419,11 @@ func ExportSlot(client rpc.Client, slot uint64, isHeadEpoch bool, tx *sqlx.Tx) e
 				if err != nil {
 					return fmt.Errorf("error writing assignments data to redis for epoch %v: %w", epoch, err)
 				}
-				// publish the event to inform the api about the new data (todo)
-				// db.PersistentRedisDbClient.Publish(context.Background(), fmt.Sprintf("%d:slotViz", utils.Config.Chain.ClConfig.DepositChainID), fmt.Sprintf("%s:%d", "ea", epoch)).Err()
+				// publish the event to inform the api about the new data
+				err = db.PersistentRedisDbClient.Publish(context.Background(), fmt.Sprintf("%d:slotViz", utils.Config.Chain.ClConfig.DepositChainID), fmt.Sprintf("%s:%d", "ea", epoch)).Err()
+				if err != nil {
+					log.Error(err, "error publishing epoch assignments event", 0)
+				}
 				log.Infof("writing current epoch assignments to redis completed")
 			}
 
@@ -2,21 +2,32 @@ package nodejobs
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
+	"errors"
 
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/consapi"
 	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	ethutil "github.com/wealdtech/go-eth2-util"
 )
@@ -36,6 +47,7 @@ func GetNodeJob(id string) (*types.NodeJob, error) {
 
 func GetNodeJobValidatorInfos(job *types.NodeJob) ([]types.NodeJobValidatorInfo, error) {
 	indicesArr := []uint64{}
+	pubkeysArr := [][]byte{}
 	if job.Type == types.BLSToExecutionChangesNodeJobType {
 		jobData, ok := job.GetBLSToExecutionChangesNodeJobData()
 		if !ok {
@@ -51,12 +63,28 @@ func GetNodeJobValidatorInfos(job *types.NodeJob) ([]types.NodeJobValidatorInfo,
 		}
 
 		indicesArr = append(indicesArr, uint64(jobData.Message.ValidatorIndex))
+	} else if job.Type == types.WithdrawalRequestsNodeJobType {
+		jobData, ok := job.GetWithdrawalRequestsNodeJobData()
+		if !ok {
+			return nil, fmt.Errorf("invalid withdrawal request job-data")
+		}
+		for _, r := range jobData {
+			pubkeysArr = append(pubkeysArr, r.ValidatorPubkey[:])
+		}
+	} else if job.Type == types.ConsolidationRequestsNodeJobType {
+		jobData, ok := job.GetConsolidationRequestsNodeJobData()
+		if !ok {
+			return nil, fmt.Errorf("invalid consolidation request job-data")
+		}
+		for _, r := range jobData {
+			pubkeysArr = append(pubkeysArr, r.SourcePubkey[:])
+		}
 	} else {
 		return []types.NodeJobValidatorInfo{}, nil
 	}
 
 	dbValis := []types.NodeJobValidatorInfo{}
-	err := db.WriterDb.Select(&dbValis, `select validatorindex, pubkey, withdrawalcredentials, exitepoch, status from validators where validatorindex = any($1)`, pq.Array(indicesArr))
+	err := db.WriterDb.Select(&dbValis, `select validatorindex, pubkey, withdrawalcredentials, exitepoch, status from validators where validatorindex = any($1) or pubkey = any($2)`, pq.Array(indicesArr), pq.ByteaArray(pubkeysArr))
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +119,54 @@ func CreateNodeJob(data []byte) (*types.NodeJob, error) {
 		return CreateBLSToExecutionChangesNodeJob(j)
 	case types.VoluntaryExitsNodeJobType:
 		return CreateVoluntaryExitNodeJob(j)
+	case types.ExecutionTransactionsNodeJobType:
+		return CreateExecutionTransactionNodeJob(j)
+	case types.WithdrawalRequestsNodeJobType:
+		return CreateWithdrawalRequestsNodeJob(j)
+	case types.ConsolidationRequestsNodeJobType:
+		return CreateConsolidationRequestsNodeJob(j)
+	}
+}
+
+// getExistingNodeJob returns the job of the given type with the given dedup-key that has not failed yet, nil if there is none
+func getExistingNodeJob(jobType types.NodeJobType, dedupKey string) (*types.NodeJob, error) {
+	job := types.NodeJob{}
+	err := db.WriterDb.Get(&job, `select id, type, status, created_time, submitted_to_node_time, completed_time, data from node_jobs where type = $1 and dedup_key = $2 and status != $3`, jobType, dedupKey, types.FailedNodeJobStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = job.ParseData()
+	return &job, err
+}
+
+// insertNodeJob stores a job with a dedup-key. If a job with the same key that has not failed exists already, e.g. because
+// a concurrent request inserted it after the check for existing jobs, that job is returned instead and inserted is false.
+func insertNodeJob(nj *types.NodeJob, dedupKey string) (job *types.NodeJob, inserted bool, err error) {
+	// the conflict target has to match the partial unique index idx_node_jobs_type_dedup_key
+	res, err := db.WriterDb.Exec(`insert into node_jobs (id, type, status, data, created_time, dedup_key) values ($1, $2, $3, $4, now(), $5)
+		on conflict (type, dedup_key) where dedup_key is not null and status <> 'FAILED' do nothing`, nj.ID, nj.Type, nj.Status, nj.RawData, dedupKey)
+	if err != nil {
+		return nil, false, err
 	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("error getting rowsAffected: %w", err)
+	}
+	if rows > 0 {
+		return nj, true, nil
+	}
+	existingJob, err := getExistingNodeJob(nj.Type, dedupKey)
+	if err != nil {
+		return nil, false, err
+	}
+	if existingJob == nil {
+		// the conflicting job failed in the meantime
+		return nil, false, fmt.Errorf("node_job with dedup-key %v conflicted but was not found", dedupKey)
+	}
+	return existingJob, false, nil
 }
 
 func UpdateNodeJobs() error {
@@ -104,6 +179,14 @@ func UpdateNodeJobs() error {
 	if err != nil {
 		return fmt.Errorf("error updating voluntary-exit-job: %w", err)
 	}
+	err = UpdateExecutionTransactionNodeJobs()
+	if err != nil {
+		return fmt.Errorf("error updating execution-transaction-job: %w", err)
+	}
+	err = UpdateExecutionRequestNodeJobs()
+	if err != nil {
+		return fmt.Errorf("error updating execution-request-job: %w", err)
+	}
 	return nil
 }
 
@@ -117,6 +200,10 @@ func SubmitNodeJobs() error {
 	if err != nil {
 		return err
 	}
+	err = SubmitExecutionTransactionNodeJobs()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -340,43 +427,64 @@ func SubmitBLSToExecutionChangesNodeJob(job *types.NodeJob) error {
 
 func CreateVoluntaryExitNodeJob(nj *types.NodeJob) (*types.NodeJob, error) {
 	if len(nj.RawData) > 5e3 {
-		return nil, fmt.Errorf("data size exceeds maximum")
+		return nil, types.CreateNodeJobUserError{Message: "data-size exceeds maximum of 5KB"}
 	}
 	nj.ID = uuid.New().String()
 	nj.Status = types.PendingNodeJobStatus
 
 	njd, ok := nj.GetVoluntaryExitsNodeJobData()
 	if !ok {
-		return nil, fmt.Errorf("invalid job")
+		return nil, types.CreateNodeJobUserError{Message: "invalid data"}
+	}
+
+	dedupKey := fmt.Sprintf("%d", njd.Message.ValidatorIndex)
+	existingJob, err := getExistingNodeJob(nj.Type, dedupKey)
+	if err != nil {
+		return nil, err
+	}
+	if existingJob != nil {
+		if !bytes.Equal(existingJob.RawData, nj.RawData) {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("there is already a job for validator with index %v", njd.Message.ValidatorIndex)}
+		}
+		return existingJob, nil
 	}
 
 	vali := struct {
 		Pubkey []byte `db:"pubkey"`
 		Status string `db:"status"`
 	}{}
-	err := db.WriterDb.Get(&vali, `select pubkey, status from validators where validatorindex = $1`, njd.Message.ValidatorIndex)
+	err = db.WriterDb.Get(&vali, `select pubkey, status from validators where validatorindex = $1`, njd.Message.ValidatorIndex)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v not found", njd.Message.ValidatorIndex)}
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	switch constypes.ValidatorDbStatus(vali.Status) {
 	case constypes.DbExited, constypes.DbExitingOffline, constypes.DbExitingOnline:
-		return nil, fmt.Errorf("validator has exited")
+		return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v has exited", njd.Message.ValidatorIndex)}
 	case constypes.DbSlashed, constypes.DbSlashingOffline, constypes.DbSlashingOnline:
-		return nil, fmt.Errorf("validator has been slashed")
+		return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v has been slashed", njd.Message.ValidatorIndex)}
 	default:
 	}
 
 	forkVersion := utils.ForkVersionAtEpoch(uint64(njd.Message.Epoch))
 	err = utils.VerifyVoluntaryExitSignature(njd, forkVersion.CurrentVersion, vali.Pubkey)
 	if err != nil {
-		return nil, err
+		return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("can not verify signature: %v", err)}
 	}
 
-	_, err = db.WriterDb.Exec(`insert into node_jobs (id, type, status, data, created_time) values ($1, $2, $3, $4, now())`, nj.ID, nj.Type, nj.Status, nj.RawData)
+	job, inserted, err := insertNodeJob(nj, dedupKey)
 	if err != nil {
 		return nil, err
 	}
+	if !inserted {
+		if !bytes.Equal(job.RawData, nj.RawData) {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("there is already a job for validator with index %v", njd.Message.ValidatorIndex)}
+		}
+		return job, nil
+	}
 	log.InfoWithFields(log.Fields{"id": nj.ID, "type": nj.Type}, "created node_job")
 	return nj, nil
 }
@@ -474,3 +582,527 @@ func SubmitVoluntaryExitNodeJob(job *types.NodeJob) error {
 	log.InfoWithFields(log.Fields{"id": job.ID, "type": job.Type, "status": jobStatus}, "submitted node_job")
 	return nil
 }
+
+// CreateExecutionTransactionNodeJob creates a job that broadcasts a raw signed transaction to the execution layer.
+// Submitting the same transaction multiple times returns the already existing job.
+func CreateExecutionTransactionNodeJob(nj *types.NodeJob) (*types.NodeJob, error) {
+	if len(nj.RawData) > 128e3 {
+		return nil, types.CreateNodeJobUserError{Message: "data-size exceeds maximum of 128KB"}
+	}
+	nj.ID = uuid.New().String()
+	nj.Status = types.PendingNodeJobStatus
+
+	tx, ok := nj.GetExecutionTransactionsNodeJobData()
+	if !ok {
+		return nil, types.CreateNodeJobUserError{Message: "invalid data"}
+	}
+	if tx.Type() == gethtypes.BlobTxType {
+		return nil, types.CreateNodeJobUserError{Message: "blob transactions can not be broadcasted without their sidecar"}
+	}
+	if !tx.Protected() {
+		return nil, types.CreateNodeJobUserError{Message: "transaction is not replay-protected"}
+	}
+	if tx.ChainId().Uint64() != utils.Config.Chain.ClConfig.DepositChainID {
+		return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("transaction chain-id %v does not match network chain-id %v", tx.ChainId(), utils.Config.Chain.ClConfig.DepositChainID)}
+	}
+	// recovering the sender verifies the signature of the transaction
+	_, err := gethtypes.LatestSignerForChainID(tx.ChainId()).Sender(tx)
+	if err != nil {
+		return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("can not verify signature: %v", err)}
+	}
+
+	dedupKey := tx.Hash().Hex()
+	existingJob, err := getExistingNodeJob(nj.Type, dedupKey)
+	if err != nil {
+		return nil, err
+	}
+	if existingJob != nil {
+		return existingJob, nil
+	}
+
+	job, inserted, err := insertNodeJob(nj, dedupKey)
+	if err != nil || !inserted {
+		return job, err
+	}
+	log.InfoWithFields(log.Fields{"id": nj.ID, "type": nj.Type, "txHash": dedupKey}, "created node_job")
+	return nj, nil
+}
+
+func UpdateExecutionTransactionNodeJobs() error {
+	jobs := []*types.NodeJob{}
+	err := db.WriterDb.Select(&jobs, `select id, type, status, created_time, submitted_to_node_time, completed_time, data from node_jobs where type = $1 and status = $2`, types.ExecutionTransactionsNodeJobType, types.SubmittedToNodeNodeJobStatus)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+	client, err := ethclient.Dial(utils.Config.NodeJobsProcessor.ElEndpoint)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	for _, job := range jobs {
+		err := job.ParseData()
+		if err != nil {
+			return err
+		}
+		err = UpdateExecutionTransactionNodeJob(client, job)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executionTransactionNodeJobTimeout is the time after which a submitted transaction that has not been included is considered dropped
+const executionTransactionNodeJobTimeout = time.Hour
+
+// UpdateExecutionTransactionNodeJob marks the job as completed once the transaction has been included in a block.
+// The job fails if another transaction with the same nonce has been included or if the transaction has been dropped from the mempool.
+func UpdateExecutionTransactionNodeJob(client *ethclient.Client, job *types.NodeJob) error {
+	tx, ok := job.GetExecutionTransactionsNodeJobData()
+	if !ok {
+		return fmt.Errorf("invalid job-data")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	sender, err := gethtypes.LatestSignerForChainID(tx.ChainId()).Sender(tx)
+	if err != nil {
+		return err
+	}
+	// the nonce is read before the receipt, so a higher nonce without receipt means that another transaction used the nonce
+	nonce, err := client.NonceAt(ctx, sender, nil)
+	if err != nil {
+		return err
+	}
+	var block *big.Int
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	switch {
+	case errors.Is(err, ethereum.NotFound):
+		if nonce <= tx.Nonce() && time.Since(job.SubmittedToNodeTime.Time) < executionTransactionNodeJobTimeout {
+			// not included yet
+			return nil
+		}
+		job.Status = types.FailedNodeJobStatus
+	case err != nil:
+		return err
+	default:
+		block = receipt.BlockNumber
+		job.Status = types.CompletedNodeJobStatus
+		if receipt.Status != gethtypes.ReceiptStatusSuccessful {
+			job.Status = types.FailedNodeJobStatus
+		}
+	}
+	job.CompletedTime.Time = time.Now()
+	job.CompletedTime.Valid = true
+	_, err = db.WriterDb.Exec(`update node_jobs set status = $1, completed_time = $2 where id = $3`, job.Status, job.CompletedTime.Time, job.ID)
+	if err != nil {
+		return err
+	}
+	log.InfoWithFields(log.Fields{"id": job.ID, "type": job.Type, "status": job.Status, "block": block}, "updated node_job")
+	return nil
+}
+
+func SubmitExecutionTransactionNodeJobs() error {
+	maxSubmittedJobs := 100
+	jobs := []*types.NodeJob{}
+	err := db.WriterDb.Select(&jobs, `select id, type, status, created_time, submitted_to_node_time, completed_time, data from node_jobs where type = $1 and status = $2 order by created_time limit $4-(select count(*) from node_jobs where type = $1 and status = $3)`, types.ExecutionTransactionsNodeJobType, types.PendingNodeJobStatus, types.SubmittedToNodeNodeJobStatus, maxSubmittedJobs)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+	client, err := ethclient.Dial(utils.Config.NodeJobsProcessor.ElEndpoint)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	for _, job := range jobs {
+		err = job.ParseData()
+		if err != nil {
+			return err
+		}
+		err = SubmitExecutionTransactionNodeJob(client, job)
+		if err != nil {
+			return fmt.Errorf("error calling SubmitExecutionTransactionNodeJob for job %v: %w", job.ID, err)
+		}
+	}
+	return nil
+}
+
+func SubmitExecutionTransactionNodeJob(client *ethclient.Client, job *types.NodeJob) error {
+	tx, ok := job.GetExecutionTransactionsNodeJobData()
+	if !ok {
+		return fmt.Errorf("invalid job-data")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	jobStatus := types.SubmittedToNodeNodeJobStatus
+	err := client.SendTransaction(ctx, tx)
+	if err != nil {
+		jobStatus = types.FailedNodeJobStatus
+		log.WarnWithFields(log.Fields{"res": err.Error(), "jobID": job.ID, "jobType": job.Type}, "failed submitting a job")
+	}
+	job.Status = jobStatus
+	job.SubmittedToNodeTime.Time = time.Now()
+	job.SubmittedToNodeTime.Valid = true
+	_, err = db.WriterDb.Exec(`update node_jobs set status = $1, submitted_to_node_time = $2 where id = $3`, job.Status, job.SubmittedToNodeTime.Time, job.ID)
+	if err != nil {
+		return err
+	}
+	log.InfoWithFields(log.Fields{"id": job.ID, "type": job.Type, "status": jobStatus}, "submitted node_job")
+	return nil
+}
+
+type executionRequestValidator struct {
+	Index                 uint64 `db:"validatorindex"`
+	Pubkey                []byte `db:"pubkey"`
+	WithdrawalCredentials []byte `db:"withdrawalcredentials"`
+	ActivationEpoch       uint64 `db:"activationepoch"`
+	ExitEpoch             uint64 `db:"exitepoch"`
+	Slashed               bool   `db:"slashed"`
+}
+
+func getExecutionRequestValidators(q sqlx.Queryer, pubkeys [][]byte) (map[phase0.BLSPubKey]*executionRequestValidator, error) {
+	dbValis := []*executionRequestValidator{}
+	err := sqlx.Select(q, &dbValis, `select validatorindex, pubkey, withdrawalcredentials, activationepoch, exitepoch, slashed from validators where pubkey = any($1)`, pq.ByteaArray(pubkeys))
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[phase0.BLSPubKey]*executionRequestValidator, len(dbValis))
+	for _, v := range dbValis {
+		res[phase0.BLSPubKey(v.Pubkey)] = v
+	}
+	return res, nil
+}
+
+// checkExecutionRequestSource checks that the request is sent by the withdrawal address of a validator that has not initiated its exit yet
+func checkExecutionRequestSource(v *executionRequestValidator, sourceAddress common.Address, epoch uint64) error {
+	if len(v.WithdrawalCredentials) != 32 || (v.WithdrawalCredentials[0] != 1 && v.WithdrawalCredentials[0] != 2) {
+		return types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v has no execution withdrawal credentials", v.Index)}
+	}
+	if !bytes.Equal(v.WithdrawalCredentials[12:], sourceAddress.Bytes()) {
+		return types.CreateNodeJobUserError{Message: fmt.Sprintf("source address %v does not match withdrawal credentials of validator with index %v", sourceAddress.Hex(), v.Index)}
+	}
+	return checkExecutionRequestActive(v, epoch)
+}
+
+func checkExecutionRequestActive(v *executionRequestValidator, epoch uint64) error {
+	if v.Slashed {
+		return types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v has been slashed", v.Index)}
+	}
+	if v.ExitEpoch != db.MaxSqlNumber {
+		return types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v has already initiated its exit", v.Index)}
+	}
+	if v.ActivationEpoch > epoch {
+		return types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v is not active", v.Index)}
+	}
+	return nil
+}
+
+func checkExecutionRequestsSupported(epoch uint64) error {
+	if epoch < utils.Config.Chain.ClConfig.ElectraForkEpoch {
+		return types.CreateNodeJobUserError{Message: "execution layer requests are not supported before the electra fork"}
+	}
+	return nil
+}
+
+// GetExecutionRequestFee returns the current fee of the withdrawal- or consolidation-request predeploy, the predeploys return it when they are called without input.
+// Requests revert if the value of their transaction is below the fee at the time of inclusion.
+func GetExecutionRequestFee(ctx context.Context, client *ethclient.Client, predeploy common.Address) (*big.Int, error) {
+	res, err := client.CallContract(ctx, ethereum.CallMsg{To: &predeploy}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fee of predeploy %v: %w", predeploy.Hex(), err)
+	}
+	return new(big.Int).SetBytes(res), nil
+}
+
+// createExecutionRequestNodeJob stores a withdrawal- or consolidation-request job. Submitting the same requests multiple times returns the already existing job.
+func createExecutionRequestNodeJob(nj *types.NodeJob, requests int) (*types.NodeJob, error) {
+	dedupKey := crypto.Keccak256Hash(nj.RawData).Hex()
+	existingJob, err := getExistingNodeJob(nj.Type, dedupKey)
+	if err != nil {
+		return nil, err
+	}
+	if existingJob != nil {
+		return existingJob, nil
+	}
+
+	job, inserted, err := insertNodeJob(nj, dedupKey)
+	if err != nil || !inserted {
+		return job, err
+	}
+	log.InfoWithFields(log.Fields{"id": nj.ID, "type": nj.Type, "requests": requests}, "created node_job")
+	return nj, nil
+}
+
+// CreateWithdrawalRequestsNodeJob creates a job for execution layer triggered exits and partial withdrawals (EIP-7002).
+// The requests have to be sent by the withdrawal address of the validators, so there is nothing to submit to a node.
+// The job stays pending until the effects of all requests are visible on the consensus layer (see UpdateExecutionRequestNodeJobs).
+func CreateWithdrawalRequestsNodeJob(nj *types.NodeJob) (*types.NodeJob, error) {
+	if len(nj.RawData) > 1e6 {
+		return nil, types.CreateNodeJobUserError{Message: "data-size exceeds maximum of 1MB"}
+	}
+	epoch := uint64(utils.TimeToEpoch(time.Now()))
+	err := checkExecutionRequestsSupported(epoch)
+	if err != nil {
+		return nil, err
+	}
+	nj.ID = uuid.New().String()
+	nj.Status = types.PendingNodeJobStatus
+
+	d, ok := nj.GetWithdrawalRequestsNodeJobData()
+	if !ok {
+		return nil, types.CreateNodeJobUserError{Message: "invalid data"}
+	}
+	pubkeys := make([][]byte, 0, len(d))
+	seen := map[phase0.BLSPubKey]bool{}
+	for _, r := range d {
+		if seen[r.ValidatorPubkey] {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("multiple entries for the same validator: %#x", r.ValidatorPubkey)}
+		}
+		seen[r.ValidatorPubkey] = true
+		pubkeys = append(pubkeys, r.ValidatorPubkey[:])
+	}
+
+	valis, err := getExecutionRequestValidators(db.WriterDb, pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range d {
+		v, exists := valis[r.ValidatorPubkey]
+		if !exists {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with pubkey %#x not found", r.ValidatorPubkey)}
+		}
+		err = checkExecutionRequestSource(v, r.SourceAddress, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if v.ActivationEpoch+utils.Config.Chain.ClConfig.ShardCommitteePeriod > epoch {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v has not been active long enough", v.Index)}
+		}
+		if r.Amount > 0 && v.WithdrawalCredentials[0] != 2 {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("partial withdrawals require compounding withdrawal credentials for validator with index %v", v.Index)}
+		}
+	}
+
+	return createExecutionRequestNodeJob(nj, len(d))
+}
+
+// CreateConsolidationRequestsNodeJob creates a job for consolidations and switches to compounding withdrawal credentials (EIP-7251).
+// Like withdrawal requests, the requests have to be sent by the withdrawal address of the source validators.
+func CreateConsolidationRequestsNodeJob(nj *types.NodeJob) (*types.NodeJob, error) {
+	if len(nj.RawData) > 1e6 {
+		return nil, types.CreateNodeJobUserError{Message: "data-size exceeds maximum of 1MB"}
+	}
+	epoch := uint64(utils.TimeToEpoch(time.Now()))
+	err := checkExecutionRequestsSupported(epoch)
+	if err != nil {
+		return nil, err
+	}
+	nj.ID = uuid.New().String()
+	nj.Status = types.PendingNodeJobStatus
+
+	d, ok := nj.GetConsolidationRequestsNodeJobData()
+	if !ok {
+		return nil, types.CreateNodeJobUserError{Message: "invalid data"}
+	}
+	pubkeys := make([][]byte, 0, len(d)*2)
+	seen := map[phase0.BLSPubKey]bool{}
+	for _, r := range d {
+		if seen[r.SourcePubkey] {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("multiple entries for the same source validator: %#x", r.SourcePubkey)}
+		}
+		seen[r.SourcePubkey] = true
+		pubkeys = append(pubkeys, r.SourcePubkey[:], r.TargetPubkey[:])
+	}
+
+	valis, err := getExecutionRequestValidators(db.WriterDb, pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range d {
+		source, exists := valis[r.SourcePubkey]
+		if !exists {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with pubkey %#x not found", r.SourcePubkey)}
+		}
+		err = checkExecutionRequestSource(source, r.SourceAddress, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if r.IsSwitchToCompounding() {
+			if source.WithdrawalCredentials[0] != 1 {
+				return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v already has compounding withdrawal credentials", source.Index)}
+			}
+			continue
+		}
+		if source.ActivationEpoch+utils.Config.Chain.ClConfig.ShardCommitteePeriod > epoch {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v has not been active long enough", source.Index)}
+		}
+		if seen[r.TargetPubkey] {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator %#x is used as source and target", r.TargetPubkey)}
+		}
+		target, exists := valis[r.TargetPubkey]
+		if !exists {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with pubkey %#x not found", r.TargetPubkey)}
+		}
+		err = checkExecutionRequestActive(target, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if target.WithdrawalCredentials[0] != 2 {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("target validator with index %v has no compounding withdrawal credentials", target.Index)}
+		}
+	}
+
+	return createExecutionRequestNodeJob(nj, len(d))
+}
+
+// executionRequestNodeJobTimeout is the time after which withdrawal- and consolidation-request jobs fail if the consensus layer has not processed all of their requests.
+// It covers the exit queue, which can take weeks to process during mass exits.
+const executionRequestNodeJobTimeout = 30 * 24 * time.Hour
+
+// UpdateExecutionRequestNodeJobs marks withdrawal- and consolidation-request jobs as completed once the consensus layer has processed all of their requests.
+// The requests are sent by the withdrawal addresses, so jobs whose requests have never been sent fail after executionRequestNodeJobTimeout.
+func UpdateExecutionRequestNodeJobs() error {
+	jobs := []*types.NodeJob{}
+	err := db.WriterDb.Select(&jobs, `select id, type, status, created_time, submitted_to_node_time, completed_time, data from node_jobs where type in ($1, $2) and status in ($3, $4)`, types.WithdrawalRequestsNodeJobType, types.ConsolidationRequestsNodeJobType, types.PendingNodeJobStatus, types.SubmittedToNodeNodeJobStatus)
+	if err != nil {
+		return err
+	}
+	var pendingPartialWithdrawals map[uint64][]constypes.PendingPartialWithdrawal
+	for _, job := range jobs {
+		if job.Type != types.WithdrawalRequestsNodeJobType {
+			continue
+		}
+		pendingPartialWithdrawals, err = getPendingPartialWithdrawals()
+		if err != nil {
+			return fmt.Errorf("error getting pending partial withdrawals: %w", err)
+		}
+		break
+	}
+	for _, job := range jobs {
+		err := job.ParseData()
+		if err != nil {
+			log.Error(err, fmt.Sprintf("error parsing data of node_job %v", job.ID), 0)
+			continue
+		}
+		completed := false
+		switch job.Type {
+		case types.WithdrawalRequestsNodeJobType:
+			completed, err = isWithdrawalRequestsNodeJobCompleted(job, db.WriterDb, pendingPartialWithdrawals)
+		case types.ConsolidationRequestsNodeJobType:
+			completed, err = isConsolidationRequestsNodeJobCompleted(job, db.WriterDb)
+		}
+		if err != nil {
+			log.Error(err, fmt.Sprintf("error checking node_job %v", job.ID), 0)
+			continue
+		}
+		switch {
+		case completed:
+			job.Status = types.CompletedNodeJobStatus
+		case time.Since(job.CreatedTime) > executionRequestNodeJobTimeout:
+			job.Status = types.FailedNodeJobStatus
+		default:
+			continue
+		}
+		job.CompletedTime.Time = time.Now()
+		job.CompletedTime.Valid = true
+		_, err = db.WriterDb.Exec(`update node_jobs set status = $1, completed_time = $2 where id = $3`, job.Status, job.CompletedTime.Time, job.ID)
+		if err != nil {
+			return err
+		}
+		log.InfoWithFields(log.Fields{"id": job.ID, "type": job.Type, "status": job.Status}, "updated node_job")
+	}
+	return nil
+}
+
+// getPendingPartialWithdrawals returns the partial withdrawals queued by withdrawal requests at the head of the chain by validator index
+func getPendingPartialWithdrawals() (map[uint64][]constypes.PendingPartialWithdrawal, error) {
+	res, err := consapi.NewClient(utils.Config.NodeJobsProcessor.ClEndpoint).GetPendingPartialWithdrawals("head")
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[uint64][]constypes.PendingPartialWithdrawal, len(res.Data))
+	for _, w := range res.Data {
+		pending[w.ValidatorIndex] = append(pending[w.ValidatorIndex], w)
+	}
+	return pending, nil
+}
+
+// isWithdrawalRequestsNodeJobCompleted checks that all exits have been initiated and that all partial withdrawals have been queued since the job has been created
+func isWithdrawalRequestsNodeJobCompleted(job *types.NodeJob, q sqlx.Queryer, pendingPartialWithdrawals map[uint64][]constypes.PendingPartialWithdrawal) (bool, error) {
+	jobData, ok := job.GetWithdrawalRequestsNodeJobData()
+	if !ok {
+		return false, fmt.Errorf("invalid job-data")
+	}
+	pubkeys := make([][]byte, 0, len(jobData))
+	for _, r := range jobData {
+		pubkeys = append(pubkeys, r.ValidatorPubkey[:])
+	}
+	valis, err := getExecutionRequestValidators(q, pubkeys)
+	if err != nil {
+		return false, err
+	}
+	// partial withdrawals queued by the requests of the job can not be withdrawable before this epoch
+	minWithdrawableEpoch := utils.EpochOfSlot(utils.TimeToSlot(uint64(job.CreatedTime.Unix()))) + utils.Config.Chain.ClConfig.MinValidatorWithdrawabilityDelay
+	for _, r := range jobData {
+		v, exists := valis[r.ValidatorPubkey]
+		if !exists {
+			return false, fmt.Errorf("validator %#x not found", r.ValidatorPubkey)
+		}
+		if r.Amount == 0 {
+			if v.ExitEpoch == db.MaxSqlNumber {
+				return false, nil
+			}
+			continue
+		}
+		// the sweep pays out excess balance on its own, so only the partial withdrawal queued by the request shows that it has been processed.
+		// The queued amount is capped to the excess balance of the validator at the time the request is processed.
+		queued := false
+		for _, w := range pendingPartialWithdrawals[v.Index] {
+			if w.WithdrawableEpoch >= minWithdrawableEpoch && w.Amount <= r.Amount {
+				queued = true
+				break
+			}
+		}
+		if !queued {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// isConsolidationRequestsNodeJobCompleted checks that all source validators have initiated their exit, respectively switched to compounding withdrawal credentials
+func isConsolidationRequestsNodeJobCompleted(job *types.NodeJob, q sqlx.Queryer) (bool, error) {
+	jobData, ok := job.GetConsolidationRequestsNodeJobData()
+	if !ok {
+		return false, fmt.Errorf("invalid job-data")
+	}
+	pubkeys := make([][]byte, 0, len(jobData))
+	for _, r := range jobData {
+		pubkeys = append(pubkeys, r.SourcePubkey[:])
+	}
+	valis, err := getExecutionRequestValidators(q, pubkeys)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range jobData {
+		v, exists := valis[r.SourcePubkey]
+		if !exists {
+			return false, fmt.Errorf("validator %#x not found", r.SourcePubkey)
+		}
+		if r.IsSwitchToCompounding() {
+			if v.WithdrawalCredentials[0] != 2 {
+				return false, nil
+			}
+			continue
+		}
+		if v.ExitEpoch == db.MaxSqlNumber {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,309 @@
+package notification
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// number of epochs (~1 day) the rolling baseline of a group is computed from
+	performanceBaselineEpochs = 225
+	// number of most recent epochs that are compared against the baseline
+	performanceEvaluationEpochs = 4
+	// a duty is only evaluated if the group had data for it in at least this many baseline epochs
+	performanceMinBaselineEpochs = 32
+)
+
+type PerformanceDuty string
+
+const (
+	PerformanceDutySource         PerformanceDuty = "source"
+	PerformanceDutyTarget         PerformanceDuty = "target"
+	PerformanceDutyHead           PerformanceDuty = "head"
+	PerformanceDutyInclusionDelay PerformanceDuty = "inclusion_delay"
+	PerformanceDutySync           PerformanceDuty = "sync"
+)
+
+type performanceDutyConfig struct {
+	Duty          PerformanceDuty
+	LowerIsBetter bool
+	// lower bound for the standard deviation of the baseline, prevents flagging tiny changes of groups that performed perfectly so far
+	MinStdDev float64
+	// minimum absolute degradation before a deviation is considered significant
+	MinDeviation float64
+}
+
+var performanceDuties = []performanceDutyConfig{
+	{Duty: PerformanceDutySource, MinStdDev: 0.005, MinDeviation: 0.01},
+	{Duty: PerformanceDutyTarget, MinStdDev: 0.005, MinDeviation: 0.01},
+	{Duty: PerformanceDutyHead, MinStdDev: 0.005, MinDeviation: 0.01},
+	{Duty: PerformanceDutyInclusionDelay, LowerIsBetter: true, MinStdDev: 0.05, MinDeviation: 0.1},
+	{Duty: PerformanceDutySync, MinStdDev: 0.01, MinDeviation: 0.05},
+}
+
+func (d PerformanceDuty) describe(value, baseline float64) string {
+	switch d {
+	case PerformanceDutySource:
+		return fmt.Sprintf("source vote correctness dropped to %.2f%% (baseline %.2f%%)", value*100, baseline*100)
+	case PerformanceDutyTarget:
+		return fmt.Sprintf("target vote correctness dropped to %.2f%% (baseline %.2f%%)", value*100, baseline*100)
+	case PerformanceDutyHead:
+		return fmt.Sprintf("head vote correctness dropped to %.2f%% (baseline %.2f%%)", value*100, baseline*100)
+	case PerformanceDutyInclusionDelay:
+		return fmt.Sprintf("average inclusion distance rose to %.2f slots (baseline %.2f slots)", value, baseline)
+	case PerformanceDutySync:
+		return fmt.Sprintf("sync committee participation dropped to %.2f%% (baseline %.2f%%)", value*100, baseline*100)
+	}
+	return string(d)
+}
+
+// performanceEpochRow holds the duties of all validators of a group summed up for one epoch
+type performanceEpochRow struct {
+	Epoch                     uint64 `db:"epoch"`
+	AttestationsScheduled     uint64 `db:"attestations_scheduled"`
+	AttestationsExecuted      uint64 `db:"attestations_executed"`
+	AttestationHeadExecuted   uint64 `db:"attestation_head_executed"`
+	AttestationSourceExecuted uint64 `db:"attestation_source_executed"`
+	AttestationTargetExecuted uint64 `db:"attestation_target_executed"`
+	InclusionDelaySum         int64  `db:"inclusion_delay_sum"`
+	SyncScheduled             uint64 `db:"sync_scheduled"`
+	SyncExecuted              uint64 `db:"sync_executed"`
+}
+
+// value returns the performance of the group for the duty, ok is false if the group had no such duty in the epoch
+func (r *performanceEpochRow) value(duty PerformanceDuty) (value float64, ok bool) {
+	switch duty {
+	case PerformanceDutySource:
+		return ratio(r.AttestationSourceExecuted, r.AttestationsScheduled)
+	case PerformanceDutyTarget:
+		return ratio(r.AttestationTargetExecuted, r.AttestationsScheduled)
+	case PerformanceDutyHead:
+		return ratio(r.AttestationHeadExecuted, r.AttestationsScheduled)
+	case PerformanceDutyInclusionDelay:
+		if r.AttestationsExecuted == 0 {
+			return 0, false
+		}
+		return 1.0 + float64(r.InclusionDelaySum)/float64(r.AttestationsExecuted), true
+	case PerformanceDutySync:
+		return ratio(r.SyncExecuted, r.SyncScheduled)
+	}
+	return 0, false
+}
+
+func ratio(executed, scheduled uint64) (float64, bool) {
+	if scheduled == 0 {
+		return 0, false
+	}
+	return float64(executed) / float64(scheduled), true
+}
+
+// detectPerformanceAnomalies compares the average of each duty over the evaluation window ending at epoch with the
+// rolling baseline before it and returns the duties that degraded by at least threshold standard deviations
+func detectPerformanceAnomalies(rows []performanceEpochRow, epoch uint64, threshold float64) []PerformanceAnomaly {
+	if epoch < performanceEvaluationEpochs {
+		return nil
+	}
+	evaluationStart := epoch - performanceEvaluationEpochs + 1
+	var anomalies []PerformanceAnomaly
+	for _, cfg := range performanceDuties {
+		var baseline, current []float64
+		for i := range rows {
+			if rows[i].Epoch > epoch {
+				continue
+			}
+			value, ok := rows[i].value(cfg.Duty)
+			if !ok {
+				continue
+			}
+			if rows[i].Epoch >= evaluationStart {
+				current = append(current, value)
+			} else if rows[i].Epoch+performanceBaselineEpochs >= evaluationStart {
+				baseline = append(baseline, value)
+			}
+		}
+		if len(baseline) < performanceMinBaselineEpochs || len(current) == 0 {
+			continue
+		}
+
+		baselineMean, baselineStdDev := meanAndStdDev(baseline)
+		currentMean, _ := meanAndStdDev(current)
+		deviation := baselineMean - currentMean
+		if cfg.LowerIsBetter {
+			deviation = -deviation
+		}
+		if deviation < cfg.MinDeviation {
+			continue
+		}
+		zScore := deviation / math.Max(baselineStdDev, cfg.MinStdDev)
+		if zScore < threshold {
+			continue
+		}
+		anomalies = append(anomalies, PerformanceAnomaly{
+			Duty:     cfg.Duty,
+			Value:    currentMean,
+			Baseline: baselineMean,
+			ZScore:   zScore,
+		})
+	}
+	return anomalies
+}
+
+func meanAndStdDev(values []float64) (mean, stdDev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		stdDev += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(stdDev / float64(len(values)))
+}
+
+// collectPerformanceAnomalyNotifications computes rolling baselines for every subscribed dashboard group from the clickhouse
+// epoch data and notifies about duties that degraded significantly. To avoid repeating the same notification every epoch
+// while a degradation persists, a duty is only reported in the first epoch it is detected.
+func collectPerformanceAnomalyNotifications(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	if epoch < performanceBaselineEpochs+performanceEvaluationEpochs {
+		return nil
+	}
+
+	subMap, err := GetSubsForEventFilter(types.ValidatorPerformanceAnomalyEventName, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for performance anomalies: %w", err)
+	}
+
+	type groupDetails struct {
+		Validators   []types.ValidatorIndex
+		Subscription *types.Subscription
+	}
+	type groupKey struct {
+		UserId      types.UserId
+		DashboardId types.DashboardId
+		GroupId     types.DashboardGroupId
+	}
+	groups := make(map[groupKey]*groupDetails)
+	for pubkey, subs := range subMap {
+		pubkeyDecoded, err := hex.DecodeString(pubkey)
+		if err != nil {
+			return fmt.Errorf("error decoding pubkey %v: %w", pubkey, err)
+		}
+		validatorIndex, err := GetIndexForPubkey(pubkeyDecoded)
+		if err != nil {
+			return fmt.Errorf("error getting validator index for pubkey %v: %w", pubkey, err)
+		}
+		for _, sub := range subs {
+			if sub.DashboardId == nil || sub.DashboardGroupId == nil {
+				continue
+			}
+			key := groupKey{*sub.UserID, types.DashboardId(*sub.DashboardId), types.DashboardGroupId(*sub.DashboardGroupId)}
+			if _, ok := groups[key]; !ok {
+				groups[key] = &groupDetails{Subscription: sub}
+			}
+			groups[key].Validators = append(groups[key].Validators, types.ValidatorIndex(validatorIndex))
+		}
+	}
+	log.Infof("evaluating performance anomalies of %d groups", len(groups))
+
+	startEpoch := uint64(0)
+	if epoch > performanceBaselineEpochs+performanceEvaluationEpochs {
+		startEpoch = epoch - performanceBaselineEpochs - performanceEvaluationEpochs
+	}
+	startTs := utils.EpochToTime(startEpoch).Unix()
+	endTs := utils.EpochToTime(epoch).Unix()
+
+	var mu sync.Mutex
+	g := errgroup.Group{}
+	g.SetLimit(10)
+	for key, group := range groups {
+		g.Go(func() error {
+			slices.Sort(group.Validators)
+			ds := goqu.Dialect("postgres").
+				Select(
+					goqu.L("epoch"),
+					goqu.L("SUM(COALESCE(attestations_scheduled, 0)) AS attestations_scheduled"),
+					goqu.L("SUM(COALESCE(attestations_executed, 0)) AS attestations_executed"),
+					goqu.L("SUM(COALESCE(attestation_head_executed, 0)) AS attestation_head_executed"),
+					goqu.L("SUM(COALESCE(attestation_source_executed, 0)) AS attestation_source_executed"),
+					goqu.L("SUM(COALESCE(attestation_target_executed, 0)) AS attestation_target_executed"),
+					goqu.L("SUM(COALESCE(inclusion_delay_sum, 0)) AS inclusion_delay_sum"),
+					goqu.L("SUM(COALESCE(sync_scheduled, 0)) AS sync_scheduled"),
+					goqu.L("SUM(COALESCE(sync_executed, 0)) AS sync_executed")).
+				From(goqu.L("validator_dashboard_data_epoch FINAL")).
+				Where(
+					goqu.L("epoch_timestamp >= fromUnixTimestamp(?)", startTs),
+					goqu.L("epoch_timestamp <= fromUnixTimestamp(?)", endTs),
+					goqu.L("validator_index IN ?", group.Validators)).
+				GroupBy(goqu.L("epoch")).
+				Order(goqu.L("epoch").Asc())
+			query, args, err := ds.Prepared(true).ToSQL()
+			if err != nil {
+				return fmt.Errorf("error preparing performance query: %w", err)
+			}
+			var rows []performanceEpochRow
+			err = db.ClickHouseReader.Select(&rows, query, args...)
+			if err != nil {
+				return fmt.Errorf("error retrieving performance data of dashboard %v group %v: %w", key.DashboardId, key.GroupId, err)
+			}
+
+			threshold := group.Subscription.EventThreshold
+			if threshold <= 0 {
+				threshold = types.PerformanceAnomalyThresholdDefault
+			}
+			anomalies := detectPerformanceAnomalies(rows, epoch, threshold)
+			if len(anomalies) == 0 {
+				return nil
+			}
+			// only report duties that just started to degrade
+			previous := detectPerformanceAnomalies(rows, epoch-1, threshold)
+			anomalies = slices.DeleteFunc(anomalies, func(a PerformanceAnomaly) bool {
+				return slices.ContainsFunc(previous, func(p PerformanceAnomaly) bool { return p.Duty == a.Duty })
+			})
+			if len(anomalies) == 0 {
+				return nil
+			}
+
+			log.Infof("creating performance anomaly notification for user %v, dashboard %v, group %v in epoch %v", key.UserId, key.DashboardId, key.GroupId, epoch)
+			n := &ValidatorPerformanceAnomalyNotification{
+				NotificationBaseImpl: types.NotificationBaseImpl{
+					SubscriptionID:     *group.Subscription.ID,
+					UserID:             *group.Subscription.UserID,
+					Epoch:              epoch,
+					EventName:          group.Subscription.EventName,
+					EventFilter:        "-",
+					DashboardId:        group.Subscription.DashboardId,
+					DashboardName:      group.Subscription.DashboardName,
+					DashboardGroupId:   group.Subscription.DashboardGroupId,
+					DashboardGroupName: group.Subscription.DashboardGroupName,
+				},
+				Threshold: threshold,
+				Anomalies: anomalies,
+			}
+			mu.Lock()
+			notificationsByUserID.AddNotification(n)
+			mu.Unlock()
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+			return nil
+		})
+	}
+	err = g.Wait()
+	if err != nil {
+		return err
+	}
+
+	log.Info("done collecting performance anomaly notifications")
+	return nil
+}
@@ -0,0 +1,82 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// performanceRows returns one row per epoch in [from, to] for a group of 64 validators with the given head votes and inclusion delay sum per epoch
+func performanceRows(from, to uint64, headExecuted func(epoch uint64) uint64, inclusionDelaySum int64) []performanceEpochRow {
+	var rows []performanceEpochRow
+	for epoch := from; epoch <= to; epoch++ {
+		rows = append(rows, performanceEpochRow{
+			Epoch:                     epoch,
+			AttestationsScheduled:     64,
+			AttestationsExecuted:      64,
+			AttestationHeadExecuted:   headExecuted(epoch),
+			AttestationSourceExecuted: 64,
+			AttestationTargetExecuted: 64,
+			InclusionDelaySum:         inclusionDelaySum,
+		})
+	}
+	return rows
+}
+
+func TestDetectPerformanceAnomalies(t *testing.T) {
+	const epoch = 1000
+	baselineStart := uint64(epoch - performanceBaselineEpochs - performanceEvaluationEpochs + 1)
+	// head votes alternate between 62 and 64 correct votes in the baseline, the sync committee is idle
+	baselineHead := func(e uint64) uint64 { return 62 + 2*(e%2) }
+
+	rows := performanceRows(baselineStart, epoch, baselineHead, 0)
+	assert.Empty(t, detectPerformanceAnomalies(rows, epoch, 3), "stable performance must not be flagged")
+
+	// head votes drop to ~80% in the evaluation window
+	rows = performanceRows(baselineStart, epoch, func(e uint64) uint64 {
+		if e > epoch-performanceEvaluationEpochs {
+			return 51
+		}
+		return baselineHead(e)
+	}, 0)
+	anomalies := detectPerformanceAnomalies(rows, epoch, 3)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, PerformanceDutyHead, anomalies[0].Duty)
+	assert.InDelta(t, 51.0/64, anomalies[0].Value, 1e-9)
+	assert.InDelta(t, 63.0/64, anomalies[0].Baseline, 1e-3)
+	assert.Greater(t, anomalies[0].ZScore, 3.0)
+	assert.Empty(t, detectPerformanceAnomalies(rows, epoch, 100), "deviation below the threshold must not be flagged")
+	assert.Empty(t, detectPerformanceAnomalies(rows, epoch-performanceEvaluationEpochs, 3), "epochs before the drop must not be flagged")
+
+	// improvements are never anomalies
+	rows = performanceRows(baselineStart, epoch, func(e uint64) uint64 {
+		if e > epoch-performanceEvaluationEpochs {
+			return 64
+		}
+		return 40 + 24*(e%2)
+	}, 0)
+	assert.Empty(t, detectPerformanceAnomalies(rows, epoch, 1))
+
+	// a group that was perfect so far is not flagged for a single missed vote, but the inclusion delay is lower-is-better
+	rows = performanceRows(baselineStart, epoch, func(e uint64) uint64 {
+		if e == epoch {
+			return 63
+		}
+		return 64
+	}, 0)
+	for i := range rows {
+		if rows[i].Epoch > epoch-performanceEvaluationEpochs {
+			rows[i].InclusionDelaySum = 64
+		}
+	}
+	anomalies = detectPerformanceAnomalies(rows, epoch, 3)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, PerformanceDutyInclusionDelay, anomalies[0].Duty)
+	assert.InDelta(t, 2.0, anomalies[0].Value, 1e-9)
+	assert.InDelta(t, 1.0, anomalies[0].Baseline, 1e-9)
+
+	// not enough history
+	rows = performanceRows(epoch-performanceMinBaselineEpochs, epoch, func(uint64) uint64 { return 0 }, 0)
+	assert.Empty(t, detectPerformanceAnomalies(rows, epoch, 3))
+}
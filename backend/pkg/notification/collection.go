@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/ethclients"
+	"github.com/gobitfly/beaconchain/pkg/commons/gasoracle"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
 	"github.com/gobitfly/beaconchain/pkg/commons/services"
@@ -51,10 +53,12 @@ func notificationCollector() {
 		gob.Register(&ValidatorProposalNotification{})
 		gob.Register(&ValidatorUpcomingProposalNotification{})
 		gob.Register(&ValidatorGroupEfficiencyNotification{})
+		gob.Register(&ValidatorPerformanceAnomalyNotification{})
 		gob.Register(&ValidatorAttestationNotification{})
 		gob.Register(&ValidatorIsOfflineNotification{})
 		gob.Register(&ValidatorIsOnlineNotification{})
 		gob.Register(&ValidatorGotSlashedNotification{})
+		gob.Register(&ValidatorSlashingRiskNotification{})
 		gob.Register(&ValidatorWithdrawalNotification{})
 		gob.Register(&NetworkNotification{})
 		gob.Register(&RocketpoolNotification{})
@@ -116,6 +120,17 @@ func notificationCollector() {
 		}
 	}()
 
+	go func() {
+		log.Infof("starting slashing risk notification collector")
+		for ; ; time.Sleep(time.Second * time.Duration(utils.Config.Chain.ClConfig.SecondsPerSlot)) {
+			err := collectSlashingRiskNotifications()
+			if err != nil {
+				metrics.Errors.WithLabelValues("notifications_collect_slashing_risk").Inc()
+				log.Error(err, "error collecting slashing risk notifications", 0)
+			}
+		}
+	}()
+
 	for {
 		latestFinalizedEpoch := cache.LatestFinalizedEpoch.Get()
 
@@ -328,6 +343,13 @@ func collectNotifications(mc modules.ModuleContext, epoch uint64) (types.Notific
 	}
 	log.Infof("collecting group efficiency notifications took: %v", time.Since(start))
 
+	err = collectPerformanceAnomalyNotifications(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_performance_anomaly").Inc()
+		return nil, fmt.Errorf("error collecting validator_performance_anomaly notifications: %v", err)
+	}
+	log.Infof("collecting performance anomaly notifications took: %v", time.Since(start))
+
 	err = collectAttestationAndOfflineValidatorNotifications(notificationsByUserID, epoch)
 	if err != nil {
 		metrics.Errors.WithLabelValues("notifications_collect_missed_attestation").Inc()
@@ -363,7 +385,7 @@ func collectNotifications(mc modules.ModuleContext, epoch uint64) (types.Notific
 	}
 	log.Infof("collecting validator got slashed notifications took: %v", time.Since(start))
 
-	err = collectWithdrawalNotifications(notificationsByUserID, epoch)
+	err = collectWithdrawalNotifications(notificationsByUserID, mc, epoch)
 	if err != nil {
 		metrics.Errors.WithLabelValues("notifications_collect_validator_withdrawal").Inc()
 		return nil, fmt.Errorf("error collecting withdrawal notifications: %v", err)
@@ -481,25 +503,18 @@ func collectUserDbNotifications(epoch uint64) (types.NotificationsPerUserId, err
 }
 
 func collectGasPriceNotifications(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
-	// retrieve gas price history
-	ts := time.Now()
-	duration := time.Minute * 10
-	gasPrices, err := db.BigtableClient.GetGasNowHistory(ts, ts.Add(duration*-1))
+	// retrieve the recent recommendations of the gas oracle
+	rapidGasPrice, err := gasoracle.RecentAverage(time.Minute * 10)
 	if err != nil {
-		return fmt.Errorf("error getting gas price history: %w", err)
+		return err
 	}
 
-	if len(gasPrices) == 0 {
+	if rapidGasPrice == nil {
 		log.Warnf("no gas price data found for epoch %v", epoch)
 		return nil
 	}
 
-	averageGasPrice := decimal.NewFromInt(0)
-	for _, gasPrice := range gasPrices {
-		log.Infof("gas price for %v: %v", gasPrice.Ts, gasPrice.Rapid)
-		averageGasPrice = averageGasPrice.Add(decimal.NewFromBigInt(gasPrice.Rapid, 0))
-	}
-	averageGasPrice = averageGasPrice.Div(decimal.NewFromInt(int64(len(gasPrices)))).Div(decimal.NewFromInt(params.GWei))
+	averageGasPrice := decimal.NewFromBigInt(rapidGasPrice, 0).Div(decimal.NewFromInt(params.GWei))
 
 	log.Infof("average gas price is %f GWei", averageGasPrice.InexactFloat64())
 
@@ -1309,7 +1324,7 @@ func collectValidatorGotSlashedNotifications(notificationsByUserID types.Notific
 }
 
 // collectWithdrawalNotifications collects all notifications validator withdrawals
-func collectWithdrawalNotifications(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+func collectWithdrawalNotifications(notificationsByUserID types.NotificationsPerUserId, mc modules.ModuleContext, epoch uint64) error {
 	// get all users that are subscribed to this event (scale: a few thousand rows depending on how many users we have)
 	subMap, err := GetSubsForEventFilter(types.ValidatorReceivedWithdrawalEventName, "", nil, nil)
 	if err != nil {
@@ -1322,8 +1337,14 @@ func collectWithdrawalNotifications(notificationsByUserID types.NotificationsPer
 		return fmt.Errorf("error getting withdrawals from database, err: %w", err)
 	}
 
+	partialWithdrawals, err := getProcessedPartialWithdrawals(mc, events, epoch)
+	if err != nil {
+		return fmt.Errorf("error getting pending partial withdrawals, err: %w", err)
+	}
+
 	log.Infof("retrieved %v events", len(events))
 	for _, event := range events {
+		withdrawalType := getWithdrawalType(event, epoch, partialWithdrawals)
 		subscribers, ok := subMap[hex.EncodeToString(event.Pubkey)]
 		if ok {
 			for _, sub := range subscribers {
@@ -1354,6 +1375,7 @@ func collectWithdrawalNotifications(notificationsByUserID types.NotificationsPer
 					Slot:           event.Slot,
 					Amount:         event.Amount,
 					Address:        event.Address,
+					Type:           withdrawalType,
 				}
 				notificationsByUserID.AddNotification(n)
 				metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
@@ -1364,6 +1386,48 @@ func collectWithdrawalNotifications(notificationsByUserID types.NotificationsPer
 	return nil
 }
 
+// getProcessedPartialWithdrawals returns the partial withdrawals requested by withdrawal addresses (EIP-7002) that are withdrawable in the epoch by validator index.
+// They are queued at least MIN_VALIDATOR_WITHDRAWABILITY_DELAY epochs before, so the state at the end of the previous epoch contains all of them.
+func getProcessedPartialWithdrawals(mc modules.ModuleContext, events []*types.WithdrawalsNotification, epoch uint64) (map[uint64][]constypes.PendingPartialWithdrawal, error) {
+	if epoch <= utils.Config.Chain.ClConfig.ElectraForkEpoch {
+		return nil, nil
+	}
+	// only compounding validators can request partial withdrawals
+	if !slices.ContainsFunc(events, func(event *types.WithdrawalsNotification) bool {
+		return utils.HasCompoundingWithdrawalCredential(event.WithdrawalCredentials)
+	}) {
+		return nil, nil
+	}
+	res, err := mc.CL.GetPendingPartialWithdrawals(epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch - 1)
+	if err != nil {
+		return nil, err
+	}
+	partialWithdrawals := make(map[uint64][]constypes.PendingPartialWithdrawal)
+	for _, w := range res.Data {
+		if w.WithdrawableEpoch <= epoch {
+			partialWithdrawals[w.ValidatorIndex] = append(partialWithdrawals[w.ValidatorIndex], w)
+		}
+	}
+	return partialWithdrawals, nil
+}
+
+// getWithdrawalType classifies a withdrawal by the state of the validator and the processed partial withdrawals, since electra not all withdrawals are sweeps of the excess balance.
+// Matched partial withdrawals are removed, so the events have to be passed in the order of their withdrawal index.
+func getWithdrawalType(event *types.WithdrawalsNotification, epoch uint64, partialWithdrawals map[uint64][]constypes.PendingPartialWithdrawal) WithdrawalType {
+	if event.WithdrawableEpoch <= epoch {
+		return FullWithdrawalType
+	}
+	// the consensus layer caps the withdrawn amount of a partial withdrawal to the excess balance of the validator
+	pending := partialWithdrawals[event.ValidatorIndex]
+	for i, w := range pending {
+		if w.Amount >= event.Amount {
+			partialWithdrawals[event.ValidatorIndex] = slices.Delete(pending, i, i+1)
+			return PartialWithdrawalType
+		}
+	}
+	return SweepWithdrawalType
+}
+
 func collectEthClientNotifications(notificationsByUserID types.NotificationsPerUserId) error {
 	updatedClients := ethclients.GetUpdatedClients() //only check if there are new updates
 	for _, client := range updatedClients {
@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWithdrawalType(t *testing.T) {
+	const epoch = 100
+	compounding := append([]byte{0x02}, make([]byte, 31)...)
+	withdrawal := func(validator uint64, amount uint64, withdrawableEpoch uint64) *types.WithdrawalsNotification {
+		return &types.WithdrawalsNotification{ValidatorIndex: validator, Amount: amount, WithdrawalCredentials: compounding, WithdrawableEpoch: withdrawableEpoch}
+	}
+	partialWithdrawals := map[uint64][]constypes.PendingPartialWithdrawal{
+		1: {{ValidatorIndex: 1, Amount: 5_000_000_000, WithdrawableEpoch: epoch}},
+	}
+
+	// the sweep of a compounding validator without a requested partial withdrawal
+	assert.Equal(t, SweepWithdrawalType, getWithdrawalType(withdrawal(0, 1_000_000_000, 200), epoch, partialWithdrawals))
+	// the sweep withdraws more than requested, so it is not the partial withdrawal
+	assert.Equal(t, SweepWithdrawalType, getWithdrawalType(withdrawal(1, 6_000_000_000, 200), epoch, partialWithdrawals))
+	// the requested amount is capped to the excess balance
+	assert.Equal(t, PartialWithdrawalType, getWithdrawalType(withdrawal(1, 4_000_000_000, 200), epoch, partialWithdrawals))
+	// each partial withdrawal is only matched once
+	assert.Equal(t, SweepWithdrawalType, getWithdrawalType(withdrawal(1, 4_000_000_000, 200), epoch, partialWithdrawals))
+	assert.Equal(t, FullWithdrawalType, getWithdrawalType(withdrawal(2, 2_048_000_000_000, epoch), epoch, partialWithdrawals))
+}
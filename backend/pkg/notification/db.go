@@ -16,6 +16,71 @@ import (
 	"github.com/lib/pq"
 )
 
+// parseDashboardEventFilter parses the event filter of a validator dashboard subscription,
+// vdb:{dashboard_id}:{group_id} or vdb:{dashboard_id}:{group_id}:{tag_key}={tag_value} for subscriptions that only apply to the validators with a tag
+func parseDashboardEventFilter(filter string) (dashboardId, groupId int64, tag *[2]string, err error) {
+	dashboardData := strings.Split(filter, ":")
+	if len(dashboardData) != 3 && len(dashboardData) != 4 || dashboardData[0] != "vdb" {
+		return 0, 0, nil, fmt.Errorf("invalid dashboard subscription: %s", filter)
+	}
+	dashboardId, err = strconv.ParseInt(dashboardData[1], 10, 64)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid dashboard id in subscription %s: %w", filter, err)
+	}
+	groupId, err = strconv.ParseInt(dashboardData[2], 10, 64)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid group id in subscription %s: %w", filter, err)
+	}
+	if len(dashboardData) == 4 {
+		tagKey, tagValue, ok := strings.Cut(dashboardData[3], "=")
+		if !ok {
+			return 0, 0, nil, fmt.Errorf("invalid dashboard tag subscription: %s", filter)
+		}
+		tag = &[2]string{tagKey, tagValue}
+	}
+	return dashboardId, groupId, tag, nil
+}
+
+// dedupeDashboardSubscriptions keeps a single subscription per user and dashboard group of the hydrated subscriptions of a validator (or node),
+// a validator can be matched by the subscription of its group and by subscriptions of its tags.
+// Tag subscriptions are preferred since their settings are more specific, ties are broken by the lowest subscription id.
+func dedupeDashboardSubscriptions(subs []*types.Subscription) []*types.Subscription {
+	type groupKey struct {
+		UserId      types.UserId
+		DashboardId int64
+		GroupId     int64
+	}
+	isTagSub := func(sub *types.Subscription) bool {
+		return sub.DashboardTag != ""
+	}
+	kept := make(map[groupKey]int)
+	result := make([]*types.Subscription, 0, len(subs))
+	for _, sub := range subs {
+		if sub.DashboardId == nil || sub.DashboardGroupId == nil || sub.UserID == nil {
+			result = append(result, sub)
+			continue
+		}
+		key := groupKey{*sub.UserID, *sub.DashboardId, *sub.DashboardGroupId}
+		i, ok := kept[key]
+		if !ok {
+			kept[key] = len(result)
+			result = append(result, sub)
+			continue
+		}
+		existing := result[i]
+		if isTagSub(sub) != isTagSub(existing) {
+			if isTagSub(sub) {
+				result[i] = sub
+			}
+			continue
+		}
+		if sub.ID != nil && (existing.ID == nil || *sub.ID < *existing.ID) {
+			result[i] = sub
+		}
+	}
+	return result
+}
+
 // Retrieves all subscription for a given event filter
 // Map key corresponds to the event filter which can be
 // a validator pubkey or an eth1 address (for RPL notifications)
@@ -84,29 +149,26 @@ func GetSubsForEventFilter(eventName types.EventName, lastSentFilter string, las
 	log.Infof("found %d subscriptions for event %s", len(subs), eventName)
 
 	dashboardConfigsToFetch := make([]types.DashboardId, 0)
+	// subscriptions that only apply to the validators of a group with a tag, the value is {key, value}
+	subTags := make(map[*types.Subscription][2]string)
+	dashboardTagsToFetch := make([]types.DashboardId, 0)
 	for _, sub := range subs {
 		// sub.LastEpoch = &zero
 		// sub.LastSent = &time.Time{}
 		sub.EventName = types.EventName(strings.Replace(string(sub.EventName), utils.GetNetwork()+":", "", 1)) // remove the network name from the event name
 		if strings.HasPrefix(sub.EventFilter, "vdb:") {
-			dashboardData := strings.Split(sub.EventFilter, ":")
-			if len(dashboardData) != 3 {
-				log.Error(fmt.Errorf("invalid dashboard subscription: %s", sub.EventFilter), "invalid dashboard subscription", 0)
-				continue
-			}
-			dashboardId, err := strconv.ParseInt(dashboardData[1], 10, 64)
+			dashboardId, dashboardGroupId, tag, err := parseDashboardEventFilter(sub.EventFilter)
 			if err != nil {
-				log.Error(err, "Invalid dashboard subscription", 0)
+				log.Error(err, "invalid dashboard subscription", 0)
 				continue
 			}
 			sub.DashboardId = &dashboardId
+			sub.DashboardGroupId = &dashboardGroupId
 
-			dashboardGroupId, err := strconv.ParseInt(dashboardData[2], 10, 64)
-			if err != nil {
-				log.Error(err, "Invalid dashboard subscription", 0)
-				continue
+			if tag != nil {
+				subTags[sub] = *tag
+				dashboardTagsToFetch = append(dashboardTagsToFetch, types.DashboardId(dashboardId))
 			}
-			sub.DashboardGroupId = &dashboardGroupId
 
 			dashboardConfigsToFetch = append(dashboardConfigsToFetch, types.DashboardId(dashboardId))
 		} else {
@@ -174,6 +236,34 @@ func GetSubsForEventFilter(eventName types.EventName, lastSentFilter string, las
 
 		log.Infof("retrieving dashboard definitions took: %v", time.Since(dashboardConfigRetrievalStartTs))
 
+		// Get the tags of the validators for subscriptions that are scoped to a tag
+		validatorTags := make(map[types.DashboardId]map[types.ValidatorIndex]map[string]string)
+		if len(dashboardTagsToFetch) > 0 {
+			var tagRows []struct {
+				DashboardId    types.DashboardId    `db:"dashboard_id"`
+				ValidatorIndex types.ValidatorIndex `db:"validator_index"`
+				Key            string               `db:"tag_key"`
+				Value          string               `db:"tag_value"`
+			}
+			err = db.AlloyWriter.Select(&tagRows, `
+			SELECT dashboard_id, validator_index, tag_key, tag_value
+			FROM users_val_dashboards_validator_tags
+			WHERE dashboard_id = ANY($1)
+		`, pq.Array(dashboardTagsToFetch))
+			if err != nil {
+				return nil, fmt.Errorf("error getting dashboard validator tags: %v", err)
+			}
+			for _, row := range tagRows {
+				if validatorTags[row.DashboardId] == nil {
+					validatorTags[row.DashboardId] = make(map[types.ValidatorIndex]map[string]string)
+				}
+				if validatorTags[row.DashboardId][row.ValidatorIndex] == nil {
+					validatorTags[row.DashboardId][row.ValidatorIndex] = make(map[string]string)
+				}
+				validatorTags[row.DashboardId][row.ValidatorIndex][row.Key] = row.Value
+			}
+		}
+
 		// Now collect the mapping of rocketpool node addresses to validator pubkeys
 		// This is needed for the rocketpool notifications
 		type rocketpoolNodeRow struct {
@@ -208,10 +298,20 @@ func GetSubsForEventFilter(eventName types.EventName, lastSentFilter string, las
 						if group.Name == "" {
 							group.Name = "default"
 						}
+						groupName := group.Name
+						dashboardTag := ""
+						tag, hasTag := subTags[sub]
+						if hasTag {
+							dashboardTag = fmt.Sprintf("%s=%s", tag[0], tag[1])
+							groupName = fmt.Sprintf("%s (%s)", group.Name, dashboardTag)
+						}
 
 						uniqueRPLNodes := make(map[string]struct{})
 
 						for _, validatorIndex := range group.Validators {
+							if hasTag && validatorTags[types.DashboardId(*sub.DashboardId)][types.ValidatorIndex(validatorIndex)][tag[0]] != tag[1] {
+								continue
+							}
 							validatorEventFilterRaw, err := GetPubkeyForIndex(validatorIndex)
 							if err != nil {
 								log.Error(err, "error retrieving pubkey for validator", 0, map[string]interface{}{"validator": validatorIndex})
@@ -244,7 +344,8 @@ func GetSubsForEventFilter(eventName types.EventName, lastSentFilter string, las
 										DashboardId:        sub.DashboardId,
 										DashboardName:      dashboard.Name,
 										DashboardGroupId:   sub.DashboardGroupId,
-										DashboardGroupName: group.Name,
+										DashboardGroupName: groupName,
+										DashboardTag:       dashboardTag,
 									}
 									subMap[nodeAddress] = append(subMap[nodeAddress], hydratedSub)
 									//log.Infof("hydrated subscription for validator %v of dashboard %d and group %d for user %d", hydratedSub.EventFilter, *hydratedSub.DashboardId, *hydratedSub.DashboardGroupId, *hydratedSub.UserID)
@@ -267,7 +368,8 @@ func GetSubsForEventFilter(eventName types.EventName, lastSentFilter string, las
 									DashboardId:        sub.DashboardId,
 									DashboardName:      dashboard.Name,
 									DashboardGroupId:   sub.DashboardGroupId,
-									DashboardGroupName: group.Name,
+									DashboardGroupName: groupName,
+									DashboardTag:       dashboardTag,
 								}
 								subMap[validatorEventFilter] = append(subMap[validatorEventFilter], hydratedSub)
 								//log.Infof("hydrated subscription for validator %v of dashboard %d and group %d for user %d", hydratedSub.EventFilter, *hydratedSub.DashboardId, *hydratedSub.DashboardGroupId, *hydratedSub.UserID)
@@ -277,6 +379,9 @@ func GetSubsForEventFilter(eventName types.EventName, lastSentFilter string, las
 				}
 			}
 		}
+		for filter, filterSubs := range subMap {
+			subMap[filter] = dedupeDashboardSubscriptions(filterSubs)
+		}
 		//log.Infof("hydrated %d subscriptions for event %s", len(subMap), eventName)
 	}
 
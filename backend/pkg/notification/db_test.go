@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDashboardEventFilter(t *testing.T) {
+	dashboardId, groupId, tag, err := parseDashboardEventFilter("vdb:12:3")
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), dashboardId)
+	assert.Equal(t, int64(3), groupId)
+	assert.Nil(t, tag)
+
+	dashboardId, groupId, tag, err = parseDashboardEventFilter("vdb:12:3:operator=lido")
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), dashboardId)
+	assert.Equal(t, int64(3), groupId)
+	assert.Equal(t, &[2]string{"operator", "lido"}, tag)
+
+	for _, filter := range []string{"vdb:12", "vdb:12:3:operator", "vdb:x:3", "vdb:12:x:operator=lido", "vdb:12:3:a=b:c"} {
+		_, _, _, err = parseDashboardEventFilter(filter)
+		assert.Error(t, err, filter)
+	}
+}
+
+func TestDedupeDashboardSubscriptions(t *testing.T) {
+	sub := func(id uint64, userId types.UserId, dashboardId, groupId int64, tag string) *types.Subscription {
+		return &types.Subscription{ID: &id, UserID: &userId, EventFilter: "a1b2", DashboardId: &dashboardId, DashboardGroupId: &groupId, DashboardTag: tag}
+	}
+	groupSub := sub(1, 10, 100, 0, "")
+	tagSub := sub(2, 10, 100, 0, "operator=lido")
+	otherTagSub := sub(3, 10, 100, 0, "region=eu")
+	otherGroupSub := sub(4, 10, 100, 1, "")
+	otherUserSub := sub(5, 11, 100, 0, "")
+	validatorSub := &types.Subscription{ID: new(uint64), EventFilter: "a1b2"}
+
+	// the tag subscription of the group wins over the group subscription, the first tag subscription wins over others
+	assert.Equal(t, []*types.Subscription{tagSub, otherGroupSub, otherUserSub, validatorSub},
+		dedupeDashboardSubscriptions([]*types.Subscription{groupSub, otherTagSub, otherGroupSub, tagSub, otherUserSub, validatorSub}))
+	assert.Equal(t, []*types.Subscription{tagSub}, dedupeDashboardSubscriptions([]*types.Subscription{tagSub, groupSub}))
+	assert.Equal(t, []*types.Subscription{groupSub}, dedupeDashboardSubscriptions([]*types.Subscription{groupSub}))
+}
@@ -387,7 +387,7 @@ func RenderEmailsForUserEvents(epoch uint64, notificationsByUserID types.Notific
 			case types.ValidatorExecutedProposalEventName:
 				//nolint:gosec // this is a static string
 				bodySummary += template.HTML(fmt.Sprintf("%s: %d validator%s, Reward: %.3f ETH", types.EventLabel[event], count, plural, totalBlockReward))
-			case types.ValidatorGroupEfficiencyEventName:
+			case types.ValidatorGroupEfficiencyEventName, types.ValidatorPerformanceAnomalyEventName:
 				//nolint:gosec // this is a static string
 				bodySummary += template.HTML(fmt.Sprintf("%s: %d Group%s", types.EventLabel[event], count, plural))
 			default:
@@ -523,7 +523,7 @@ func RenderPushMessagesForUserEvents(epoch uint64, notificationsByUserID types.N
 						bodySummary += fmt.Sprintf("%s: %d machine%s", types.EventLabel[event], count, plural)
 					case types.ValidatorExecutedProposalEventName:
 						bodySummary += fmt.Sprintf("%s: %d validator%s, Reward: %.3f ETH", types.EventLabel[event], count, plural, totalBlockReward)
-					case types.ValidatorGroupEfficiencyEventName:
+					case types.ValidatorGroupEfficiencyEventName, types.ValidatorPerformanceAnomalyEventName:
 						bodySummary += fmt.Sprintf("%s: %d group%s", types.EventLabel[event], count, plural)
 					default:
 						bodySummary += fmt.Sprintf("%s: %d validator%s", types.EventLabel[event], count, plural)
@@ -897,7 +897,7 @@ func QueueWebhookNotifications(notificationsByUserID types.NotificationsPerUserI
 							summary += fmt.Sprintf("%s: %d machine%s", types.EventLabel[event], count, plural)
 						case types.ValidatorExecutedProposalEventName:
 							summary += fmt.Sprintf("%s: %d validator%s, Reward: %.3f ETH", types.EventLabel[event], count, plural, totalBlockReward)
-						case types.ValidatorGroupEfficiencyEventName:
+						case types.ValidatorGroupEfficiencyEventName, types.ValidatorPerformanceAnomalyEventName:
 							summary += fmt.Sprintf("%s: %d group%s", types.EventLabel[event], count, plural)
 						default:
 							summary += fmt.Sprintf("%s: %d validator%s", types.EventLabel[event], count, plural)
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/lib/pq"
+)
+
+// collectSlashingRiskNotifications queues notifications for the slashing risks detected by the exporter that have not been
+// notified yet. Slashing risks are urgent, so they don't wait for the epoch based collector and are sent to everyone that is
+// subscribed to slashing notifications of the validator.
+func collectSlashingRiskNotifications() error {
+	type riskRow struct {
+		Id             uint64 `db:"id"`
+		ValidatorIndex uint64 `db:"validator_index"`
+		RiskType       string `db:"risk_type"`
+		Slot           uint64 `db:"slot"`
+		Epoch          uint64 `db:"epoch"`
+		Details        string `db:"details"`
+	}
+	var risks []riskRow
+	err := db.WriterDb.Select(&risks, `
+		SELECT id, validator_index, risk_type, slot, epoch, details
+		FROM validator_slashing_risks
+		WHERE notified_ts IS NULL
+		ORDER BY id
+		LIMIT 1000`)
+	if err != nil {
+		return fmt.Errorf("error retrieving pending slashing risks: %w", err)
+	}
+	if len(risks) == 0 {
+		return nil
+	}
+
+	subMap, err := GetSubsForEventFilter(types.ValidatorGotSlashedEventName, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for slashing risks: %w", err)
+	}
+
+	notificationsByUserID := types.NotificationsPerUserId{}
+	ids := make([]uint64, 0, len(risks))
+	epoch := uint64(0)
+	for _, risk := range risks {
+		pubkey, err := GetPubkeyForIndex(risk.ValidatorIndex)
+		if err != nil {
+			// the risk stays pending and is retried in the next run
+			log.Error(err, "error retrieving pubkey for validator", 0, map[string]interface{}{"validator": risk.ValidatorIndex})
+			continue
+		}
+		ids = append(ids, risk.Id)
+		epoch = max(epoch, risk.Epoch)
+
+		eventFilter := hex.EncodeToString(pubkey)
+		for _, sub := range subMap[eventFilter] {
+			if sub.UserID == nil || sub.ID == nil {
+				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			log.Infof("creating %v notification for validator %v in slot %v", types.ValidatorSlashingRiskEventName, risk.ValidatorIndex, risk.Slot)
+			n := &ValidatorSlashingRiskNotification{
+				NotificationBaseImpl: types.NotificationBaseImpl{
+					SubscriptionID:     *sub.ID,
+					UserID:             *sub.UserID,
+					Epoch:              risk.Epoch,
+					EventFilter:        eventFilter,
+					EventName:          types.ValidatorSlashingRiskEventName,
+					DashboardId:        sub.DashboardId,
+					DashboardName:      sub.DashboardName,
+					DashboardGroupId:   sub.DashboardGroupId,
+					DashboardGroupName: sub.DashboardGroupName,
+				},
+				ValidatorIndex: risk.ValidatorIndex,
+				Slot:           risk.Slot,
+				RiskType:       risk.RiskType,
+				Details:        risk.Details,
+			}
+			notificationsByUserID.AddNotification(n)
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		}
+	}
+
+	if len(notificationsByUserID) > 0 {
+		err = queueNotifications(epoch, notificationsByUserID)
+		if err != nil {
+			return fmt.Errorf("error queuing slashing risk notifications: %w", err)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err = db.WriterDb.Exec(`UPDATE validator_slashing_risks SET notified_ts = NOW() WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("error marking slashing risks as notified: %w", err)
+	}
+	return nil
+}
@@ -275,6 +275,48 @@ func (n *ValidatorGroupEfficiencyNotification) GetLegacyTitle() string {
 	return n.GetTitle()
 }
 
+type ValidatorPerformanceAnomalyNotification struct {
+	types.NotificationBaseImpl
+
+	Threshold float64 // z-score sensitivity
+	Anomalies []PerformanceAnomaly
+}
+
+// PerformanceAnomaly describes a duty of a group that degraded compared to the rolling baseline of the group
+type PerformanceAnomaly struct {
+	Duty     PerformanceDuty
+	Value    float64 // average over the evaluation window
+	Baseline float64 // average over the baseline window
+	ZScore   float64 // deviation from the baseline in standard deviations, always positive for degradations
+}
+
+func (n *ValidatorPerformanceAnomalyNotification) GetEntitiyId() string {
+	return fmt.Sprintf("%s - %s", n.GetDashboardName(), n.GetDashboardGroupName())
+}
+
+// Overwrite specific methods
+func (n *ValidatorPerformanceAnomalyNotification) GetInfo(format types.NotificationFormat) string {
+	dashboardAndGroupInfo := formatPureDashboardAndGroupLink(format, n)
+	epoch := formatEpochLink(format, n.Epoch)
+	details := make([]string, 0, len(n.Anomalies))
+	for _, anomaly := range n.Anomalies {
+		details = append(details, anomaly.Duty.describe(anomaly.Value, anomaly.Baseline))
+	}
+	return fmt.Sprintf(`%s performance degraded in epoch %s: %s.`, dashboardAndGroupInfo, epoch, strings.Join(details, ", "))
+}
+
+func (n *ValidatorPerformanceAnomalyNotification) GetTitle() string {
+	return "Group performance anomaly"
+}
+
+func (n *ValidatorPerformanceAnomalyNotification) GetLegacyInfo() string {
+	return n.GetInfo(types.NotifciationFormatText)
+}
+
+func (n *ValidatorPerformanceAnomalyNotification) GetLegacyTitle() string {
+	return n.GetTitle()
+}
+
 type ValidatorAttestationNotification struct {
 	types.NotificationBaseImpl
 
@@ -383,6 +425,58 @@ func (n *ValidatorGotSlashedNotification) GetLegacyTitle() string {
 	return "Validator got Slashed"
 }
 
+type ValidatorSlashingRiskNotification struct {
+	types.NotificationBaseImpl
+
+	ValidatorIndex uint64
+	Slot           uint64
+	RiskType       string // double_vote, surround_vote or double_proposal
+	Details        string
+}
+
+func (n *ValidatorSlashingRiskNotification) GetEntitiyId() string {
+	return fmt.Sprintf("%d", n.ValidatorIndex)
+}
+
+func (n *ValidatorSlashingRiskNotification) riskDescription() string {
+	switch n.RiskType {
+	case "double_vote":
+		return "signed two conflicting attestations for the same epoch"
+	case "surround_vote":
+		return "signed an attestation surrounding another of its attestations"
+	case "double_proposal":
+		return "proposed two different blocks for the same slot"
+	}
+	return "signed conflicting messages"
+}
+
+func (n *ValidatorSlashingRiskNotification) GetInfo(format types.NotificationFormat) string {
+	dashboardAndGroupInfo := formatValidatorPrefixedDashboardAndGroupLink(format, n)
+	vali := formatValidatorLink(format, n.ValidatorIndex)
+	slot := formatSlotLink(format, n.Slot)
+	return fmt.Sprintf(`Validator %v%v %s in slot %v (%s). This usually means the same validator key is running on more than one machine. Stop all but one instance immediately, the validator will be slashed once the conflicting messages are included on chain.`, vali, dashboardAndGroupInfo, n.riskDescription(), slot, n.Details)
+}
+
+func (n *ValidatorSlashingRiskNotification) GetTitle() string {
+	return n.GetLegacyTitle()
+}
+
+func (n *ValidatorSlashingRiskNotification) GetLegacyInfo() string {
+	return fmt.Sprintf(`Validator %v %s in slot %v (%s). This usually means the same validator key is running on more than one machine. Stop all but one instance immediately, the validator will be slashed once the conflicting messages are included on chain.`, n.ValidatorIndex, n.riskDescription(), n.Slot, n.Details)
+}
+
+func (n *ValidatorSlashingRiskNotification) GetLegacyTitle() string {
+	return "Urgent: Validator at risk of being slashed"
+}
+
+type WithdrawalType string
+
+const (
+	SweepWithdrawalType   WithdrawalType = "sweep"   // automatic withdrawal of the balance above the max effective balance
+	PartialWithdrawalType WithdrawalType = "partial" // withdrawal of a compounding validator requested by the withdrawal address (EIP-7002)
+	FullWithdrawalType    WithdrawalType = "full"    // withdrawal of the whole balance of a withdrawable validator
+)
+
 type ValidatorWithdrawalNotification struct {
 	types.NotificationBaseImpl
 
@@ -391,17 +485,29 @@ type ValidatorWithdrawalNotification struct {
 	Slot           uint64
 	Amount         uint64
 	Address        []byte
+	Type           WithdrawalType
 }
 
 func (n *ValidatorWithdrawalNotification) GetEntitiyId() string {
 	return fmt.Sprintf("%v", n.ValidatorIndex)
 }
 
+func (n *ValidatorWithdrawalNotification) getKind() string {
+	switch n.Type {
+	case FullWithdrawalType:
+		return "A full"
+	case PartialWithdrawalType:
+		return "A partial"
+	default:
+		return "An automatic"
+	}
+}
+
 func (n *ValidatorWithdrawalNotification) GetInfo(format types.NotificationFormat) string {
 	dashboardAndGroupInfo := formatValidatorPrefixedDashboardAndGroupLink(format, n)
 	vali := formatValidatorLink(format, n.ValidatorIndex)
 	amount := utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false)
-	generalPart := fmt.Sprintf(`An automatic withdrawal of %s has been processed for validator %s%s.`, amount, vali, dashboardAndGroupInfo)
+	generalPart := fmt.Sprintf(`%s withdrawal of %s has been processed for validator %s%s.`, n.getKind(), amount, vali, dashboardAndGroupInfo)
 
 	return generalPart
 }
@@ -411,7 +517,7 @@ func (n *ValidatorWithdrawalNotification) GetTitle() string {
 }
 
 func (n *ValidatorWithdrawalNotification) GetLegacyInfo() string {
-	generalPart := fmt.Sprintf(`An automatic withdrawal of %v has been processed for validator %v.`, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.ValidatorIndex)
+	generalPart := fmt.Sprintf(`%s withdrawal of %v has been processed for validator %v.`, n.getKind(), utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.ValidatorIndex)
 	return generalPart
 }
 
@@ -0,0 +1,29 @@
+// Code generated by tygo. DO NOT EDIT.
+/* eslint-disable */
+import type { Hash, ApiDataResponse } from './common'
+
+//////////
+// source: broadcast.go
+
+export interface NetworkBroadcast {
+  id: string;
+  type: 'bls_to_execution_changes' | 'voluntary_exit' | 'execution_transaction' | 'withdrawal_requests' | 'consolidation_requests';
+  status: 'pending' | 'submitted' | 'completed' | 'failed';
+  created_ts: number /* int64 */;
+  submitted_ts?: number /* int64 */;
+  completed_ts?: number /* int64 */;
+  validators?: number /* uint64 */[];
+  tx_hash?: Hash;
+  /**
+   * transactions that have to be sent by the withdrawal addresses for withdrawal and consolidation requests
+   */
+  call_data?: NetworkBroadcastCallData[];
+}
+export interface NetworkBroadcastCallData {
+  from: Hash;
+  to: Hash;
+  data: string;
+  value?: string /* decimal.Decimal */; // value to send in wei, twice the current fee of the predeploy as the fee at the time of inclusion can be higher, omitted if the fee is unavailable
+}
+export type PostNetworkBroadcastsResponse = ApiDataResponse<NetworkBroadcast>;
+export type GetNetworkBroadcastResponse = ApiDataResponse<NetworkBroadcast>;
@@ -0,0 +1,53 @@
+// Code generated by tygo. DO NOT EDIT.
+/* eslint-disable */
+import type { Address, Hash, ApiDataResponse, ApiPagingResponse } from './common'
+
+//////////
+// source: multisig.go
+
+export interface MultisigSafe {
+  address: Address;
+  owners: Address[];
+  threshold: number /* uint64 */;
+  setup_tx_hash?: Hash; // omitted if the safe was set up before the indexed range
+  setup_block?: number /* uint64 */;
+  setup_timestamp?: number /* int64 */;
+}
+export type GetMultisigSafeResponse = ApiDataResponse<MultisigSafe>;
+export interface MultisigConfirmation {
+  owner: Address;
+  type: 'ecdsa' | 'eth_sign' | 'contract' | 'approved_hash' | 'sender';
+  /**
+   * only set for on-chain approvals
+   */
+  tx_hash?: Hash;
+  block?: number /* uint64 */;
+  timestamp?: number /* int64 */;
+}
+/**
+ * call details and confirmations are only known if the safe was called directly, not via another contract
+ */
+export interface MultisigSafeTransactionTableRow {
+  safe_tx_hash: Hash;
+  tx_hash: Hash;
+  block: number /* uint64 */;
+  transaction_index: number /* uint64 */;
+  log_index: number /* uint64 */;
+  timestamp: number /* int64 */;
+  status: 'success' | 'failed';
+  to?: Address;
+  value?: string /* decimal.Decimal */;
+  data?: string;
+  operation?: 'call' | 'delegate_call';
+  payment: string /* decimal.Decimal */; // refund paid to the executor
+  confirmation_count: number /* uint64 */;
+}
+export type GetMultisigSafeTransactionsResponse = ApiPagingResponse<MultisigSafeTransactionTableRow>;
+export interface MultisigTransactionConfirmations {
+  safe_tx_hash: Hash;
+  safe?: Address; // omitted if the transaction has not been executed yet
+  status: 'pending' | 'success' | 'failed';
+  tx_hash?: Hash;
+  confirmations: MultisigConfirmation[];
+}
+export type GetMultisigTransactionConfirmationsResponse = ApiDataResponse<MultisigTransactionConfirmations>;
@@ -0,0 +1,346 @@
+// Code generated by tygo. DO NOT EDIT.
+/* eslint-disable */
+import type { ApiPagingResponse, ApiDataResponse, Hash, PubKey, Address, ChartData } from './common'
+
+//////////
+// source: network.go
+
+export interface NetworkSlasher {
+  index: number /* uint64 */;
+  total_slashings: number /* uint64 */; // number of validators this validator has slashed so far
+}
+export interface NetworkSlashingTableRow {
+  slot: number /* uint64 */;
+  epoch: number /* uint64 */;
+  timestamp: number /* int64 */;
+  type: 'proposer' | 'attester';
+  index: number /* uint64 */; // slashed validator
+  slasher: NetworkSlasher;
+  penalty: string /* decimal.Decimal */; // penalties incurred by the slashed validator since the slashing
+  whistleblower_reward: string /* decimal.Decimal */;
+}
+export type GetNetworkSlashingsResponse = ApiPagingResponse<NetworkSlashingTableRow>;
+export interface NetworkSlashingPenaltyHistoryEntry {
+  timestamp: number /* int64 */; // start of the day
+  penalty: string /* decimal.Decimal */;
+}
+export interface NetworkValidatorSlashings {
+  slashing?: NetworkSlashingTableRow; // only set if the validator got slashed
+  penalty_history: NetworkSlashingPenaltyHistoryEntry[];
+  slashed: NetworkSlashingTableRow[]; // slashings the validator included as block proposer
+}
+export type GetNetworkValidatorSlashingsResponse = ApiDataResponse<NetworkValidatorSlashings>;
+export interface NetworkVoluntaryExitTableRow {
+  slot: number /* uint64 */;
+  epoch: number /* uint64 */; // epoch the exit message was signed for
+  timestamp: number /* int64 */;
+  index: number /* uint64 */;
+  signature: Hash;
+  exit_epoch?: number /* uint64 */;
+  queue_position?: number /* uint64 */; // only set while the validator is still waiting in the exit queue
+  estimated_exit?: number /* int64 */; // timestamp
+}
+export type GetNetworkVoluntaryExitsResponse = ApiPagingResponse<NetworkVoluntaryExitTableRow>;
+export type GetNetworkBlockVoluntaryExitsResponse = ApiDataResponse<NetworkVoluntaryExitTableRow[]>;
+export interface NetworkDepositTableRow {
+  public_key: PubKey;
+  index?: number /* uint64 */;
+  block: number /* uint64 */;
+  timestamp: number /* int64 */;
+  from: Address;
+  depositor: Address;
+  tx_hash: Hash;
+  withdrawal_credential: Hash;
+  amount: string /* decimal.Decimal */;
+  valid: boolean; // only the first deposit of a public key needs a valid signature, the consensus layer ignores invalid ones before it
+  is_top_up: boolean; // an earlier deposit with a valid signature for the same public key exists
+}
+export type GetNetworkDepositsResponse = ApiPagingResponse<NetworkDepositTableRow>;
+export interface NetworkWithdrawalTableRow {
+  epoch: number /* uint64 */;
+  slot: number /* uint64 */;
+  timestamp: number /* int64 */;
+  index: number /* uint64 */; // withdrawal index
+  validator: number /* uint64 */;
+  recipient: Address;
+  amount: string /* decimal.Decimal */;
+}
+export type GetNetworkWithdrawalsResponse = ApiPagingResponse<NetworkWithdrawalTableRow>;
+export interface NetworkWithdrawalCredentialTotals {
+  withdrawal_credential: Hash;
+  validators: number /* uint64 */; // number of validators that currently use the withdrawal credential
+  deposits_count: number /* uint64 */;
+  deposits_amount: string /* decimal.Decimal */; // only valid deposits are counted
+  withdrawals_count: number /* uint64 */;
+  withdrawals_amount: string /* decimal.Decimal */;
+}
+export type GetNetworkWithdrawalCredentialTotalsResponse = ApiDataResponse<NetworkWithdrawalCredentialTotals>;
+export interface NetworkBlsChangeTableRow {
+  slot: number /* uint64 */;
+  epoch: number /* uint64 */;
+  timestamp: number /* int64 */;
+  index: number /* uint64 */;
+  signature: Hash;
+  bls_pubkey: Hash;
+  new_withdrawal_address: Address;
+}
+export type GetNetworkBlsChangesResponse = ApiPagingResponse<NetworkBlsChangeTableRow>;
+export type GetNetworkBlockBlsChangesResponse = ApiDataResponse<NetworkBlsChangeTableRow[]>;
+export interface NetworkBlob {
+  versioned_hash: Hash;
+  slot: number /* uint64 */;
+  index: number /* uint64 */;
+  block_root: Hash;
+  proposer_index: number /* uint64 */;
+  parent_root: Hash;
+  state_root: Hash;
+  kzg_commitment: Hash;
+  kzg_proof: Hash;
+  size: number /* uint64 */;
+  data?: string; // hex encoded, empty if the blob has not been archived (yet)
+}
+export type GetNetworkBlobResponse = ApiDataResponse<NetworkBlob>;
+export type GetNetworkBlockBlobsResponse = ApiDataResponse<NetworkBlob[]>;
+export interface NetworkBlobBlockTableRow {
+  slot: number /* uint64 */;
+  block: number /* uint64 */;
+  timestamp: number /* int64 */;
+  blob_count: number /* uint64 */;
+  blob_gas_used: number /* uint64 */;
+  excess_blob_gas: number /* uint64 */;
+  blob_base_fee: string /* decimal.Decimal */;
+}
+export type GetNetworkBlobBlocksResponse = ApiPagingResponse<NetworkBlobBlockTableRow>;
+/**
+ * categories are the start timestamps of the days, series ids are 'blob_count', 'blob_gas_used', 'burned_blob_fees', 'avg_blob_gas_used', 'avg_excess_blob_gas', 'avg_blob_base_fee' and 'avg_blobs_per_block'
+ */
+export type GetNetworkBlobHistoryResponse = ApiDataResponse<ChartData<string, number /* float64 */>>;
+export interface NetworkBlobSubmitter {
+  address: Address; // rollups are identified by the address label
+  transaction_count: number /* uint64 */;
+  blob_count: number /* uint64 */;
+  blob_gas_used: number /* uint64 */;
+  blob_fees: string /* decimal.Decimal */;
+  share: number /* float64 */; // share of all blobs submitted in the period
+}
+export type GetNetworkBlobSubmittersResponse = ApiDataResponse<NetworkBlobSubmitter[]>;
+export interface NetworkDecodedArgument {
+  name?: string; // empty if only the signature is known
+  type: string;
+  value: string;
+  indexed?: boolean;
+}
+export interface NetworkDecodedCall {
+  name: string;
+  signature: string;
+  source: 'abi' | 'signature';
+  arguments: NetworkDecodedArgument[]; // arguments of events decoded by signature are not known
+}
+export interface NetworkTransactionLog {
+  index: number /* uint64 */;
+  address: Address;
+  topics: Hash[];
+  data: string;
+  decoded?: NetworkDecodedCall;
+}
+export interface NetworkTransactionFees {
+  total: string /* decimal.Decimal */;
+  burned: string /* decimal.Decimal */; // base fee of the used gas
+  priority: string /* decimal.Decimal */; // received by the fee recipient of the block
+  blob_fee?: string /* decimal.Decimal */; // burned for the blobs of the transaction, not part of the total
+}
+export interface NetworkTransaction {
+  hash: Hash;
+  block: number /* uint64 */;
+  transaction_index: number /* uint64 */;
+  timestamp: number /* int64 */;
+  status: 'success' | 'failed';
+  error_message?: string;
+  type: number /* uint32 */;
+  nonce: number /* uint64 */;
+  method: string;
+  from: Address;
+  to?: Address; // empty for contract creations
+  contract_created?: Address; // set for contract creations
+  value: string /* decimal.Decimal */;
+  gas_limit: number /* uint64 */;
+  gas_used: number /* uint64 */;
+  gas_price: string /* decimal.Decimal */; // effective gas price
+  fees: NetworkTransactionFees;
+  input: string;
+  decoded_input?: NetworkDecodedCall;
+  logs: NetworkTransactionLog[];
+}
+export type GetNetworkTransactionResponse = ApiDataResponse<NetworkTransaction>;
+export interface NetworkTransactionTableRow {
+  hash: Hash;
+  block: number /* uint64 */;
+  transaction_index: number /* uint64 */;
+  timestamp: number /* int64 */;
+  status: 'success' | 'failed';
+  method: string;
+  from: Address;
+  to?: Address; // empty for contract creations
+  contract_created?: Address; // set for contract creations
+  value: string /* decimal.Decimal */;
+  gas_price: string /* decimal.Decimal */; // effective gas price
+  fees: NetworkTransactionFees;
+}
+export type GetNetworkTransactionsResponse = ApiPagingResponse<NetworkTransactionTableRow>;
+export type GetNetworkBlockTransactionsResponse = ApiDataResponse<NetworkTransactionTableRow[]>;
+/**
+ * token transfers and internal transactions reference the transaction they are part of
+ */
+export interface NetworkAddressTransactionTableRow {
+  type: 'general' | 'internal' | 'erc20' | 'erc721' | 'erc1155';
+  hash: Hash;
+  block: number /* uint64 */;
+  timestamp: number /* int64 */;
+  status?: 'success' | 'failed'; // general transactions only
+  method?: string; // method of general and call type of internal transactions
+  direction: 'in' | 'out' | 'self';
+  from: Address;
+  to?: Address; // empty for contract creations
+  value: string /* decimal.Decimal */; // amount of the native currency or of the transferred tokens
+  token?: Address;
+  token_id?: string;
+  fee?: string /* decimal.Decimal */; // general transactions only
+}
+export type GetNetworkAddressTransactionsResponse = ApiPagingResponse<NetworkAddressTransactionTableRow>;
+export interface NetworkContractAbi {
+  address: Hash;
+  name: string;
+  methods: number /* uint64 */;
+  events: number /* uint64 */;
+}
+export type PutNetworkContractAbiResponse = ApiDataResponse<NetworkContractAbi>;
+export interface NetworkAddressBalanceSnapshot {
+  block: number /* uint64 */;
+  timestamp: number /* int64 */;
+  balance?: string /* decimal.Decimal */; // missing if the balance call of the token reverted
+}
+export type GetNetworkAddressBalanceHistoryResponse = ApiPagingResponse<NetworkAddressBalanceSnapshot>;
+export interface NetworkTokenSupplySnapshot {
+  block: number /* uint64 */;
+  timestamp: number /* int64 */;
+  total_supply?: string /* decimal.Decimal */; // missing if the total supply call of the token reverted
+}
+export type GetNetworkAddressTokenSupplyHistoryResponse = ApiPagingResponse<NetworkTokenSupplySnapshot>;
+export interface NetworkAddressEventLog {
+  transaction_hash: Hash;
+  block: number /* uint64 */;
+  timestamp: number /* int64 */;
+  transaction_index: number /* uint64 */;
+  log_index: number /* uint64 */; // index of the log within its transaction
+  topics: Hash[];
+  data: string;
+  decoded?: NetworkDecodedCall;
+}
+export type GetNetworkAddressEventLogsResponse = ApiPagingResponse<NetworkAddressEventLog>;
+export interface NetworkBaseFeePrediction {
+  blocks_ahead: number /* uint64 */;
+  min: string /* decimal.Decimal */; // base fee if all blocks until then are empty
+  max: string /* decimal.Decimal */; // base fee if all blocks until then are full
+}
+export interface NetworkGasNow {
+  timestamp: number /* int64 */;
+  base_fee: string /* decimal.Decimal */; // base fee of the next block
+  slow: string /* decimal.Decimal */;
+  standard: string /* decimal.Decimal */;
+  fast: string /* decimal.Decimal */;
+  rapid: string /* decimal.Decimal */;
+  base_fee_predictions: NetworkBaseFeePrediction[];
+}
+export type GetNetworkGasNowResponse = ApiDataResponse<NetworkGasNow>;
+/**
+ * categories are the start timestamps of the days, series id is 'avg_gas_limit'
+ */
+export type GetNetworkAverageGasLimitHistoryResponse = ApiDataResponse<ChartData<string, number /* float64 */>>;
+/**
+ * categories are the start timestamps of the days, series ids are 'total_gas_used', 'avg_gas_used', 'non_failed_tx_gas_used' and 'avg_block_utilization'
+ */
+export type GetNetworkGasUsedHistoryResponse = ApiDataResponse<ChartData<string, number /* float64 */>>;
+export interface NetworkBatchTableRow {
+  index?: number /* uint64 */; // only set if the rollup numbers its batches on layer 1
+  tx_hash: Hash;
+  block: number /* uint64 */;
+  transaction_index: number /* uint64 */;
+  timestamp: number /* int64 */;
+  submitter: Address;
+  data_location: 'calldata' | 'blob';
+  size: number /* uint64 */; // bytes
+  blob_versioned_hashes?: Hash[];
+  fee: string /* decimal.Decimal */; // execution and blob fees paid on layer 1
+}
+export type GetNetworkBatchesResponse = ApiPagingResponse<NetworkBatchTableRow>;
+/**
+ * from, to and value are empty if the bridge doesn't emit them on layer 1
+ */
+export interface NetworkLayer1ToLayer2TransactionTableRow {
+  tx_hash: Hash;
+  block: number /* uint64 */;
+  transaction_index: number /* uint64 */;
+  log_index: number /* uint64 */;
+  timestamp: number /* int64 */;
+  message_id?: Hash;
+  from?: Address;
+  to?: Address; // recipient on layer 2
+  value?: string /* decimal.Decimal */;
+}
+export type GetNetworkLayer1ToLayer2TransactionsResponse = ApiPagingResponse<NetworkLayer1ToLayer2TransactionTableRow>;
+/**
+ * withdrawals of op-stack rollups are listed once they are proven and again once they are finalized
+ */
+export interface NetworkLayer2ToLayer1TransactionTableRow {
+  tx_hash: Hash;
+  block: number /* uint64 */;
+  transaction_index: number /* uint64 */;
+  log_index: number /* uint64 */;
+  timestamp: number /* int64 */;
+  status: 'proven' | 'finalized' | 'failed';
+  message_id?: Hash;
+  from?: Address; // sender on layer 2
+  to?: Address;
+  value?: string /* decimal.Decimal */;
+}
+export type GetNetworkLayer2ToLayer1TransactionsResponse = ApiPagingResponse<NetworkLayer2ToLayer1TransactionTableRow>;
+export interface NetworkEnsName {
+  name: string;
+  address: Address; // address the name resolves to
+  is_primary: boolean; // the address reverse resolves to the name
+  expiry: number /* int64 */; // timestamp of the expiry of the registration
+  resolver?: Address;
+  text_records: { [key: string]: string};
+}
+export type GetNetworkEnsResponse = ApiDataResponse<NetworkEnsName>;
+export interface NetworkAddressEns {
+  primary_name?: NetworkEnsName;
+  names: NetworkEnsName[]; // all names that resolve to the address, including the primary name
+}
+export type GetNetworkAddressEnsResponse = ApiDataResponse<NetworkAddressEns>;
+export interface NetworkUserOperationTableRow {
+  hash: Hash;
+  tx_hash: Hash;
+  block: number /* uint64 */;
+  transaction_index: number /* uint64 */;
+  log_index: number /* uint64 */;
+  timestamp: number /* int64 */;
+  entry_point: Address;
+  sender: Address;
+  paymaster?: Address; // empty if the account paid for the gas itself
+  bundler: Address;
+  nonce: string /* decimal.Decimal */;
+  success: boolean;
+  gas_used: number /* uint64 */;
+  gas_cost: string /* decimal.Decimal */;
+}
+export type GetNetworkAddressUserOperationsResponse = ApiPagingResponse<NetworkUserOperationTableRow>;
+/**
+ * sponsored gas of a paymaster, aggregated per finished day
+ */
+export interface NetworkPaymasterSponsorship {
+  operation_count: number /* uint64 */;
+  gas_used: number /* uint64 */;
+  gas_cost: string /* decimal.Decimal */;
+}
+export type GetNetworkAddressSponsorshipResponse = ApiDataResponse<NetworkPaymasterSponsorship>;
@@ -44,7 +44,7 @@ export interface NotificationDashboardsTableRow {
   group_id: number /* uint64 */;
   group_name: string;
   entity_count: number /* uint64 */;
-  event_types: ('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[];
+  event_types: ('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'performance_anomaly' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'slashing_risk' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[];
 }
 export type InternalGetUserNotificationDashboardsResponse = ApiPagingResponse<NotificationDashboardsTableRow>;
 export interface NotificationEventValidatorBackOnline {
@@ -56,15 +56,28 @@ export interface NotificationEventWithdrawal {
   amount: string /* decimal.Decimal */;
   address: Address;
 }
+export interface NotificationEventPerformanceAnomaly {
+  duty: 'source' | 'target' | 'head' | 'inclusion_delay' | 'sync';
+  value: number /* float64 */;
+  baseline: number /* float64 */;
+  z_score: number /* float64 */;
+}
+export interface NotificationEventSlashingRisk {
+  index: number /* uint64 */;
+  slot: number /* uint64 */;
+  type: 'double_vote' | 'surround_vote' | 'double_proposal';
+}
 export interface NotificationValidatorDashboardDetail {
   dashboard_name: string;
   group_name: string;
   validator_offline: number /* uint64 */[]; // validator indices
   group_efficiency_below?: number /* float64 */; // fill with the `group_efficiency_below` threshold if event is present
+  performance_anomalies: NotificationEventPerformanceAnomaly[];
   proposal_missed: IndexSlots[];
   proposal_done: IndexBlocks[];
   upcoming_proposals: IndexSlots[];
   slashed: number /* uint64 */[]; // validator indices
+  slashing_risks: NotificationEventSlashingRisk[];
   sync_committee: number /* uint64 */[]; // validator indices
   attestation_missed: IndexEpoch[]; // index (epoch)
   withdrawal: NotificationEventWithdrawal[];
@@ -180,18 +193,29 @@ export interface NotificationSettingsValidatorDashboard {
   is_validator_offline_subscribed: boolean;
   is_group_efficiency_below_subscribed: boolean;
   group_efficiency_below_threshold: number /* float64 */;
+  is_performance_anomaly_subscribed: boolean;
+  performance_anomaly_threshold: number /* float64 */; // number of standard deviations a duty has to degrade by
   is_attestations_missed_subscribed: boolean;
   is_block_proposal_subscribed: boolean;
   is_upcoming_block_proposal_subscribed: boolean;
   is_sync_subscribed: boolean;
   is_withdrawal_processed_subscribed: boolean;
-  is_slashed_subscribed: boolean;
+  is_slashed_subscribed: boolean; // also enables urgent warnings if a validator signs conflicting messages that could get it slashed
   is_max_collateral_subscribed: boolean;
   max_collateral_threshold: number /* float64 */;
   is_min_collateral_subscribed: boolean;
   min_collateral_threshold: number /* float64 */;
 }
 export type InternalPutUserNotificationSettingsValidatorDashboardResponse = ApiDataResponse<NotificationSettingsValidatorDashboard>;
+/**
+ * notification settings that only apply to the validators of a group that have a tag, webhooks are configured per group
+ */
+export interface NotificationSettingsValidatorDashboardTag {
+  group_id: number /* uint64 */;
+  tag: VDBValidatorTag;
+  settings: NotificationSettingsValidatorDashboard;
+}
+export type InternalGetUserNotificationSettingsValidatorDashboardTagsResponse = ApiDataResponse<NotificationSettingsValidatorDashboardTag[]>;
 export interface NotificationSettingsAccountDashboard {
   webhook_url: string;
   is_webhook_discord_enabled: boolean;
@@ -12,3 +12,23 @@ export interface ApiWeightItem {
   Weight: number /* int */;
 }
 export type InternalGetRatelimitWeightsResponse = ApiDataResponse<ApiWeightItem[]>;
+export interface ApiUsageRoute {
+  route: string;
+  requests: number /* int64 */;
+  consumed: number /* int64 */; // requests multiplied by the current weight of the route
+}
+export interface ApiUsageBucket {
+  bucket: string;
+  requests: number /* int64 */;
+  consumed: number /* int64 */;
+  limit_second: number /* int64 */; // 0 means unlimited
+  limit_hour: number /* int64 */;
+  limit_month: number /* int64 */;
+  routes: ApiUsageRoute[]; // sorted by consumption
+}
+export interface UserApiUsage {
+  start_ts: number /* int64 */;
+  end_ts: number /* int64 */;
+  buckets: ApiUsageBucket[];
+}
+export type GetUserApiUsageResponse = ApiDataResponse<UserApiUsage>;
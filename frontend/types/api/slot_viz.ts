@@ -47,3 +47,26 @@ export interface SlotVizEpoch {
   slots?: VDBSlotVizSlot[]; // only on dashboard page
 }
 export type GetValidatorDashboardSlotVizResponse = ApiDataResponse<SlotVizEpoch[]>;
+/**
+ * ------------------------------------------------------------
+ * Live Updates
+ */
+export interface VDBLiveDutyResult {
+  slot: number /* uint64 */;
+  type: 'proposal' | 'attestation' | 'sync' | 'slashing';
+  result: 'success' | 'failed'; // failed slashing means the validators got slashed
+  validators: number /* uint64 */[];
+}
+/**
+ * Sent as `slot-viz` event, only contains the epochs and slots that changed since the last event unless `reset` is set.
+ * Clients should only keep the 4 most recent epochs.
+ */
+export interface VDBLiveSlotVizUpdate {
+  reset?: boolean; // epochs contains the full slot viz, previous data should be discarded
+  epochs: SlotVizEpoch[];
+}
+export interface VDBLiveUpdate {
+  id: number /* uint64 */; // sent as event id, pass it as `Last-Event-ID` to resume a stream
+  slot_viz?: VDBLiveSlotVizUpdate; // nil if nothing changed
+  duties?: VDBLiveDutyResult[]; // sent as `duties` event, duty results that became available since the last event
+}
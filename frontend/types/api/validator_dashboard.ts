@@ -50,6 +50,7 @@ export interface VDBSummaryTableRow {
   attestations: StatusCount;
   proposals: StatusCount;
   reward: ClElValue<string /* decimal.Decimal */>;
+  tag?: VDBValidatorTag; // set if grouped by tag, the group id is only valid within the response then
 }
 export type GetValidatorDashboardSummaryResponse = ApiPagingResponse<VDBSummaryTableRow>;
 export interface VDBGroupSummaryColumnItem {
@@ -243,6 +244,27 @@ export interface VDBTotalWithdrawalsData {
   total_amount: string /* decimal.Decimal */;
 }
 export type GetValidatorDashboardTotalWithdrawalsResponse = ApiDataResponse<VDBTotalWithdrawalsData>;
+/**
+ * ------------------------------------------------------------
+ * Credential Migration
+ */
+export interface VDBPendingBlsChange {
+  index: number /* uint64 */;
+  broadcast_id: string;
+  status: 'pending' | 'submitted';
+}
+export interface VDBCompletedBlsChange {
+  index: number /* uint64 */;
+  slot: number /* uint64 */;
+  timestamp: number /* int64 */;
+  new_withdrawal_address: Address;
+}
+export interface VDBBlsChangesData {
+  bls: number /* uint64 */[]; // validators that still use 0x00 withdrawal credentials and have no pending change
+  pending: VDBPendingBlsChange[]; // changes that were broadcast but are not included in a block yet
+  completed: VDBCompletedBlsChange[];
+}
+export type GetValidatorDashboardBlsChangesResponse = ApiDataResponse<VDBBlsChangesData>;
 /**
  * ------------------------------------------------------------
  * Rocket Pool Tab
@@ -295,6 +317,25 @@ export interface VDBRocketPoolMinipoolsTableRow {
   penalties: number /* uint64 */;
 }
 export type GetValidatorDashboardRocketPoolMinipoolsResponse = ApiPagingResponse<VDBRocketPoolMinipoolsTableRow>;
+/**
+ * ------------------------------------------------------------
+ * Income Reports
+ */
+export interface VDBIncomeReport {
+  id: string;
+  status: 'pending' | 'running' | 'completed' | 'failed';
+  format: 'csv' | 'pdf';
+  aggregation: 'validator' | 'group';
+  group_id?: number /* int64 */;
+  start_ts: number /* int64 */;
+  end_ts: number /* int64 */;
+  currency: string;
+  created_ts: number /* int64 */;
+  completed_ts?: number /* int64 */;
+  error?: string;
+}
+export type PostValidatorDashboardIncomeReportsResponse = ApiDataResponse<VDBIncomeReport>;
+export type GetValidatorDashboardIncomeReportResponse = ApiDataResponse<VDBIncomeReport>;
 /**
  * ------------------------------------------------------------
  * Manage Modal
@@ -307,8 +348,74 @@ export interface VDBManageValidatorsTableRow {
   status: 'slashed' | 'exited' | 'deposited' | 'pending' | 'slashing_offline' | 'slashing_online' | 'exiting_offline' | 'exiting_online' | 'active_offline' | 'active_online';
   queue_position?: number /* uint64 */;
   withdrawal_credential: Hash;
+  is_safe_withdrawal: boolean; // the withdrawal address is a Safe multisig
+  tags?: { [key: string]: string};
 }
 export type GetValidatorDashboardValidatorsResponse = ApiPagingResponse<VDBManageValidatorsTableRow>;
+/**
+ * ------------------------------------------------------------
+ * Tags
+ */
+export interface VDBValidatorTag {
+  key: string;
+  value: string;
+}
+export interface VDBTagKey {
+  key: string;
+  values: string[];
+  validator_count: number /* uint64 */;
+}
+export type GetValidatorDashboardTagsResponse = ApiDataResponse<VDBTagKey[]>;
+/**
+ * ------------------------------------------------------------
+ * Import / Export
+ */
+export interface VDBExportValidator {
+  index: number /* uint64 */;
+  public_key: PubKey; // used to match validators on import, indices are ignored as they differ between networks
+  tags?: { [key: string]: string};
+}
+export interface VDBExportTagNotificationSettings {
+  tag: VDBValidatorTag;
+  settings: NotificationSettingsValidatorDashboard;
+}
+export interface VDBExportGroup {
+  id: number /* uint64 */;
+  name: string;
+  validators: VDBExportValidator[];
+  notification_settings?: NotificationSettingsValidatorDashboard;
+  tag_notification_settings?: VDBExportTagNotificationSettings[]; // settings that only apply to the validators of the group with a tag
+}
+export interface VDBExportPublicId {
+  name: string;
+  share_groups: boolean;
+}
+export interface VDBExport {
+  version: number /* uint64 */;
+  name: string;
+  network: number /* uint64 */;
+  groups: VDBExportGroup[];
+  public_ids?: VDBExportPublicId[];
+}
+export type GetValidatorDashboardExportResponse = ApiDataResponse<VDBExport>;
+export interface VDBImportGroupResult {
+  id?: number /* uint64 */; // not set for groups that would be created in a dry run
+  name: string;
+  is_new: boolean;
+  validators_added: number /* uint64 */;
+  validators_moved: number /* uint64 */; // validators of the dashboard that are moved to this group
+}
+export interface VDBImportResult {
+  dry_run: boolean;
+  groups: VDBImportGroupResult[];
+  validators_added: number /* uint64 */;
+  validators_moved: number /* uint64 */;
+  unknown_validators: string[]; // public keys that don't exist on the dashboard network
+  skipped_validators: string[]; // exceed the validator limit of the subscription plan
+  skipped_groups: string[]; // exceed the group limit of the subscription plan, their validators are skipped too
+  public_ids_created: number /* uint64 */;
+}
+export type PostValidatorDashboardImportResponse = ApiDataResponse<VDBImportResult>;
 /**
  * ------------------------------------------------------------
  * Misc.